@@ -0,0 +1,11 @@
+data "sonarqube_qualityprofile" "main" {
+  name = "my-quality-profile"
+}
+
+data "sonarqube_active_rules" "main" {
+  quality_profile_key = data.sonarqube_qualityprofile.main.key
+}
+
+output "missing_rules" {
+  value = setsubtract(["java:S2068", "java:S3649"], data.sonarqube_active_rules.main.rule_keys)
+}
@@ -0,0 +1,24 @@
+data "sonarqube_alm_repositories" "services" {
+  alm          = "github"
+  alm_setting  = "github-alm"
+  organization = "my-org"
+  name_regex   = "-service$"
+}
+
+locals {
+  services = { for repository in data.sonarqube_alm_repositories.services.repositories : repository.name => repository }
+}
+
+resource "sonarqube_project" "services" {
+  for_each   = local.services
+  name       = each.value.name
+  project    = each.value.name
+  visibility = "private"
+}
+
+resource "sonarqube_github_binding" "services" {
+  for_each    = local.services
+  alm_setting = "github-alm"
+  project     = sonarqube_project.services[each.key].project
+  repository  = each.value.repository
+}
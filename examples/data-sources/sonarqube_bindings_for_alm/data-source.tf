@@ -0,0 +1,7 @@
+data "sonarqube_bindings_for_alm" "legacy_gitlab" {
+  alm_setting = "gitlab-legacy"
+}
+
+output "projects_on_legacy_gitlab" {
+  value = data.sonarqube_bindings_for_alm.legacy_gitlab.project_keys
+}
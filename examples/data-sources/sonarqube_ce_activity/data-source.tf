@@ -0,0 +1,8 @@
+data "sonarqube_ce_activity" "failed" {
+  component = "my_project"
+  statuses  = ["FAILED"]
+}
+
+output "pending_background_tasks" {
+  value = data.sonarqube_ce_activity.failed.pending
+}
@@ -0,0 +1,7 @@
+data "sonarqube_ce_task" "last_analysis" {
+  component = "my_project"
+}
+
+output "last_analysis_status" {
+  value = data.sonarqube_ce_task.last_analysis.status
+}
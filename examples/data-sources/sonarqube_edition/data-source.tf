@@ -0,0 +1,9 @@
+data "sonarqube_edition" "current" {}
+
+resource "sonarqube_github_binding" "binding" {
+  count = data.sonarqube_edition.current.is_developer ? 1 : 0
+
+  alm_setting = "github"
+  project     = "my-project"
+  repository  = "my-org/my-repo"
+}
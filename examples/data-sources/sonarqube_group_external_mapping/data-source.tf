@@ -0,0 +1,9 @@
+resource "sonarqube_group" "developers" {
+  name        = "my-org/developers"
+  description = "Synchronized from the developers team of the my-org GitHub organization"
+}
+
+data "sonarqube_group_external_mapping" "developers" {
+  identity_provider = "github"
+  group             = sonarqube_group.developers.name
+}
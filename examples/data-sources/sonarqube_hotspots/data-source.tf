@@ -0,0 +1,7 @@
+data "sonarqube_hotspots" "to_review" {
+  project = "my_project"
+}
+
+output "high_priority_hotspots" {
+  value = [for hotspot in data.sonarqube_hotspots.to_review.hotspots : hotspot.key if hotspot.vulnerability_probability == "HIGH"]
+}
@@ -0,0 +1,12 @@
+data "sonarqube_issue_counts" "main" {
+  project = "my_project"
+  branch  = "main"
+}
+
+output "blocker_issues" {
+  value = lookup(data.sonarqube_issue_counts.main.by_severity, "BLOCKER", 0)
+}
+
+output "duplicated_lines_density" {
+  value = data.sonarqube_issue_counts.main.duplicated_lines_density
+}
@@ -0,0 +1,9 @@
+data "sonarqube_issues" "criticals" {
+  project    = "my_project"
+  severities = ["BLOCKER", "CRITICAL"]
+  types      = ["BUG", "VULNERABILITY"]
+}
+
+output "critical_issues" {
+  value = [for issue in data.sonarqube_issues.criticals.issues : "${issue.component}:${issue.line} ${issue.rule}"]
+}
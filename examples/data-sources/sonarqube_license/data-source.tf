@@ -0,0 +1,5 @@
+data "sonarqube_license" "license" {}
+
+output "remaining_loc" {
+  value = data.sonarqube_license.license.remaining_loc
+}
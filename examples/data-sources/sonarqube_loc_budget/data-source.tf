@@ -0,0 +1,8 @@
+# Fails the plan when the new projects are expected to exceed the lines of code of the license
+data "sonarqube_loc_budget" "main" {
+  planned_loc = 250000
+}
+
+output "remaining_loc" {
+  value = data.sonarqube_loc_budget.main.remaining_loc
+}
@@ -0,0 +1,5 @@
+data "sonarqube_monitoring_metrics" "this" {}
+
+output "web_is_up" {
+  value = data.sonarqube_monitoring_metrics.this.values["sonarqube_health_web_status"] == 1
+}
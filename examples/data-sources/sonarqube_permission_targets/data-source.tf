@@ -0,0 +1,10 @@
+data "sonarqube_permission_targets" "team" {
+  users  = ["jdoe", "asmith"]
+  groups = ["Developers"]
+}
+
+resource "sonarqube_permissions" "developers" {
+  group_name  = data.sonarqube_permission_targets.team.group_names["Developers"]
+  project_key = "my-project"
+  permissions = ["user", "codeviewer"]
+}
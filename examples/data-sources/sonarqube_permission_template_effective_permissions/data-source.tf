@@ -0,0 +1,7 @@
+data "sonarqube_permission_template_effective_permissions" "default" {
+  template_name = "Default template"
+}
+
+output "template_admins" {
+  value = [for p in data.sonarqube_permission_template_effective_permissions.default.permissions : p if p.permission == "admin"]
+}
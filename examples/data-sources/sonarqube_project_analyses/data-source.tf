@@ -0,0 +1,12 @@
+data "sonarqube_project_analyses" "releases" {
+  project  = "my_project"
+  category = "VERSION"
+}
+
+output "last_release_analysis" {
+  value = data.sonarqube_project_analyses.releases.last_analysis_date
+}
+
+output "released_versions" {
+  value = [for analysis in data.sonarqube_project_analyses.releases.analyses : analysis.project_version]
+}
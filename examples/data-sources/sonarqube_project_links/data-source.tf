@@ -0,0 +1,15 @@
+data "sonarqube_project_links" "catalog" {
+  project = "my_project"
+}
+
+output "catalog_links" {
+  value = {
+    dashboard = data.sonarqube_project_links.catalog.dashboard_url
+    links     = { for link in data.sonarqube_project_links.catalog.links : link.type => link.url }
+  }
+}
+
+output "catalog_coverage_badge" {
+  value     = data.sonarqube_project_links.catalog.badge_urls["coverage"]
+  sensitive = true
+}
@@ -0,0 +1,14 @@
+data "sonarqube_projects" "team" {
+  key_prefix = "payments-"
+}
+
+# Generates the import blocks to adopt the existing projects
+output "import_blocks" {
+  value = join("\n", [for project in data.sonarqube_projects.team.projects : <<-EOT
+    import {
+      to = sonarqube_project.${replace(project.key, "-", "_")}
+      id = "${project.import_id}"
+    }
+  EOT
+  ])
+}
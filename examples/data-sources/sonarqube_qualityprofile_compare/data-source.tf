@@ -0,0 +1,18 @@
+data "sonarqube_qualityprofile" "team" {
+  name     = "team-java"
+  language = "java"
+}
+
+data "sonarqube_qualityprofile" "sonar_way" {
+  name     = "Sonar way"
+  language = "java"
+}
+
+data "sonarqube_qualityprofile_compare" "main" {
+  left_key  = data.sonarqube_qualityprofile.team.key
+  right_key = data.sonarqube_qualityprofile.sonar_way.key
+}
+
+output "rules_missing_from_team_profile" {
+  value = data.sonarqube_qualityprofile_compare.main.in_right[*].key
+}
@@ -0,0 +1,25 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+resource "sonarqube_analysis_exclusions" "main" {
+  project    = sonarqube_project.main.project
+  exclusions = ["**/generated/**"]
+}
+
+data "sonarqube_scanner_configuration" "main" {
+  project = sonarqube_project.main.project
+  additional_properties = {
+    "sonar.sources" = "src"
+  }
+
+  depends_on = [sonarqube_analysis_exclusions.main]
+}
+
+resource "github_repository_file" "sonar_project_properties" {
+  repository = "my-repository"
+  file       = "sonar-project.properties"
+  content    = data.sonarqube_scanner_configuration.main.content
+}
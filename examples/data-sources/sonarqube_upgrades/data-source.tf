@@ -0,0 +1,9 @@
+data "sonarqube_upgrades" "available" {}
+
+output "sonarqube_upgrade" {
+  value = data.sonarqube_upgrades.available.upgrade_available ? {
+    version              = data.sonarqube_upgrades.available.latest_version
+    lta                  = data.sonarqube_upgrades.available.latest_lta
+    incompatible_plugins = data.sonarqube_upgrades.available.upgrades[length(data.sonarqube_upgrades.available.upgrades) - 1].incompatible_plugins
+  } : null
+}
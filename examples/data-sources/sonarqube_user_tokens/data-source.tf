@@ -1,3 +1,12 @@
 data "sonarqube_user_tokens" "user_tokens_admin" {
-  login = "admin"
+  login_name = "admin"
+}
+
+data "sonarqube_user_tokens" "expiring_scanner_tokens" {
+  login_name          = "ci-scanner"
+  expires_within_days = 30
+}
+
+output "expiring_scanner_tokens" {
+  value = data.sonarqube_user_tokens.expiring_scanner_tokens.user_tokens[*].name
 }
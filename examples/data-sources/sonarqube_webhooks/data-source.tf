@@ -0,0 +1,7 @@
+data "sonarqube_webhooks" "project" {
+  project = "my_project"
+}
+
+output "webhook_import_ids" {
+  value = { for webhook in data.sonarqube_webhooks.project.webhooks : webhook.name => webhook.import_id }
+}
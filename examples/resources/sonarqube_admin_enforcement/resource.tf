@@ -0,0 +1,5 @@
+resource "sonarqube_admin_enforcement" "break_glass" {
+  login_names = ["admin"]
+  group_names = ["sonar-administrators"]
+  strict      = true
+}
@@ -7,3 +7,11 @@ resource "sonarqube_alm_github" "github-alm" {
   url            = "https://api.github.com"
   webhook_secret = "mysecret"
 }
+
+# The GitHub App itself is created on GitHub, its webhook settings are computed by SonarQube
+output "github_app_webhook" {
+  value = {
+    url    = sonarqube_alm_github.github-alm.webhook_url
+    events = sonarqube_alm_github.github-alm.webhook_events
+  }
+}
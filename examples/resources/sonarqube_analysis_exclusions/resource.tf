@@ -0,0 +1,13 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+resource "sonarqube_analysis_exclusions" "main" {
+  project             = sonarqube_project.main.project
+  exclusions          = ["**/generated/**", "**/*.min.js"]
+  test_inclusions     = ["**/*_test.go"]
+  coverage_exclusions = ["**/mocks/**"]
+  cpd_exclusions      = ["**/migrations/**"]
+}
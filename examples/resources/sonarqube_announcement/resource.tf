@@ -0,0 +1,9 @@
+variable "maintenance_window" {
+  type    = string
+  default = ""
+}
+
+resource "sonarqube_announcement" "maintenance" {
+  count   = var.maintenance_window != "" ? 1 : 0
+  message = "SonarQube will be unavailable ${var.maintenance_window}."
+}
@@ -0,0 +1,15 @@
+variable "bitbucket_client_id" {
+  type      = string
+  sensitive = true
+}
+
+variable "bitbucket_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+resource "sonarqube_auth_bitbucket" "main" {
+  client_id     = var.bitbucket_client_id
+  client_secret = var.bitbucket_client_secret
+  workspaces    = ["my-workspace"]
+}
@@ -0,0 +1,18 @@
+variable "github_client_id" {
+  type      = string
+  sensitive = true
+}
+
+variable "github_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+resource "sonarqube_auth_github" "main" {
+  app_id        = "123456"
+  private_key   = file("${path.module}/github-app.pem")
+  client_id     = var.github_client_id
+  client_secret = var.github_client_secret
+  organizations = ["my-org"]
+  groups_sync   = true
+}
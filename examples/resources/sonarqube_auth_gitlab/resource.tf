@@ -0,0 +1,17 @@
+variable "gitlab_application_id" {
+  type      = string
+  sensitive = true
+}
+
+variable "gitlab_secret" {
+  type      = string
+  sensitive = true
+}
+
+resource "sonarqube_auth_gitlab" "main" {
+  url            = "https://gitlab.example.com"
+  application_id = var.gitlab_application_id
+  secret         = var.gitlab_secret
+  allowed_groups = ["engineering"]
+  groups_sync    = true
+}
@@ -0,0 +1,23 @@
+variable "azuread_tenant_id" {
+  type = string
+}
+
+variable "azuread_client_id" {
+  type      = string
+  sensitive = true
+}
+
+variable "azuread_client_secret" {
+  type      = string
+  sensitive = true
+}
+
+# Azure AD (Microsoft Entra ID) through the OpenID Connect plugin
+resource "sonarqube_auth_oidc" "azuread" {
+  issuer_uri        = "https://login.microsoftonline.com/${var.azuread_tenant_id}/v2.0"
+  client_id         = var.azuread_client_id
+  client_secret     = var.azuread_client_secret
+  login_strategy    = "Email"
+  login_button_text = "Azure AD"
+  groups_sync       = true
+}
@@ -0,0 +1,5 @@
+resource "sonarqube_branch_protection" "main" {
+  force_authentication = true
+  main_branch_name     = "main"
+  branches_to_keep     = ["main", "release/.*"]
+}
@@ -0,0 +1,9 @@
+variable "maintenance" {
+  type    = bool
+  default = false
+}
+
+# Pause the background tasks queue while the maintenance is in progress
+resource "sonarqube_ce_queue_pause" "maintenance" {
+  count = var.maintenance ? 1 : 0
+}
@@ -0,0 +1,4 @@
+resource "sonarqube_ce_worker" "main" {
+  worker_count           = 4
+  parallel_project_tasks = true
+}
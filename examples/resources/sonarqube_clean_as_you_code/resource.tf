@@ -0,0 +1,5 @@
+resource "sonarqube_clean_as_you_code" "main" {
+  new_code_period_type  = "NUMBER_OF_DAYS"
+  new_code_period_value = "30"
+  ignore_small_changes  = true
+}
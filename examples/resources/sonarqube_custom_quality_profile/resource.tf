@@ -0,0 +1,18 @@
+# rules.json: [{ "key": "java:S107", "severity": "MAJOR", "params": { "max": "5" } }, { "key": "java:S1068", "severity": "MINOR" }]
+locals {
+  java_rules = jsondecode(file("${path.module}/rules.json"))
+}
+
+resource "sonarqube_custom_quality_profile" "java" {
+  name     = "My Java way"
+  language = "java"
+
+  dynamic "rule" {
+    for_each = local.java_rules
+    content {
+      key      = rule.value.key
+      severity = rule.value.severity
+      params   = lookup(rule.value, "params", {})
+    }
+  }
+}
@@ -0,0 +1,3 @@
+resource "sonarqube_default_visibility" "main" {
+  visibility = "private"
+}
@@ -0,0 +1,18 @@
+resource "sonarqube_alm_github" "github" {
+  app_id         = "12345"
+  client_id      = "56789"
+  client_secret  = "secret"
+  key            = "myalm"
+  private_key    = "myprivate_key"
+  url            = "https://api.github.com"
+  webhook_secret = "mysecret"
+}
+
+resource "sonarqube_dop_translation_bound_project" "main" {
+  project                   = "my_project"
+  name                      = "My Project"
+  alm_setting               = sonarqube_alm_github.github.key
+  repository                = "myorg/myrepo"
+  new_code_definition_type  = "NUMBER_OF_DAYS"
+  new_code_definition_value = "30"
+}
@@ -0,0 +1,14 @@
+variable "pull_request" {
+  type = string
+}
+
+resource "sonarqube_ephemeral_project" "preview" {
+  project           = "my_project-pr-${var.pull_request}"
+  quality_gate      = "Preview environments"
+  auto_delete_after = "336h"
+}
+
+output "sonar_token" {
+  value     = sonarqube_ephemeral_project.preview.token
+  sensitive = true
+}
@@ -0,0 +1,8 @@
+resource "sonarqube_group" "quality" {
+  name = "quality-team"
+}
+
+resource "sonarqube_global_role" "quality" {
+  group_name = sonarqube_group.quality.name
+  role       = "quality-manager"
+}
@@ -0,0 +1,9 @@
+resource "sonarqube_group" "developers" {
+  name = "developers"
+}
+
+# Users added to the group outside of Terraform are removed on the next apply
+resource "sonarqube_group_members" "developers" {
+  group   = sonarqube_group.developers.name
+  members = ["alice", "bob"]
+}
@@ -0,0 +1,15 @@
+variable "team_projects" {
+  type    = set(string)
+  default = ["payments-api", "payments-web", "payments-batch"]
+}
+
+resource "sonarqube_group" "payments" {
+  name        = "payments-team"
+  description = "Payments team"
+}
+
+resource "sonarqube_group_permissions" "payments" {
+  group_name   = sonarqube_group.payments.name
+  project_keys = var.team_projects
+  permissions  = ["user", "codeviewer", "issueadmin"]
+}
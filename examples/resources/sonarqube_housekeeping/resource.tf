@@ -0,0 +1,15 @@
+resource "sonarqube_housekeeping" "global" {
+  inactive_branches_days = 60
+}
+
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+# Feature branches of this project are deleted sooner
+resource "sonarqube_housekeeping" "main" {
+  project                = sonarqube_project.main.project
+  inactive_branches_days = 7
+}
@@ -0,0 +1,16 @@
+variable "sonarqube_version" {
+  type = string
+}
+
+# Wait for the reindexation that follows an upgrade before reading issues
+resource "sonarqube_indexation" "upgrade" {
+  triggers = {
+    version = var.sonarqube_version
+  }
+}
+
+data "sonarqube_issue_counts" "main" {
+  project = "my_project"
+
+  depends_on = [sonarqube_indexation.upgrade]
+}
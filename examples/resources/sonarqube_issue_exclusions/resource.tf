@@ -0,0 +1,24 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+resource "sonarqube_issue_exclusions" "main" {
+  project = sonarqube_project.main.project
+
+  ignore {
+    rule_key     = "*"
+    resource_key = "**/generated/**"
+  }
+
+  ignore {
+    rule_key     = "java:S1195"
+    resource_key = "**/legacy/**/*.java"
+  }
+
+  enforce {
+    rule_key     = "java:S2068"
+    resource_key = "**/src/main/**"
+  }
+}
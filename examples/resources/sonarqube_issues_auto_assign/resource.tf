@@ -0,0 +1,10 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+resource "sonarqube_issues_auto_assign" "main" {
+  project          = sonarqube_project.main.project
+  default_assignee = "team-lead"
+}
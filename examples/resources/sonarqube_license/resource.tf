@@ -0,0 +1,3 @@
+resource "sonarqube_license" "main" {
+  license = var.sonarqube_license
+}
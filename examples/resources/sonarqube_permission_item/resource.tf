@@ -0,0 +1,20 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "private"
+}
+
+resource "sonarqube_group" "developers" {
+  name = "developers"
+}
+
+resource "sonarqube_permission_item" "developers_codeviewer" {
+  group_name  = sonarqube_group.developers.name
+  project_key = sonarqube_project.main.project
+  permission  = "codeviewer"
+}
+
+resource "sonarqube_permission_item" "admin_gateadmin" {
+  login_name = "admin"
+  permission = "gateadmin"
+}
@@ -0,0 +1,29 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "private"
+}
+
+resource "sonarqube_group" "developers" {
+  name = "developers"
+}
+
+resource "sonarqube_permissions_authoritative" "my_project" {
+  project_key = sonarqube_project.main.project
+  exclusive   = true
+
+  group {
+    name        = "sonar-administrators"
+    permissions = ["admin", "issueadmin", "securityhotspotadmin"]
+  }
+
+  group {
+    name        = sonarqube_group.developers.name
+    permissions = ["codeviewer", "user"]
+  }
+
+  user {
+    login       = "admin"
+    permissions = ["admin"]
+  }
+}
@@ -0,0 +1,15 @@
+resource "sonarqube_portfolio" "main" {
+  key            = "portfolio-key"
+  name           = "portfolio-name"
+  description    = "portfolio-description"
+  selection_mode = "TAGS"
+  tags           = ["team-a"]
+}
+
+resource "sonarqube_portfolio_refresh" "main" {
+  key                 = sonarqube_portfolio.main.key
+  wait_for_completion = true
+  triggers = {
+    tags = join(",", sonarqube_portfolio.main.tags)
+  }
+}
@@ -0,0 +1,10 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+resource "sonarqube_project_ai_code_assurance" "main" {
+  project          = sonarqube_project.main.project
+  contains_ai_code = true
+}
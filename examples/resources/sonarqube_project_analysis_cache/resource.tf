@@ -0,0 +1,15 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+resource "sonarqube_project_analysis_cache" "main" {
+  project = sonarqube_project.main.project
+  enabled = true
+
+  # Purge the cache of every branch when the analyzers are upgraded
+  purge_triggers = {
+    analyzers = "10.7"
+  }
+}
@@ -0,0 +1,10 @@
+variable "release_version" {
+  type = string
+}
+
+# Stamps the most recent analysis of the release branch with the released version
+resource "sonarqube_project_analysis_event" "release" {
+  project = "my_project"
+  branch  = "release"
+  name    = var.release_version
+}
@@ -0,0 +1,16 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "private"
+}
+
+resource "time_rotating" "badge" {
+  rotation_days = 90
+}
+
+resource "sonarqube_project_badge" "main" {
+  project = sonarqube_project.main.project
+  rotation_triggers = {
+    rotation = time_rotating.badge.id
+  }
+}
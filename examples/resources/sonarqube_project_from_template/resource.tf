@@ -0,0 +1,12 @@
+resource "sonarqube_project_from_template" "main" {
+  project             = "my-service"
+  name                = "My Service"
+  permission_template = "team-payments"
+  quality_gate        = "Payments Gate"
+  quality_profiles = {
+    java = "Payments Java"
+    ts   = "Payments TypeScript"
+  }
+  new_code_period_type  = "REFERENCE_BRANCH"
+  new_code_period_value = "main"
+}
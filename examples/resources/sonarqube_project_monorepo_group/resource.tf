@@ -0,0 +1,23 @@
+resource "sonarqube_alm_github" "github" {
+  app_id         = "12345"
+  client_id      = "56789"
+  client_secret  = "secret"
+  key            = "myalm"
+  private_key    = "myprivate_key"
+  url            = "https://api.github.com"
+  webhook_secret = "mysecret"
+}
+
+resource "sonarqube_project" "services" {
+  for_each   = toset(["billing", "orders", "shipping"])
+  name       = each.value
+  project    = each.value
+  visibility = "private"
+}
+
+resource "sonarqube_project_monorepo_group" "services" {
+  alm          = "github"
+  alm_setting  = sonarqube_alm_github.github.key
+  repository   = "myorg/services"
+  project_keys = [for project in sonarqube_project.services : project.project]
+}
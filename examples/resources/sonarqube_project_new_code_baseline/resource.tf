@@ -0,0 +1,12 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+# New branches compare with the main branch from their first analysis,
+# the main branch compares with the previous version
+resource "sonarqube_project_new_code_baseline" "main" {
+  project          = sonarqube_project.main.project
+  main_branch_type = "PREVIOUS_VERSION"
+}
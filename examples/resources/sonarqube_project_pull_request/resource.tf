@@ -0,0 +1,12 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+resource "sonarqube_project_pull_request" "main" {
+  project                       = sonarqube_project.main.project
+  provider_name                 = "GitHub"
+  github_summary_comment        = false
+  inactive_days_before_deletion = 14
+}
@@ -0,0 +1,13 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+resource "sonarqube_project_quality_profile" "main" {
+  project = sonarqube_project.main.project
+  profiles = {
+    java = "My Java"
+    ts   = "My TS"
+  }
+}
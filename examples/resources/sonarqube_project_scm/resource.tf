@@ -0,0 +1,12 @@
+resource "sonarqube_project" "main" {
+  name       = "SonarQube"
+  project    = "my_project"
+  visibility = "public"
+}
+
+resource "sonarqube_project_scm" "main" {
+  project      = sonarqube_project.main.project
+  provider_key = "git"
+  disabled     = false
+  url          = "https://github.com/my-org/my-project"
+}
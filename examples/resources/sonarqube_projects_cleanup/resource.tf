@@ -0,0 +1,12 @@
+resource "time_rotating" "cleanup" {
+  rotation_days = 7
+}
+
+resource "sonarqube_projects_cleanup" "previews" {
+  key_prefix      = "preview-"
+  analyzed_before = formatdate("YYYY-MM-DD", timeadd(time_rotating.cleanup.id, "-720h"))
+  exclude         = ["preview-main"]
+  triggers = {
+    rotation = time_rotating.cleanup.id
+  }
+}
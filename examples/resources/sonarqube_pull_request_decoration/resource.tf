@@ -0,0 +1,5 @@
+# The project was imported from GitHub, so its binding is not managed by Terraform
+resource "sonarqube_pull_request_decoration" "main" {
+  project                 = "my_project"
+  summary_comment_enabled = false
+}
@@ -0,0 +1,10 @@
+resource "sonarqube_qualityprofile" "team" {
+  name     = "team-java"
+  language = "java"
+}
+
+resource "sonarqube_qualityprofile_inheritance" "team" {
+  quality_profile = sonarqube_qualityprofile.team.name
+  language        = sonarqube_qualityprofile.team.language
+  parent          = "Sonar way"
+}
@@ -0,0 +1,10 @@
+resource "sonarqube_portfolio" "main" {
+  key         = "my-portfolio"
+  name        = "My Portfolio"
+  description = "Portfolio of the main projects"
+}
+
+resource "sonarqube_report_subscription" "main" {
+  component = sonarqube_portfolio.main.key
+  frequency = "Monthly"
+}
@@ -0,0 +1,11 @@
+resource "sonarqube_scanner_engine" "main" {
+  download_only_required_analyzers = true
+
+  # The apply fails, and the plans show a drift, when SonarQube is upgraded with other versions
+  engine_sha256 = "0b5a2f3c6e2d1f0c9a8b7e6d5c4b3a2f1e0d9c8b7a6f5e4d3c2b1a0f9e8d7c6b"
+
+  pinned_analyzers = {
+    java   = "8.1.0.36847"
+    python = "4.21.0.16016"
+  }
+}
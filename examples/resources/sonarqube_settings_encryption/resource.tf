@@ -0,0 +1,20 @@
+variable "smtp_password" {
+  type      = string
+  sensitive = true
+}
+
+# The secret key has to be installed on the server before values can be encrypted
+resource "sonarqube_settings_encryption" "key" {
+  generate_secret_key = true
+}
+
+resource "sonarqube_settings_encryption" "values" {
+  values = {
+    smtp_password = var.smtp_password
+  }
+}
+
+resource "sonarqube_setting" "smtp_password" {
+  key   = "email.smtp_password.secured"
+  value = sonarqube_settings_encryption.values.encrypted_values["smtp_password"]
+}
@@ -0,0 +1,3 @@
+resource "sonarqube_telemetry" "main" {
+  enabled = false
+}
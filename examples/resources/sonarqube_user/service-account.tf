@@ -0,0 +1,10 @@
+resource "sonarqube_user" "scanner" {
+  login_name          = "ci-scanner"
+  name                = "CI scanner"
+  is_local            = false
+  deletion_protection = true
+}
+
+data "sonarqube_user_tokens" "scanner" {
+  login_name = sonarqube_user.scanner.login_name
+}
@@ -0,0 +1,18 @@
+variable "dashboard_token" {
+  type      = string
+  sensitive = true
+}
+
+# The homepage is set for the user authenticated by the provider
+provider "sonarqube" {
+  alias = "dashboard"
+  host  = "https://sonarqube.example.com"
+  token = var.dashboard_token
+}
+
+resource "sonarqube_user_homepage" "dashboard" {
+  provider  = sonarqube.dashboard
+  type      = "PROJECT"
+  component = "my_project"
+  branch    = "main"
+}
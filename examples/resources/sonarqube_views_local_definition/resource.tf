@@ -0,0 +1,25 @@
+resource "sonarqube_portfolio" "company" {
+  key         = "company"
+  name        = "Company"
+  description = "All the portfolios of the company"
+}
+
+resource "sonarqube_portfolio" "team" {
+  key         = "team"
+  name        = "Team"
+  description = "The projects of the team"
+}
+
+resource "sonarqube_views_local_definition" "company" {
+  portfolio = sonarqube_portfolio.company.key
+
+  reference {
+    type = "portfolio"
+    key  = sonarqube_portfolio.team.key
+  }
+
+  reference {
+    type = "application"
+    key  = "my-application"
+  }
+}
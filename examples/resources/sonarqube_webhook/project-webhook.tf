@@ -7,5 +7,6 @@ resource "sonarqube_project" "project" {
 resource "sonarqube_webhook" "webhook" {
   name    = "terraform-webhook"
   url     = "https://my-webhook-destination.example.com"
+  scope   = "project"
   project = sonarqube_project.project.name
 }
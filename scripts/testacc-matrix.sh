@@ -0,0 +1,74 @@
+#!/usr/bin/env bash
+# Runs the acceptance tests against several versions of SonarQube, one container at a time.
+#
+# The images are taken from SONARQUBE_IMAGES, separated by spaces. The developer edition images are only added
+# when SONAR_LICENSE is set, as the developer features need a license. The tests of features the running edition
+# or version does not support are skipped by their pre checks. Extra arguments are passed to go test, for example:
+#
+#   ./scripts/testacc-matrix.sh -run TestAccSonarqubeProject
+set -uo pipefail
+
+SONARQUBE_IMAGES=${SONARQUBE_IMAGES:-"sonarqube:9.9-community sonarqube:10.7-community sonarqube:latest"}
+SONARQUBE_DEVELOPER_IMAGES=${SONARQUBE_DEVELOPER_IMAGES:-"sonarqube:9.9-developer sonarqube:10.7-developer sonarqube:developer"}
+SONARQUBE_PORT=${SONARQUBE_PORT:-9001}
+SONARQUBE_START_TIMEOUT=${SONARQUBE_START_TIMEOUT:-300}
+SONAR_USER=${SONAR_USER:-admin}
+SONAR_PASS=${SONAR_PASS:-admin}
+CONTAINER_NAME=sonarqube-testacc
+
+images="$SONARQUBE_IMAGES"
+if [[ -n "${SONAR_LICENSE:-}" ]]; then
+  images="$images $SONARQUBE_DEVELOPER_IMAGES"
+fi
+
+stop_sonarqube() {
+  docker rm -f "$CONTAINER_NAME" >/dev/null 2>&1 || true
+}
+trap stop_sonarqube EXIT
+
+wait_for_sonarqube() {
+  local deadline=$((SECONDS + SONARQUBE_START_TIMEOUT))
+  until [[ "$(curl -s "http://localhost:$SONARQUBE_PORT/api/system/status")" == *'"status":"UP"'* ]]; do
+    if ((SECONDS > deadline)); then
+      return 1
+    fi
+    echo "waiting for SonarQube to start"
+    sleep 15
+  done
+}
+
+# The license is set before the tests, so the tests of the developer features are not skipped
+set_license() {
+  curl -s -f -o /dev/null -u "$SONAR_USER:$SONAR_PASS" -X POST \
+    --data-urlencode "license=$SONAR_LICENSE" "http://localhost:$SONARQUBE_PORT/api/editions/set_license"
+}
+
+failed=()
+for image in $images; do
+  echo "=== Running the acceptance tests against $image"
+  stop_sonarqube
+  if ! docker run --name "$CONTAINER_NAME" -d -p "$SONARQUBE_PORT:9000" "$image" >/dev/null; then
+    failed+=("$image (failed to start)")
+    continue
+  fi
+  if ! wait_for_sonarqube; then
+    failed+=("$image (not up after ${SONARQUBE_START_TIMEOUT}s)")
+    continue
+  fi
+  if [[ "$image" == *developer* ]] && ! set_license; then
+    failed+=("$image (failed to set the license)")
+    continue
+  fi
+
+  if ! TF_ACC=1 SONAR_HOST="http://localhost:$SONARQUBE_PORT" SONAR_USER="$SONAR_USER" SONAR_PASS="$SONAR_PASS" \
+    go test -count=1 ./... "$@"; then
+    failed+=("$image")
+  fi
+done
+
+if ((${#failed[@]} > 0)); then
+  echo "=== The acceptance tests failed against:"
+  printf '  %s\n' "${failed[@]}"
+  exit 1
+fi
+echo "=== The acceptance tests passed against: $images"
@@ -0,0 +1,129 @@
+package sonarqube
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// githubAppWebhookPath is the endpoint of SonarQube that receives the webhook events of a GitHub App
+const githubAppWebhookPath = "/api/alm_integrations/webhook_github"
+
+// githubAppWebhookEvents are the events a GitHub App sends to SonarQube, to re-run analyses from the checks
+var githubAppWebhookEvents = []string{"check_run"}
+
+// githubAppProvisioningWebhookEvents are the additional events required by GitHub provisioning, which synchronizes
+// users, teams and repositories
+var githubAppProvisioningWebhookEvents = []string{"organization", "member", "membership", "repository", "team"}
+
+// almValidateSchema returns the schema of the validate attribute, shared by the ALM resources that can check
+// their credentials against the platform
+func almValidateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Whether to check with `api/alm_settings/validate` that SonarQube can connect to the platform and enumerate repositories with the configured credentials after every change. When the check fails, the apply fails and the change is rolled back: a new setting is deleted and an updated setting gets its previous configuration back.",
+	}
+}
+
+// validateAlmSetting checks that SonarQube can connect to the platform of an ALM setting with its credentials
+func validateAlmSetting(key string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_settings/validate"
+	sonarQubeURL.RawQuery = url.Values{
+		"key": []string{key},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"validateAlmSetting",
+	)
+	if err != nil {
+		return fmt.Errorf("validateAlmSetting: the configuration of ALM setting '%s' is invalid: %+v", key, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// almBoundProjectsSchema returns the schema of the bound_projects attribute, shared by the ALM resources whose key
+// can be changed in place
+func almBoundProjectsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Description: "The projects bound to the setting when its `key` was last changed. They are listed in the plan of a key change, and keep their binding as the setting is updated in place. The web API has no endpoint listing the bindings of a setting, so planning a key change reads the binding of every project, with one request per project.",
+	}
+}
+
+// almKeyChangeDiff lists the projects bound to an ALM setting in bound_projects when its key changes, so the plan
+// shows the bindings affected by the change. This costs one request per project, see listAlmSettingBindings, so it
+// only runs for the plans that change the key.
+func almKeyChangeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("key") {
+		return nil
+	}
+
+	oldKey, newKey := d.GetChange("key")
+	projects, err := findAlmSettingBindings(oldKey.(string), m)
+	if err != nil {
+		return err
+	}
+	if len(projects) > 0 {
+		log.Printf("[WARN][almKeyChangeDiff] Changing the key of ALM setting '%s' to '%s' affects the bindings of the projects %s", oldKey, newKey, strings.Join(projects, ", "))
+	}
+	return d.SetNew("bound_projects", projects)
+}
+
+// almProjectBinding is a project bound to an ALM setting
+type almProjectBinding struct {
+	project ProjectSearchResult
+	binding GetBinding
+}
+
+// findAlmSettingBindings returns the keys of the projects bound to the ALM setting
+func findAlmSettingBindings(key string, m interface{}) ([]string, error) {
+	bindings, err := listAlmSettingBindings(key, m)
+	if err != nil {
+		return nil, err
+	}
+
+	bound := []string{}
+	for _, b := range bindings {
+		bound = append(bound, b.project.Key)
+	}
+	return bound, nil
+}
+
+// listAlmSettingBindings returns the projects bound to the ALM setting with their binding. SonarQube has no endpoint
+// listing the bindings of a setting, api/alm_settings/list only returns the settings, so the binding of every
+// project is read.
+func listAlmSettingBindings(key string, m interface{}) ([]almProjectBinding, error) {
+	projects, err := searchProjectsByKeyPrefix("", "", m)
+	if err != nil {
+		return nil, fmt.Errorf("listAlmSettingBindings: %+v", err)
+	}
+
+	bindings := []almProjectBinding{}
+	for _, project := range projects {
+		binding, err := findProjectBindingFromApi(project.Key, m)
+		if err != nil {
+			return nil, fmt.Errorf("listAlmSettingBindings: Failed to read the binding of project '%s': %+v", project.Key, err)
+		}
+		if binding != nil && binding.Key == key {
+			bindings = append(bindings, almProjectBinding{project: project, binding: *binding})
+		}
+	}
+	return bindings, nil
+}
@@ -0,0 +1,57 @@
+package sonarqube
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+func TestValidateAlmSetting(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		response string
+		wantErr  string
+	}{
+		{
+			name:   "Valid configuration",
+			status: http.StatusNoContent,
+		},
+		{
+			name:     "Invalid credentials",
+			status:   http.StatusBadRequest,
+			response: `{"errors":[{"msg":"Invalid personal access token"}]}`,
+			wantErr:  "the configuration of ALM setting 'gitlab' is invalid: API returned an error for resource validateAlmSetting: Invalid personal access token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/alm_settings/validate" || r.URL.Query().Get("key") != "gitlab" {
+					t.Errorf("unexpected request %s", r.URL.String())
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			serverURL, _ := url.Parse(server.URL)
+			client := retryablehttp.NewClient()
+			client.RetryMax = 0
+			conf := &ProviderConfiguration{httpClient: client, sonarQubeURL: *serverURL}
+
+			err := validateAlmSetting("gitlab", conf)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("validateAlmSetting() unexpected error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("validateAlmSetting() error = %v, want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
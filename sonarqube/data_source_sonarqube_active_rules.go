@@ -0,0 +1,195 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ActiveRuleParam is a parameter value of a rule activated in a quality profile
+type ActiveRuleParam struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ActiveRule is the activation of a rule in a quality profile
+type ActiveRule struct {
+	QProfile string            `json:"qProfile"`
+	Inherit  string            `json:"inherit"`
+	Severity string            `json:"severity"`
+	Params   []ActiveRuleParam `json:"params"`
+}
+
+// SearchActiveRules for unmarshalling response body of api/rules/search with the actives field
+type SearchActiveRules struct {
+	Total   int                     `json:"total"`
+	P       int                     `json:"p"`
+	PS      int                     `json:"ps"`
+	Rules   []Rule                  `json:"rules"`
+	Actives map[string][]ActiveRule `json:"actives"`
+}
+
+func dataSourceSonarqubeActiveRules() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to get the rules activated in a quality profile, with their severity and parameters, for example
+to check that a profile activates a required baseline of rules.`,
+		Read: dataSourceSonarqubeActiveRulesRead,
+		Schema: map[string]*schema.Schema{
+			"quality_profile_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the quality profile. Can be obtained with the `sonarqube_qualityprofile` data source.",
+			},
+			"rule_keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The keys of the active rules, sorted.",
+			},
+			"rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the rule.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the rule.",
+						},
+						"language": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The language of the rule.",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The severity of the rule in the quality profile.",
+						},
+						"inherit": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Whether the activation is inherited from the parent profile: `NONE`, `INHERITED` or `OVERRIDES`.",
+						},
+						"params": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The parameter values of the rule in the quality profile.",
+						},
+					},
+				},
+				Description: "The active rules, sorted by key.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeActiveRulesRead(d *schema.ResourceData, m interface{}) error {
+	qualityProfileKey := d.Get("quality_profile_key").(string)
+
+	rules, actives, err := searchActiveRulesFromApi(qualityProfileKey, m)
+	if err != nil {
+		return err
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].RuleKey < rules[j].RuleKey })
+
+	ruleKeys := []string{}
+	rulesList := []interface{}{}
+	for _, rule := range rules {
+		ruleKeys = append(ruleKeys, rule.RuleKey)
+
+		values := map[string]interface{}{
+			"key":      rule.RuleKey,
+			"name":     rule.Name,
+			"language": rule.Lang,
+			"severity": rule.Severity,
+			"inherit":  "",
+			"params":   map[string]interface{}{},
+		}
+		// The activation of this profile is the one that applies, not the default of the rule
+		for _, active := range actives[rule.RuleKey] {
+			if active.QProfile != qualityProfileKey {
+				continue
+			}
+			params := map[string]interface{}{}
+			for _, param := range active.Params {
+				params[param.Key] = param.Value
+			}
+			values["severity"] = active.Severity
+			values["inherit"] = active.Inherit
+			values["params"] = params
+		}
+		rulesList = append(rulesList, values)
+	}
+
+	d.SetId(qualityProfileKey)
+	errs := []error{}
+	errs = append(errs, d.Set("rule_keys", ruleKeys))
+	errs = append(errs, d.Set("rules", rulesList))
+	return errors.Join(errs...)
+}
+
+// searchActiveRulesFromApi returns the rules activated in the quality profile, with their activations keyed by rule key
+func searchActiveRulesFromApi(qualityProfileKey string, m interface{}) ([]Rule, map[string][]ActiveRule, error) {
+	rules := []Rule{}
+	actives := map[string][]ActiveRule{}
+
+	for page := 1; ; page++ {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/rules/search"
+		sonarQubeURL.RawQuery = url.Values{
+			"qprofile":   []string{qualityProfileKey},
+			"activation": []string{"true"},
+			"f":          []string{"name,lang,severity,params,actives"},
+			"ps":         []string{"500"},
+			"p":          []string{strconv.Itoa(page)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"searchActiveRulesFromApi",
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("searchActiveRulesFromApi: Failed to search the active rules of quality profile '%s': %+v", qualityProfileKey, err)
+		}
+
+		// Decode response into struct
+		response := SearchActiveRules{}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("searchActiveRulesFromApi: Failed to decode json into struct: %+v", err)
+		}
+
+		rules = append(rules, response.Rules...)
+		for key, ruleActives := range response.Actives {
+			actives[key] = append(actives[key], ruleActives...)
+		}
+
+		if len(response.Rules) == 0 || response.PS == 0 || page*response.PS >= response.Total {
+			break
+		}
+	}
+
+	return rules, actives, nil
+}
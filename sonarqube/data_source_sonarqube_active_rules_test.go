@@ -0,0 +1,111 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeActiveRulesDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[1]s"
+			language = "xml"
+		}
+
+		resource "sonarqube_rule" "%[1]s" {
+			custom_key           = "%[1]s"
+			markdown_description = "My rule"
+			name                 = "%[1]s"
+			severity             = "MAJOR"
+			template_key         = "xml:XPathCheck"
+			type                 = "VULNERABILITY"
+		}
+
+		resource "sonarqube_qualityprofile_activate_rule" "%[1]s" {
+			key      = sonarqube_qualityprofile.%[1]s.key
+			rule     = sonarqube_rule.%[1]s.id
+			severity = "BLOCKER"
+		}
+
+		data "sonarqube_active_rules" "%[1]s" {
+			quality_profile_key = sonarqube_qualityprofile.%[1]s.key
+			depends_on          = [sonarqube_qualityprofile_activate_rule.%[1]s]
+		}`, rnd)
+}
+
+func TestAccSonarqubeActiveRulesDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_active_rules." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeActiveRulesDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "rule_keys.#", "1"),
+					resource.TestCheckResourceAttr(name, "rule_keys.0", "xml:"+rnd),
+					resource.TestCheckResourceAttr(name, "rules.0.severity", "BLOCKER"),
+					resource.TestCheckResourceAttr(name, "rules.0.inherit", "NONE"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceSonarqubeActiveRulesRead(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.handle("GET", "/api/rules/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("activation") != "true" || r.URL.Query().Get("qprofile") != "my-profile" {
+			mockError(w, http.StatusBadRequest, "unexpected query "+r.URL.RawQuery)
+			return
+		}
+		// One rule per page, so both pages must be read
+		if r.URL.Query().Get("p") == "1" {
+			mockJSON(w, map[string]interface{}{
+				"total": 2, "p": 1, "ps": 1,
+				"rules": []map[string]interface{}{{"key": "java:S107", "name": "Too many parameters", "lang": "java", "severity": "MAJOR"}},
+				"actives": map[string]interface{}{
+					"java:S107": []map[string]interface{}{
+						{"qProfile": "parent-profile", "inherit": "NONE", "severity": "MAJOR", "params": []map[string]string{{"key": "max", "value": "7"}}},
+						{"qProfile": "my-profile", "inherit": "OVERRIDES", "severity": "CRITICAL", "params": []map[string]string{{"key": "max", "value": "5"}}},
+					},
+				},
+			})
+			return
+		}
+		mockJSON(w, map[string]interface{}{
+			"total": 2, "p": 2, "ps": 1,
+			"rules": []map[string]interface{}{{"key": "java:S1068", "name": "Unused private fields", "lang": "java", "severity": "MAJOR"}},
+			"actives": map[string]interface{}{
+				"java:S1068": []map[string]interface{}{{"qProfile": "my-profile", "inherit": "NONE", "severity": "MINOR", "params": []map[string]string{}}},
+			},
+		})
+	})
+
+	r := dataSourceSonarqubeActiveRules()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"quality_profile_key": "my-profile"})
+	if err := r.Read(d, mock.conf("community", "10.7")); err != nil {
+		t.Fatalf("Read() error = %+v", err)
+	}
+
+	if got, expected := d.Get("rule_keys"), []interface{}{"java:S1068", "java:S107"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("rule_keys = %v, want %v", got, expected)
+	}
+	expected := []interface{}{
+		map[string]interface{}{"key": "java:S1068", "name": "Unused private fields", "language": "java", "severity": "MINOR", "inherit": "NONE", "params": map[string]interface{}{}},
+		map[string]interface{}{"key": "java:S107", "name": "Too many parameters", "language": "java", "severity": "CRITICAL", "inherit": "OVERRIDES", "params": map[string]interface{}{"max": "5"}},
+	}
+	if got := d.Get("rules"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("rules = %v, want %v", got, expected)
+	}
+	if got := len(mock.requests()); got != 2 {
+		t.Errorf("expected 2 requests, got %d: %v", got, mock.requests())
+	}
+}
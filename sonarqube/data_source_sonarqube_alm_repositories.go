@@ -0,0 +1,199 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// AlmRepository is a repository returned by api/alm_integrations/list_github_repositories or search_gitlab_repos
+type AlmRepository struct {
+	ID           json.Number `json:"id"`
+	Key          string      `json:"key"`
+	Name         string      `json:"name"`
+	URL          string      `json:"url"`
+	PathSlug     string      `json:"pathSlug"`
+	SqProjectKey string      `json:"sqProjectKey"`
+}
+
+// ListAlmRepositories for unmarshalling response body of the repository lists of api/alm_integrations
+type ListAlmRepositories struct {
+	Paging       Paging          `json:"paging"`
+	Repositories []AlmRepository `json:"repositories"`
+}
+
+func dataSourceSonarqubeAlmRepositories() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to list the repositories that SonarQube can import from a GitHub organization or from GitLab,
+for example to create a project with ` + "`for_each`" + ` for every repository matching a filter. It requires the ` + "`provisioning`" + `
+permission. For GitLab, the user of the provider must have a personal access token for the ALM setting, which can be set in the
+SonarQube UI or with api/alm_integrations/set_pat.`,
+		Read: dataSourceSonarqubeAlmRepositoriesRead,
+		Schema: map[string]*schema.Schema{
+			"alm": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"github", "gitlab"}, false)),
+				Description:      "The DevOps platform of the ALM setting. Possible values are `github` and `gitlab`.",
+			},
+			"alm_setting": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the ALM setting.",
+			},
+			"organization": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The GitHub organization whose repositories are listed. Required for GitHub.",
+			},
+			"query": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the repositories whose name contains this text, filtered by the DevOps platform.",
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description:      "Only list the repositories whose name matches this regular expression.",
+			},
+			"repositories": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"repository": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The identifier of the repository in the bindings: the key, for example `my-org/my-repo`, for GitHub and the ID for GitLab.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the repository.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The full path of the repository, for example `my-org/my-repo` or `my-group/my-subgroup/my-repo`.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL of the repository.",
+						},
+						"project_key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the SonarQube project already imported from the repository, if any.",
+						},
+					},
+				},
+				Description: "The repositories, in the order of the DevOps platform.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeAlmRepositoriesRead(d *schema.ResourceData, m interface{}) error {
+	alm := d.Get("alm").(string)
+	almSetting := d.Get("alm_setting").(string)
+	organization := d.Get("organization").(string)
+	if alm == "github" && organization == "" {
+		return fmt.Errorf("dataSourceSonarqubeAlmRepositoriesRead: organization is required to list GitHub repositories")
+	}
+
+	var nameRegex *regexp.Regexp
+	if expression := d.Get("name_regex").(string); expression != "" {
+		nameRegex = regexp.MustCompile(expression)
+	}
+
+	repositories, err := listAlmRepositoriesFromApi(alm, almSetting, organization, d.Get("query").(string), m)
+	if err != nil {
+		return err
+	}
+
+	repositoriesList := []interface{}{}
+	for _, repository := range repositories {
+		if nameRegex != nil && !nameRegex.MatchString(repository.Name) {
+			continue
+		}
+		identifier, path := repository.Key, repository.Key
+		if alm == "gitlab" {
+			identifier, path = repository.ID.String(), repository.PathSlug
+		}
+		repositoriesList = append(repositoriesList, map[string]interface{}{
+			"repository":  identifier,
+			"name":        repository.Name,
+			"path":        path,
+			"url":         repository.URL,
+			"project_key": repository.SqProjectKey,
+		})
+	}
+
+	d.SetId(strings.Join([]string{alm, almSetting, organization}, "/"))
+	errs := []error{}
+	errs = append(errs, d.Set("repositories", repositoriesList))
+	return errors.Join(errs...)
+}
+
+// listAlmRepositoriesFromApi returns every page of the repositories of a GitHub organization or of GitLab
+func listAlmRepositoriesFromApi(alm string, almSetting string, organization string, query string, m interface{}) ([]AlmRepository, error) {
+	repositories := []AlmRepository{}
+
+	for page := 1; ; page++ {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		rawQuery := url.Values{
+			"almSetting": []string{almSetting},
+			"p":          []string{strconv.Itoa(page)},
+			"ps":         []string{"100"},
+		}
+		if alm == "github" {
+			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_integrations/list_github_repositories"
+			rawQuery.Set("organization", organization)
+			if query != "" {
+				rawQuery.Set("q", query)
+			}
+		} else {
+			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_integrations/search_gitlab_repos"
+			if query != "" {
+				rawQuery.Set("projectName", query)
+			}
+		}
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"listAlmRepositoriesFromApi",
+		)
+		if err != nil {
+			return nil, fmt.Errorf("listAlmRepositoriesFromApi: Failed to list the %s repositories of ALM setting '%s': %+v", alm, almSetting, err)
+		}
+
+		// Decode response into struct
+		response := ListAlmRepositories{}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("listAlmRepositoriesFromApi: Failed to decode json into struct: %+v", err)
+		}
+
+		repositories = append(repositories, response.Repositories...)
+		if len(response.Repositories) == 0 || int64(len(repositories)) >= response.Paging.Total {
+			break
+		}
+	}
+
+	return repositories, nil
+}
@@ -0,0 +1,85 @@
+package sonarqube
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceSonarqubeAlmRepositoriesGithub(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.handle("GET", "/api/alm_integrations/list_github_repositories", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("almSetting") != "my-github" || r.URL.Query().Get("organization") != "my-org" {
+			mockError(w, http.StatusBadRequest, "unexpected query "+r.URL.RawQuery)
+			return
+		}
+		if r.URL.Query().Get("p") == "1" {
+			mockJSON(w, map[string]interface{}{
+				"paging": map[string]int{"pageIndex": 1, "pageSize": 2, "total": 3},
+				"repositories": []map[string]interface{}{
+					{"id": 1, "key": "my-org/api-service", "name": "api-service", "url": "https://github.com/my-org/api-service", "sqProjectKey": "api-service"},
+					{"id": 2, "key": "my-org/docs", "name": "docs", "url": "https://github.com/my-org/docs"},
+				},
+			})
+			return
+		}
+		mockJSON(w, map[string]interface{}{
+			"paging":       map[string]int{"pageIndex": 2, "pageSize": 2, "total": 3},
+			"repositories": []map[string]interface{}{{"id": 3, "key": "my-org/web-service", "name": "web-service", "url": "https://github.com/my-org/web-service"}},
+		})
+	})
+
+	r := dataSourceSonarqubeAlmRepositories()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"alm":          "github",
+		"alm_setting":  "my-github",
+		"organization": "my-org",
+		"name_regex":   "-service$",
+	})
+	if err := r.Read(d, mock.conf("Developer", "10.7")); err != nil {
+		t.Fatalf("Read() error = %+v", err)
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{"repository": "my-org/api-service", "name": "api-service", "path": "my-org/api-service", "url": "https://github.com/my-org/api-service", "project_key": "api-service"},
+		map[string]interface{}{"repository": "my-org/web-service", "name": "web-service", "path": "my-org/web-service", "url": "https://github.com/my-org/web-service", "project_key": ""},
+	}
+	if got := d.Get("repositories"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("repositories = %v, want %v", got, expected)
+	}
+}
+
+func TestDataSourceSonarqubeAlmRepositoriesGitlab(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.handle("GET", "/api/alm_integrations/search_gitlab_repos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("projectName") != "service" {
+			mockError(w, http.StatusBadRequest, "unexpected query "+r.URL.RawQuery)
+			return
+		}
+		mockJSON(w, map[string]interface{}{
+			"paging":       map[string]int{"pageIndex": 1, "pageSize": 100, "total": 1},
+			"repositories": []map[string]interface{}{{"id": 42, "name": "api-service", "pathSlug": "my-group/api-service", "url": "https://gitlab.com/my-group/api-service"}},
+		})
+	})
+
+	r := dataSourceSonarqubeAlmRepositories()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"alm":         "gitlab",
+		"alm_setting": "my-gitlab",
+		"query":       "service",
+	})
+	if err := r.Read(d, mock.conf("Developer", "10.7")); err != nil {
+		t.Fatalf("Read() error = %+v", err)
+	}
+	if d.Get("repositories.0.repository") != "42" || d.Get("repositories.0.path") != "my-group/api-service" {
+		t.Errorf("repositories = %v", d.Get("repositories"))
+	}
+
+	// GitHub repositories can only be listed by organization
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"alm": "github", "alm_setting": "my-github"})
+	if err := r.Read(d, mock.conf("Developer", "10.7")); err == nil {
+		t.Errorf("Read() should require the organization for GitHub")
+	}
+}
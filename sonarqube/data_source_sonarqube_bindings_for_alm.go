@@ -0,0 +1,93 @@
+package sonarqube
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubeBindingsForAlm() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to list the projects bound to an ALM/DevOps Platform setting, for example to find the projects that
+still use a deprecated GitLab configuration. SonarQube has no endpoint listing the bindings of a setting, so the binding of every project
+is read, which takes a while on instances with many projects.`,
+		Read: dataSourceSonarqubeBindingsForAlmRead,
+		Schema: map[string]*schema.Schema{
+			"alm_setting": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the ALM setting.",
+			},
+			"project_keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The keys of the projects bound to the ALM setting.",
+			},
+			"bindings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the project.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the project.",
+						},
+						"alm": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The DevOps platform of the binding, for example `gitlab`.",
+						},
+						"repository": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The repository the project is bound to.",
+						},
+						"monorepo": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the repository is a monorepo.",
+						},
+					},
+				},
+				Description: "The bindings of the projects bound to the ALM setting.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeBindingsForAlmRead(d *schema.ResourceData, m interface{}) error {
+	almSetting := d.Get("alm_setting").(string)
+
+	bindings, err := listAlmSettingBindings(almSetting, m)
+	if err != nil {
+		return err
+	}
+
+	projectKeys := []string{}
+	bindingsList := []interface{}{}
+	for _, b := range bindings {
+		projectKeys = append(projectKeys, b.project.Key)
+		bindingsList = append(bindingsList, map[string]interface{}{
+			"project":    b.project.Key,
+			"name":       b.project.Name,
+			"alm":        b.binding.Alm,
+			"repository": b.binding.Repository,
+			"monorepo":   b.binding.Monorepo,
+		})
+	}
+
+	d.SetId(almSetting)
+	errs := []error{}
+	errs = append(errs, d.Set("project_keys", projectKeys))
+	errs = append(errs, d.Set("bindings", bindingsList))
+	return errors.Join(errs...)
+}
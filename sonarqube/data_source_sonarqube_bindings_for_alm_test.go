@@ -0,0 +1,52 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSonarqubeBindingsForAlmDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_bindings_for_alm." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckGitlabBindingSupport(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "sonarqube_alm_gitlab" "%[1]s" {
+						personal_access_token = "123456"
+						key                   = "%[1]s"
+						url                   = "https://gitlab.com/api/v4"
+					}
+
+					resource "sonarqube_project" "%[1]s" {
+						name       = "%[1]s"
+						project    = "%[1]s"
+						visibility = "public"
+					}
+
+					resource "sonarqube_gitlab_binding" "%[1]s" {
+						alm_setting = sonarqube_alm_gitlab.%[1]s.key
+						project     = sonarqube_project.%[1]s.project
+						repository  = "123"
+					}
+
+					data "sonarqube_bindings_for_alm" "%[1]s" {
+						alm_setting = sonarqube_alm_gitlab.%[1]s.key
+
+						depends_on = [sonarqube_gitlab_binding.%[1]s]
+					}`, rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "project_keys.#", "1"),
+					resource.TestCheckResourceAttr(name, "project_keys.0", rnd),
+					resource.TestCheckResourceAttr(name, "bindings.0.alm", "gitlab"),
+					resource.TestCheckResourceAttr(name, "bindings.0.repository", "123"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,301 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// CeTask for unmarshalling the background tasks returned by the api/ce endpoints
+type CeTask struct {
+	ID              string `json:"id"`
+	Type            string `json:"type"`
+	ComponentKey    string `json:"componentKey"`
+	Status          string `json:"status"`
+	Branch          string `json:"branch"`
+	PullRequest     string `json:"pullRequest"`
+	SubmittedAt     string `json:"submittedAt"`
+	StartedAt       string `json:"startedAt"`
+	ExecutedAt      string `json:"executedAt"`
+	ExecutionTimeMs int64  `json:"executionTimeMs"`
+	AnalysisID      string `json:"analysisId"`
+	ErrorMessage    string `json:"errorMessage"`
+}
+
+// GetCeActivity for unmarshalling response body of api/ce/activity
+type GetCeActivity struct {
+	Tasks []CeTask `json:"tasks"`
+}
+
+// CeActivityStatus for unmarshalling response body of api/ce/activity_status
+type CeActivityStatus struct {
+	Pending       int64 `json:"pending"`
+	InProgress    int64 `json:"inProgress"`
+	Failing       int64 `json:"failing"`
+	PendingTimeMs int64 `json:"pendingTime"`
+}
+
+// ceTaskStatuses are the statuses a background task can have
+var ceTaskStatuses = []string{"SUCCESS", "FAILED", "CANCELED", "PENDING", "IN_PROGRESS"}
+
+func dataSourceSonarqubeCeActivity() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the background tasks (Compute Engine activity) of SonarQube, globally or for a single project.",
+		Read:        dataSourceSonarqubeCeActivityRead,
+		Schema: map[string]*schema.Schema{
+			"component": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The key of the project to get the background tasks of. When not set, the tasks of all projects are returned.",
+			},
+			"statuses": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(ceTaskStatuses, false)),
+				},
+				Description: "Only return the tasks with these statuses. Possible values are `SUCCESS`, `FAILED`, `CANCELED`, `PENDING` and `IN_PROGRESS`.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the tasks of this type, for example `REPORT`.",
+			},
+			"only_current": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only return the most recent task of each project and branch.",
+			},
+			"max_results": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 1000)),
+				Description:      "The maximum number of tasks to return. Defaults to `100`.",
+			},
+			"pending": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of pending tasks.",
+			},
+			"in_progress": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of tasks in progress.",
+			},
+			"failing": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of projects whose most recent task failed.",
+			},
+			"pending_time_ms": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The time in milliseconds the oldest pending task has been waiting.",
+			},
+			"tasks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: ceTaskSchema(),
+				},
+				Description: "The list of background tasks, most recent first.",
+			},
+		},
+	}
+}
+
+// ceTaskSchema returns the computed attributes of a background task
+func ceTaskSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the task.",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The type of the task, for example `REPORT`.",
+		},
+		"component_key": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The key of the project of the task.",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The status of the task.",
+		},
+		"branch": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The branch of the task.",
+		},
+		"pull_request": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The pull request of the task.",
+		},
+		"submitted_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the task was submitted.",
+		},
+		"started_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the task was started.",
+		},
+		"executed_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the task finished.",
+		},
+		"execution_time_ms": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The execution time of the task in milliseconds.",
+		},
+		"analysis_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the analysis created by the task.",
+		},
+		"error_message": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The error message of a failed task.",
+		},
+	}
+}
+
+func dataSourceSonarqubeCeActivityRead(d *schema.ResourceData, m interface{}) error {
+	component := d.Get("component").(string)
+	statuses := expandStringList(d.Get("statuses"))
+	d.SetId(fmt.Sprintf("%d", schema.HashString(fmt.Sprintf("%s/%s/%s/%t/%d", component, strings.Join(statuses, ","), d.Get("type").(string), d.Get("only_current").(bool), d.Get("max_results").(int)))))
+
+	activity, err := readCeActivityFromApi(d, m)
+	if err != nil {
+		return err
+	}
+	status, err := readCeActivityStatusFromApi(component, m)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("tasks", flattenCeTasks(activity.Tasks)))
+	errs = append(errs, d.Set("pending", status.Pending))
+	errs = append(errs, d.Set("in_progress", status.InProgress))
+	errs = append(errs, d.Set("failing", status.Failing))
+	errs = append(errs, d.Set("pending_time_ms", status.PendingTimeMs))
+	return errors.Join(errs...)
+}
+
+func readCeActivityFromApi(d *schema.ResourceData, m interface{}) (*GetCeActivity, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/ce/activity"
+
+	RawQuery := url.Values{
+		"ps":           []string{strconv.Itoa(d.Get("max_results").(int))},
+		"onlyCurrents": []string{strconv.FormatBool(d.Get("only_current").(bool))},
+	}
+	if component, ok := d.GetOk("component"); ok {
+		RawQuery.Add("component", component.(string))
+	}
+	if statuses := expandStringList(d.Get("statuses")); len(statuses) > 0 {
+		RawQuery.Add("status", strings.Join(statuses, ","))
+	}
+	if taskType, ok := d.GetOk("type"); ok {
+		RawQuery.Add("type", taskType.(string))
+	}
+	sonarQubeURL.RawQuery = RawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readCeActivityFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readCeActivityFromApi: Failed to read Sonarqube background tasks: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	activity := GetCeActivity{}
+	err = json.NewDecoder(resp.Body).Decode(&activity)
+	if err != nil {
+		return nil, fmt.Errorf("readCeActivityFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &activity, nil
+}
+
+func readCeActivityStatusFromApi(component string, m interface{}) (*CeActivityStatus, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/ce/activity_status"
+	if component != "" {
+		sonarQubeURL.RawQuery = url.Values{
+			"component": []string{component},
+		}.Encode()
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readCeActivityStatusFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readCeActivityStatusFromApi: Failed to read Sonarqube background tasks status: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	status := CeActivityStatus{}
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	if err != nil {
+		return nil, fmt.Errorf("readCeActivityStatusFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &status, nil
+}
+
+func flattenCeTask(task CeTask) map[string]interface{} {
+	return map[string]interface{}{
+		"id":                task.ID,
+		"type":              task.Type,
+		"component_key":     task.ComponentKey,
+		"status":            task.Status,
+		"branch":            task.Branch,
+		"pull_request":      task.PullRequest,
+		"submitted_at":      task.SubmittedAt,
+		"started_at":        task.StartedAt,
+		"executed_at":       task.ExecutedAt,
+		"execution_time_ms": task.ExecutionTimeMs,
+		"analysis_id":       task.AnalysisID,
+		"error_message":     task.ErrorMessage,
+	}
+}
+
+func flattenCeTasks(tasks []CeTask) []interface{} {
+	tasksList := []interface{}{}
+	for _, task := range tasks {
+		tasksList = append(tasksList, flattenCeTask(task))
+	}
+	return tasksList
+}
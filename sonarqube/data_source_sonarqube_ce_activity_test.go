@@ -0,0 +1,42 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeCeActivityDataSourceConfig(rnd string, projectKey string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		data "sonarqube_ce_activity" "%[1]s" {
+			component = sonarqube_project.%[1]s.project
+			statuses  = ["SUCCESS", "FAILED"]
+		}`, rnd, projectKey)
+}
+
+func TestAccSonarqubeCeActivityDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_ce_activity." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeCeActivityDataSourceConfig(rnd, "testAccSonarqubeCeActivityDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "tasks.#", "0"),
+					resource.TestCheckResourceAttr(name, "pending", "0"),
+					resource.TestCheckResourceAttr(name, "in_progress", "0"),
+				),
+			},
+		},
+	})
+}
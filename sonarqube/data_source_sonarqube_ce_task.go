@@ -0,0 +1,180 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetCeTask for unmarshalling response body of api/ce/task
+type GetCeTask struct {
+	Task CeTask `json:"task"`
+}
+
+// GetCeComponent for unmarshalling response body of api/ce/component
+type GetCeComponent struct {
+	Queue   []CeTask `json:"queue"`
+	Current *CeTask  `json:"current"`
+}
+
+func dataSourceSonarqubeCeTask() *schema.Resource {
+	taskSchema := ceTaskSchema()
+	delete(taskSchema, "id")
+	taskSchema["task_id"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"task_id", "component"},
+		Description:  "The ID of the background task. Cannot be used with `component`.",
+	}
+	taskSchema["component"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ExactlyOneOf: []string{"task_id", "component"},
+		Description:  "The key of a project. When set, the most recent background task of the project is returned. Cannot be used with `task_id`.",
+	}
+	taskSchema["queue_size"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "The number of pending and in progress tasks of the project. Only set when `component` is used.",
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to get the status of a SonarQube background task, or of the most recent background task of a project.",
+		Read:        dataSourceSonarqubeCeTaskRead,
+		Schema:      taskSchema,
+	}
+}
+
+func dataSourceSonarqubeCeTaskRead(d *schema.ResourceData, m interface{}) error {
+	var task *CeTask
+	queueSize := 0
+
+	if component, ok := d.GetOk("component"); ok {
+		ceComponent, err := readCeComponentFromApi(component.(string), m)
+		if err != nil {
+			return err
+		}
+		if ceComponent.Current == nil {
+			return fmt.Errorf("dataSourceSonarqubeCeTaskRead: No background task found for project: %s", component.(string))
+		}
+		task = ceComponent.Current
+		queueSize = len(ceComponent.Queue)
+	} else {
+		ceTask, err := readCeTaskFromApi(d.Get("task_id").(string), m)
+		if err != nil {
+			return err
+		}
+		task = ceTask
+	}
+
+	d.SetId(task.ID)
+	errs := []error{}
+	for key, value := range flattenCeTask(*task) {
+		if key == "id" {
+			key = "task_id"
+		}
+		errs = append(errs, d.Set(key, value))
+	}
+	errs = append(errs, d.Set("queue_size", queueSize))
+	return errors.Join(errs...)
+}
+
+func readCeTaskFromApi(id string, m interface{}) (*CeTask, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/ce/task"
+	sonarQubeURL.RawQuery = url.Values{
+		"id": []string{id},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readCeTaskFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readCeTaskFromApi: Failed to read Sonarqube background task: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	ceTask := GetCeTask{}
+	err = json.NewDecoder(resp.Body).Decode(&ceTask)
+	if err != nil {
+		return nil, fmt.Errorf("readCeTaskFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &ceTask.Task, nil
+}
+
+func readCeComponentFromApi(component string, m interface{}) (*GetCeComponent, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/ce/component"
+	sonarQubeURL.RawQuery = url.Values{
+		"component": []string{component},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readCeComponentFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readCeComponentFromApi: Failed to read Sonarqube background tasks of project: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	ceComponent := GetCeComponent{}
+	err = json.NewDecoder(resp.Body).Decode(&ceComponent)
+	if err != nil {
+		return nil, fmt.Errorf("readCeComponentFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &ceComponent, nil
+}
+
+// waitForFirstAnalysisSchema returns the schema of the wait_for_first_analysis attribute, shared by the resources
+// that can wait for the first analysis of their project
+func waitForFirstAnalysisSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Description: "Whether to wait on creation until the first analysis of the project has been processed, so that dependent resources and data sources see real data. The wait is limited by the `create` timeout, which defaults to 30 minutes.",
+	}
+}
+
+// waitForFirstAnalysis polls api/ce/component until the most recent background task of the project has succeeded
+func waitForFirstAnalysis(project string, timeout time.Duration, m interface{}) error {
+	return retry.RetryContext(context.Background(), timeout, func() *retry.RetryError {
+		ceComponent, err := readCeComponentFromApi(project, m)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		if ceComponent.Current == nil {
+			log.Printf("[DEBUG][waitForFirstAnalysis] No analysis processed yet for project %s", project)
+			return retry.RetryableError(fmt.Errorf("waitForFirstAnalysis: no analysis processed yet for project %s", project))
+		}
+		if ceComponent.Current.Status == "FAILED" {
+			return retry.NonRetryableError(fmt.Errorf("waitForFirstAnalysis: the first analysis of project %s failed: %s", project, ceComponent.Current.ErrorMessage))
+		}
+		if ceComponent.Current.Status != "SUCCESS" {
+			return retry.RetryableError(fmt.Errorf("waitForFirstAnalysis: the first analysis of project %s is %s", project, ceComponent.Current.Status))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,37 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeCeTaskDataSourceConfig(rnd string, projectKey string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		data "sonarqube_ce_task" "%[1]s" {
+			component = sonarqube_project.%[1]s.project
+		}`, rnd, projectKey)
+}
+
+func TestAccSonarqubeCeTaskDataSourceNoAnalysis(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeCeTaskDataSourceConfig(rnd, "testAccSonarqubeCeTaskDataSource"),
+				ExpectError: regexp.MustCompile("No background task found for project"),
+			},
+		},
+	})
+}
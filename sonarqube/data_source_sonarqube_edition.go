@@ -0,0 +1,62 @@
+package sonarqube
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubeEdition() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the version and edition of the SonarQube instance the provider is connected to. This can be used to conditionally create resources that are only supported by some editions.",
+		Read:        dataSourceSonarqubeEditionRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The version of SonarQube.",
+			},
+			"edition": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The edition of SonarQube.",
+			},
+			"is_community": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the edition is Community.",
+			},
+			"is_developer": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the edition is Developer or higher. False when the edition is unknown, for example on SonarCloud. Use this to create resources that require at least a Developer edition, like ALM bindings.",
+			},
+			"is_enterprise": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the edition is Enterprise or Data Center. Use this to create resources that require at least an Enterprise edition, like portfolios.",
+			},
+			"is_datacenter": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the edition is Data Center.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeEditionRead(d *schema.ResourceData, m interface{}) error {
+	conf := m.(*ProviderConfiguration)
+	edition := strings.ToLower(conf.sonarQubeEdition)
+
+	d.SetId(conf.sonarQubeVersion.String())
+	errs := []error{}
+	errs = append(errs, d.Set("version", conf.sonarQubeVersion.String()))
+	errs = append(errs, d.Set("edition", conf.sonarQubeEdition))
+	errs = append(errs, d.Set("is_community", edition == "community"))
+	errs = append(errs, d.Set("is_developer", edition == "developer" || edition == "enterprise" || edition == "data center"))
+	errs = append(errs, d.Set("is_enterprise", edition == "enterprise" || edition == "data center"))
+	errs = append(errs, d.Set("is_datacenter", edition == "data center"))
+	return errors.Join(errs...)
+}
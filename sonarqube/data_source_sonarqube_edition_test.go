@@ -0,0 +1,62 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeEditionDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		data "sonarqube_edition" "%[1]s" {}`, rnd)
+}
+
+func TestAccSonarqubeEditionDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_edition." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeEditionDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "version"),
+					resource.TestCheckResourceAttrSet(name, "edition"),
+					resource.TestCheckResourceAttrSet(name, "is_developer"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceSonarqubeEditionRead(t *testing.T) {
+	mock := newMockSonarQube(t)
+	r := dataSourceSonarqubeEdition()
+
+	tests := []struct {
+		edition    string
+		community  bool
+		developer  bool
+		enterprise bool
+	}{
+		{"Community", true, false, false},
+		{"Developer", false, true, false},
+		{"Enterprise", false, true, true},
+		{"Data Center", false, true, true},
+		// SonarCloud, or an edition that was not detected
+		{"", false, false, false},
+	}
+	for _, tt := range tests {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+		if err := r.Read(d, mock.conf(tt.edition, "10.7")); err != nil {
+			t.Fatalf("Read(%q) error = %+v", tt.edition, err)
+		}
+		if d.Get("is_community") != tt.community || d.Get("is_developer") != tt.developer || d.Get("is_enterprise") != tt.enterprise {
+			t.Errorf("Read(%q) is_community = %v, is_developer = %v, is_enterprise = %v", tt.edition, d.Get("is_community"), d.Get("is_developer"), d.Get("is_enterprise"))
+		}
+	}
+}
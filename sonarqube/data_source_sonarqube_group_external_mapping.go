@@ -0,0 +1,89 @@
+package sonarqube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// groupsSyncSettings are the settings that enable the group synchronization of each identity provider. The LDAP
+// synchronization is configured in sonar.properties, which cannot be read with the web API.
+var groupsSyncSettings = map[string]string{
+	"saml":   "sonar.auth.saml.group.name",
+	"oidc":   "sonar.auth.oidc.groupsSync",
+	"github": "sonar.auth.github.groupsSync",
+	"gitlab": "sonar.auth.gitlab.groupsSync",
+}
+
+func dataSourceSonarqubeGroupExternalMapping() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to check whether a SonarQube group gets the members of the group with the same name in an
+external identity provider (SAML, LDAP, OpenID Connect, GitHub or GitLab). SonarQube has no API to map groups: it synchronizes the
+external groups by name when the users log in, once the group synchronization is enabled by the authentication resources, for
+example ` + "`groups_sync`" + ` of ` + "`sonarqube_auth_github`" + `. Use ` + "`groups_sync`" + ` in a precondition to fail the plan when it is disabled.`,
+		Read: dataSourceSonarqubeGroupExternalMappingRead,
+		Schema: map[string]*schema.Schema{
+			"identity_provider": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"saml", "ldap", "oidc", "github", "gitlab"}, false)),
+				Description:      "The identity provider of the external group. Possible values are `saml`, `ldap`, `oidc`, `github` and `gitlab`.",
+			},
+			"group": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the SonarQube group, which is also the name of the external group, for example `my-org/my-team` for a GitHub team.",
+			},
+			"groups_sync": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the group synchronization of the identity provider is enabled. Always `true` for LDAP, whose configuration cannot be read.",
+			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the group is managed by the provisioning of the identity provider. Only set from SonarQube 10.5.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeGroupExternalMappingRead(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("group").(string)
+	group, err := findGroupFromApi(name, m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeGroupExternalMappingRead: %+v", err)
+	}
+	if group == nil {
+		return fmt.Errorf("dataSourceSonarqubeGroupExternalMappingRead: group '%s' not found", name)
+	}
+
+	managed := false
+	if supportsV2API(m.(*ProviderConfiguration), groupsV2MinimumVersion) {
+		groupV2, err := readGroupV2FromApi(group.Name, m)
+		if err != nil {
+			return fmt.Errorf("dataSourceSonarqubeGroupExternalMappingRead: %+v", err)
+		}
+		managed = groupV2.Managed
+	}
+
+	provider := d.Get("identity_provider").(string)
+	groupsSync := true
+	if key, ok := groupsSyncSettings[provider]; ok {
+		settings, err := getSettingsByKeys("", []string{key}, m)
+		if err != nil {
+			return fmt.Errorf("dataSourceSonarqubeGroupExternalMappingRead: %+v", err)
+		}
+		// SAML synchronizes the groups when the name of the groups attribute is set, the others have a boolean setting
+		value := settings[key].Value
+		groupsSync = value == "true" || (provider == "saml" && value != "")
+	}
+
+	d.SetId(provider + "/" + group.Name)
+	errs := []error{}
+	errs = append(errs, d.Set("groups_sync", groupsSync))
+	errs = append(errs, d.Set("managed", managed))
+	return errors.Join(errs...)
+}
@@ -0,0 +1,72 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeGroupExternalMappingDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_group" "%[1]s" {
+			name = "my-org/%[1]s"
+		}
+
+		data "sonarqube_group_external_mapping" "%[1]s" {
+			identity_provider = "github"
+			group             = sonarqube_group.%[1]s.name
+		}`, rnd)
+}
+
+func TestAccSonarqubeGroupExternalMappingDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_group_external_mapping." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGroupExternalMappingDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "github/my-org/"+rnd),
+					resource.TestCheckResourceAttr(name, "groups_sync", "false"),
+					resource.TestCheckResourceAttr(name, "managed", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceSonarqubeGroupExternalMappingRead(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/user_groups/search", http.StatusOK, `{"groups":[{"name":"developers-all"},{"name":"developers"}]}`)
+	mock.respond("GET", "/api/v2/authorizations/groups", http.StatusOK, `{"groups":[{"id":"1","name":"developers","managed":true}]}`)
+	mock.respond("GET", "/api/settings/values", http.StatusOK, `{"settings":[{"key":"sonar.auth.saml.group.name","value":"groups"}]}`)
+	r := dataSourceSonarqubeGroupExternalMapping()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"identity_provider": "saml", "group": "developers"})
+	if err := r.Read(d, mock.conf("Enterprise", "10.5")); err != nil {
+		t.Fatalf("Read() error = %+v", err)
+	}
+	if d.Id() != "saml/developers" || !d.Get("groups_sync").(bool) || !d.Get("managed").(bool) {
+		t.Errorf("Read() id = %s, groups_sync = %v, managed = %v", d.Id(), d.Get("groups_sync"), d.Get("managed"))
+	}
+
+	// The errors of the v2 api are reported
+	mock.respond("GET", "/api/v2/authorizations/groups", http.StatusForbidden, `{"message":"Insufficient privileges"}`)
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"identity_provider": "saml", "group": "developers"})
+	if err := r.Read(d, mock.conf("Enterprise", "10.5")); err == nil || !strings.Contains(err.Error(), "Insufficient privileges") {
+		t.Errorf("Read() error = %v, want the error of the v2 api", err)
+	}
+
+	// A missing group fails the read
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"identity_provider": "ldap", "group": "testers"})
+	if err := r.Read(d, mock.conf("Enterprise", "10.4")); err == nil || !strings.Contains(err.Error(), "group 'testers' not found") {
+		t.Errorf("Read() error = %v, want a not found error", err)
+	}
+}
@@ -36,6 +36,11 @@ func dataSourceSonarqubeGroups() *schema.Resource {
 							Computed:    true,
 							Description: "The group description.",
 						},
+						"import_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID to import the group as a `sonarqube_group` resource.",
+						},
 					},
 				},
 				Description: "The list of groups.",
@@ -101,6 +106,7 @@ func flattenReadGroupsResponse(groups []Group) []interface{} {
 		values := map[string]interface{}{
 			"name":        group.Name,
 			"description": group.Description,
+			"import_id":   group.Name,
 		}
 
 		groupsList = append(groupsList, values)
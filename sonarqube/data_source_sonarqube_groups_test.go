@@ -33,6 +33,7 @@ func TestAccSonarqubeGroupsDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "groups.#", "1"),
 					resource.TestCheckResourceAttr(name, "groups.0.name", "testAccSonarqubeGroupsDataSource"),
 					resource.TestCheckResourceAttr(name, "groups.0.description", "Terraform Test Groups Data-source"),
+					resource.TestCheckResourceAttr(name, "groups.0.import_id", "testAccSonarqubeGroupsDataSource"),
 				),
 			},
 		},
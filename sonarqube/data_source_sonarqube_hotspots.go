@@ -0,0 +1,227 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Hotspot used in SearchHotspots
+type Hotspot struct {
+	Key                      string `json:"key"`
+	RuleKey                  string `json:"ruleKey"`
+	Component                string `json:"component"`
+	SecurityCategory         string `json:"securityCategory"`
+	VulnerabilityProbability string `json:"vulnerabilityProbability"`
+	Status                   string `json:"status"`
+	Resolution               string `json:"resolution"`
+	Line                     int64  `json:"line"`
+	Message                  string `json:"message"`
+	Author                   string `json:"author"`
+	Assignee                 string `json:"assignee"`
+	CreationDate             string `json:"creationDate"`
+	UpdateDate               string `json:"updateDate"`
+}
+
+// SearchHotspots for unmarshalling response body of api/hotspots/search
+type SearchHotspots struct {
+	Paging   Paging    `json:"paging"`
+	Hotspots []Hotspot `json:"hotspots"`
+}
+
+func dataSourceSonarqubeHotspots() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to get the security hotspots of a project or branch, for example to export the hotspots that
+were not reviewed to other systems from a compliance pipeline. At most ` + "`max_results`" + ` hotspots are returned: compare their
+number with ` + "`total`" + ` to detect that the list is truncated.`,
+		Read: dataSourceSonarqubeHotspotsRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the project.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the branch. When not set, the main branch is used.",
+			},
+			"status": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "TO_REVIEW",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"TO_REVIEW", "REVIEWED"}, false)),
+				Description:      "Only return the hotspots with this status. Possible values are `TO_REVIEW` and `REVIEWED`. Defaults to `TO_REVIEW`.",
+			},
+			"resolution": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"FIXED", "SAFE", "ACKNOWLEDGED"}, false)),
+				Description:      "Only return the reviewed hotspots with this resolution. Possible values are `FIXED`, `SAFE` and `ACKNOWLEDGED`. Requires `status` to be `REVIEWED`.",
+			},
+			"max_results": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 500)),
+				Description:      "The maximum number of hotspots to return, up to `500`. Defaults to `100`.",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of hotspots matching the filters, including those that are not returned.",
+			},
+			"hotspots": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the hotspot.",
+						},
+						"rule": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the rule that raised the hotspot.",
+						},
+						"component": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the file of the hotspot.",
+						},
+						"security_category": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The security category of the hotspot, for example `sql-injection`.",
+						},
+						"vulnerability_probability": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The review priority of the hotspot: `HIGH`, `MEDIUM` or `LOW`.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the hotspot.",
+						},
+						"resolution": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The resolution of a reviewed hotspot.",
+						},
+						"line": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The line of the hotspot, `0` for the hotspots on a whole file.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The message of the hotspot.",
+						},
+						"author": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The SCM author of the code of the hotspot.",
+						},
+						"assignee": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The login of the user assigned to the hotspot.",
+						},
+						"creation_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the hotspot was created.",
+						},
+						"update_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the hotspot was last updated.",
+						},
+					},
+				},
+				Description: "The security hotspots, in the order of SonarQube.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeHotspotsRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	branch := d.Get("branch").(string)
+	status := d.Get("status").(string)
+	resolution := d.Get("resolution").(string)
+	maxResults := d.Get("max_results").(int)
+	if resolution != "" && status != "REVIEWED" {
+		return fmt.Errorf("dataSourceSonarqubeHotspotsRead: resolution can only be set when status is REVIEWED")
+	}
+	d.SetId(fmt.Sprintf("%d", schema.HashString(fmt.Sprintf("%s/%s/%s/%s/%d", project, branch, status, resolution, maxResults))))
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/hotspots/search"
+	RawQuery := url.Values{
+		"project": []string{project},
+		"status":  []string{status},
+		"ps":      []string{strconv.Itoa(maxResults)},
+	}
+	if branch != "" {
+		RawQuery.Add("branch", branch)
+	}
+	if resolution != "" {
+		RawQuery.Add("resolution", resolution)
+	}
+	sonarQubeURL.RawQuery = RawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeHotspotsRead",
+	)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeHotspotsRead: Failed to search the hotspots of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	hotspots := SearchHotspots{}
+	err = json.NewDecoder(resp.Body).Decode(&hotspots)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeHotspotsRead: Failed to decode json into struct: %+v", err)
+	}
+
+	hotspotsList := []interface{}{}
+	for _, hotspot := range hotspots.Hotspots {
+		hotspotsList = append(hotspotsList, map[string]interface{}{
+			"key":                       hotspot.Key,
+			"rule":                      hotspot.RuleKey,
+			"component":                 hotspot.Component,
+			"security_category":         hotspot.SecurityCategory,
+			"vulnerability_probability": hotspot.VulnerabilityProbability,
+			"status":                    hotspot.Status,
+			"resolution":                hotspot.Resolution,
+			"line":                      int(hotspot.Line),
+			"message":                   hotspot.Message,
+			"author":                    hotspot.Author,
+			"assignee":                  hotspot.Assignee,
+			"creation_date":             hotspot.CreationDate,
+			"update_date":               hotspot.UpdateDate,
+		})
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("total", int(hotspots.Paging.Total)))
+	errs = append(errs, d.Set("hotspots", hotspotsList))
+	return errors.Join(errs...)
+}
@@ -0,0 +1,78 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeHotspotsDataSourceConfig(rnd string, project string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name    = "%[2]s"
+			project = "%[2]s"
+		}
+
+		data "sonarqube_hotspots" "%[1]s" {
+			project = sonarqube_project.%[1]s.project
+		}`, rnd, project)
+}
+
+func TestAccSonarqubeHotspotsDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_hotspots." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeHotspotsDataSourceConfig(rnd, "testAccSonarqubeHotspots"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "total", "0"),
+					resource.TestCheckResourceAttr(name, "hotspots.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceSonarqubeHotspotsRead(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/hotspots/search", http.StatusOK, `{
+		"paging": {"pageIndex": 1, "pageSize": 100, "total": 1},
+		"hotspots": [{"key": "HS1", "ruleKey": "java:S2068", "component": "my_project:src/Config.java", "securityCategory": "auth", "vulnerabilityProbability": "HIGH", "status": "REVIEWED", "resolution": "ACKNOWLEDGED", "line": 7}]
+	}`)
+	conf := mock.conf("Community", "10.7")
+	r := dataSourceSonarqubeHotspots()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project":    "my_project",
+		"status":     "REVIEWED",
+		"resolution": "ACKNOWLEDGED",
+	})
+	if err := r.Read(d, conf); err != nil {
+		t.Fatalf("Read() unexpected error = %v", err)
+	}
+
+	expected := []string{"GET /api/hotspots/search?project=my_project&ps=100&resolution=ACKNOWLEDGED&status=REVIEWED"}
+	if got := mock.requests(); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Read() requests = %v, want %v", got, expected)
+	}
+	if d.Get("total").(int) != 1 || d.Get("hotspots.0.rule").(string) != "java:S2068" || d.Get("hotspots.0.vulnerability_probability").(string) != "HIGH" {
+		t.Errorf("Read() hotspots = %v, want the hotspot returned by SonarQube", d.Get("hotspots"))
+	}
+
+	// A resolution only applies to the reviewed hotspots
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project":    "my_project",
+		"resolution": "SAFE",
+	})
+	if err := r.Read(d, conf); err == nil || !strings.Contains(err.Error(), "resolution can only be set when status is REVIEWED") {
+		t.Errorf("Read() error = %v, want an error about the resolution", err)
+	}
+}
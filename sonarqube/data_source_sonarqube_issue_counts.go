@@ -0,0 +1,226 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// IssuesFacetValue is a single value of a facet of api/issues/search
+type IssuesFacetValue struct {
+	Val   string `json:"val"`
+	Count int64  `json:"count"`
+}
+
+// IssuesFacet is a facet of api/issues/search
+type IssuesFacet struct {
+	Property string             `json:"property"`
+	Values   []IssuesFacetValue `json:"values"`
+}
+
+// GetIssuesFacets for unmarshalling the total and facets of api/issues/search
+type GetIssuesFacets struct {
+	Total  int64         `json:"total"`
+	Facets []IssuesFacet `json:"facets"`
+}
+
+// GetComponentMeasures for unmarshalling response body of api/measures/component
+type GetComponentMeasures struct {
+	Component struct {
+		Key      string `json:"key"`
+		Measures []struct {
+			Metric string `json:"metric"`
+			Value  string `json:"value"`
+		} `json:"measures"`
+	} `json:"component"`
+}
+
+// duplicationMetrics are the measures of api/measures/component exposed by sonarqube_issue_counts, under the same name
+var duplicationMetrics = []string{"duplicated_lines_density", "duplicated_lines", "duplicated_blocks"}
+
+func dataSourceSonarqubeIssueCounts() *schema.Resource {
+	countsMap := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+			Description: description,
+		}
+	}
+
+	return &schema.Resource{
+		Description: "Use this data source to get the number of open issues of a project or branch by severity and type, and its duplication measures, for example to export them to dashboards.",
+		Read:        dataSourceSonarqubeIssueCountsRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the project.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the branch. When not set, the main branch is used.",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of open issues.",
+			},
+			"by_severity": countsMap("The number of open issues by severity, for example `MAJOR`."),
+			"by_type":     countsMap("The number of open issues by type, for example `BUG`."),
+			"duplicated_lines_density": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "The percentage of duplicated lines.",
+			},
+			"duplicated_lines": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of duplicated lines.",
+			},
+			"duplicated_blocks": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of duplicated blocks.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeIssueCountsRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	branch := d.Get("branch").(string)
+	if branch != "" {
+		d.SetId(project + "/" + branch)
+	} else {
+		d.SetId(project)
+	}
+
+	issues, err := readIssuesFacetsFromApi(project, branch, m)
+	if err != nil {
+		return err
+	}
+	measures, err := readComponentMeasuresFromApi(project, branch, m)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("total", issues.Total))
+	errs = append(errs, d.Set("by_severity", flattenIssuesFacet(issues.Facets, "severities")))
+	errs = append(errs, d.Set("by_type", flattenIssuesFacet(issues.Facets, "types")))
+	for _, metric := range duplicationMetrics {
+		// Measures are missing until the first analysis
+		value, err := strconv.ParseFloat(measures[metric], 64)
+		if err != nil {
+			value = 0
+		}
+		if metric == "duplicated_lines_density" {
+			errs = append(errs, d.Set(metric, value))
+		} else {
+			errs = append(errs, d.Set(metric, int(value)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func readIssuesFacetsFromApi(project string, branch string, m interface{}) (*GetIssuesFacets, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/issues/search"
+	rawQuery := url.Values{
+		"components": []string{project},
+		"resolved":   []string{"false"},
+		"facets":     []string{"severities,types"},
+		"ps":         []string{"1"},
+	}
+	if branch != "" {
+		rawQuery.Add("branch", branch)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readIssuesFacetsFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readIssuesFacetsFromApi: Failed to search the issues of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	issues := GetIssuesFacets{}
+	err = json.NewDecoder(resp.Body).Decode(&issues)
+	if err != nil {
+		return nil, fmt.Errorf("readIssuesFacetsFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &issues, nil
+}
+
+// readComponentMeasuresFromApi returns the duplication measures of a project, indexed by metric key.
+// Projects that were never analyzed have no measures.
+func readComponentMeasuresFromApi(project string, branch string, m interface{}) (map[string]string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/measures/component"
+	rawQuery := url.Values{
+		"component":  []string{project},
+		"metricKeys": []string{strings.Join(duplicationMetrics, ",")},
+	}
+	if branch != "" {
+		rawQuery.Add("branch", branch)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readComponentMeasuresFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readComponentMeasuresFromApi: Failed to read the measures of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	component := GetComponentMeasures{}
+	err = json.NewDecoder(resp.Body).Decode(&component)
+	if err != nil {
+		return nil, fmt.Errorf("readComponentMeasuresFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	measures := map[string]string{}
+	for _, measure := range component.Component.Measures {
+		measures[measure.Metric] = measure.Value
+	}
+	return measures, nil
+}
+
+// flattenIssuesFacet returns the counts of a facet indexed by value, leaving out the values without issues
+func flattenIssuesFacet(facets []IssuesFacet, property string) map[string]interface{} {
+	counts := map[string]interface{}{}
+	for _, facet := range facets {
+		if facet.Property != property {
+			continue
+		}
+		for _, value := range facet.Values {
+			if value.Count > 0 {
+				counts[value.Val] = int(value.Count)
+			}
+		}
+	}
+	return counts
+}
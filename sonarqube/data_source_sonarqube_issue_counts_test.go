@@ -0,0 +1,65 @@
+package sonarqube
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeIssueCountsDataSourceConfig(rnd string, project string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name    = "%[2]s"
+			project = "%[2]s"
+		}
+
+		data "sonarqube_issue_counts" "%[1]s" {
+			project = sonarqube_project.%[1]s.project
+		}`, rnd, project)
+}
+
+func TestAccSonarqubeIssueCountsDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_issue_counts." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeIssueCountsDataSourceConfig(rnd, "testAccSonarqubeIssueCounts"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "total", "0"),
+					resource.TestCheckResourceAttr(name, "by_severity.%", "0"),
+					resource.TestCheckResourceAttr(name, "duplicated_lines", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenIssuesFacet(t *testing.T) {
+	facets := []IssuesFacet{
+		{Property: "severities", Values: []IssuesFacetValue{{Val: "MAJOR", Count: 3}, {Val: "BLOCKER", Count: 0}}},
+		{Property: "types", Values: []IssuesFacetValue{{Val: "BUG", Count: 1}, {Val: "CODE_SMELL", Count: 2}}},
+	}
+
+	tests := []struct {
+		property string
+		expected map[string]interface{}
+	}{
+		{"severities", map[string]interface{}{"MAJOR": 3}},
+		{"types", map[string]interface{}{"BUG": 1, "CODE_SMELL": 2}},
+		{"statuses", map[string]interface{}{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.property, func(t *testing.T) {
+			if counts := flattenIssuesFacet(facets, test.property); !reflect.DeepEqual(counts, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, counts)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Issue used in SearchIssues
+type Issue struct {
+	Key          string   `json:"key"`
+	Rule         string   `json:"rule"`
+	Severity     string   `json:"severity"`
+	Type         string   `json:"type"`
+	Component    string   `json:"component"`
+	Line         int64    `json:"line"`
+	Message      string   `json:"message"`
+	Status       string   `json:"status"`
+	Author       string   `json:"author"`
+	Assignee     string   `json:"assignee"`
+	Tags         []string `json:"tags"`
+	CreationDate string   `json:"creationDate"`
+	UpdateDate   string   `json:"updateDate"`
+}
+
+// SearchIssues for unmarshalling response body of api/issues/search
+type SearchIssues struct {
+	Paging Paging  `json:"paging"`
+	Issues []Issue `json:"issues"`
+}
+
+// issueSeverities and issueTypes are the values of the severities and types filters of api/issues/search
+var (
+	issueSeverities = []string{"BLOCKER", "CRITICAL", "MAJOR", "MINOR", "INFO"}
+	issueTypes      = []string{"BUG", "VULNERABILITY", "CODE_SMELL"}
+)
+
+func dataSourceSonarqubeIssues() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to get the unresolved issues of a project or branch, for example to export the critical issues
+to other systems from a compliance pipeline. At most ` + "`max_results`" + ` issues are returned: compare their number with ` + "`total`" + `
+to detect that the list is truncated.`,
+		Read: dataSourceSonarqubeIssuesRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the project.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the branch. When not set, the main branch is used.",
+			},
+			"severities": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(issueSeverities, false)),
+				},
+				Description: "Only return the issues with these severities. Possible values are `BLOCKER`, `CRITICAL`, `MAJOR`, `MINOR` and `INFO`.",
+			},
+			"types": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(issueTypes, false)),
+				},
+				Description: "Only return the issues of these types. Possible values are `BUG`, `VULNERABILITY` and `CODE_SMELL`.",
+			},
+			"max_results": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 500)),
+				Description:      "The maximum number of issues to return, up to `500`. Defaults to `100`.",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of unresolved issues matching the filters, including those that are not returned.",
+			},
+			"issues": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the issue.",
+						},
+						"rule": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the rule that raised the issue.",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The severity of the issue.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the issue.",
+						},
+						"component": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the file of the issue.",
+						},
+						"line": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The line of the issue, `0` for the issues on a whole file.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The message of the issue.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the issue, for example `OPEN`.",
+						},
+						"author": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The SCM author of the code of the issue.",
+						},
+						"assignee": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The login of the user assigned to the issue.",
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The tags of the issue.",
+						},
+						"creation_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the issue was created.",
+						},
+						"update_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the issue was last updated.",
+						},
+					},
+				},
+				Description: "The unresolved issues, in the order of SonarQube.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeIssuesRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	branch := d.Get("branch").(string)
+	severities := expandStringList(d.Get("severities"))
+	types := expandStringList(d.Get("types"))
+	maxResults := d.Get("max_results").(int)
+	d.SetId(fmt.Sprintf("%d", schema.HashString(fmt.Sprintf("%s/%s/%s/%s/%d", project, branch, strings.Join(severities, ","), strings.Join(types, ","), maxResults))))
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/issues/search"
+	RawQuery := url.Values{
+		"components": []string{project},
+		"resolved":   []string{"false"},
+		"ps":         []string{strconv.Itoa(maxResults)},
+	}
+	if branch != "" {
+		RawQuery.Add("branch", branch)
+	}
+	if len(severities) > 0 {
+		RawQuery.Add("severities", strings.Join(severities, ","))
+	}
+	if len(types) > 0 {
+		RawQuery.Add("types", strings.Join(types, ","))
+	}
+	sonarQubeURL.RawQuery = RawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeIssuesRead",
+	)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeIssuesRead: Failed to search the issues of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	issues := SearchIssues{}
+	err = json.NewDecoder(resp.Body).Decode(&issues)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeIssuesRead: Failed to decode json into struct: %+v", err)
+	}
+
+	issuesList := []interface{}{}
+	for _, issue := range issues.Issues {
+		issuesList = append(issuesList, map[string]interface{}{
+			"key":           issue.Key,
+			"rule":          issue.Rule,
+			"severity":      issue.Severity,
+			"type":          issue.Type,
+			"component":     issue.Component,
+			"line":          int(issue.Line),
+			"message":       issue.Message,
+			"status":        issue.Status,
+			"author":        issue.Author,
+			"assignee":      issue.Assignee,
+			"tags":          issue.Tags,
+			"creation_date": issue.CreationDate,
+			"update_date":   issue.UpdateDate,
+		})
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("total", int(issues.Paging.Total)))
+	errs = append(errs, d.Set("issues", issuesList))
+	return errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeIssuesDataSourceConfig(rnd string, project string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name    = "%[2]s"
+			project = "%[2]s"
+		}
+
+		data "sonarqube_issues" "%[1]s" {
+			project    = sonarqube_project.%[1]s.project
+			severities = ["BLOCKER", "CRITICAL"]
+		}`, rnd, project)
+}
+
+func TestAccSonarqubeIssuesDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_issues." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeIssuesDataSourceConfig(rnd, "testAccSonarqubeIssues"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "total", "0"),
+					resource.TestCheckResourceAttr(name, "issues.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceSonarqubeIssuesRead(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/issues/search", http.StatusOK, `{
+		"paging": {"pageIndex": 1, "pageSize": 1, "total": 3},
+		"issues": [{"key": "AX1", "rule": "java:S2076", "severity": "CRITICAL", "type": "VULNERABILITY", "component": "my_project:src/Main.java", "line": 12, "status": "OPEN", "tags": ["cwe"]}]
+	}`)
+	r := dataSourceSonarqubeIssues()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project":     "my_project",
+		"branch":      "main",
+		"severities":  []interface{}{"BLOCKER", "CRITICAL"},
+		"types":       []interface{}{"VULNERABILITY"},
+		"max_results": 1,
+	})
+	if err := r.Read(d, mock.conf("Community", "10.7")); err != nil {
+		t.Fatalf("Read() unexpected error = %v", err)
+	}
+
+	expected := []string{"GET /api/issues/search?branch=main&components=my_project&ps=1&resolved=false&severities=BLOCKER%2CCRITICAL&types=VULNERABILITY"}
+	if got := mock.requests(); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Read() requests = %v, want %v", got, expected)
+	}
+	if d.Get("total").(int) != 3 || d.Get("issues.#").(int) != 1 {
+		t.Errorf("Read() total = %v, issues = %v, want 3 and 1", d.Get("total"), d.Get("issues.#"))
+	}
+	if d.Get("issues.0.rule").(string) != "java:S2076" || d.Get("issues.0.line").(int) != 12 || d.Get("issues.0.tags.0").(string) != "cwe" {
+		t.Errorf("Read() issue = %v, want the issue returned by SonarQube", d.Get("issues.0"))
+	}
+}
@@ -60,7 +60,7 @@ func dataSourceSonarqubeLanguages() *schema.Resource {
 func dataSourceSonarqubeLanguagesRead(d *schema.ResourceData, m interface{}) error {
 	d.SetId(fmt.Sprintf("%d", schema.HashString(d.Get("search"))))
 
-	languagesReadResponse, err := readLanguagesFromApi(d, m)
+	languagesReadResponse, err := readLanguagesFromApi(d.Get("search").(string), m)
 	if err != nil {
 		return err
 	}
@@ -71,12 +71,11 @@ func dataSourceSonarqubeLanguagesRead(d *schema.ResourceData, m interface{}) err
 	return errors.Join(errs...)
 }
 
-func readLanguagesFromApi(d *schema.ResourceData, m interface{}) (*GetLanguages, error) {
+func readLanguagesFromApi(search string, m interface{}) (*GetLanguages, error) {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/languages/list"
 
-	if data, ok := d.GetOk("search"); ok {
-		search := data.(string)
+	if search != "" {
 		sonarQubeURL.RawQuery = url.Values{
 			"q": []string{search},
 		}.Encode()
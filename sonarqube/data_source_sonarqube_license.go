@@ -0,0 +1,97 @@
+package sonarqube
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubeLicense() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the details of the license installed in a Developer, Enterprise or Data Center edition of SonarQube.",
+		Read:        dataSourceSonarqubeLicenseRead,
+		Schema: map[string]*schema.Schema{
+			"has_license": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether a license is installed.",
+			},
+			"edition": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The edition the license is valid for.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the license, for example `PRODUCTION` or `EVALUATION`.",
+			},
+			"server_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The server ID the license is bound to.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The expiration date of the license.",
+			},
+			"is_expired": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the license is expired.",
+			},
+			"is_valid_edition": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the license matches the installed edition.",
+			},
+			"max_loc": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The maximum number of lines of code allowed by the license.",
+			},
+			"loc": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of lines of code currently counted against the license.",
+			},
+			"remaining_loc": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of lines of code that can still be analyzed before reaching `max_loc`.",
+			},
+			"remaining_loc_threshold": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The remaining lines of code below which SonarQube starts warning administrators.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeLicenseRead(d *schema.ResourceData, m interface{}) error {
+	if err := checkLicenseSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	license, err := readLicenseFromApi(m)
+	if err != nil {
+		return err
+	}
+
+	d.SetId("license")
+	errs := []error{}
+	errs = append(errs, d.Set("has_license", license.HasLicense))
+	errs = append(errs, d.Set("edition", license.Edition))
+	errs = append(errs, d.Set("type", license.Type))
+	errs = append(errs, d.Set("server_id", license.ServerId))
+	errs = append(errs, d.Set("expires_at", license.ExpiresAt))
+	errs = append(errs, d.Set("is_expired", license.IsExpired))
+	errs = append(errs, d.Set("is_valid_edition", license.IsValidEdition))
+	errs = append(errs, d.Set("max_loc", license.MaxLoc))
+	errs = append(errs, d.Set("loc", license.Loc))
+	errs = append(errs, d.Set("remaining_loc", license.MaxLoc-license.Loc))
+	errs = append(errs, d.Set("remaining_loc_threshold", license.RemainingLocThreshold))
+	return errors.Join(errs...)
+}
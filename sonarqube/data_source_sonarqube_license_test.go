@@ -0,0 +1,36 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeLicenseDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		data "sonarqube_license" "%[1]s" {}`, rnd)
+}
+
+func TestAccSonarqubeLicenseDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_license." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			if err := checkLicenseSupport(testAccProviderConfiguration(t)); err != nil {
+				t.Skipf("Skipping test of unsupported feature (License)")
+			}
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeLicenseDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "has_license"),
+				),
+			},
+		},
+	})
+}
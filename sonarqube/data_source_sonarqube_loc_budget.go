@@ -0,0 +1,266 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ProjectLicenseUsage is the lines of code of a project counted against the license
+type ProjectLicenseUsage struct {
+	ProjectKey  string `json:"projectKey"`
+	ProjectName string `json:"projectName"`
+	LinesOfCode int64  `json:"linesOfCode"`
+}
+
+// GetProjectsLicenseUsage for unmarshalling response body of api/projects/license_usage
+type GetProjectsLicenseUsage struct {
+	Projects []ProjectLicenseUsage `json:"projects"`
+}
+
+// GetMeasuresSearch for unmarshalling response body of api/measures/search
+type GetMeasuresSearch struct {
+	Measures []struct {
+		Metric    string `json:"metric"`
+		Value     string `json:"value"`
+		Component string `json:"component"`
+	} `json:"measures"`
+}
+
+// measuresSearchBatchSize is the maximum number of projects of a single api/measures/search request
+const measuresSearchBatchSize = 100
+
+func dataSourceSonarqubeLocBudget() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to check the lines of code analyzed by the instance against a budget at plan time.
+By default the budget is the maximum number of lines of code of the license, and the plan fails when the lines of code already analyzed
+plus ` + "`planned_loc`" + `, for example the expected size of the projects created by the same configuration, exceed it.
+In the Community edition, which has no license, the lines of code of the main branch of every project are counted and ` + "`budget`" + ` must be set.`,
+		Read: dataSourceSonarqubeLocBudgetRead,
+		Schema: map[string]*schema.Schema{
+			"budget": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The maximum number of lines of code. Defaults to the maximum number of lines of code of the license.",
+			},
+			"planned_loc": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "The lines of code expected from projects that are not analyzed yet. Defaults to `0`.",
+			},
+			"fail_when_exceeded": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether reading the data source fails, and with it the plan, when the budget is exceeded. Defaults to `true`.",
+			},
+			"loc": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The lines of code currently analyzed.",
+			},
+			"remaining_loc": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The lines of code left in the budget once `planned_loc` is analyzed. Negative when the budget is exceeded.",
+			},
+			"exceeded": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the lines of code analyzed plus `planned_loc` exceed the budget.",
+			},
+			"projects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the project.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the project.",
+						},
+						"loc": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The lines of code of the project.",
+						},
+					},
+				},
+				Description: "The projects and their lines of code, largest first.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeLocBudgetRead(d *schema.ResourceData, m interface{}) error {
+	conf := m.(*ProviderConfiguration)
+	budget := int64(d.Get("budget").(int))
+	planned := int64(d.Get("planned_loc").(int))
+
+	var projects []ProjectLicenseUsage
+	var err error
+	if strings.ToLower(conf.sonarQubeEdition) == "community" {
+		if budget == 0 {
+			return fmt.Errorf("dataSourceSonarqubeLocBudgetRead: 'budget' must be set in the Community edition of SonarQube, which has no license")
+		}
+		projects, err = readProjectsNclocFromApi(m)
+	} else {
+		if budget == 0 {
+			license, err := readLicenseFromApi(m)
+			if err != nil {
+				return err
+			}
+			if !license.HasLicense {
+				return fmt.Errorf("dataSourceSonarqubeLocBudgetRead: no license is installed, 'budget' must be set")
+			}
+			budget = license.MaxLoc
+		}
+		projects, err = readProjectsLicenseUsageFromApi(m)
+	}
+	if err != nil {
+		return err
+	}
+
+	var loc int64
+	for _, project := range projects {
+		loc += project.LinesOfCode
+	}
+	remaining, exceeded := evaluateLocBudget(loc, planned, budget)
+	if exceeded && d.Get("fail_when_exceeded").(bool) {
+		return fmt.Errorf("the lines of code budget of %d is exceeded: %d lines of code are analyzed and %d are planned", budget, loc, planned)
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projects[i].LinesOfCode > projects[j].LinesOfCode
+	})
+	projectsList := []interface{}{}
+	for _, project := range projects {
+		projectsList = append(projectsList, map[string]interface{}{
+			"key":  project.ProjectKey,
+			"name": project.ProjectName,
+			"loc":  int(project.LinesOfCode),
+		})
+	}
+
+	d.SetId("loc_budget")
+	errs := []error{}
+	errs = append(errs, d.Set("budget", int(budget)))
+	errs = append(errs, d.Set("loc", int(loc)))
+	errs = append(errs, d.Set("remaining_loc", int(remaining)))
+	errs = append(errs, d.Set("exceeded", exceeded))
+	errs = append(errs, d.Set("projects", projectsList))
+	return errors.Join(errs...)
+}
+
+// evaluateLocBudget returns the lines of code left in the budget once the planned lines of code are analyzed,
+// and whether the budget is exceeded
+func evaluateLocBudget(loc int64, planned int64, budget int64) (int64, bool) {
+	remaining := budget - loc - planned
+	return remaining, remaining < 0
+}
+
+// readProjectsLicenseUsageFromApi returns the lines of code of every project counted against the license,
+// which is the size of its largest branch
+func readProjectsLicenseUsageFromApi(m interface{}) ([]ProjectLicenseUsage, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/license_usage"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readProjectsLicenseUsageFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readProjectsLicenseUsageFromApi: Failed to read the license usage of the projects: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	usage := GetProjectsLicenseUsage{}
+	err = json.NewDecoder(resp.Body).Decode(&usage)
+	if err != nil {
+		return nil, fmt.Errorf("readProjectsLicenseUsageFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return usage.Projects, nil
+}
+
+// readProjectsNclocFromApi returns the lines of code of the main branch of every project
+func readProjectsNclocFromApi(m interface{}) ([]ProjectLicenseUsage, error) {
+	projects, err := searchProjectsByKeyPrefix("", "", m)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := []ProjectLicenseUsage{}
+	for start := 0; start < len(projects); start += measuresSearchBatchSize {
+		end := start + measuresSearchBatchSize
+		if end > len(projects) {
+			end = len(projects)
+		}
+
+		keys := []string{}
+		for _, project := range projects[start:end] {
+			keys = append(keys, project.Key)
+		}
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/measures/search"
+		sonarQubeURL.RawQuery = url.Values{
+			"projectKeys": []string{strings.Join(keys, ",")},
+			"metricKeys":  []string{"ncloc"},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"readProjectsNclocFromApi",
+		)
+		if err != nil {
+			return nil, fmt.Errorf("readProjectsNclocFromApi: Failed to read the lines of code of the projects: %+v", err)
+		}
+
+		// Decode response into struct
+		search := GetMeasuresSearch{}
+		err = json.NewDecoder(resp.Body).Decode(&search)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("readProjectsNclocFromApi: Failed to decode json into struct: %+v", err)
+		}
+
+		ncloc := map[string]int64{}
+		for _, measure := range search.Measures {
+			value, err := strconv.ParseInt(measure.Value, 10, 64)
+			if err == nil {
+				ncloc[measure.Component] = value
+			}
+		}
+		// Projects that were never analyzed have no measure
+		for _, project := range projects[start:end] {
+			usage = append(usage, ProjectLicenseUsage{ProjectKey: project.Key, ProjectName: project.Name, LinesOfCode: ncloc[project.Key]})
+		}
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,66 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeLocBudgetDataSourceConfig(rnd string, budget int, plannedLoc int) string {
+	return fmt.Sprintf(`
+		data "sonarqube_loc_budget" "%[1]s" {
+			budget      = %[2]d
+			planned_loc = %[3]d
+		}`, rnd, budget, plannedLoc)
+}
+
+func TestAccSonarqubeLocBudgetDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_loc_budget." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeLocBudgetDataSourceConfig(rnd, 1000000000, 1000),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "budget", "1000000000"),
+					resource.TestCheckResourceAttr(name, "exceeded", "false"),
+					resource.TestCheckResourceAttrSet(name, "loc"),
+				),
+			},
+			{
+				Config:      testAccSonarqubeLocBudgetDataSourceConfig(rnd, 1, 1000),
+				ExpectError: regexp.MustCompile("the lines of code budget of 1 is exceeded"),
+			},
+		},
+	})
+}
+
+func TestEvaluateLocBudget(t *testing.T) {
+	tests := []struct {
+		name              string
+		loc               int64
+		planned           int64
+		budget            int64
+		expectedRemaining int64
+		expectedExceeded  bool
+	}{
+		{"within budget", 1000, 500, 2000, 500, false},
+		{"exactly at budget", 1500, 500, 2000, 0, false},
+		{"exceeded by planned", 1500, 1000, 2000, -500, true},
+		{"already exceeded", 3000, 0, 2000, -1000, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining, exceeded := evaluateLocBudget(tt.loc, tt.planned, tt.budget)
+			if remaining != tt.expectedRemaining || exceeded != tt.expectedExceeded {
+				t.Errorf("evaluateLocBudget() = %d, %t; want %d, %t", remaining, exceeded, tt.expectedRemaining, tt.expectedExceeded)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package sonarqube
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// MonitoringMetric is a single sample of the Prometheus output of api/monitoring/metrics
+type MonitoringMetric struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+func dataSourceSonarqubeMonitoringMetrics() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to get the Prometheus metrics of SonarQube from api/monitoring/metrics, for example to codify liveness checks.
+The endpoint is authenticated with the provider credentials, which must belong to a system administrator, or with the monitoring passcode.
+The passcode itself (` + "`sonar.web.systemPasscode`" + `) can only be configured in the sonar.properties file of the server, not through the web API.`,
+		Read: dataSourceSonarqubeMonitoringMetricsRead,
+		Schema: map[string]*schema.Schema{
+			"passcode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The monitoring passcode, sent in the `X-Sonar-Passcode` header. When not set, the provider credentials are used.",
+			},
+			"metrics": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the metric.",
+						},
+						"labels": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The labels of the sample.",
+						},
+						"value": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "The value of the sample.",
+						},
+					},
+				},
+				Description: "The list of metric samples.",
+			},
+			"values": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeFloat,
+				},
+				Description: "The value of every metric sample without labels, indexed by metric name. For example `sonarqube_health_web_status`.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeMonitoringMetricsRead(d *schema.ResourceData, m interface{}) error {
+	d.SetId("monitoring_metrics")
+
+	body, err := readMonitoringMetricsFromApi(d.Get("passcode").(string), m)
+	if err != nil {
+		return err
+	}
+	metrics, err := parsePrometheusMetrics(body)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeMonitoringMetricsRead: Failed to parse metrics: %+v", err)
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("metrics", flattenMonitoringMetrics(metrics)))
+	errs = append(errs, d.Set("values", flattenMonitoringMetricValues(metrics)))
+	return errors.Join(errs...)
+}
+
+func readMonitoringMetricsFromApi(passcode string, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/monitoring/metrics"
+	if passcode != "" {
+		sonarQubeURL.User = nil
+	}
+
+	// httpRequestHelper cannot send headers, so the request is built here
+	req, err := retryablehttp.NewRequest("GET", sonarQubeURL.String(), http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("readMonitoringMetricsFromApi: failed to create request: %w", censorHttpError(err))
+	}
+	if passcode != "" {
+		req.Header.Set("X-Sonar-Passcode", passcode)
+	}
+
+	resp, err := m.(*ProviderConfiguration).httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("readMonitoringMetricsFromApi: failed to send request: %w", censorHttpError(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", responseError(resp, "GET", sonarQubeURL.String(), http.StatusOK, "readMonitoringMetricsFromApi")
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("readMonitoringMetricsFromApi: failed to read response body: %+v", err)
+	}
+	return string(body), nil
+}
+
+// parsePrometheusMetrics parses the samples of the Prometheus text exposition format, ignoring comments
+func parsePrometheusMetrics(body string) ([]MonitoringMetric, error) {
+	metrics := []MonitoringMetric{}
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		metric := MonitoringMetric{Labels: map[string]string{}}
+		rest := line
+		if i := strings.Index(line, "{"); i >= 0 {
+			j := strings.LastIndex(line, "}")
+			if j < i {
+				return nil, fmt.Errorf("invalid metric line: %s", line)
+			}
+			metric.Name = line[:i]
+			for _, label := range splitPrometheusLabels(line[i+1 : j]) {
+				parts := strings.SplitN(label, "=", 2)
+				if len(parts) != 2 {
+					return nil, fmt.Errorf("invalid label '%s' in metric line: %s", label, line)
+				}
+				metric.Labels[strings.TrimSpace(parts[0])] = strings.Trim(strings.TrimSpace(parts[1]), `"`)
+			}
+			rest = line[j+1:]
+		} else {
+			fields := strings.Fields(line)
+			metric.Name = fields[0]
+			rest = strings.TrimPrefix(line, fields[0])
+		}
+
+		// The value may be followed by a timestamp
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("missing value in metric line: %s", line)
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in metric line: %s", line)
+		}
+		metric.Value = value
+		metrics = append(metrics, metric)
+	}
+	return metrics, scanner.Err()
+}
+
+// splitPrometheusLabels splits the labels of a sample on the commas that are not part of a quoted value
+func splitPrometheusLabels(labels string) []string {
+	result := []string{}
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(labels); i++ {
+		switch labels[i] {
+		case '\\':
+			i++
+		case '"':
+			inQuotes = !inQuotes
+		case ',':
+			if !inQuotes {
+				result = append(result, labels[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if strings.TrimSpace(labels[start:]) != "" {
+		result = append(result, labels[start:])
+	}
+	return result
+}
+
+func flattenMonitoringMetrics(metrics []MonitoringMetric) []interface{} {
+	metricsList := []interface{}{}
+	for _, metric := range metrics {
+		metricsList = append(metricsList, map[string]interface{}{
+			"name":   metric.Name,
+			"labels": metric.Labels,
+			"value":  metric.Value,
+		})
+	}
+	return metricsList
+}
+
+func flattenMonitoringMetricValues(metrics []MonitoringMetric) map[string]interface{} {
+	values := map[string]interface{}{}
+	for _, metric := range metrics {
+		if len(metric.Labels) == 0 {
+			values[metric.Name] = metric.Value
+		}
+	}
+	return values
+}
@@ -0,0 +1,79 @@
+package sonarqube
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeMonitoringMetricsDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		data "sonarqube_monitoring_metrics" "%[1]s" {}`, rnd)
+}
+
+func TestAccSonarqubeMonitoringMetricsDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_monitoring_metrics." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeMonitoringMetricsDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "values.sonarqube_health_web_status", "1"),
+					resource.TestCheckResourceAttrSet(name, "metrics.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestParsePrometheusMetrics(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []MonitoringMetric
+		wantErr bool
+	}{
+		{
+			name: "Sample without labels",
+			body: "# HELP sonarqube_health_web_status Tells whether Web process is up or down. 1 for up, 0 for down\n# TYPE sonarqube_health_web_status gauge\nsonarqube_health_web_status 1.0\n",
+			want: []MonitoringMetric{
+				{Name: "sonarqube_health_web_status", Labels: map[string]string{}, Value: 1},
+			},
+		},
+		{
+			name: "Sample with labels and timestamp",
+			body: `sonarqube_compute_engine_tasks_total{status="success",queue="a,b"} 42 1700000000000`,
+			want: []MonitoringMetric{
+				{Name: "sonarqube_compute_engine_tasks_total", Labels: map[string]string{"status": "success", "queue": "a,b"}, Value: 42},
+			},
+		},
+		{
+			name:    "Missing value",
+			body:    "sonarqube_health_web_status",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid value",
+			body:    "sonarqube_health_web_status up",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePrometheusMetrics(tt.body)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePrometheusMetrics() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parsePrometheusMetrics() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
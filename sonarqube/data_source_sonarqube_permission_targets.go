@@ -0,0 +1,196 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubePermissionTargets() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to validate that users and groups exist before granting them permissions, and to get their canonical names.
+SonarQube matches logins and group names without case sensitivity, so a permission granted to ` + "`Developers`" + ` silently applies to ` + "`developers`" + `
+while the state keeps the configured casing. Using the names returned by this data source keeps the state in line with SonarQube.`,
+		Read: dataSourceSonarqubePermissionTargetsRead,
+		Schema: map[string]*schema.Schema{
+			"users": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				AtLeastOneOf: []string{"users", "groups"},
+				Description:  "The logins of the users to validate.",
+			},
+			"groups": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				AtLeastOneOf: []string{"users", "groups"},
+				Description:  "The names of the groups to validate.",
+			},
+			"fail_when_missing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether reading the data source fails when a user or a group does not exist. Defaults to `true`.",
+			},
+			"user_logins": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The canonical login of every existing user, keyed by the login given in `users`.",
+			},
+			"group_names": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The canonical name of every existing group, keyed by the name given in `groups`.",
+			},
+			"missing_users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The logins given in `users` that do not exist.",
+			},
+			"missing_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The names given in `groups` that do not exist.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubePermissionTargetsRead(d *schema.ResourceData, m interface{}) error {
+	users := expandPermissions(d.Get("users"))
+	groups := expandPermissions(d.Get("groups"))
+	sort.Strings(users)
+	sort.Strings(groups)
+	d.SetId(fmt.Sprintf("%d", schema.HashString(strings.Join(users, ",")+"/"+strings.Join(groups, ","))))
+
+	userLogins, missingUsers, err := resolvePrincipalNames(principalUser, users, m)
+	if err != nil {
+		return err
+	}
+	groupNames, missingGroups, err := resolvePrincipalNames(principalGroup, groups, m)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("fail_when_missing").(bool) && len(missingUsers)+len(missingGroups) > 0 {
+		missing := []string{}
+		if len(missingUsers) > 0 {
+			missing = append(missing, "users "+strings.Join(missingUsers, ", "))
+		}
+		if len(missingGroups) > 0 {
+			missing = append(missing, "groups "+strings.Join(missingGroups, ", "))
+		}
+		return fmt.Errorf("dataSourceSonarqubePermissionTargetsRead: the %s do not exist", strings.Join(missing, " and the "))
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("user_logins", userLogins))
+	errs = append(errs, d.Set("group_names", groupNames))
+	errs = append(errs, d.Set("missing_users", missingUsers))
+	errs = append(errs, d.Set("missing_groups", missingGroups))
+	return errors.Join(errs...)
+}
+
+// resolvePrincipalNames returns the canonical name of every existing user or group, keyed by the requested name,
+// and the requested names that do not exist
+func resolvePrincipalNames(principalType string, names []string, m interface{}) (map[string]string, []string, error) {
+	canonical := map[string]string{}
+	missing := []string{}
+	for _, name := range names {
+		candidates, err := searchPrincipalNames(principalType, name, m)
+		if err != nil {
+			return nil, nil, err
+		}
+		if match, ok := matchPrincipalName(name, candidates); ok {
+			canonical[name] = match
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	return canonical, missing, nil
+}
+
+// searchPrincipalNames returns the logins of the users, or the names of the groups, that match the search
+func searchPrincipalNames(principalType string, search string, m interface{}) ([]string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	if principalType == principalUser {
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/search"
+	} else {
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/search"
+	}
+	sonarQubeURL.RawQuery = url.Values{
+		"q":  []string{search},
+		"ps": []string{"500"},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"searchPrincipalNames",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("searchPrincipalNames: Failed to search the %ss matching '%s': %+v", principalType, search, err)
+	}
+	defer resp.Body.Close()
+
+	names := []string{}
+	if principalType == principalUser {
+		users := GetUser{}
+		err = json.NewDecoder(resp.Body).Decode(&users)
+		for _, user := range users.Users {
+			names = append(names, user.Login)
+		}
+	} else {
+		groups := GetGroup{}
+		err = json.NewDecoder(resp.Body).Decode(&groups)
+		for _, group := range groups.Groups {
+			names = append(names, group.Name)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("searchPrincipalNames: Failed to decode json into struct: %+v", err)
+	}
+
+	return names, nil
+}
+
+// matchPrincipalName returns the candidate that is the requested name, preferring an exact match over a match
+// that only differs in case
+func matchPrincipalName(name string, candidates []string) (string, bool) {
+	for _, candidate := range candidates {
+		if candidate == name {
+			return candidate, true
+		}
+	}
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate, name) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,66 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSonarqubePermissionTargetsDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_permission_targets." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "sonarqube_group" "%[1]s" {
+						name = "%[1]s-Group"
+					}
+
+					data "sonarqube_permission_targets" "%[1]s" {
+						users  = ["ADMIN"]
+						groups = [lower(sonarqube_group.%[1]s.name)]
+					}`, rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "user_logins.ADMIN", "admin"),
+					resource.TestCheckResourceAttr(name, "group_names."+rnd+"-group", rnd+"-Group"),
+					resource.TestCheckResourceAttr(name, "missing_users.#", "0"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "sonarqube_permission_targets" "%[1]s" {
+						groups = ["%[1]s-missing"]
+					}`, rnd),
+				ExpectError: regexp.MustCompile("the groups " + rnd + "-missing do not exist"),
+			},
+		},
+	})
+}
+
+func TestMatchPrincipalName(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		expected   string
+		found      bool
+	}{
+		{name: "developers", candidates: []string{"Developers", "developers"}, expected: "developers", found: true},
+		{name: "developers", candidates: []string{"Developers", "developers-ops"}, expected: "Developers", found: true},
+		{name: "dev", candidates: []string{"developers"}, expected: "", found: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, found := matchPrincipalName(tt.name, tt.candidates)
+			if result != tt.expected || found != tt.found {
+				t.Errorf("matchPrincipalName() = %v, %v, want %v, %v", result, found, tt.expected, tt.found)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubePermissionTemplateEffectivePermissions() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to get every permission granted by a permission template, to the users, to the groups and to
+the project creator, for example to export the content of the templates for an audit.`,
+		Read: dataSourceSonarqubePermissionTemplateEffectivePermissionsRead,
+		Schema: map[string]*schema.Schema{
+			"template_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"template_id", "template_name"},
+				Description:  "The ID of the permission template.",
+			},
+			"template_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"template_id", "template_name"},
+				Description:  "The name of the permission template.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the permission template.",
+			},
+			"project_key_pattern": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The project key pattern of the permission template.",
+			},
+			"users": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"login": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The login of the user.",
+						},
+						"permissions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The permissions granted to the user.",
+						},
+					},
+				},
+				Description: "The users that get permissions from the template, sorted by login.",
+			},
+			"groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the group.",
+						},
+						"permissions": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The permissions granted to the group.",
+						},
+					},
+				},
+				Description: "The groups that get permissions from the template, sorted by name.",
+			},
+			"project_creator_permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The permissions granted to the user who creates the project.",
+			},
+			"permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"permission": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the permission.",
+						},
+						"users": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The logins of the users that get the permission.",
+						},
+						"groups": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The names of the groups that get the permission.",
+						},
+						"with_project_creator": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the user who creates the project gets the permission.",
+						},
+					},
+				},
+				Description: "The same permissions by permission name, sorted by name. Only the permissions granted to someone are listed.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubePermissionTemplateEffectivePermissionsRead(d *schema.ResourceData, m interface{}) error {
+	template, err := findPermissionTemplateFromApi(d.Get("template_id").(string), d.Get("template_name").(string), m)
+	if err != nil {
+		return err
+	}
+
+	scope := permissionScope{templateID: template.ID}
+	users, err := listScopePermissions(principalUser, scope, "", m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubePermissionTemplateEffectivePermissionsRead: %+v", err)
+	}
+	groups, err := listScopePermissions(principalGroup, scope, "", m)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubePermissionTemplateEffectivePermissionsRead: %+v", err)
+	}
+	projectCreatorPermissions := []string{}
+	for _, permission := range template.Permissions {
+		if permission.WithProjectCreator {
+			projectCreatorPermissions = append(projectCreatorPermissions, permission.Key)
+		}
+	}
+	sort.Strings(projectCreatorPermissions)
+
+	d.SetId(template.ID)
+	errs := []error{}
+	errs = append(errs, d.Set("template_id", template.ID))
+	errs = append(errs, d.Set("template_name", template.Name))
+	errs = append(errs, d.Set("name", template.Name))
+	errs = append(errs, d.Set("project_key_pattern", template.ProjectKeyPattern))
+	errs = append(errs, d.Set("users", flattenTemplatePrincipals(users, "login")))
+	errs = append(errs, d.Set("groups", flattenTemplatePrincipals(groups, "name")))
+	errs = append(errs, d.Set("project_creator_permissions", projectCreatorPermissions))
+	errs = append(errs, d.Set("permissions", flattenTemplatePermissionMatrix(users, groups, projectCreatorPermissions)))
+	return errors.Join(errs...)
+}
+
+// findPermissionTemplateFromApi returns the permission template with the ID, or else with the name
+func findPermissionTemplateFromApi(templateID string, templateName string, m interface{}) (*PermissionTemplate, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/search_templates"
+	if templateName != "" {
+		sonarQubeURL.RawQuery = url.Values{
+			"q": []string{templateName},
+		}.Encode()
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"findPermissionTemplateFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("findPermissionTemplateFromApi: Failed to read Sonarqube permission templates: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	permissionTemplates := GetPermissionTemplates{}
+	err = json.NewDecoder(resp.Body).Decode(&permissionTemplates)
+	if err != nil {
+		return nil, fmt.Errorf("findPermissionTemplateFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, template := range permissionTemplates.PermissionTemplates {
+		if (templateID != "" && template.ID == templateID) || (templateID == "" && strings.EqualFold(template.Name, templateName)) {
+			return &template, nil
+		}
+	}
+	return nil, fmt.Errorf("findPermissionTemplateFromApi: permission template '%s%s' not found", templateID, templateName)
+}
+
+// flattenTemplatePrincipals returns the principals sorted by name, with their sorted permissions
+func flattenTemplatePrincipals(permissions map[string][]string, nameAttribute string) []interface{} {
+	names := []string{}
+	for name := range permissions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	principals := []interface{}{}
+	for _, name := range names {
+		principalPermissions := append([]string{}, permissions[name]...)
+		sort.Strings(principalPermissions)
+		principals = append(principals, map[string]interface{}{
+			nameAttribute: name,
+			"permissions": principalPermissions,
+		})
+	}
+	return principals
+}
+
+// flattenTemplatePermissionMatrix returns, for every permission granted to someone, who gets it
+func flattenTemplatePermissionMatrix(users map[string][]string, groups map[string][]string, projectCreatorPermissions []string) []interface{} {
+	usersByPermission := map[string][]string{}
+	for login, permissions := range users {
+		for _, permission := range permissions {
+			usersByPermission[permission] = append(usersByPermission[permission], login)
+		}
+	}
+	groupsByPermission := map[string][]string{}
+	for name, permissions := range groups {
+		for _, permission := range permissions {
+			groupsByPermission[permission] = append(groupsByPermission[permission], name)
+		}
+	}
+	withProjectCreator := map[string]bool{}
+	for _, permission := range projectCreatorPermissions {
+		withProjectCreator[permission] = true
+	}
+
+	permissionNames := []string{}
+	for _, byPermission := range []map[string][]string{usersByPermission, groupsByPermission} {
+		for permission := range byPermission {
+			if !withProjectCreator[permission] {
+				permissionNames = append(permissionNames, permission)
+			}
+		}
+	}
+	permissionNames = append(permissionNames, projectCreatorPermissions...)
+	sort.Strings(permissionNames)
+
+	matrix := []interface{}{}
+	for i, permission := range permissionNames {
+		if i > 0 && permissionNames[i-1] == permission {
+			continue
+		}
+		permissionUsers, permissionGroups := append([]string{}, usersByPermission[permission]...), append([]string{}, groupsByPermission[permission]...)
+		sort.Strings(permissionUsers)
+		sort.Strings(permissionGroups)
+		matrix = append(matrix, map[string]interface{}{
+			"permission":           permission,
+			"users":                permissionUsers,
+			"groups":               permissionGroups,
+			"with_project_creator": withProjectCreator[permission],
+		})
+	}
+	return matrix
+}
@@ -0,0 +1,78 @@
+package sonarqube
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubePermissionTemplateEffectivePermissionsDataSourceConfig(rnd string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_permission_template" "%[1]s" {
+			name = "%[1]s"
+		}
+
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[1]s"
+		}
+
+		resource "sonarqube_permissions" "%[1]s_group" {
+			group_name    = sonarqube_group.%[1]s.name
+			template_name = sonarqube_permission_template.%[1]s.name
+			permissions   = ["user", "codeviewer"]
+		}
+
+		resource "sonarqube_permissions" "%[1]s_creator" {
+			special_group_name = "project_creator"
+			template_name      = sonarqube_permission_template.%[1]s.name
+			permissions        = ["admin"]
+		}
+
+		data "sonarqube_permission_template_effective_permissions" "%[1]s" {
+			template_name = sonarqube_permission_template.%[1]s.name
+			depends_on    = [sonarqube_permissions.%[1]s_group, sonarqube_permissions.%[1]s_creator]
+		}`, rnd)
+}
+
+func TestAccSonarqubePermissionTemplateEffectivePermissionsDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_permission_template_effective_permissions." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubePermissionTemplateEffectivePermissionsDataSourceConfig(rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "groups.#", "1"),
+					resource.TestCheckResourceAttr(name, "groups.0.name", rnd),
+					resource.TestCheckResourceAttr(name, "groups.0.permissions.#", "2"),
+					resource.TestCheckResourceAttr(name, "project_creator_permissions.#", "1"),
+					resource.TestCheckResourceAttr(name, "project_creator_permissions.0", "admin"),
+					resource.TestCheckResourceAttr(name, "permissions.#", "3"),
+					resource.TestCheckResourceAttr(name, "permissions.0.permission", "admin"),
+					resource.TestCheckResourceAttr(name, "permissions.0.with_project_creator", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenTemplatePermissionMatrix(t *testing.T) {
+	users := map[string][]string{"bob": {"user", "admin"}, "alice": {"user"}}
+	groups := map[string][]string{"developers": {"user", "scan"}}
+	projectCreator := []string{"admin"}
+
+	expected := []interface{}{
+		map[string]interface{}{"permission": "admin", "users": []string{"bob"}, "groups": []string{}, "with_project_creator": true},
+		map[string]interface{}{"permission": "scan", "users": []string{}, "groups": []string{"developers"}, "with_project_creator": false},
+		map[string]interface{}{"permission": "user", "users": []string{"alice", "bob"}, "groups": []string{"developers"}, "with_project_creator": false},
+	}
+	if got := flattenTemplatePermissionMatrix(users, groups, projectCreator); !reflect.DeepEqual(got, expected) {
+		t.Errorf("flattenTemplatePermissionMatrix() = %v, want %v", got, expected)
+	}
+}
@@ -46,6 +46,11 @@ func dataSourceSonarqubePermissionTemplates() *schema.Resource {
 							Computed:    true,
 							Description: "The project key pattern of the permission template.",
 						},
+						"import_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID to import the permission template as a `sonarqube_permission_template` resource.",
+						},
 					},
 				},
 				Description: "The list of permission templates.",
@@ -110,6 +115,7 @@ func flattenReadPermissionTemplatesResponse(permissionTemplates []PermissionTemp
 			"name":                permissionTemplate.Name,
 			"description":         permissionTemplate.Description,
 			"project_key_pattern": permissionTemplate.ProjectKeyPattern,
+			"import_id":           permissionTemplate.ID,
 		}
 
 		permissionTemplatesList = append(permissionTemplatesList, values)
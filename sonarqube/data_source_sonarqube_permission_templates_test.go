@@ -33,6 +33,7 @@ func TestAccSonarqubePermissionTemplatesDataSource(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "permission_templates.#", "1"),
 					resource.TestCheckResourceAttr(name, "permission_templates.0.name", "testAccSonarqubePermissionTemplatesDataSource"),
+					resource.TestCheckResourceAttrPair(name, "permission_templates.0.import_id", "sonarqube_permission_template."+rnd, "id"),
 					resource.TestCheckResourceAttr(name, "permission_templates.0.description", "These are internal projects"),
 					resource.TestCheckResourceAttr(name, "permission_templates.0.project_key_pattern", "internal.*"),
 				),
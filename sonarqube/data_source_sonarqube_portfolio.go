@@ -19,6 +19,11 @@ func dataSourceSonarqubePortfolio() *schema.Resource {
 				Computed:    true,
 				Description: "Name of the portfolio",
 			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the page of the portfolio in the SonarQube UI",
+			},
 			"description": {
 				Type:        schema.TypeString,
 				Computed:    true,
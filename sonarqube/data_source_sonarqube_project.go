@@ -24,6 +24,27 @@ func dataSourceSonarqubeProject() *schema.Resource {
 				Computed:    true,
 				Description: "Project visibility",
 			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the overview page of the project in the SonarQube UI",
+			},
+			"tags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The tags of the project, without the `default_project_tags` of the provider",
+			},
+			"tags_all": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "All the tags of the project",
+			},
 		},
 	}
 }
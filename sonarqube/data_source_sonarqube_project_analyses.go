@@ -0,0 +1,232 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ProjectAnalysisEvent for unmarshalling the events of an analysis
+type ProjectAnalysisEvent struct {
+	Key         string `json:"key"`
+	Category    string `json:"category"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ProjectAnalysis for unmarshalling a single analysis of api/project_analyses/search
+type ProjectAnalysis struct {
+	Key            string                 `json:"key"`
+	Date           string                 `json:"date"`
+	ProjectVersion string                 `json:"projectVersion"`
+	BuildString    string                 `json:"buildString"`
+	Revision       string                 `json:"revision"`
+	Events         []ProjectAnalysisEvent `json:"events"`
+}
+
+// GetProjectAnalyses for unmarshalling response body of api/project_analyses/search
+type GetProjectAnalyses struct {
+	Paging   Paging            `json:"paging"`
+	Analyses []ProjectAnalysis `json:"analyses"`
+}
+
+// projectAnalysisEventCategories are the categories of the events of an analysis
+var projectAnalysisEventCategories = []string{"VERSION", "OTHER", "QUALITY_PROFILE", "QUALITY_GATE", "DEFINITION_CHANGE", "ISSUE_DETECTION", "SQ_UPGRADE"}
+
+func dataSourceSonarqubeProjectAnalyses() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to get the analyses of a project and their events, such as new versions or quality gate status changes.",
+		Read:        dataSourceSonarqubeProjectAnalysesRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the project.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the branch. When not set, the main branch is used.",
+			},
+			"category": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(projectAnalysisEventCategories, false)),
+				Description:      "Only return the analyses with an event of this category. Possible values are `VERSION`, `OTHER`, `QUALITY_PROFILE`, `QUALITY_GATE`, `DEFINITION_CHANGE`, `ISSUE_DETECTION` and `SQ_UPGRADE`.",
+			},
+			"from": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the analyses made on or after this date, for example `2024-01-31`.",
+			},
+			"to": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the analyses made on or before this date, for example `2024-01-31`.",
+			},
+			"max_results": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          100,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 500)),
+				Description:      "The maximum number of analyses to return. Defaults to `100`.",
+			},
+			"last_analysis_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date of the most recent analysis returned. Empty when there is none.",
+			},
+			"analyses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the analysis.",
+						},
+						"date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date of the analysis.",
+						},
+						"project_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the project set by the scanner.",
+						},
+						"build_string": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The build string set by the scanner.",
+						},
+						"revision": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The SCM revision of the analysis.",
+						},
+						"events": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The key of the event.",
+									},
+									"category": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The category of the event, for example `VERSION` or `QUALITY_GATE`.",
+									},
+									"name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the event, for example the version or the new quality gate status.",
+									},
+									"description": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The description of the event.",
+									},
+								},
+							},
+							Description: "The events of the analysis.",
+						},
+					},
+				},
+				Description: "The list of analyses, most recent first.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeProjectAnalysesRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	d.SetId(fmt.Sprintf("%d", schema.HashString(fmt.Sprintf("%s/%s/%s/%s/%s/%d", project, d.Get("branch").(string), d.Get("category").(string), d.Get("from").(string), d.Get("to").(string), d.Get("max_results").(int)))))
+
+	analyses, err := readProjectAnalysesFromApi(d, m)
+	if err != nil {
+		return err
+	}
+
+	lastAnalysisDate := ""
+	if len(analyses.Analyses) > 0 {
+		lastAnalysisDate = analyses.Analyses[0].Date
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("analyses", flattenProjectAnalyses(analyses.Analyses)))
+	errs = append(errs, d.Set("last_analysis_date", lastAnalysisDate))
+	return errors.Join(errs...)
+}
+
+func readProjectAnalysesFromApi(d *schema.ResourceData, m interface{}) (*GetProjectAnalyses, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_analyses/search"
+
+	RawQuery := url.Values{
+		"project": []string{d.Get("project").(string)},
+		"ps":      []string{strconv.Itoa(d.Get("max_results").(int))},
+	}
+	for _, parameter := range []string{"branch", "category", "from", "to"} {
+		if value, ok := d.GetOk(parameter); ok {
+			RawQuery.Add(parameter, value.(string))
+		}
+	}
+	sonarQubeURL.RawQuery = RawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readProjectAnalysesFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readProjectAnalysesFromApi: Failed to read the analyses of project '%s': %+v", d.Get("project").(string), err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	analyses := GetProjectAnalyses{}
+	err = json.NewDecoder(resp.Body).Decode(&analyses)
+	if err != nil {
+		return nil, fmt.Errorf("readProjectAnalysesFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &analyses, nil
+}
+
+func flattenProjectAnalyses(analyses []ProjectAnalysis) []interface{} {
+	analysesList := []interface{}{}
+	for _, analysis := range analyses {
+		eventsList := []interface{}{}
+		for _, event := range analysis.Events {
+			eventsList = append(eventsList, map[string]interface{}{
+				"key":         event.Key,
+				"category":    event.Category,
+				"name":        event.Name,
+				"description": event.Description,
+			})
+		}
+		analysesList = append(analysesList, map[string]interface{}{
+			"key":             analysis.Key,
+			"date":            analysis.Date,
+			"project_version": analysis.ProjectVersion,
+			"build_string":    analysis.BuildString,
+			"revision":        analysis.Revision,
+			"events":          eventsList,
+		})
+	}
+	return analysesList
+}
@@ -0,0 +1,40 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectAnalysesDataSourceConfig(rnd string, projectKey string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name    = "%[2]s"
+			project = "%[2]s"
+		}
+
+		data "sonarqube_project_analyses" "%[1]s" {
+			project  = sonarqube_project.%[1]s.project
+			category = "VERSION"
+		}`, rnd, projectKey)
+}
+
+func TestAccSonarqubeProjectAnalysesDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_project_analyses." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectAnalysesDataSourceConfig(rnd, "testAccSonarqubeProjectAnalysesDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "analyses.#", "0"),
+					resource.TestCheckResourceAttr(name, "last_analysis_date", ""),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,177 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProjectLink used in SearchProjectLinks
+type ProjectLink struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// SearchProjectLinks for unmarshalling response body of api/project_links/search
+type SearchProjectLinks struct {
+	Links []ProjectLink `json:"links"`
+}
+
+// projectBadgeMetrics are the metrics that api/project_badges/measure renders a badge for
+var projectBadgeMetrics = []string{
+	"bugs",
+	"code_smells",
+	"coverage",
+	"duplicated_lines_density",
+	"ncloc",
+	"reliability_rating",
+	"security_hotspots",
+	"security_rating",
+	"sqale_index",
+	"sqale_rating",
+	"vulnerabilities",
+}
+
+func dataSourceSonarqubeProjectLinks() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to get the links, the dashboard URL and the badge URLs of a project in one place, for example to
+generate the entries of an internal service catalog such as Backstage from Terraform outputs. The badge URLs include the badge token of the
+project, so that they also work for private projects.`,
+		Read: dataSourceSonarqubeProjectLinksRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the project.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the branch of the dashboard and the badges. When not set, the main branch is used.",
+			},
+			"links": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the link.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the link. Empty for the links provided by SonarQube, which only have a type.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the link, for example `homepage`, `ci`, `issue`, `scm` or `custom`.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL of the link.",
+						},
+					},
+				},
+				Description: "The links of the project, as shown on its information page.",
+			},
+			"dashboard_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the overview page of the project or branch.",
+			},
+			"quality_gate_badge_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The URL of the quality gate badge of the project, including the token.",
+			},
+			"badge_urls": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Sensitive:   true,
+				Description: "The URLs of the measure badges of the project by metric key, for example `coverage` or `bugs`, including the token.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeProjectLinksRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	branch := d.Get("branch").(string)
+	d.SetId(fmt.Sprintf("%d", schema.HashString(project+"/"+branch)))
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_links/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"projectKey": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"dataSourceSonarqubeProjectLinksRead",
+	)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeProjectLinksRead: Failed to read the links of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	links := SearchProjectLinks{}
+	err = json.NewDecoder(resp.Body).Decode(&links)
+	if err != nil {
+		return fmt.Errorf("dataSourceSonarqubeProjectLinksRead: Failed to decode json into struct: %+v", err)
+	}
+
+	token, err := readProjectBadgeToken(project, m)
+	if err != nil {
+		return err
+	}
+
+	linksList := []interface{}{}
+	for _, link := range links.Links {
+		linksList = append(linksList, map[string]interface{}{
+			"id":   link.ID,
+			"name": link.Name,
+			"type": link.Type,
+			"url":  link.URL,
+		})
+	}
+
+	conf := m.(*ProviderConfiguration)
+	query := url.Values{"id": []string{project}}
+	badgeQuery := url.Values{"project": []string{project}, "token": []string{token}}
+	if branch != "" {
+		query.Set("branch", branch)
+		badgeQuery.Set("branch", branch)
+	}
+	badgeURLs := map[string]interface{}{}
+	for _, metric := range projectBadgeMetrics {
+		badgeQuery.Set("metric", metric)
+		badgeURLs[metric] = sonarQubeWebURL(conf, "/api/project_badges/measure", badgeQuery)
+	}
+	badgeQuery.Del("metric")
+
+	errs := []error{}
+	errs = append(errs, d.Set("links", linksList))
+	errs = append(errs, d.Set("dashboard_url", sonarQubeWebURL(conf, "/dashboard", query)))
+	errs = append(errs, d.Set("quality_gate_badge_url", sonarQubeWebURL(conf, "/api/project_badges/quality_gate", badgeQuery)))
+	errs = append(errs, d.Set("badge_urls", badgeURLs))
+	return errors.Join(errs...)
+}
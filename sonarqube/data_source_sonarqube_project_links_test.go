@@ -0,0 +1,77 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectLinksDataSourceConfig(rnd string, project string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name    = "%[2]s"
+			project = "%[2]s"
+		}
+
+		data "sonarqube_project_links" "%[1]s" {
+			project = sonarqube_project.%[1]s.project
+		}`, rnd, project)
+}
+
+func TestAccSonarqubeProjectLinksDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_project_links." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectLinksDataSourceConfig(rnd, "testAccSonarqubeProjectLinks"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "dashboard_url"),
+					resource.TestCheckResourceAttrSet(name, "quality_gate_badge_url"),
+					resource.TestCheckResourceAttrSet(name, "badge_urls.coverage"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceSonarqubeProjectLinksRead(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/project_links/search", http.StatusOK, `{"links": [
+		{"id": "1", "type": "homepage", "url": "https://example.com"},
+		{"id": "2", "name": "Runbook", "type": "custom", "url": "https://wiki.example.com/runbook"}
+	]}`)
+	mock.respond("GET", "/api/project_badges/token", http.StatusOK, `{"token": "sqb_123"}`)
+	conf := mock.conf("Community", "10.7")
+	conf.sonarQubeURL.User = url.UserPassword("admin", "secret")
+	r := dataSourceSonarqubeProjectLinks()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project": "my_project",
+		"branch":  "develop",
+	})
+	if err := r.Read(d, conf); err != nil {
+		t.Fatalf("Read() unexpected error = %v", err)
+	}
+
+	if d.Get("links.#").(int) != 2 || d.Get("links.0.type").(string) != "homepage" || d.Get("links.1.name").(string) != "Runbook" {
+		t.Errorf("Read() links = %v, want the links returned by SonarQube", d.Get("links"))
+	}
+	// The URLs do not contain the credentials of the provider
+	if got, want := d.Get("dashboard_url").(string), mock.server.URL+"/dashboard?branch=develop&id=my_project"; got != want {
+		t.Errorf("Read() dashboard_url = %s, want %s", got, want)
+	}
+	if got, want := d.Get("quality_gate_badge_url").(string), mock.server.URL+"/api/project_badges/quality_gate?branch=develop&project=my_project&token=sqb_123"; got != want {
+		t.Errorf("Read() quality_gate_badge_url = %s, want %s", got, want)
+	}
+	if got, want := d.Get("badge_urls.coverage").(string), mock.server.URL+"/api/project_badges/measure?branch=develop&metric=coverage&project=my_project&token=sqb_123"; got != want {
+		t.Errorf("Read() badge_urls.coverage = %s, want %s", got, want)
+	}
+}
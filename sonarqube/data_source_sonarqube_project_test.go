@@ -2,8 +2,10 @@ package sonarqube
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -39,3 +41,19 @@ func TestAccSonarqubeProjectDataSource(t *testing.T) {
 		},
 	})
 }
+
+func TestDataSourceSonarqubeProjectTags(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/components/show", http.StatusOK, `{"component":{"key":"my-project","name":"My Project","visibility":"public","tags":["api","team-a"]}}`)
+	conf := mock.conf("Community", "10.7")
+	conf.defaultProjectTags = []string{"team-a"}
+	ds := dataSourceSonarqubeProject()
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{"project": "my-project"})
+	if err := ds.Read(d, conf); err != nil {
+		t.Fatalf("Read() unexpected error = %v", err)
+	}
+	if d.Get("tags.#").(int) != 1 || d.Get("tags.0").(string) != "api" || d.Get("tags_all.#").(int) != 2 {
+		t.Errorf("Read() state = %v, want the tags without the default tags, and all the tags", d.State().Attributes)
+	}
+}
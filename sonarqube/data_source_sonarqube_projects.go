@@ -0,0 +1,82 @@
+package sonarqube
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubeProjects() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to list Sonarqube projects, for example to adopt the existing projects with ` + "`import`" + ` blocks
+by their ` + "`import_id`" + `.`,
+		Read: dataSourceSonarqubeProjectsRead,
+		Schema: map[string]*schema.Schema{
+			"key_prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the projects whose key starts with this prefix. When not set, every project is listed.",
+			},
+			"projects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the project.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the project.",
+						},
+						"visibility": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The visibility of the project, `public` or `private`.",
+						},
+						"last_analysis_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date of the last analysis of the project, empty when it was never analyzed.",
+						},
+						"import_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID to import the project as a `sonarqube_project` resource.",
+						},
+					},
+				},
+				Description: "The list of projects.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeProjectsRead(d *schema.ResourceData, m interface{}) error {
+	d.SetId(fmt.Sprintf("%d", schema.HashString(d.Get("key_prefix"))))
+
+	projects, err := searchProjectsByKeyPrefix(d.Get("key_prefix").(string), "", m)
+	if err != nil {
+		return err
+	}
+
+	projectsList := []interface{}{}
+	for _, project := range projects {
+		projectsList = append(projectsList, map[string]interface{}{
+			"key":                project.Key,
+			"name":               project.Name,
+			"visibility":         project.Visibility,
+			"last_analysis_date": project.LastAnalysisDate,
+			"import_id":          project.Key,
+		})
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("projects", projectsList))
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,42 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectsDataSourceConfig(rnd string, projectKey string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		data "sonarqube_projects" "%[1]s" {
+			key_prefix = sonarqube_project.%[1]s.project
+		}`, rnd, projectKey)
+}
+
+func TestAccSonarqubeProjectsDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_projects." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectsDataSourceConfig(rnd, "testAccSonarqubeProjectsDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "projects.#", "1"),
+					resource.TestCheckResourceAttr(name, "projects.0.key", "testAccSonarqubeProjectsDataSource"),
+					resource.TestCheckResourceAttr(name, "projects.0.visibility", "public"),
+					resource.TestCheckResourceAttr(name, "projects.0.import_id", "testAccSonarqubeProjectsDataSource"),
+				),
+			},
+		},
+	})
+}
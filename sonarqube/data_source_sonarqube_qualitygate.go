@@ -14,6 +14,11 @@ func dataSourceSonarqubeQualityGate() *schema.Resource {
 				Required:    true,
 				Description: "The name of the Quality Gate.",
 			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the page of the Quality Gate in the SonarQube UI.",
+			},
 			"copy_from": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -12,15 +12,20 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// ListQualityGates for unmarshalling response body of api/qualitygates/list
+type ListQualityGates struct {
+	QualityGates []GetQualityGate `json:"qualitygates"`
+}
+
 func dataSourceSonarqubeQualityGates() *schema.Resource {
 	return &schema.Resource{
-		Description: "Use this data source to get Sonarqube quality gates resources",
+		Description: "Use this data source to get Sonarqube quality gates resources. When `name` is not set, every quality gate is listed.",
 		Read:        dataSourceSonarqubeQualityGatesRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Search quality gates by name.",
+				Optional:    true,
+				Description: "Search quality gates by name. When not set, every quality gate is listed.",
 			},
 			"ignore_missing": {
 				Type:        schema.TypeBool,
@@ -78,6 +83,11 @@ func dataSourceSonarqubeQualityGates() *schema.Resource {
 							},
 							Description: "List of Quality Gate conditions.",
 						},
+						"import_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID to import the quality gate as a `sonarqube_qualitygate` resource.",
+						},
 					},
 				},
 				Description: "The list of quality gates.",
@@ -89,30 +99,37 @@ func dataSourceSonarqubeQualityGates() *schema.Resource {
 func dataSourceSonarqubeQualityGatesRead(d *schema.ResourceData, m interface{}) error {
 	d.SetId(fmt.Sprintf("%d", schema.HashString(d.Get("name"))))
 
-	qualityGateReadResponse, err := readQualityGatesFromApi(d, m)
-	if err != nil {
-		return err
+	names := []string{d.Get("name").(string)}
+	if names[0] == "" {
+		var err error
+		if names, err = listQualityGateNamesFromApi(m); err != nil {
+			return err
+		}
 	}
 
-	errs := []error{}
-	if qualityGateReadResponse != nil {
-		errs = append(errs, d.Set("quality_gates", flattenReadQualityGateResponse(*qualityGateReadResponse)))
-	} else {
-		errs = append(errs, d.Set("quality_gates", []interface{}{}))
+	qualityGatesList := []interface{}{}
+	for _, name := range names {
+		qualityGateReadResponse, err := readQualityGatesFromApi(name, d.Get("ignore_missing").(bool), m)
+		if err != nil {
+			return err
+		}
+		if qualityGateReadResponse != nil {
+			qualityGatesList = append(qualityGatesList, flattenReadQualityGateResponse(*qualityGateReadResponse)...)
+		}
 	}
 
+	errs := []error{}
+	errs = append(errs, d.Set("quality_gates", qualityGatesList))
+
 	return errors.Join(errs...)
 }
 
-func readQualityGatesFromApi(d *schema.ResourceData, m interface{}) (*GetQualityGate, error) {
+func readQualityGatesFromApi(name string, ignoreMissing bool, m interface{}) (*GetQualityGate, error) {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/show"
 
 	RawQuery := url.Values{}
-
-	if name, ok := d.GetOk("name"); ok {
-		RawQuery.Add("name", name.(string))
-	}
+	RawQuery.Add("name", name)
 
 	sonarQubeURL.RawQuery = RawQuery.Encode()
 
@@ -124,7 +141,7 @@ func readQualityGatesFromApi(d *schema.ResourceData, m interface{}) (*GetQuality
 		"readQualityGatesFromApi",
 	)
 	if err != nil {
-		if resp.StatusCode == http.StatusNotFound && d.Get("ignore_missing").(bool) {
+		if resp.StatusCode == http.StatusNotFound && ignoreMissing {
 			// If the quality gate does not exist, we don't want to fail the data source
 			return nil, nil
 		}
@@ -147,6 +164,38 @@ func readQualityGatesFromApi(d *schema.ResourceData, m interface{}) (*GetQuality
 	return &qualityGateReadResponse, nil
 }
 
+// listQualityGateNamesFromApi returns the names of every quality gate, sorted
+func listQualityGateNamesFromApi(m interface{}) ([]string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/list"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"listQualityGateNamesFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("listQualityGateNamesFromApi: Failed to list Sonarqube quality gates: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	qualityGatesListResponse := ListQualityGates{}
+	err = json.NewDecoder(resp.Body).Decode(&qualityGatesListResponse)
+	if err != nil {
+		return nil, fmt.Errorf("listQualityGateNamesFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	names := []string{}
+	for _, qualityGate := range qualityGatesListResponse.QualityGates {
+		names = append(names, qualityGate.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 func flattenReadQualityGateResponse(qualityGate GetQualityGate) []interface{} {
 	qualityGatesList := []interface{}{}
 
@@ -156,6 +205,7 @@ func flattenReadQualityGateResponse(qualityGate GetQualityGate) []interface{} {
 		// Api returns if true if set as default is available. when is_default=true setAsDefault=false so is_default=true
 		"is_default": !qualityGate.Actions.SetAsDefault,
 		"condition":  flattenReadQualityGateConditionsResponse(&qualityGate.Conditions),
+		"import_id":  qualityGate.Name,
 	}
 
 	qualityGatesList = append(qualityGatesList, values)
@@ -2,8 +2,10 @@ package sonarqube
 
 import (
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -36,6 +38,7 @@ func TestAccSonarqubeQualityGatesDataSource(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "quality_gates.#", "1"),
 					resource.TestCheckResourceAttr(name, "quality_gates.0.name", "testAccSonarqubeQualityGatesDataSource"),
+					resource.TestCheckResourceAttr(name, "quality_gates.0.import_id", "testAccSonarqubeQualityGatesDataSource"),
 					resource.TestCheckResourceAttr(name, "quality_gates.0.condition.0.metric", "new_coverage"),
 					resource.TestCheckResourceAttr(name, "quality_gates.0.condition.0.op", "LT"),
 					resource.TestCheckResourceAttr(name, "quality_gates.0.condition.0.threshold", "50"),
@@ -44,3 +47,32 @@ func TestAccSonarqubeQualityGatesDataSource(t *testing.T) {
 		},
 	})
 }
+
+func TestDataSourceSonarqubeQualityGatesListAll(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/qualitygates/list", http.StatusOK, `{"qualitygates":[{"name":"Sonar way","isBuiltIn":true},{"name":"Backend"}]}`)
+	mock.handle("GET", "/api/qualitygates/show", func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		mockJSON(w, map[string]interface{}{
+			"name":       name,
+			"conditions": []map[string]string{{"id": "1", "metric": "new_coverage", "op": "LT", "error": "80"}},
+			"actions":    map[string]bool{"setAsDefault": name != "Sonar way"},
+		})
+	})
+
+	r := dataSourceSonarqubeQualityGates()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	if err := r.Read(d, mock.conf("Community", "10.7")); err != nil {
+		t.Fatalf("Read() error = %+v", err)
+	}
+
+	if d.Get("quality_gates.#") != 2 {
+		t.Fatalf("quality_gates = %v, want 2 quality gates", d.Get("quality_gates"))
+	}
+	if d.Get("quality_gates.0.import_id") != "Backend" || d.Get("quality_gates.1.import_id") != "Sonar way" || d.Get("quality_gates.1.is_default") != true {
+		t.Errorf("quality_gates = %v", d.Get("quality_gates"))
+	}
+	if d.Get("quality_gates.0.condition.0.threshold") != "80" {
+		t.Errorf("quality_gates.0.condition = %v", d.Get("quality_gates.0.condition"))
+	}
+}
@@ -0,0 +1,221 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// QualityProfileCompareRule is a rule activated in only one of the profiles of api/qualityprofiles/compare
+type QualityProfileCompareRule struct {
+	Key      string `json:"key"`
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+}
+
+// QualityProfileCompareActivation is the activation of a rule in one of the profiles of api/qualityprofiles/compare
+type QualityProfileCompareActivation struct {
+	Severity string            `json:"severity"`
+	Params   map[string]string `json:"params"`
+}
+
+// QualityProfileCompareModifiedRule is a rule activated with different settings in both profiles of api/qualityprofiles/compare
+type QualityProfileCompareModifiedRule struct {
+	Key   string                          `json:"key"`
+	Name  string                          `json:"name"`
+	Left  QualityProfileCompareActivation `json:"left"`
+	Right QualityProfileCompareActivation `json:"right"`
+}
+
+// GetQualityProfileCompare for unmarshalling response body of api/qualityprofiles/compare
+type GetQualityProfileCompare struct {
+	InLeft   []QualityProfileCompareRule         `json:"inLeft"`
+	InRight  []QualityProfileCompareRule         `json:"inRight"`
+	Modified []QualityProfileCompareModifiedRule `json:"modified"`
+	Same     []QualityProfileCompareRule         `json:"same"`
+}
+
+func dataSourceSonarqubeQualityProfileCompare() *schema.Resource {
+	rulesList := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"key": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The key of the rule.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the rule.",
+					},
+					"severity": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The severity of the rule in the profile that activates it.",
+					},
+				},
+			},
+			Description: description,
+		}
+	}
+
+	return &schema.Resource{
+		Description: `Use this data source to compare two quality profiles with api/qualityprofiles/compare, for example a team profile with the built-in ` + "`Sonar way`" + ` profile.
+The rules that differ can be used in checks or outputs to notice when a profile falls behind its baseline.`,
+		Read: dataSourceSonarqubeQualityProfileCompareRead,
+		Schema: map[string]*schema.Schema{
+			"left_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the first quality profile, for example the profile of a team.",
+			},
+			"right_key": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the second quality profile, for example the built-in profile used as baseline.",
+			},
+			"in_left":  rulesList("The rules only activated in the left profile."),
+			"in_right": rulesList("The rules only activated in the right profile."),
+			"modified": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the rule.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the rule.",
+						},
+						"left_severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The severity of the rule in the left profile.",
+						},
+						"right_severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The severity of the rule in the right profile.",
+						},
+						"left_params": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The parameters of the rule in the left profile.",
+						},
+						"right_params": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The parameters of the rule in the right profile.",
+						},
+					},
+				},
+				Description: "The rules activated in both profiles with a different severity or different parameters.",
+			},
+			"same_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of rules activated with the same settings in both profiles.",
+			},
+			"identical": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether both profiles activate the same rules with the same settings.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeQualityProfileCompareRead(d *schema.ResourceData, m interface{}) error {
+	leftKey := d.Get("left_key").(string)
+	rightKey := d.Get("right_key").(string)
+	d.SetId(leftKey + "/" + rightKey)
+
+	compare, err := readQualityProfileCompareFromApi(leftKey, rightKey, m)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("in_left", flattenQualityProfileCompareRules(compare.InLeft)))
+	errs = append(errs, d.Set("in_right", flattenQualityProfileCompareRules(compare.InRight)))
+	errs = append(errs, d.Set("modified", flattenQualityProfileCompareModifiedRules(compare.Modified)))
+	errs = append(errs, d.Set("same_count", len(compare.Same)))
+	errs = append(errs, d.Set("identical", len(compare.InLeft) == 0 && len(compare.InRight) == 0 && len(compare.Modified) == 0))
+	return errors.Join(errs...)
+}
+
+func readQualityProfileCompareFromApi(leftKey string, rightKey string, m interface{}) (*GetQualityProfileCompare, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/compare"
+	sonarQubeURL.RawQuery = url.Values{
+		"leftKey":  []string{leftKey},
+		"rightKey": []string{rightKey},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readQualityProfileCompareFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readQualityProfileCompareFromApi: Failed to compare quality profiles '%s' and '%s': %+v", leftKey, rightKey, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	compare := GetQualityProfileCompare{}
+	err = json.NewDecoder(resp.Body).Decode(&compare)
+	if err != nil {
+		return nil, fmt.Errorf("readQualityProfileCompareFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &compare, nil
+}
+
+func flattenQualityProfileCompareRules(rules []QualityProfileCompareRule) []interface{} {
+	rulesList := []interface{}{}
+	for _, rule := range rules {
+		rulesList = append(rulesList, map[string]interface{}{
+			"key":      rule.Key,
+			"name":     rule.Name,
+			"severity": rule.Severity,
+		})
+	}
+	return rulesList
+}
+
+func flattenQualityProfileCompareModifiedRules(rules []QualityProfileCompareModifiedRule) []interface{} {
+	rulesList := []interface{}{}
+	for _, rule := range rules {
+		rulesList = append(rulesList, map[string]interface{}{
+			"key":            rule.Key,
+			"name":           rule.Name,
+			"left_severity":  rule.Left.Severity,
+			"right_severity": rule.Right.Severity,
+			"left_params":    rule.Left.Params,
+			"right_params":   rule.Right.Params,
+		})
+	}
+	return rulesList
+}
@@ -0,0 +1,82 @@
+package sonarqube
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeQualityProfileCompareDataSourceConfig(rnd string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "js"
+		}
+
+		resource "sonarqube_qualityprofile_activate_rule" "%[1]s" {
+			key      = sonarqube_qualityprofile.%[1]s.key
+			rule     = "javascript:S1192"
+			severity = "MAJOR"
+		}
+
+		data "sonarqube_qualityprofile" "%[1]s" {
+			name     = "Sonar way"
+			language = "js"
+		}
+
+		data "sonarqube_qualityprofile_compare" "%[1]s" {
+			left_key  = sonarqube_qualityprofile_activate_rule.%[1]s.key
+			right_key = data.sonarqube_qualityprofile.%[1]s.key
+		}`, rnd, name)
+}
+
+func TestAccSonarqubeQualityProfileCompareDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_qualityprofile_compare." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileCompareDataSourceConfig(rnd, "testAccSonarqubeQualityProfileCompare"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "identical", "false"),
+					resource.TestCheckResourceAttr(name, "same_count", "0"),
+					resource.TestCheckResourceAttr(name, "in_left.#", "1"),
+					resource.TestCheckResourceAttr(name, "in_left.0.key", "javascript:S1192"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenQualityProfileCompareModifiedRules(t *testing.T) {
+	rules := []QualityProfileCompareModifiedRule{
+		{
+			Key:   "java:S107",
+			Name:  "Methods should not have too many parameters",
+			Left:  QualityProfileCompareActivation{Severity: "MAJOR", Params: map[string]string{"max": "5"}},
+			Right: QualityProfileCompareActivation{Severity: "MAJOR", Params: map[string]string{"max": "7"}},
+		},
+	}
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"key":            "java:S107",
+			"name":           "Methods should not have too many parameters",
+			"left_severity":  "MAJOR",
+			"right_severity": "MAJOR",
+			"left_params":    map[string]string{"max": "5"},
+			"right_params":   map[string]string{"max": "7"},
+		},
+	}
+	if flattened := flattenQualityProfileCompareModifiedRules(rules); !reflect.DeepEqual(flattened, expected) {
+		t.Errorf("expected %v, got %v", expected, flattened)
+	}
+	if flattened := flattenQualityProfileCompareModifiedRules(nil); len(flattened) != 0 {
+		t.Errorf("expected no rules, got %v", flattened)
+	}
+}
@@ -51,6 +51,11 @@ func dataSourceSonarqubeQualityProfiles() *schema.Resource {
 							Computed:    true,
 							Description: "Whether the Quality Profile is default.",
 						},
+						"import_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID to import the quality profile as a `sonarqube_qualityprofile` resource.",
+						},
 					},
 				},
 				Description: "The list of quality profiles.",
@@ -119,6 +124,7 @@ func flattenReadQualityProfilesResponse(qualityProfiles []GetQualityProfile) []i
 			"name":       qualityProfile.Name,
 			"language":   qualityProfile.Language,
 			"is_default": qualityProfile.IsDefault,
+			"import_id":  qualityProfile.Key,
 		}
 
 		qualityProfilesList = append(qualityProfilesList, values)
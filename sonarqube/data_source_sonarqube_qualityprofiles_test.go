@@ -35,6 +35,7 @@ func TestAccSonarqubeQualityProfilesDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "quality_profiles.#", "1"),
 					resource.TestCheckResourceAttr(name, "quality_profiles.0.name", "testAccSonarqubeQualityProfilesDataSource"),
 					resource.TestCheckResourceAttr(name, "quality_profiles.0.language", "js"),
+					resource.TestCheckResourceAttrPair(name, "quality_profiles.0.import_id", "sonarqube_qualityprofile."+rnd, "id"),
 				),
 			},
 		},
@@ -0,0 +1,214 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// scannerIdentificationProperties are written first in the generated sonar-project.properties, in this order
+var scannerIdentificationProperties = []string{"sonar.projectKey", "sonar.projectName", "sonar.host.url"}
+
+func dataSourceSonarqubeScannerConfiguration() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to generate the content of the ` + "`sonar-project.properties`" + ` file of a project, from the project,
+its DevOps platform binding and its analysis exclusions, so the scanner configuration of the repositories is templated from the same source of truth.
+The content can be written to the repositories with the provider of the DevOps platform, for example ` + "`github_repository_file`" + `.`,
+		Read: dataSourceSonarqubeScannerConfigurationRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the project.",
+			},
+			"additional_properties": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Additional scanner properties, for example `sonar.sources`. They override the properties read from SonarQube.",
+			},
+			"include_exclusions": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to include the analysis exclusions and inclusions of the project, such as `sonar.exclusions`. Defaults to `true`.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the project.",
+			},
+			"alm": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The DevOps platform the project is bound to, for example `github`. Empty when the project is not bound.",
+			},
+			"alm_setting": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key of the ALM setting the project is bound to. Empty when the project is not bound.",
+			},
+			"repository": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The repository the project is bound to. Empty when the project is not bound.",
+			},
+			"properties": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The scanner properties, keyed by property name.",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The content of the `sonar-project.properties` file.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeScannerConfigurationRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+
+	component, err := readProjectComponentFromApi(project, m)
+	if err != nil {
+		return err
+	}
+	binding, err := findProjectBindingFromApi(project, m)
+	if err != nil {
+		return err
+	}
+
+	properties := map[string]string{
+		"sonar.projectKey":  component.Key,
+		"sonar.projectName": component.Name,
+		"sonar.host.url":    sonarQubeWebURL(m.(*ProviderConfiguration), "", nil),
+	}
+	if d.Get("include_exclusions").(bool) {
+		keys := make([]string, 0, len(analysisExclusionsSettings))
+		for _, a := range analysisExclusionsSettings {
+			keys = append(keys, a.key)
+		}
+		settings, err := getSettingsByKeys(project, keys, m)
+		if err != nil {
+			return fmt.Errorf("dataSourceSonarqubeScannerConfigurationRead: Failed to read the exclusions of project '%s': %+v", project, err)
+		}
+		for key, setting := range settings {
+			if len(setting.Values) > 0 {
+				properties[key] = strings.Join(setting.Values, ",")
+			} else if setting.Value != "" {
+				properties[key] = setting.Value
+			}
+		}
+	}
+	for key, value := range d.Get("additional_properties").(map[string]interface{}) {
+		properties[key] = value.(string)
+	}
+
+	header := []string{fmt.Sprintf("Generated from the SonarQube project '%s'", component.Key)}
+	if binding != nil {
+		header = append(header, fmt.Sprintf("Bound to the %s repository '%s' (ALM setting '%s')", binding.Alm, binding.Repository, binding.Key))
+	} else {
+		binding = &GetBinding{}
+	}
+
+	d.SetId(component.Key)
+	errs := []error{}
+	errs = append(errs, d.Set("name", component.Name))
+	errs = append(errs, d.Set("alm", binding.Alm))
+	errs = append(errs, d.Set("alm_setting", binding.Key))
+	errs = append(errs, d.Set("repository", binding.Repository))
+	errs = append(errs, d.Set("properties", properties))
+	errs = append(errs, d.Set("content", renderScannerProperties(header, properties)))
+	return errors.Join(errs...)
+}
+
+// readProjectComponentFromApi returns the project as returned by api/components/show
+func readProjectComponentFromApi(project string, m interface{}) (*ProjectComponent, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/components/show"
+	sonarQubeURL.RawQuery = url.Values{
+		"component": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readProjectComponentFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readProjectComponentFromApi: Failed to read project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	projectResponse := GetProject{}
+	err = json.NewDecoder(resp.Body).Decode(&projectResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readProjectComponentFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &projectResponse.Component, nil
+}
+
+// renderScannerProperties returns the content of a properties file with the header as comments, the identification
+// properties first and the other properties sorted by name
+func renderScannerProperties(header []string, properties map[string]string) string {
+	var content strings.Builder
+	for _, line := range header {
+		content.WriteString("# " + line + "\n")
+	}
+
+	keys := []string{}
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		rankI, rankJ := scannerPropertyRank(keys[i]), scannerPropertyRank(keys[j])
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+		return keys[i] < keys[j]
+	})
+
+	for _, key := range keys {
+		content.WriteString(escapeProperty(key, true) + "=" + escapeProperty(properties[key], false) + "\n")
+	}
+	return content.String()
+}
+
+// scannerPropertyRank returns the position of an identification property, after which come all other properties
+func scannerPropertyRank(key string) int {
+	for i, identification := range scannerIdentificationProperties {
+		if key == identification {
+			return i
+		}
+	}
+	return len(scannerIdentificationProperties)
+}
+
+// escapeProperty escapes the characters that have a meaning in a properties file
+func escapeProperty(value string, isKey bool) string {
+	replacements := []string{"\\", "\\\\", "\n", "\\n", "\r", "\\r", "\t", "\\t"}
+	if isKey {
+		replacements = append(replacements, "=", "\\=", ":", "\\:", " ", "\\ ")
+	}
+	escaped := strings.NewReplacer(replacements...).Replace(value)
+	if !isKey && strings.HasPrefix(escaped, " ") {
+		escaped = "\\" + escaped
+	}
+	return escaped
+}
@@ -0,0 +1,126 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSonarqubeScannerConfigurationDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_scanner_configuration." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "sonarqube_project" "%[1]s" {
+						name       = "%[1]s"
+						project    = "%[1]s"
+						visibility = "public"
+					}
+
+					resource "sonarqube_analysis_exclusions" "%[1]s" {
+						project    = sonarqube_project.%[1]s.project
+						exclusions = ["**/generated/**", "**/vendor/**"]
+					}
+
+					data "sonarqube_scanner_configuration" "%[1]s" {
+						project = sonarqube_project.%[1]s.project
+						additional_properties = {
+							"sonar.sources" = "src"
+						}
+
+						depends_on = [sonarqube_analysis_exclusions.%[1]s]
+					}`, rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "name", rnd),
+					resource.TestCheckResourceAttr(name, "alm", ""),
+					resource.TestCheckResourceAttr(name, "properties.sonar.projectKey", rnd),
+					resource.TestCheckResourceAttr(name, "properties.sonar.exclusions", "**/generated/**,**/vendor/**"),
+					resource.TestCheckResourceAttr(name, "properties.sonar.sources", "src"),
+					resource.TestCheckResourceAttrSet(name, "content"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceSonarqubeScannerConfigurationRead(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/components/show", http.StatusOK, `{"component":{"key":"my_project","name":"My project"}}`)
+	mock.respond("GET", "/api/alm_settings/get_binding", http.StatusNotFound, `{"errors":[{"msg":"Project 'my_project' is not bound to any DevOps Platform"}]}`)
+	conf := mock.conf("Community", "10.7")
+	// The URL of the provider is built like configureProvider builds it, with its credentials
+	host, err := url.Parse(mock.server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf.sonarQubeURL = sonarQubeBaseURL(host)
+	conf.sonarQubeURL.User = url.UserPassword("admin", "secret")
+	ds := dataSourceSonarqubeScannerConfiguration()
+
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"project":            "my_project",
+		"include_exclusions": false,
+	})
+	if err := ds.Read(d, conf); err != nil {
+		t.Fatalf("Read() unexpected error = %v", err)
+	}
+	if got := d.Get("properties").(map[string]interface{})["sonar.host.url"]; got != mock.server.URL {
+		t.Errorf("Read() sonar.host.url = %s, want %s", got, mock.server.URL)
+	}
+	if content := d.Get("content").(string); !strings.Contains(content, "sonar.host.url="+escapeProperty(mock.server.URL, false)+"\n") {
+		t.Errorf("Read() content = %q, want the public URL of SonarQube", content)
+	}
+}
+
+func TestRenderScannerProperties(t *testing.T) {
+	properties := map[string]string{
+		"sonar.sources":     "src",
+		"sonar.exclusions":  "**/generated/**,C:\\temp",
+		"sonar.projectName": "My project",
+		"sonar.projectKey":  "my_project",
+		"sonar.host.url":    "https://sonarqube.example.com",
+	}
+
+	expected := `# Generated from the SonarQube project 'my_project'
+sonar.projectKey=my_project
+sonar.projectName=My project
+sonar.host.url=https://sonarqube.example.com
+sonar.exclusions=**/generated/**,C:\\temp
+sonar.sources=src
+`
+	if result := renderScannerProperties([]string{"Generated from the SonarQube project 'my_project'"}, properties); result != expected {
+		t.Errorf("renderScannerProperties() = %q, want %q", result, expected)
+	}
+}
+
+func TestEscapeProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		isKey    bool
+		expected string
+	}{
+		{name: "plain value", value: "src/main", expected: "src/main"},
+		{name: "value with backslash and newline", value: "a\\b\nc", expected: "a\\\\b\\nc"},
+		{name: "value with leading space", value: " src", expected: "\\ src"},
+		{name: "key with separators", value: "my key=a:b", isKey: true, expected: "my\\ key\\=a\\:b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := escapeProperty(tt.value, tt.isKey); result != tt.expected {
+				t.Errorf("escapeProperty() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// UpgradePlugin is a plugin that has to be updated, or that is incompatible, to upgrade SonarQube
+type UpgradePlugin struct {
+	Key     string `json:"key"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Upgrade for unmarshalling a single upgrade of api/system/upgrades
+type Upgrade struct {
+	Version               string `json:"version"`
+	Description           string `json:"description"`
+	ReleaseDate           string `json:"releaseDate"`
+	ChangeLogURL          string `json:"changeLogUrl"`
+	DownloadURL           string `json:"downloadUrl"`
+	DownloadDeveloperURL  string `json:"downloadDeveloperUrl"`
+	DownloadEnterpriseURL string `json:"downloadEnterpriseUrl"`
+	DownloadDatacenterURL string `json:"downloadDatacenterUrl"`
+	Plugins               struct {
+		RequireUpdate []UpgradePlugin `json:"requireUpdate"`
+		Incompatible  []UpgradePlugin `json:"incompatible"`
+	} `json:"plugins"`
+}
+
+// GetUpgrades for unmarshalling response body of api/system/upgrades
+type GetUpgrades struct {
+	Upgrades               []Upgrade `json:"upgrades"`
+	UpdateCenterRefresh    string    `json:"updateCenterRefresh"`
+	LatestLTA              string    `json:"latestLTA"`
+	LatestLTS              string    `json:"latestLTS"`
+	InstalledVersionActive *bool     `json:"installedVersionActive"`
+}
+
+func dataSourceSonarqubeUpgrades() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to get the SonarQube upgrades available from the update center, and the plugins that have to be
+updated or removed before upgrading. For example to open a change request when a new LTA (long-term active) version is released.`,
+		Read: dataSourceSonarqubeUpgradesRead,
+		Schema: map[string]*schema.Schema{
+			"upgrade_available": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether at least one upgrade is available.",
+			},
+			"latest_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The most recent version available. Empty when no upgrade is available.",
+			},
+			"latest_lta": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The most recent LTA (long-term active, formerly LTS) version known to the update center. Empty when SonarQube does not report it.",
+			},
+			"installed_version_active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the installed version is still supported. `true` when SonarQube does not report it.",
+			},
+			"update_center_refresh": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the update center data was last refreshed.",
+			},
+			"upgrades": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The version of the upgrade.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the upgrade.",
+						},
+						"release_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The release date of the upgrade.",
+						},
+						"change_log_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL of the release notes.",
+						},
+						"download_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The download URL of the upgrade for the installed edition.",
+						},
+						"plugins_require_update": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The keys of the installed plugins that must be updated together with SonarQube.",
+						},
+						"incompatible_plugins": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The keys of the installed plugins that are not compatible with the upgrade and must be removed.",
+						},
+					},
+				},
+				Description: "The available upgrades, oldest first.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeUpgradesRead(d *schema.ResourceData, m interface{}) error {
+	upgrades, err := readUpgradesFromApi(m)
+	if err != nil {
+		return err
+	}
+
+	latestVersion := ""
+	if len(upgrades.Upgrades) > 0 {
+		latestVersion = upgrades.Upgrades[len(upgrades.Upgrades)-1].Version
+	}
+	latestLTA := upgrades.LatestLTA
+	if latestLTA == "" {
+		latestLTA = upgrades.LatestLTS
+	}
+	installedVersionActive := true
+	if upgrades.InstalledVersionActive != nil {
+		installedVersionActive = *upgrades.InstalledVersionActive
+	}
+
+	d.SetId("upgrades")
+	errs := []error{}
+	errs = append(errs, d.Set("upgrade_available", len(upgrades.Upgrades) > 0))
+	errs = append(errs, d.Set("latest_version", latestVersion))
+	errs = append(errs, d.Set("latest_lta", latestLTA))
+	errs = append(errs, d.Set("installed_version_active", installedVersionActive))
+	errs = append(errs, d.Set("update_center_refresh", upgrades.UpdateCenterRefresh))
+	errs = append(errs, d.Set("upgrades", flattenUpgrades(upgrades.Upgrades, m.(*ProviderConfiguration).sonarQubeEdition)))
+	return errors.Join(errs...)
+}
+
+func readUpgradesFromApi(m interface{}) (*GetUpgrades, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/system/upgrades"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readUpgradesFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readUpgradesFromApi: Failed to read the available upgrades: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	upgrades := GetUpgrades{}
+	err = json.NewDecoder(resp.Body).Decode(&upgrades)
+	if err != nil {
+		return nil, fmt.Errorf("readUpgradesFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &upgrades, nil
+}
+
+func flattenUpgrades(upgrades []Upgrade, edition string) []interface{} {
+	upgradesList := []interface{}{}
+	for _, upgrade := range upgrades {
+		requireUpdate := []interface{}{}
+		for _, plugin := range upgrade.Plugins.RequireUpdate {
+			requireUpdate = append(requireUpdate, plugin.Key)
+		}
+		incompatible := []interface{}{}
+		for _, plugin := range upgrade.Plugins.Incompatible {
+			incompatible = append(incompatible, plugin.Key)
+		}
+
+		upgradesList = append(upgradesList, map[string]interface{}{
+			"version":                upgrade.Version,
+			"description":            upgrade.Description,
+			"release_date":           upgrade.ReleaseDate,
+			"change_log_url":         upgrade.ChangeLogURL,
+			"download_url":           upgradeDownloadURL(upgrade, edition),
+			"plugins_require_update": requireUpdate,
+			"incompatible_plugins":   incompatible,
+		})
+	}
+	return upgradesList
+}
+
+// upgradeDownloadURL returns the download URL of the upgrade for the edition, falling back to the Community edition
+func upgradeDownloadURL(upgrade Upgrade, edition string) string {
+	url := ""
+	switch strings.ToLower(edition) {
+	case "developer":
+		url = upgrade.DownloadDeveloperURL
+	case "enterprise":
+		url = upgrade.DownloadEnterpriseURL
+	case "data center":
+		url = upgrade.DownloadDatacenterURL
+	}
+	if url == "" {
+		url = upgrade.DownloadURL
+	}
+	return url
+}
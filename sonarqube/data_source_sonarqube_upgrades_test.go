@@ -0,0 +1,52 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSonarqubeUpgradesDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_upgrades." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "sonarqube_upgrades" "%[1]s" {}`, rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "upgrade_available"),
+					resource.TestCheckResourceAttrSet(name, "installed_version_active"),
+				),
+			},
+		},
+	})
+}
+
+func TestUpgradeDownloadURL(t *testing.T) {
+	upgrade := Upgrade{
+		DownloadURL:           "https://example.com/sonarqube.zip",
+		DownloadEnterpriseURL: "https://example.com/sonarqube-enterprise.zip",
+	}
+
+	tests := []struct {
+		edition  string
+		expected string
+	}{
+		{edition: "Community", expected: "https://example.com/sonarqube.zip"},
+		{edition: "Enterprise", expected: "https://example.com/sonarqube-enterprise.zip"},
+		{edition: "Developer", expected: "https://example.com/sonarqube.zip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.edition, func(t *testing.T) {
+			if result := upgradeDownloadURL(upgrade, tt.edition); result != tt.expected {
+				t.Errorf("upgradeDownloadURL() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ import (
 
 func dataSourceSonarqubeUserTokens() *schema.Resource {
 	return &schema.Resource{
-		Description: "Use this data source to get Sonarqube user token resources",
+		Description: "Use this data source to get Sonarqube user token resources. Token values are never returned, but the dates can be used to report expiring tokens or trigger their rotation.",
 		Read:        dataSourceSonarqubeUserTokensRead,
 		Schema: map[string]*schema.Schema{
 			"login_name": {
@@ -28,6 +28,11 @@ func dataSourceSonarqubeUserTokens() *schema.Resource {
 				Optional:    true,
 				Description: "If set to true, the data source will not fail if the user does not exist.",
 			},
+			"expires_within_days": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return the tokens that are expired or expire within this number of days. Tokens without expiration date are not returned.",
+			},
 			"user_tokens": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -65,6 +70,16 @@ func dataSourceSonarqubeUserTokens() *schema.Resource {
 							Optional:    true,
 							Description: "The key of the only project that can be analyzed by the user token.",
 						},
+						"is_expired": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the user token is expired.",
+						},
+						"last_connection_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the user token was last used.",
+						},
 					},
 				},
 				Description: "The list of user tokens.",
@@ -74,7 +89,7 @@ func dataSourceSonarqubeUserTokens() *schema.Resource {
 }
 
 func dataSourceSonarqubeUserTokensRead(d *schema.ResourceData, m interface{}) error {
-	d.SetId(fmt.Sprintf("%d", schema.HashString(d.Get("login_name"))))
+	d.SetId(fmt.Sprintf("%d", schema.HashString(fmt.Sprintf("%s/%d", d.Get("login_name").(string), d.Get("expires_within_days").(int)))))
 
 	userTokensReadResponse, err := readUserTokensFromApi(d, m)
 	if err != nil {
@@ -83,7 +98,14 @@ func dataSourceSonarqubeUserTokensRead(d *schema.ResourceData, m interface{}) er
 
 	errs := []error{}
 	if userTokensReadResponse != nil {
-		userTokens, err := flattenReadUserTokensResponse(userTokensReadResponse.Login, userTokensReadResponse.Tokens)
+		tokens := userTokensReadResponse.Tokens
+		if days, ok := d.GetOk("expires_within_days"); ok {
+			tokens, err = filterUserTokensExpiringWithin(tokens, days.(int), time.Now())
+			if err != nil {
+				return err
+			}
+		}
+		userTokens, err := flattenReadUserTokensResponse(userTokensReadResponse.Login, tokens)
 		if err != nil {
 			return err
 		}
@@ -139,9 +161,10 @@ func flattenReadUserTokensResponse(login string, tokens []Token) ([]interface{},
 
 	for _, token := range tokens {
 		values := map[string]interface{}{
-			"id":   fmt.Sprintf("%s/%s", login, token.Name),
-			"name": token.Name,
-			"type": token.Type,
+			"id":         fmt.Sprintf("%s/%s", login, token.Name),
+			"name":       token.Name,
+			"type":       token.Type,
+			"is_expired": token.IsExpired,
 		}
 
 		if token.Project.Key != "" {
@@ -164,8 +187,35 @@ func flattenReadUserTokensResponse(login string, tokens []Token) ([]interface{},
 			values["expiration_date"] = date.Format("2006-01-02")
 		}
 
+		if token.LastConnection != "" {
+			date, err := time.Parse("2006-01-02T15:04:05-0700", token.LastConnection)
+			if err != nil {
+				return nil, fmt.Errorf("flattenReadUserTokensResponse: Failed to parse LastConnectionDate: %+v", err)
+			}
+			values["last_connection_date"] = date.Format("2006-01-02")
+		}
+
 		userTokensList = append(userTokensList, values)
 	}
 
 	return userTokensList, nil
 }
+
+// filterUserTokensExpiringWithin returns the tokens that are expired or expire within the given number of days
+func filterUserTokensExpiringWithin(tokens []Token, days int, now time.Time) ([]Token, error) {
+	limit := now.AddDate(0, 0, days)
+	filtered := []Token{}
+	for _, token := range tokens {
+		if token.ExpirationDate == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02T15:04:05-0700", token.ExpirationDate)
+		if err != nil {
+			return nil, fmt.Errorf("filterUserTokensExpiringWithin: Failed to parse ExpirationDate: %+v", err)
+		}
+		if !date.After(limit) {
+			filtered = append(filtered, token)
+		}
+	}
+	return filtered, nil
+}
@@ -3,6 +3,7 @@ package sonarqube
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
@@ -43,3 +44,39 @@ func TestAccSonarqubeUserTokensDataSource(t *testing.T) {
 		},
 	})
 }
+
+func TestFilterUserTokensExpiringWithin(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	tokens := []Token{
+		{Name: "no-expiration"},
+		{Name: "expired", ExpirationDate: "2024-05-01T00:00:00+0000"},
+		{Name: "soon", ExpirationDate: "2024-06-10T00:00:00+0000"},
+		{Name: "later", ExpirationDate: "2024-12-01T00:00:00+0000"},
+	}
+
+	tests := []struct {
+		name string
+		days int
+		want []string
+	}{
+		{name: "Only expired", days: 0, want: []string{"expired"}},
+		{name: "Within 30 days", days: 30, want: []string{"expired", "soon"}},
+		{name: "Within a year", days: 365, want: []string{"expired", "soon", "later"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterUserTokensExpiringWithin(tokens, tt.days, now)
+			if err != nil {
+				t.Fatalf("filterUserTokensExpiringWithin() error = %v", err)
+			}
+			names := []string{}
+			for _, token := range got {
+				names = append(names, token.Name)
+			}
+			if !stringSlicesEqual(names, tt.want, false) {
+				t.Errorf("filterUserTokensExpiringWithin() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
@@ -46,6 +46,11 @@ func dataSourceSonarqubeUsers() *schema.Resource {
 							Computed:    true,
 							Description: "Whether the user is local.",
 						},
+						"import_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID to import the user as a `sonarqube_user` resource.",
+						},
 					},
 				},
 				Description: "The list of users.",
@@ -113,6 +118,7 @@ func flattenReadUsersResponse(users []User) []interface{} {
 			"name":       user.Name,
 			"email":      user.Email,
 			"is_local":   user.IsLocal,
+			"import_id":  user.Login,
 		}
 
 		usersList = append(usersList, values)
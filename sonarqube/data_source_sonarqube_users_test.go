@@ -37,6 +37,7 @@ func TestAccSonarqubeUsersDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "users.0.name", "testAccSonarqubeUsersDataSource"),
 					resource.TestCheckResourceAttr(name, "users.0.email", "terraform-test@sonarqube.com"),
 					resource.TestCheckResourceAttr(name, "users.0.is_local", "true"),
+					resource.TestCheckResourceAttr(name, "users.0.import_id", "testAccSonarqubeUsersDataSource"),
 				),
 			},
 		},
@@ -0,0 +1,90 @@
+package sonarqube
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSonarqubeWebhooks() *schema.Resource {
+	return &schema.Resource{
+		Description: `Use this data source to list the global webhooks, or the webhooks of a project, for example to audit where the analysis
+results are sent or to adopt the existing webhooks with ` + "`import`" + ` blocks. The secrets of the webhooks are never returned.`,
+		Read: dataSourceSonarqubeWebhooksRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The key of the project whose webhooks are listed. When not set, the global webhooks are listed.",
+			},
+			"webhooks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The key of the webhook.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the webhook.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL the webhook calls.",
+						},
+						"has_secret": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the payload is signed with a secret.",
+						},
+						"import_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID to import the webhook as a `sonarqube_webhook` resource.",
+						},
+					},
+				},
+				Description: "The webhooks, in the order of SonarQube.",
+			},
+		},
+	}
+}
+
+func dataSourceSonarqubeWebhooksRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+
+	webhooks, err := readWebhooksFromApi(project, m)
+	if err != nil {
+		return err
+	}
+
+	webhooksList := []interface{}{}
+	for _, webhook := range webhooks {
+		importID := webhook.Key
+		if project != "" {
+			importID += "/" + project
+		}
+		webhooksList = append(webhooksList, map[string]interface{}{
+			"key":  webhook.Key,
+			"name": webhook.Name,
+			"url":  webhook.Url,
+			// Before SonarQube 10.1, the secret is returned instead of hasSecret
+			"has_secret": webhook.HasSecret || webhook.Secret != "",
+			"import_id":  importID,
+		})
+	}
+
+	if project != "" {
+		d.SetId(project)
+	} else {
+		d.SetId("global")
+	}
+	errs := []error{}
+	errs = append(errs, d.Set("webhooks", webhooksList))
+	return errors.Join(errs...)
+}
@@ -0,0 +1,84 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeWebhooksDataSourceConfig(rnd string, projectKey string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_webhook" "%[1]s" {
+			name    = "%[1]s"
+			url     = "https://webhook.example.com/%[1]s"
+			secret  = "secret"
+			project = sonarqube_project.%[1]s.project
+		}
+
+		data "sonarqube_webhooks" "%[1]s" {
+			project    = sonarqube_project.%[1]s.project
+			depends_on = [sonarqube_webhook.%[1]s]
+		}`, rnd, projectKey)
+}
+
+func TestAccSonarqubeWebhooksDataSource(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "data.sonarqube_webhooks." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeWebhooksDataSourceConfig(rnd, "testAccSonarqubeWebhooksDataSource"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "webhooks.#", "1"),
+					resource.TestCheckResourceAttr(name, "webhooks.0.name", rnd),
+					resource.TestCheckResourceAttr(name, "webhooks.0.url", "https://webhook.example.com/"+rnd),
+					resource.TestCheckResourceAttr(name, "webhooks.0.has_secret", "true"),
+					resource.TestCheckResourceAttrPair(name, "webhooks.0.key", "sonarqube_webhook."+rnd, "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceSonarqubeWebhooksImportID(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.handle("GET", "/api/webhooks/list", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("project") == "" {
+			mockJSON(w, map[string]interface{}{"webhooks": []map[string]interface{}{{"key": "global-key", "name": "ci", "url": "https://ci.example.com", "hasSecret": false}}})
+			return
+		}
+		// SonarQube before 10.1 returns the secret
+		mockJSON(w, map[string]interface{}{"webhooks": []map[string]interface{}{{"key": "project-key", "name": "chat", "url": "https://chat.example.com", "secret": "s3cr3t"}}})
+	})
+	r := dataSourceSonarqubeWebhooks()
+
+	for _, test := range []struct {
+		project   string
+		id        string
+		importID  string
+		hasSecret bool
+	}{
+		{"", "global", "global-key", false},
+		{"my_project", "my_project", "project-key/my_project", true},
+	} {
+		d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project": test.project})
+		if err := r.Read(d, mock.conf("Community", "9.9")); err != nil {
+			t.Fatalf("Read() error = %+v", err)
+		}
+		if d.Id() != test.id || d.Get("webhooks.0.import_id") != test.importID || d.Get("webhooks.0.has_secret") != test.hasSecret {
+			t.Errorf("Read(%q) id = %s, webhooks = %v", test.project, d.Id(), d.Get("webhooks"))
+		}
+	}
+}
@@ -2,10 +2,16 @@ package sonarqube
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"os"
 	"strings"
+	"testing"
 
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func generateRandomResourceName() string {
@@ -29,3 +35,39 @@ func generateHCLMap(m map[string]string) string {
 	fmt.Fprintf(b, "}")
 	return b.String()
 }
+
+// testAccProviderConfiguration returns the configuration of the acceptance test provider, configuring it from the
+// environment when the test did not run a step yet, so the edition and the version are known in the pre checks
+func testAccProviderConfiguration(t *testing.T) *ProviderConfiguration {
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	if meta := testAccProvider.Meta(); meta != nil {
+		return meta.(*ProviderConfiguration)
+	}
+
+	if diags := testAccProvider.Configure(context.Background(), terraform.NewResourceConfigRaw(nil)); diags.HasError() {
+		t.Fatalf("failed to configure the provider: %+v", diags)
+	}
+	return testAccProviderConfiguration(t)
+}
+
+// testAccPreCheckEdition skips the test unless SonarQube runs one of the editions, for example "enterprise"
+func testAccPreCheckEdition(t *testing.T, editions ...string) {
+	conf := testAccProviderConfiguration(t)
+	for _, edition := range editions {
+		if strings.EqualFold(conf.sonarQubeEdition, edition) {
+			return
+		}
+	}
+	t.Skipf("Skipping test of a feature of the %s editions, SonarQube runs the %s edition", strings.Join(editions, ", "), conf.sonarQubeEdition)
+}
+
+// testAccPreCheckMinimumVersion skips the test when SonarQube is older than the version
+func testAccPreCheckMinimumVersion(t *testing.T, minimum string) {
+	conf := testAccProviderConfiguration(t)
+	minimumVersion := version.Must(version.NewVersion(minimum))
+	if conf.sonarQubeVersion == nil || conf.sonarQubeVersion.LessThan(minimumVersion) {
+		t.Skipf("Skipping test of a feature of SonarQube %s and above, SonarQube runs version %s", minimum, conf.sonarQubeVersion)
+	}
+}
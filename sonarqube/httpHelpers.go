@@ -1,12 +1,18 @@
 package sonarqube
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/hashicorp/go-retryablehttp"
+	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/go-version"
 )
 
 // ErrorResponse struct
@@ -19,6 +25,14 @@ type ErrorMessage struct {
 	Message string `json:"msg,omitempty"`
 }
 
+// V2ErrorResponse for unmarshalling the errors of the v2 API, which are either a message or RFC 7807 problem details
+type V2ErrorResponse struct {
+	Message string `json:"message,omitempty"`
+	Title   string `json:"title,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+	Status  int    `json:"status,omitempty"`
+}
+
 // Paging used in /search API endpoints
 type Paging struct {
 	PageIndex int64 `json:"pageIndex"`
@@ -42,26 +56,126 @@ func httpRequestHelper(client *retryablehttp.Client, method string, sonarqubeURL
 
 	// Check response code
 	if resp.StatusCode != expectedResponseCode {
-		if resp.Body == http.NoBody {
-			// No error message in the body
-			return *resp, fmt.Errorf("statusCode: %v does not match expectedResponseCode: %v for resource %s", resp.StatusCode, expectedResponseCode, resource)
-		}
+		return *resp, responseError(resp, method, sonarqubeURL, expectedResponseCode, resource)
+	}
 
-		// The response body has content, try to decode the error message
-		errorResponse := ErrorResponse{}
-		err = json.NewDecoder(resp.Body).Decode(&errorResponse)
+	return *resp, nil
+}
+
+// helper function to make a request to the v2 api of sonarqube, which expects JSON bodies instead of query parameters.
+// A nil body sends no body; PATCH requests are sent as JSON merge patches.
+func httpRequestHelperV2(client *retryablehttp.Client, method string, sonarqubeURL string, body interface{}, expectedResponseCode int, resource string) (http.Response, error) {
+	// Prepare request
+	var reqBody interface{} = http.NoBody
+	if body != nil {
+		encoded, err := json.Marshal(body)
 		if err != nil {
-			return *resp, fmt.Errorf("failed to decode error response json into struct for resource %s: %+v", resource, err)
+			return http.Response{}, fmt.Errorf("failed to encode request body for resource %s: %+v", resource, err)
 		}
-		if len(errorResponse.Errors) == 0 {
-			return *resp, fmt.Errorf("statusCode: %v does not match expectedResponseCode for resource %s: %v. No error message found in the response body", resp.StatusCode, resource, expectedResponseCode)
+		reqBody = bytes.NewReader(encoded)
+	}
+	req, err := retryablehttp.NewRequest(method, sonarqubeURL, reqBody)
+	if err != nil {
+		return http.Response{}, fmt.Errorf("failed to create request for resource %s: %w", resource, censorHttpError(err))
+	}
+	// Some endpoints can also send files, the JSON representation is always used
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		if method == http.MethodPatch {
+			req.Header.Set("Content-Type", "application/merge-patch+json")
+		} else {
+			req.Header.Set("Content-Type", "application/json")
 		}
-		return *resp, fmt.Errorf("API returned an error for resource %s: %+v", resource, errorResponse.Errors[0].Message)
+	}
+
+	// Execute request
+	resp, err := client.Do(req)
+	if err != nil {
+		return http.Response{}, fmt.Errorf("failed to send request for resource %s: %w", resource, censorHttpError(err))
+	}
+
+	// Check response code
+	if resp.StatusCode != expectedResponseCode {
+		return *resp, responseError(resp, method, sonarqubeURL, expectedResponseCode, resource)
 	}
 
 	return *resp, nil
 }
 
+// maxErrorBodySize limits the size of a response body without error messages included in an error
+const maxErrorBodySize = 512
+
+// responseError returns the error for a response with an unexpected status code. It includes the error messages
+// of the response body and the request, without the host and secrets, so the cause can be found without debug logs.
+func responseError(resp *http.Response, method string, sonarqubeURL string, expectedResponseCode int, resource string) error {
+	request := describeRequest(method, sonarqubeURL)
+
+	responseData, err := io.ReadAll(resp.Body)
+	if err != nil || len(bytes.TrimSpace(responseData)) == 0 {
+		// No error message in the body
+		return fmt.Errorf("statusCode: %v does not match expectedResponseCode: %v for resource %s (%s)", resp.StatusCode, expectedResponseCode, resource, request)
+	}
+
+	messages := decodeErrorMessages(responseData)
+	if len(messages) == 0 {
+		body := redactBody(responseData)
+		if len(body) > maxErrorBodySize {
+			body = body[:maxErrorBodySize] + "...(truncated)"
+		}
+		return fmt.Errorf("statusCode: %v does not match expectedResponseCode: %v for resource %s (%s). No error message found in the response body: %s", resp.StatusCode, expectedResponseCode, resource, request, body)
+	}
+	return fmt.Errorf("API returned an error for resource %s: %s (%s, statusCode: %v)", resource, strings.Join(messages, "; "), request, resp.StatusCode)
+}
+
+// decodeErrorMessages returns the error messages of a response body of the web API, or of the v2 API
+func decodeErrorMessages(responseData []byte) []string {
+	messages := []string{}
+
+	errorResponse := ErrorResponse{}
+	if err := json.Unmarshal(responseData, &errorResponse); err == nil {
+		for _, e := range errorResponse.Errors {
+			if e.Message != "" {
+				messages = append(messages, e.Message)
+			}
+		}
+		if len(messages) > 0 {
+			return messages
+		}
+	}
+
+	v2ErrorResponse := V2ErrorResponse{}
+	if err := json.Unmarshal(responseData, &v2ErrorResponse); err == nil {
+		switch {
+		case v2ErrorResponse.Message != "":
+			messages = append(messages, v2ErrorResponse.Message)
+		case v2ErrorResponse.Detail != "":
+			messages = append(messages, v2ErrorResponse.Title+": "+v2ErrorResponse.Detail)
+		case v2ErrorResponse.Title != "":
+			messages = append(messages, v2ErrorResponse.Title)
+		}
+	}
+	return messages
+}
+
+// describeRequest returns the method and the path of a request with its redacted query parameters
+func describeRequest(method string, sonarqubeURL string) string {
+	u, err := url.Parse(sonarqubeURL)
+	if err != nil {
+		return method
+	}
+	redacted, err := url.Parse(redactURL(u))
+	if err != nil {
+		return method
+	}
+	return method + " " + strings.TrimSuffix(redacted.RequestURI(), "?")
+}
+
+// supportsV2API returns whether the v2 api endpoints introduced in the given version can be used
+func supportsV2API(conf *ProviderConfiguration, minimumVersion string) bool {
+	minimum, _ := version.NewVersion(minimumVersion)
+	return conf.sonarQubeVersion != nil && conf.sonarQubeVersion.GreaterThanOrEqual(minimum)
+}
+
 func censorHttpError(error error) error {
 	sanitizedError := sanitizeSensitiveURLs(error.Error())
 	return errors.New(sanitizedError)
@@ -0,0 +1,33 @@
+package sonarqube
+
+import (
+	"fmt"
+	"strings"
+)
+
+// parseImportID splits a composite import ID into its parts, following a format like "{project}/{repository}".
+// The last part gets the remainder of the ID, so it may contain a "/". Trailing parts written as "[{name}]"
+// are optional and returned empty when missing from the ID.
+func parseImportID(id string, format string) ([]string, error) {
+	names := strings.Split(format, "/")
+	required := 0
+	for _, name := range names {
+		if !strings.HasPrefix(name, "[") {
+			required++
+		}
+	}
+
+	parts := strings.SplitN(id, "/", len(names))
+	if len(parts) < required {
+		return nil, fmt.Errorf("import ID '%s' is not in the format '%s'", id, format)
+	}
+	for i := 0; i < required; i++ {
+		if parts[i] == "" {
+			return nil, fmt.Errorf("import ID '%s' is not in the format '%s': %s is empty", id, format, names[i])
+		}
+	}
+
+	result := make([]string, len(names))
+	copy(result, parts)
+	return result, nil
+}
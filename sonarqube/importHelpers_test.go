@@ -0,0 +1,77 @@
+package sonarqube
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseImportID(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		format  string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "Single part",
+			id:     "my_project",
+			format: "{project}",
+			want:   []string{"my_project"},
+		},
+		{
+			name:   "Two parts",
+			id:     "my_project/my_repository",
+			format: "{project}/{repository}",
+			want:   []string{"my_project", "my_repository"},
+		},
+		{
+			name:   "Last part keeps the separator",
+			id:     "my_project/group/subgroup/repository",
+			format: "{project}/{repository}",
+			want:   []string{"my_project", "group/subgroup/repository"},
+		},
+		{
+			name:   "Optional part set",
+			id:     "my_webhook/my_project",
+			format: "{key}/[{project}]",
+			want:   []string{"my_webhook", "my_project"},
+		},
+		{
+			name:   "Optional part missing",
+			id:     "my_webhook",
+			format: "{key}/[{project}]",
+			want:   []string{"my_webhook", ""},
+		},
+		{
+			name:    "Missing part",
+			id:      "my_project",
+			format:  "{project}/{repository}",
+			wantErr: true,
+		},
+		{
+			name:    "Empty part",
+			id:      "my_project/",
+			format:  "{project}/{repository}",
+			wantErr: true,
+		},
+		{
+			name:    "Empty ID",
+			id:      "",
+			format:  "{project}",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseImportID(tt.id, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseImportID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseImportID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
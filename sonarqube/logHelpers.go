@@ -0,0 +1,155 @@
+package sonarqube
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxLoggedBodySize limits the size of the request and response bodies written to the logs
+const maxLoggedBodySize = 8192
+
+// sensitiveParameterNames are the parts of query parameter and JSON field names whose value is never logged
+var sensitiveParameterNames = []string{"password", "secret", "token", "privatekey", "passcode", "license"}
+
+var regexSensitiveJSONField = regexp.MustCompile(`("[^"]*(?i:password|secret|token|privatekey|passcode|license)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// httpLogger sends the logs of the retryable http client to tflog, so they follow TF_LOG and TF_LOG_PROVIDER
+type httpLogger struct {
+	ctx context.Context
+}
+
+// newHttpLogger returns a logger for the http client. The credentials of the provider are masked in every log entry.
+func newHttpLogger(ctx context.Context, credentials ...string) *httpLogger {
+	secrets := []string{}
+	for _, credential := range credentials {
+		if credential != "" {
+			secrets = append(secrets, credential)
+		}
+	}
+	ctx = tflog.MaskMessageStrings(ctx, secrets...)
+	ctx = tflog.MaskAllFieldValuesStrings(ctx, secrets...)
+	return &httpLogger{ctx: ctx}
+}
+
+func (l *httpLogger) Error(msg string, keysAndValues ...interface{}) {
+	tflog.Error(l.ctx, msg, redactLogFields(keysAndValues))
+}
+
+func (l *httpLogger) Info(msg string, keysAndValues ...interface{}) {
+	tflog.Info(l.ctx, msg, redactLogFields(keysAndValues))
+}
+
+func (l *httpLogger) Debug(msg string, keysAndValues ...interface{}) {
+	tflog.Debug(l.ctx, msg, redactLogFields(keysAndValues))
+}
+
+func (l *httpLogger) Warn(msg string, keysAndValues ...interface{}) {
+	tflog.Warn(l.ctx, msg, redactLogFields(keysAndValues))
+}
+
+// logRequest logs every request sent to SonarQube, including its body
+func (l *httpLogger) logRequest(_ retryablehttp.Logger, req *http.Request, attempt int) {
+	fields := map[string]interface{}{
+		"method":  req.Method,
+		"url":     redactURL(req.URL),
+		"attempt": attempt,
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err == nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			fields["body"] = redactBody(body)
+		}
+	}
+	tflog.Debug(l.ctx, "Sending request to SonarQube", fields)
+}
+
+// logResponse logs every response of SonarQube, the body is restored so it can still be decoded
+func (l *httpLogger) logResponse(_ retryablehttp.Logger, resp *http.Response) {
+	fields := map[string]interface{}{
+		"status": resp.StatusCode,
+	}
+	if resp.Request != nil {
+		fields["method"] = resp.Request.Method
+		fields["url"] = redactURL(resp.Request.URL)
+	}
+	if resp.Body != nil && resp.Body != http.NoBody {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if err == nil {
+			fields["body"] = redactBody(body)
+		}
+	}
+	tflog.Debug(l.ctx, "Received response from SonarQube", fields)
+}
+
+// redactLogFields converts the key and value pairs of the retryable http client to tflog fields, without secrets
+func redactLogFields(keysAndValues []interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key := fmt.Sprint(keysAndValues[i])
+		switch v := keysAndValues[i+1].(type) {
+		case *url.URL:
+			fields[key] = redactURL(v)
+		case error:
+			fields[key] = sanitizeSensitiveURLs(v.Error())
+		case string:
+			fields[key] = sanitizeSensitiveURLs(v)
+		default:
+			fields[key] = v
+		}
+	}
+	return fields
+}
+
+// redactURL returns the URL without credentials and with the values of sensitive query parameters replaced.
+// The values of secured settings sent to api/settings/set, and the values sent to api/settings/encrypt, are replaced as well.
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	redacted := *u
+	redacted.User = nil
+
+	query := redacted.Query()
+	securedSetting := strings.HasSuffix(query.Get("key"), ".secured") || strings.HasSuffix(redacted.Path, "/api/settings/encrypt")
+	for name, values := range query {
+		if isSensitiveParameter(name) || (securedSetting && (name == "value" || name == "values" || name == "fieldValues")) {
+			for i := range values {
+				values[i] = "***"
+			}
+		}
+	}
+	redacted.RawQuery = query.Encode()
+	return redacted.String()
+}
+
+// redactBody returns the body with the values of sensitive JSON fields replaced, truncated to maxLoggedBodySize
+func redactBody(body []byte) string {
+	redacted := regexSensitiveJSONField.ReplaceAllString(string(body), `${1}"***"`)
+	if len(redacted) > maxLoggedBodySize {
+		redacted = redacted[:maxLoggedBodySize] + "...(truncated)"
+	}
+	return redacted
+}
+
+func isSensitiveParameter(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveParameterNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package sonarqube
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "credentials",
+			input:    "https://token:@example.com/api/projects/search?q=abc",
+			expected: "https://example.com/api/projects/search?q=abc",
+		},
+		{
+			name:     "sensitive parameters",
+			input:    "https://example.com/api/alm_settings/create_github?clientSecret=abc&key=github&privateKey=def",
+			expected: "https://example.com/api/alm_settings/create_github?clientSecret=%2A%2A%2A&key=github&privateKey=%2A%2A%2A",
+		},
+		{
+			name:     "secured setting",
+			input:    "https://example.com/api/settings/set?key=sonar.auth.github.clientSecret.secured&value=abc",
+			expected: "https://example.com/api/settings/set?key=sonar.auth.github.clientSecret.secured&value=%2A%2A%2A",
+		},
+		{
+			name:     "encrypted value",
+			input:    "https://example.com/api/settings/encrypt?value=abc",
+			expected: "https://example.com/api/settings/encrypt?value=%2A%2A%2A",
+		},
+		{
+			name:     "license key",
+			input:    "https://example.com/api/editions/set_license?license=abc",
+			expected: "https://example.com/api/editions/set_license?license=%2A%2A%2A",
+		},
+		{
+			name:     "setting",
+			input:    "https://example.com/api/settings/set?key=sonar.auth.github.enabled&value=true",
+			expected: "https://example.com/api/settings/set?key=sonar.auth.github.enabled&value=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result := redactURL(u); result != tt.expected {
+				t.Errorf("redactURL() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "token",
+			input:    `{"login":"admin","name":"ci","token":"squ_123"}`,
+			expected: `{"login":"admin","name":"ci","token":"***"}`,
+		},
+		{
+			name:     "nested secret with escaped quote",
+			input:    `{"github":{"clientSecret" : "a\"b","appId":"1"}}`,
+			expected: `{"github":{"clientSecret" : "***","appId":"1"}}`,
+		},
+		{
+			name:     "no secret",
+			input:    `{"errors":[{"msg":"Project key already exists"}]}`,
+			expected: `{"errors":[{"msg":"Project key already exists"}]}`,
+		},
+		{
+			name:     "truncated",
+			input:    strings.Repeat("a", maxLoggedBodySize+1),
+			expected: strings.Repeat("a", maxLoggedBodySize) + "...(truncated)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := redactBody([]byte(tt.input)); result != tt.expected {
+				t.Errorf("redactBody() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHttpLoggerKeepsResponseBody(t *testing.T) {
+	logger := newHttpLogger(context.Background(), "secret")
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"token":"secret"}`)),
+	}
+
+	logger.logResponse(nil, resp)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"token":"secret"}` {
+		t.Errorf("response body = %s, want it unchanged", body)
+	}
+}
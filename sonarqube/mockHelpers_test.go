@@ -0,0 +1,103 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/go-version"
+)
+
+// mockSonarQube is a SonarQube web API served by httptest, so the CRUD functions of the resources can be unit tested
+// without a live server. Requests to an endpoint without a handler fail the test and get a 404 with an error body.
+type mockSonarQube struct {
+	t        *testing.T
+	server   *httptest.Server
+	mutex    sync.Mutex
+	handlers map[string]http.HandlerFunc
+	calls    []string
+}
+
+func newMockSonarQube(t *testing.T) *mockSonarQube {
+	mock := &mockSonarQube{
+		t:        t,
+		handlers: map[string]http.HandlerFunc{},
+	}
+	mock.server = httptest.NewServer(http.HandlerFunc(mock.serveHTTP))
+	t.Cleanup(mock.server.Close)
+	return mock
+}
+
+func (s *mockSonarQube) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	s.calls = append(s.calls, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+	handler, ok := s.handlers[r.Method+" "+r.URL.Path]
+	s.mutex.Unlock()
+
+	if !ok {
+		s.t.Errorf("mockSonarQube: unexpected request %s %s", r.Method, r.URL.String())
+		mockError(w, http.StatusNotFound, "Unknown url : "+r.URL.Path)
+		return
+	}
+	handler(w, r)
+}
+
+// handle registers the handler of an endpoint, for example handle("GET", "/api/alm_settings/get_binding", ...)
+func (s *mockSonarQube) handle(method string, path string, handler http.HandlerFunc) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.handlers[method+" "+path] = handler
+}
+
+// respond registers a handler that always answers with the status and the body
+func (s *mockSonarQube) respond(method string, path string, status int, body string) {
+	s.handle(method, path, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+// requests returns the requests received so far, as "METHOD path?query"
+func (s *mockSonarQube) requests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{}, s.calls...)
+}
+
+// conf returns a provider configuration that sends the requests to the mock, without retries
+func (s *mockSonarQube) conf(edition string, sonarQubeVersion string) *ProviderConfiguration {
+	host, err := url.Parse(s.server.URL)
+	if err != nil {
+		s.t.Fatalf("mockSonarQube: %+v", err)
+	}
+	// Built like configureProvider builds it
+	serverURL := sonarQubeBaseURL(host)
+	client := retryablehttp.NewClient()
+	client.RetryMax = 0
+	client.Logger = nil
+
+	return &ProviderConfiguration{
+		httpClient:       client,
+		sonarQubeURL:     serverURL,
+		sonarQubeVersion: version.Must(version.NewVersion(sonarQubeVersion)),
+		sonarQubeEdition: edition,
+	}
+}
+
+// mockJSON writes the value as a JSON response body
+func mockJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// mockError writes an error response the way the web API does
+func mockError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = fmt.Fprintf(w, `{"errors":[{"msg":%q}]}`, message)
+}
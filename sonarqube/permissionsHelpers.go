@@ -0,0 +1,364 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Principal types that permissions can be granted to. The project creator only exists on permission templates.
+const (
+	principalUser           = "user"
+	principalGroup          = "group"
+	principalProjectCreator = "project_creator"
+)
+
+// Permission names of the web API
+const (
+	permissionAdmin                = "admin"
+	permissionGateAdmin            = "gateadmin"
+	permissionProfileAdmin         = "profileadmin"
+	permissionProvisioning         = "provisioning"
+	permissionScan                 = "scan"
+	permissionApplicationCreator   = "applicationcreator"
+	permissionPortfolioCreator     = "portfoliocreator"
+	permissionCodeViewer           = "codeviewer"
+	permissionIssueAdmin           = "issueadmin"
+	permissionSecurityHotspotAdmin = "securityhotspotadmin"
+	permissionUser                 = "user"
+)
+
+// permissionDefinition is a permission of a catalog, with the SonarQube versions and editions that support it
+type permissionDefinition struct {
+	name string
+	// since is the first version supporting the permission, empty when all versions support it
+	since string
+	// editions supporting the permission in lower case, empty when all editions support it
+	editions []string
+}
+
+// globalPermissionCatalog lists the permissions that can be granted without a project or a permission template
+var globalPermissionCatalog = []permissionDefinition{
+	{name: permissionAdmin},
+	{name: permissionGateAdmin},
+	{name: permissionProfileAdmin},
+	{name: permissionProvisioning},
+	{name: permissionScan},
+	{name: permissionApplicationCreator, editions: []string{"developer", "enterprise", "data center"}},
+	{name: permissionPortfolioCreator, editions: []string{"enterprise", "data center"}},
+}
+
+// projectPermissionCatalog lists the permissions that can be granted on a project or through a permission template
+var projectPermissionCatalog = []permissionDefinition{
+	{name: permissionAdmin},
+	{name: permissionCodeViewer},
+	{name: permissionIssueAdmin},
+	{name: permissionSecurityHotspotAdmin, since: "8.1"},
+	{name: permissionScan},
+	{name: permissionUser},
+}
+
+// supportedBy returns whether the version and the edition support the permission. An unknown version or edition
+// supports every permission.
+func (p permissionDefinition) supportedBy(sonarQubeVersion *version.Version, edition string) bool {
+	if p.since != "" && sonarQubeVersion != nil {
+		since, _ := version.NewVersion(p.since)
+		if sonarQubeVersion.LessThan(since) {
+			return false
+		}
+	}
+	if len(p.editions) > 0 && edition != "" {
+		for _, e := range p.editions {
+			if strings.EqualFold(e, edition) {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// supportedPermissions returns the permissions the connected SonarQube supports for the given scope
+func supportedPermissions(conf *ProviderConfiguration, projectScope bool) []string {
+	catalog := globalPermissionCatalog
+	if projectScope {
+		catalog = projectPermissionCatalog
+	}
+
+	var sonarQubeVersion *version.Version
+	edition := ""
+	if conf != nil {
+		sonarQubeVersion = conf.sonarQubeVersion
+		edition = conf.sonarQubeEdition
+	}
+	supported := make([]string, 0, len(catalog))
+	for _, permission := range catalog {
+		if permission.supportedBy(sonarQubeVersion, edition) {
+			supported = append(supported, permission.name)
+		}
+	}
+	return supported
+}
+
+// maxConcurrentPermissionRequests bounds the number of permission API calls that run in parallel
+const maxConcurrentPermissionRequests = 4
+
+// permissionChange is a single permission to add or remove
+type permissionChange struct {
+	action     string
+	permission string
+}
+
+// permissionScope identifies where permissions are granted. Leaving all fields empty targets the global permissions.
+type permissionScope struct {
+	projectKey   string
+	templateID   string
+	templateName string
+}
+
+func (s permissionScope) isTemplate() bool {
+	return s.templateID != "" || s.templateName != ""
+}
+
+// id returns the scope in the format used by the permission import IDs: global, p_<project_key>, t_<template_id>
+// or tn_<template_name>
+func (s permissionScope) id() string {
+	switch {
+	case s.projectKey != "":
+		return "p_" + s.projectKey
+	case s.templateID != "":
+		return "t_" + s.templateID
+	case s.templateName != "":
+		return "tn_" + s.templateName
+	default:
+		return "global"
+	}
+}
+
+func (s permissionScope) query() url.Values {
+	query := url.Values{}
+	if s.projectKey != "" {
+		query.Add("projectKey", s.projectKey)
+	}
+	if s.templateID != "" {
+		query.Add("templateId", s.templateID)
+	}
+	if s.templateName != "" {
+		query.Add("templateName", s.templateName)
+	}
+	return query
+}
+
+// expandPermissionScope reads the project_key, template_id and template_name attributes of a resource
+func expandPermissionScope(d *schema.ResourceData) permissionScope {
+	return permissionScope{
+		projectKey:   d.Get("project_key").(string),
+		templateID:   d.Get("template_id").(string),
+		templateName: d.Get("template_name").(string),
+	}
+}
+
+// parsePermissionScope is the reverse of permissionScope.id
+func parsePermissionScope(id string) (permissionScope, error) {
+	switch {
+	case id == "global":
+		return permissionScope{}, nil
+	case strings.HasPrefix(id, "p_"):
+		return permissionScope{projectKey: id[2:]}, nil
+	case strings.HasPrefix(id, "t_"):
+		return permissionScope{templateID: id[2:]}, nil
+	case strings.HasPrefix(id, "tn_"):
+		return permissionScope{templateName: id[3:]}, nil
+	default:
+		return permissionScope{}, fmt.Errorf("invalid permission scope '%s', expected 'global' or a project_key (p_), template_id (t_) or template_name (tn_) with prefixes", id)
+	}
+}
+
+// updatePermission grants (add) or revokes (remove) a single permission of a user, group or project creator
+func updatePermission(action string, principalType string, principal string, scope permissionScope, permission string, m interface{}) error {
+	endpoint := action + "_" + principalType
+	if scope.isTemplate() {
+		if action == "add" {
+			endpoint += "_to_template"
+		} else {
+			endpoint += "_from_template"
+		}
+	}
+
+	rawQuery := scope.query()
+	switch principalType {
+	case principalUser:
+		rawQuery.Add("login", principal)
+	case principalGroup:
+		rawQuery.Add("groupName", principal)
+	}
+	rawQuery.Add("permission", permission)
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/" + endpoint
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"updatePermission",
+	)
+	if err != nil {
+		return fmt.Errorf("updatePermission: failed to %s permission '%s' for %s '%s' on scope '%s': %+v", action, permission, principalType, principal, scope.id(), err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// applyPermissionChanges sends the permission changes of a principal in parallel, with at most
+// maxConcurrentPermissionRequests requests in flight. When a change fails, the changes that succeeded are reverted
+// so that the principal keeps the permissions it had before.
+func applyPermissionChanges(principalType string, principal string, scope permissionScope, changes []permissionChange, m interface{}) error {
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	semaphore := make(chan struct{}, maxConcurrentPermissionRequests)
+	applied := []permissionChange{}
+	errs := []error{}
+
+	for _, change := range changes {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(change permissionChange) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			err := updatePermission(change.action, principalType, principal, scope, change.permission, m)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				applied = append(applied, change)
+			}
+		}(change)
+	}
+	wg.Wait()
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	// Roll back the changes that succeeded
+	for _, change := range applied {
+		revert := "remove"
+		if change.action == "remove" {
+			revert = "add"
+		}
+		if err := updatePermission(revert, principalType, principal, scope, change.permission, m); err != nil {
+			errs = append(errs, fmt.Errorf("applyPermissionChanges: failed to roll back: %+v", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// listScopePermissions returns the permissions of every user or group that has at least one permission on the scope.
+// A non-empty search restricts the result to the principals whose name contains it.
+func listScopePermissions(principalType string, scope permissionScope, search string, m interface{}) (map[string][]string, error) {
+	endpoint := principalType + "s"
+	if scope.isTemplate() {
+		endpoint = "template_" + endpoint
+	}
+
+	permissions := make(map[string][]string)
+	for page := 1; ; page++ {
+		rawQuery := scope.query()
+		rawQuery.Add("ps", "100")
+		rawQuery.Add("p", strconv.Itoa(page))
+		if search != "" {
+			rawQuery.Add("q", search)
+		}
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/" + endpoint
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"listScopePermissions",
+		)
+		if err != nil {
+			return nil, fmt.Errorf("listScopePermissions: failed to list %s permissions on scope '%s': %+v", principalType, scope.id(), err)
+		}
+
+		var paging Paging
+		if principalType == principalUser {
+			users := GetUser{}
+			err = json.NewDecoder(resp.Body).Decode(&users)
+			for _, user := range users.Users {
+				if len(user.Permissions) > 0 {
+					permissions[user.Login] = user.Permissions
+				}
+			}
+			paging = users.Paging
+		} else {
+			groups := GetGroupPermissions{}
+			err = json.NewDecoder(resp.Body).Decode(&groups)
+			for _, group := range groups.Groups {
+				if len(group.Permissions) > 0 {
+					permissions[group.Name] = group.Permissions
+				}
+			}
+			paging = groups.Paging
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("listScopePermissions: Failed to decode json into struct: %+v", err)
+		}
+
+		if paging.PageSize == 0 || paging.PageIndex*paging.PageSize >= paging.Total {
+			break
+		}
+	}
+
+	return permissions, nil
+}
+
+// hasPermission returns whether the permissions include the permission, ignoring the case like SonarQube
+func hasPermission(permissions []string, permission string) bool {
+	for _, p := range permissions {
+		if strings.EqualFold(p, permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPrincipalPermissions returns the permissions of a single user or group on the scope
+func getPrincipalPermissions(principalType string, principal string, scope permissionScope, m interface{}) ([]string, error) {
+	// The search parameter requires at least 3 characters
+	search := ""
+	if len(principal) >= 3 {
+		search = principal
+	}
+
+	permissions, err := listScopePermissions(principalType, scope, search, m)
+	if err != nil {
+		return nil, err
+	}
+	for name, principalPermissions := range permissions {
+		if strings.EqualFold(name, principal) {
+			return principalPermissions, nil
+		}
+	}
+	return []string{}, nil
+}
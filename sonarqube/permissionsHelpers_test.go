@@ -0,0 +1,94 @@
+package sonarqube
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+func TestParsePermissionScope(t *testing.T) {
+	tests := []struct {
+		id       string
+		expected permissionScope
+	}{
+		{id: "global", expected: permissionScope{}},
+		{id: "p_my_project", expected: permissionScope{projectKey: "my_project"}},
+		{id: "t_AU-Tpxb--iU5OvuD2FLy", expected: permissionScope{templateID: "AU-Tpxb--iU5OvuD2FLy"}},
+		{id: "tn_my_template", expected: permissionScope{templateName: "my_template"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			scope, err := parsePermissionScope(tt.id)
+			if err != nil {
+				t.Fatalf("parsePermissionScope(%s) returned an error: %v", tt.id, err)
+			}
+			if scope != tt.expected {
+				t.Errorf("parsePermissionScope(%s) = %+v; want %+v", tt.id, scope, tt.expected)
+			}
+			if scope.id() != tt.id {
+				t.Errorf("permissionScope.id() = %s; want %s", scope.id(), tt.id)
+			}
+		})
+	}
+
+	if _, err := parsePermissionScope("invalid"); err == nil {
+		t.Errorf("parsePermissionScope(invalid) should return an error")
+	}
+}
+
+func TestApplyPermissionChangesRollback(t *testing.T) {
+	var mutex sync.Mutex
+	calls := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		calls = append(calls, r.URL.Path+" "+r.URL.Query().Get("permission"))
+		mutex.Unlock()
+		if r.URL.Query().Get("permission") == "scan" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":[{"msg":"failure"}]}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	conf := &ProviderConfiguration{
+		httpClient:   retryablehttp.NewClient(),
+		sonarQubeURL: *serverURL,
+	}
+
+	changes := []permissionChange{
+		{action: "add", permission: "admin"},
+		{action: "remove", permission: "user"},
+		{action: "add", permission: "scan"},
+	}
+	err := applyPermissionChanges(principalGroup, "developers", permissionScope{projectKey: "my_project"}, changes, conf)
+	if err == nil {
+		t.Fatalf("applyPermissionChanges should return an error")
+	}
+
+	sort.Strings(calls)
+	expected := []string{
+		"/api/permissions/add_group admin",
+		"/api/permissions/add_group scan",
+		"/api/permissions/add_group user",
+		"/api/permissions/remove_group admin",
+		"/api/permissions/remove_group user",
+	}
+	if len(calls) != len(expected) {
+		t.Fatalf("applyPermissionChanges made calls %v; want %v", calls, expected)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Errorf("applyPermissionChanges made calls %v; want %v", calls, expected)
+			break
+		}
+	}
+}
@@ -7,13 +7,16 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/tidwall/gjson"
 )
 
@@ -38,11 +41,12 @@ func Provider() *schema.Provider {
 				RequiredWith: []string{"user"},
 			},
 			"token": {
-				Type:         schema.TypeString,
-				DefaultFunc:  schema.MultiEnvDefaultFunc([]string{"SONAR_TOKEN", "SONARQUBE_TOKEN"}, nil),
-				Optional:     true,
-				Sensitive:    true,
-				ExactlyOneOf: []string{"pass"},
+				Type:          schema.TypeString,
+				DefaultFunc:   schema.MultiEnvDefaultFunc([]string{"SONAR_TOKEN", "SONARQUBE_TOKEN"}, nil),
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"pass"},
+				Description:   "The token of the user. When neither a token nor a user and password are set, the provider connects anonymously, which only supports the data sources, for example to read a public SonarQube.",
 			},
 			"host": {
 				Type:        schema.TypeString,
@@ -54,14 +58,18 @@ func Provider() *schema.Provider {
 				Optional: true,
 			},
 			"installed_version": {
-				Type:        schema.TypeString,
-				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"INSTALLED_VERSION"}, ""),
-				Optional:    true,
+				Type:             schema.TypeString,
+				DefaultFunc:      schema.MultiEnvDefaultFunc([]string{"INSTALLED_VERSION"}, ""),
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateInstalledVersion),
+				Description:      "The version of SonarQube, for example `10.7`. When set, the version is not detected with api/system/info. Defaults to the `INSTALLED_VERSION` environment variable.",
 			},
 			"installed_edition": {
-				Type:        schema.TypeString,
-				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"INSTALLED_EDITION"}, ""),
-				Optional:    true,
+				Type:             schema.TypeString,
+				DefaultFunc:      schema.MultiEnvDefaultFunc([]string{"INSTALLED_EDITION"}, ""),
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"", "Community", "Developer", "Enterprise", "Data Center"}, true)),
+				Description:      "The edition of SonarQube: `Community`, `Developer`, `Enterprise` or `Data Center`. When set, the edition is not detected with api/system/info. Defaults to the `INSTALLED_EDITION` environment variable.",
 			},
 			"tls_insecure_skip_verify": {
 				Optional:    true,
@@ -75,6 +83,43 @@ func Provider() *schema.Provider {
 				Description: "Allows anonymizing users on destroy. Requires Sonarqube version >= 9.7.",
 				Default:     false,
 			},
+			"project_key_prefix": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "When set, the keys of the projects created or renamed by this provider must start with this prefix.",
+			},
+			"enforce_key_regex": {
+				Optional:         true,
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description:      "When set, the keys of the projects created or renamed by this provider must match this regular expression.",
+			},
+			"sonarcloud": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: "Whether the host is SonarCloud. The version is not detected, and the organization is added to the requests that SonarCloud requires it for. Only the resources whose web API SonarCloud has in common with SonarQube are supported. Defaults to false.",
+				Default:     false,
+			},
+			"organization": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"SONARCLOUD_ORGANIZATION"}, nil),
+				Description: "The key of the SonarCloud organization. Required when `sonarcloud` is true. This can also be set via the `SONARCLOUD_ORGANIZATION` environment variable.",
+			},
+			"default_project_tags": {
+				Optional: true,
+				Type:     schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Tags added to every `sonarqube_project` managed by this provider, in addition to the `tags` of the resource. They are reported in the `tags_all` attribute of the projects.",
+			},
+			"skip_permission_validation": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: "Allows permission names that are not known for the version and edition of SonarQube, for example for forks or custom builds. Defaults to false.",
+				Default:     false,
+			},
 		},
 		// Add the resources supported by this provider to this map.
 		ResourcesMap: map[string]*schema.Resource{
@@ -106,29 +151,141 @@ func Provider() *schema.Provider {
 			"sonarqube_alm_gitlab":                           resourceSonarqubeAlmGitlab(),
 			"sonarqube_gitlab_binding":                       resourceSonarqubeGitlabBinding(),
 			"sonarqube_new_code_periods":                     resourceSonarqubeNewCodePeriodsBinding(),
+			"sonarqube_license":                              resourceSonarqubeLicense(),
+			"sonarqube_project_ai_code_assurance":            resourceSonarqubeProjectAiCodeAssurance(),
+			"sonarqube_clean_as_you_code":                    resourceSonarqubeCleanAsYouCode(),
+			"sonarqube_permissions_authoritative":            resourceSonarqubePermissionsAuthoritative(),
+			"sonarqube_permission_item":                      resourceSonarqubePermissionItem(),
+			"sonarqube_project_scm":                          resourceSonarqubeProjectScm(),
+			"sonarqube_analysis_exclusions":                  resourceSonarqubeAnalysisExclusions(),
+			"sonarqube_issue_exclusions":                     resourceSonarqubeIssueExclusions(),
+			"sonarqube_ce_worker":                            resourceSonarqubeCeWorker(),
+			"sonarqube_project_badge":                        resourceSonarqubeProjectBadge(),
+			"sonarqube_report_subscription":                  resourceSonarqubeReportSubscription(),
+			"sonarqube_views_local_definition":               resourceSonarqubeViewsLocalDefinition(),
+			"sonarqube_project_pull_request":                 resourceSonarqubeProjectPullRequest(),
+			"sonarqube_dop_translation_bound_project":        resourceSonarqubeDopTranslationBoundProject(),
+			"sonarqube_projects_cleanup":                     resourceSonarqubeProjectsCleanup(),
+			"sonarqube_ephemeral_project":                    resourceSonarqubeEphemeralProject(),
+			"sonarqube_global_role":                          resourceSonarqubeGlobalRole(),
+			"sonarqube_default_visibility":                   resourceSonarqubeDefaultVisibility(),
+			"sonarqube_portfolio_refresh":                    resourceSonarqubePortfolioRefresh(),
+			"sonarqube_qualityprofile_inheritance":           resourceSonarqubeQualityProfileInheritance(),
+			"sonarqube_user_homepage":                        resourceSonarqubeUserHomepage(),
+			"sonarqube_auth_gitlab":                          resourceSonarqubeAuthGitlab(),
+			"sonarqube_auth_github":                          resourceSonarqubeAuthGithub(),
+			"sonarqube_auth_bitbucket":                       resourceSonarqubeAuthBitbucket(),
+			"sonarqube_auth_oidc":                            resourceSonarqubeAuthOidc(),
+			"sonarqube_branch_protection":                    resourceSonarqubeBranchProtection(),
+			"sonarqube_group_permissions":                    resourceSonarqubeGroupPermissions(),
+			"sonarqube_settings_encryption":                  resourceSonarqubeSettingsEncryption(),
+			"sonarqube_ce_queue_pause":                       resourceSonarqubeCeQueuePause(),
+			"sonarqube_project_analysis_event":               resourceSonarqubeProjectAnalysisEvent(),
+			"sonarqube_project_monorepo_group":               resourceSonarqubeProjectMonorepoGroup(),
+			"sonarqube_indexation":                           resourceSonarqubeIndexation(),
+			"sonarqube_telemetry":                            resourceSonarqubeTelemetry(),
+			"sonarqube_announcement":                         resourceSonarqubeAnnouncement(),
+			"sonarqube_project_new_code_baseline":            resourceSonarqubeProjectNewCodeBaseline(),
+			"sonarqube_admin_enforcement":                    resourceSonarqubeAdminEnforcement(),
+			"sonarqube_project_quality_profile":              resourceSonarqubeProjectQualityProfile(),
+			"sonarqube_custom_quality_profile":               resourceSonarqubeCustomQualityProfile(),
+			"sonarqube_project_analysis_cache":               resourceSonarqubeProjectAnalysisCache(),
+			"sonarqube_project_from_template":                resourceSonarqubeProjectFromTemplate(),
+			"sonarqube_issues_auto_assign":                   resourceSonarqubeIssuesAutoAssign(),
+			"sonarqube_housekeeping":                         resourceSonarqubeHousekeeping(),
+			"sonarqube_pull_request_decoration":              resourceSonarqubePullRequestDecoration(),
+			"sonarqube_group_members":                        resourceSonarqubeGroupMembers(),
+			"sonarqube_scanner_engine":                       resourceSonarqubeScannerEngine(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"sonarqube_user":                 dataSourceSonarqubeUser(),
-			"sonarqube_users":                dataSourceSonarqubeUsers(),
-			"sonarqube_user_tokens":          dataSourceSonarqubeUserTokens(),
-			"sonarqube_group":                dataSourceSonarqubeGroup(),
-			"sonarqube_groups":               dataSourceSonarqubeGroups(),
-			"sonarqube_group_members":        dataSourceSonarqubeGroupMembers(),
-			"sonarqube_project":              dataSourceSonarqubeProject(),
-			"sonarqube_portfolio":            dataSourceSonarqubePortfolio(),
-			"sonarqube_qualityprofile":       dataSourceSonarqubeQualityProfile(),
-			"sonarqube_qualityprofiles":      dataSourceSonarqubeQualityProfiles(),
-			"sonarqube_qualitygate":          dataSourceSonarqubeQualityGate(),
-			"sonarqube_qualitygates":         dataSourceSonarqubeQualityGates(),
-			"sonarqube_rule":                 dataSourceSonarqubeRule(),
-			"sonarqube_languages":            dataSourceSonarqubeLanguages(),
-			"sonarqube_permission_templates": dataSourceSonarqubePermissionTemplates(),
+			"sonarqube_user":                                      dataSourceSonarqubeUser(),
+			"sonarqube_users":                                     dataSourceSonarqubeUsers(),
+			"sonarqube_user_tokens":                               dataSourceSonarqubeUserTokens(),
+			"sonarqube_group":                                     dataSourceSonarqubeGroup(),
+			"sonarqube_groups":                                    dataSourceSonarqubeGroups(),
+			"sonarqube_group_members":                             dataSourceSonarqubeGroupMembers(),
+			"sonarqube_project":                                   dataSourceSonarqubeProject(),
+			"sonarqube_portfolio":                                 dataSourceSonarqubePortfolio(),
+			"sonarqube_qualityprofile":                            dataSourceSonarqubeQualityProfile(),
+			"sonarqube_qualityprofiles":                           dataSourceSonarqubeQualityProfiles(),
+			"sonarqube_qualitygate":                               dataSourceSonarqubeQualityGate(),
+			"sonarqube_qualitygates":                              dataSourceSonarqubeQualityGates(),
+			"sonarqube_rule":                                      dataSourceSonarqubeRule(),
+			"sonarqube_languages":                                 dataSourceSonarqubeLanguages(),
+			"sonarqube_permission_templates":                      dataSourceSonarqubePermissionTemplates(),
+			"sonarqube_license":                                   dataSourceSonarqubeLicense(),
+			"sonarqube_edition":                                   dataSourceSonarqubeEdition(),
+			"sonarqube_ce_activity":                               dataSourceSonarqubeCeActivity(),
+			"sonarqube_ce_task":                                   dataSourceSonarqubeCeTask(),
+			"sonarqube_monitoring_metrics":                        dataSourceSonarqubeMonitoringMetrics(),
+			"sonarqube_issue_counts":                              dataSourceSonarqubeIssueCounts(),
+			"sonarqube_qualityprofile_compare":                    dataSourceSonarqubeQualityProfileCompare(),
+			"sonarqube_loc_budget":                                dataSourceSonarqubeLocBudget(),
+			"sonarqube_project_analyses":                          dataSourceSonarqubeProjectAnalyses(),
+			"sonarqube_permission_targets":                        dataSourceSonarqubePermissionTargets(),
+			"sonarqube_upgrades":                                  dataSourceSonarqubeUpgrades(),
+			"sonarqube_scanner_configuration":                     dataSourceSonarqubeScannerConfiguration(),
+			"sonarqube_bindings_for_alm":                          dataSourceSonarqubeBindingsForAlm(),
+			"sonarqube_permission_template_effective_permissions": dataSourceSonarqubePermissionTemplateEffectivePermissions(),
+			"sonarqube_active_rules":                              dataSourceSonarqubeActiveRules(),
+			"sonarqube_alm_repositories":                          dataSourceSonarqubeAlmRepositories(),
+			"sonarqube_webhooks":                                  dataSourceSonarqubeWebhooks(),
+			"sonarqube_projects":                                  dataSourceSonarqubeProjects(),
+			"sonarqube_issues":                                    dataSourceSonarqubeIssues(),
+			"sonarqube_hotspots":                                  dataSourceSonarqubeHotspots(),
+			"sonarqube_project_links":                             dataSourceSonarqubeProjectLinks(),
+			"sonarqube_group_external_mapping":                    dataSourceSonarqubeGroupExternalMapping(),
+		},
+		ConfigureContextFunc: func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+			conf, err := configureProvider(ctx, d)
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+			return conf, nil
 		},
-		ConfigureFunc: configureProvider,
+	}
+	for name, resource := range sonarqubeProvider.ResourcesMap {
+		requireCredentials(name, resource)
 	}
 	return sonarqubeProvider
 }
 
+// requireCredentials makes the changes of the resource fail with a clear message when the provider connects
+// anonymously, instead of the 401 of the first request
+func requireCredentials(name string, resource *schema.Resource) {
+	check := func(m interface{}) error {
+		if conf, ok := m.(*ProviderConfiguration); ok && conf.anonymous {
+			return fmt.Errorf("%s cannot be changed without credentials: set the token, or the user and password, of the provider. Anonymous connections only support the data sources", name)
+		}
+		return nil
+	}
+
+	if create := resource.Create; create != nil {
+		resource.Create = func(d *schema.ResourceData, m interface{}) error {
+			if err := check(m); err != nil {
+				return err
+			}
+			return create(d, m)
+		}
+	}
+	if update := resource.Update; update != nil {
+		resource.Update = func(d *schema.ResourceData, m interface{}) error {
+			if err := check(m); err != nil {
+				return err
+			}
+			return update(d, m)
+		}
+	}
+	if del := resource.Delete; del != nil {
+		resource.Delete = func(d *schema.ResourceData, m interface{}) error {
+			if err := check(m); err != nil {
+				return err
+			}
+			return del(d, m)
+		}
+	}
+}
+
 // ProviderConfiguration contains the sonarqube providers configuration
 type ProviderConfiguration struct {
 	httpClient              *retryablehttp.Client
@@ -136,9 +293,19 @@ type ProviderConfiguration struct {
 	sonarQubeVersion        *version.Version
 	sonarQubeEdition        string
 	sonarQubeAnonymizeUsers bool
+	projectKeyPrefix        string
+	projectKeyRegex         *regexp.Regexp
+	// defaultProjectTags are added to the tags of every sonarqube_project
+	defaultProjectTags []string
+	// skipPermissionValidation disables the plan time validation of permission names
+	skipPermissionValidation bool
+	// anonymous is true when the provider has no credentials, which only supports the data sources
+	anonymous bool
+	// sonarCloudOrganization is the key of the organization when the host is SonarCloud
+	sonarCloudOrganization string
 }
 
-func configureProvider(d *schema.ResourceData) (interface{}, error) {
+func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, error) {
 	transport := cleanhttp.DefaultPooledTransport()
 	if proxy, ok := d.GetOk("http_proxy"); ok {
 		proxyUrl, err := url.Parse(proxy.(string))
@@ -151,42 +318,46 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 		InsecureSkipVerify: d.Get("tls_insecure_skip_verify").(bool), // #nosec G402
 	}
 
+	// Requests and responses are logged with tflog, without the credentials and other secrets
+	logger := newHttpLogger(ctx, d.Get("token").(string), d.Get("pass").(string))
 	client := retryablehttp.NewClient()
 	client.HTTPClient.Transport = transport
+	client.Logger = logger
+	client.RequestLogHook = logger.logRequest
+	client.ResponseLogHook = logger.logResponse
 
 	host, err := url.Parse(d.Get("host").(string))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse sonarqube host: %+v", err)
 	}
 
-	sonarQubeURL := url.URL{
-		Scheme:     host.Scheme,
-		Host:       host.Host,
-		Path:       host.Path,
-		ForceQuery: true,
-	}
+	sonarQubeURL := sonarQubeBaseURL(host)
 
+	anonymous := false
 	if token, ok := d.GetOk("token"); ok {
 		sonarQubeURL.User = url.UserPassword(token.(string), "")
-	} else {
+	} else if _, ok := d.GetOk("user"); ok {
 		sonarQubeURL.User = url.UserPassword(d.Get("user").(string), d.Get("pass").(string))
+	} else {
+		anonymous = true
 	}
 
-	// If either of installed_version or installed_edition is not set, we need to fetch them from the API
-	installedVersion := d.Get("installed_version").(string)
-	installedEdition := d.Get("installed_edition").(string)
-	if installedVersion == "" || installedEdition == "" {
-		installedVersionAPI, installedEditionAPI, err := sonarqubeSystemInfo(client, sonarQubeURL)
-		if err != nil {
-			return nil, err
+	installedVersion, installedEdition := d.Get("installed_version").(string), d.Get("installed_edition").(string)
+	organization := ""
+	if d.Get("sonarcloud").(bool) {
+		organization = d.Get("organization").(string)
+		if organization == "" {
+			return nil, fmt.Errorf("organization must be set when sonarcloud is true")
 		}
-
+		client.HTTPClient.Transport = &organizationTransport{base: transport, organization: organization}
 		if installedVersion == "" {
-			installedVersion = installedVersionAPI
-		}
-		if installedEdition == "" {
-			installedEdition = installedEditionAPI
+			installedVersion = sonarCloudVersion
 		}
+		installedEdition = sonarCloudEdition
+	}
+	installedVersion, installedEdition, err = detectSonarQubeVersion(client, sonarQubeURL, installedVersion, installedEdition, anonymous)
+	if err != nil {
+		return nil, err
 	}
 
 	parsedInstalledVersion, err := version.NewVersion(installedVersion)
@@ -203,15 +374,115 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 	minimumVersionForAnonymize, _ := version.NewVersion("9.7")
 	anonymizeUsers := d.Get("anonymize_user_on_delete").(bool) && parsedInstalledVersion.GreaterThanOrEqual(minimumVersionForAnonymize)
 
+	var projectKeyRegex *regexp.Regexp
+	if keyRegex := d.Get("enforce_key_regex").(string); keyRegex != "" {
+		projectKeyRegex, err = regexp.Compile(keyRegex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse enforce_key_regex: %+v", err)
+		}
+	}
+
 	return &ProviderConfiguration{
-		httpClient:              client,
-		sonarQubeURL:            sonarQubeURL,
-		sonarQubeVersion:        parsedInstalledVersion,
-		sonarQubeEdition:        installedEdition,
-		sonarQubeAnonymizeUsers: anonymizeUsers,
+		httpClient:               client,
+		sonarQubeURL:             sonarQubeURL,
+		sonarQubeVersion:         parsedInstalledVersion,
+		sonarQubeEdition:         installedEdition,
+		sonarQubeAnonymizeUsers:  anonymizeUsers,
+		projectKeyPrefix:         d.Get("project_key_prefix").(string),
+		projectKeyRegex:          projectKeyRegex,
+		defaultProjectTags:       expandStringList(d.Get("default_project_tags")),
+		skipPermissionValidation: d.Get("skip_permission_validation").(bool),
+		anonymous:                anonymous,
+		sonarCloudOrganization:   organization,
 	}, nil
 }
 
+// sonarQubeBaseURL returns the URL that the requests of the provider are built from. It keeps the "?" of requests
+// without query parameters.
+func sonarQubeBaseURL(host *url.URL) url.URL {
+	return url.URL{
+		Scheme:     host.Scheme,
+		Host:       host.Host,
+		Path:       host.Path,
+		ForceQuery: true,
+	}
+}
+
+// detectSonarQubeVersion returns the version and the edition of SonarQube. The values set in the provider block are
+// used as they are, so api/system/info is only requested when one of them is missing, for example behind a gateway
+// that blocks it. Anonymous connections cannot read api/system/info and use the public api/navigation/global instead.
+func detectSonarQubeVersion(client *retryablehttp.Client, sonarqube url.URL, installedVersion string, installedEdition string, anonymous bool) (string, string, error) {
+	if installedVersion != "" && installedEdition != "" {
+		return installedVersion, installedEdition, nil
+	}
+
+	detect := sonarqubeSystemInfo
+	if anonymous {
+		detect = sonarqubeNavigationGlobal
+	}
+	installedVersionAPI, installedEditionAPI, err := detect(client, sonarqube)
+	if err != nil {
+		return "", "", err
+	}
+	if installedVersion == "" {
+		installedVersion = installedVersionAPI
+	}
+	if installedEdition == "" {
+		installedEdition = installedEditionAPI
+	}
+	return installedVersion, installedEdition, nil
+}
+
+// validateInstalledVersion checks that installed_version can be compared with the minimum versions of the features
+func validateInstalledVersion(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+	if v == "" {
+		return nil, nil
+	}
+	if _, err := version.NewVersion(v); err != nil {
+		return nil, []error{fmt.Errorf("%s '%s' is not a valid version: %+v", k, v, err)}
+	}
+	return nil, nil
+}
+
+// sonarqubeNavigationGlobal returns the version and the edition of SonarQube from api/navigation/global, which does not
+// require a user
+func sonarqubeNavigationGlobal(client *retryablehttp.Client, sonarqube url.URL) (string, string, error) {
+	sonarqube.Path = strings.TrimSuffix(sonarqube.Path, "/") + "/api/navigation/global"
+	resp, err := httpRequestHelper(
+		client,
+		"GET",
+		sonarqube.String(),
+		http.StatusOK,
+		"sonarqubeNavigationGlobal",
+	)
+	if err != nil {
+		return "", "", fmt.Errorf("cannot get sonarqube version/edition anonymously. Please configure installed_version and installed_edition: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	responseData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse response body on GET sonarqube navigation/global api: %+v", err)
+	}
+
+	// The edition is a lowercase key, for example "datacenter", instead of the name returned by api/system/info
+	editions := map[string]string{
+		"community":  "Community",
+		"developer":  "Developer",
+		"enterprise": "Enterprise",
+		"datacenter": "Data Center",
+	}
+	sonarqubeEdition := gjson.GetBytes(responseData, "edition").String()
+	if name, ok := editions[sonarqubeEdition]; ok {
+		sonarqubeEdition = name
+	}
+	return gjson.GetBytes(responseData, "version").String(), sonarqubeEdition, nil
+}
+
 func sonarqubeSystemInfo(client *retryablehttp.Client, sonarqube url.URL) (string, string, error) {
 	// Make request to sonarqube version endpoint
 	sonarqube.Path = strings.TrimSuffix(sonarqube.Path, "/") + "/api/system/info"
@@ -1,10 +1,15 @@
 package sonarqube
 
 import (
+	"context"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -32,6 +37,19 @@ func TestProvider_impl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+// sharedConfigForSweepers configures the provider from the environment for the sweepers
+func sharedConfigForSweepers() (*ProviderConfiguration, error) {
+	if os.Getenv("SONAR_HOST") == "" {
+		return nil, fmt.Errorf("SONAR_HOST must be set for the sweepers")
+	}
+
+	provider := Provider()
+	if diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(nil)); diags.HasError() {
+		return nil, fmt.Errorf("failed to configure the provider: %+v", diags)
+	}
+	return provider.Meta().(*ProviderConfiguration), nil
+}
+
 func testAccPreCheck(t *testing.T) {
 	testSonarHost(t)
 	if v := os.Getenv("SONAR_TOKEN"); v == "" {
@@ -57,3 +75,70 @@ func testSonarHost(t *testing.T) {
 		t.Fatal("SONAR_HOST must be set for this acceptance test")
 	}
 }
+
+func TestProviderConfigureInstalledVersion(t *testing.T) {
+	mock := newMockSonarQube(t)
+	configure := func(raw map[string]interface{}) (*ProviderConfiguration, error) {
+		raw["host"] = mock.server.URL
+		raw["token"] = "my-token"
+		provider := Provider()
+		if diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+			return nil, fmt.Errorf("%+v", diags)
+		}
+		return provider.Meta().(*ProviderConfiguration), nil
+	}
+
+	// Both values are set, so api/system/info is not requested
+	conf, err := configure(map[string]interface{}{"installed_version": "10.7", "installed_edition": "enterprise"})
+	if err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if conf.sonarQubeVersion.String() != "10.7.0" || conf.sonarQubeEdition != "enterprise" || len(mock.requests()) != 0 {
+		t.Errorf("Configure() = %s %s after requests %v", conf.sonarQubeVersion, conf.sonarQubeEdition, mock.requests())
+	}
+
+	// The missing edition is detected
+	mock.respond("GET", "/api/system/info", http.StatusOK, `{"System":{"Version":"10.5.1","Edition":"Developer"}}`)
+	conf, err = configure(map[string]interface{}{"installed_version": "10.7"})
+	if err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if conf.sonarQubeVersion.String() != "10.7.0" || conf.sonarQubeEdition != "Developer" {
+		t.Errorf("Configure() = %s %s, want 10.7.0 Developer", conf.sonarQubeVersion, conf.sonarQubeEdition)
+	}
+
+	if _, err := configure(map[string]interface{}{"installed_version": "latest", "installed_edition": "Community"}); err == nil {
+		t.Errorf("Configure() should reject an installed_version that is not a version")
+	}
+}
+
+func TestProviderConfigureAnonymous(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.handle("GET", "/api/navigation/global", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("anonymous requests should not be authenticated")
+		}
+		mockJSON(w, map[string]interface{}{"version": "10.7.0.96327", "edition": "datacenter"})
+	})
+
+	provider := Provider()
+	raw := map[string]interface{}{"host": mock.server.URL}
+	if diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		t.Fatalf("Configure() error = %+v", diags)
+	}
+	conf := provider.Meta().(*ProviderConfiguration)
+	if !conf.anonymous || conf.sonarQubeVersion.String() != "10.7.0.96327" || conf.sonarQubeEdition != "Data Center" {
+		t.Errorf("Configure() = anonymous %t, %s %s", conf.anonymous, conf.sonarQubeVersion, conf.sonarQubeEdition)
+	}
+
+	// Changes fail before any request is sent
+	r := provider.ResourcesMap["sonarqube_group"]
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "developers"})
+	err := r.Create(d, conf)
+	if err == nil || !strings.Contains(err.Error(), "sonarqube_group cannot be changed without credentials") {
+		t.Errorf("Create() error = %v, want the credentials error", err)
+	}
+	if got := len(mock.requests()); got != 1 {
+		t.Errorf("expected only the request of the version, got %v", mock.requests())
+	}
+}
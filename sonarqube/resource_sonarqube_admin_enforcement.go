@@ -0,0 +1,178 @@
+package sonarqube
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeAdminEnforcement() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Admin Enforcement resource. This ensures that a set of users and groups always keep the global
+` + "`admin`" + ` permission, to prevent lockouts when it is removed by hand or by another configuration. The permission is checked
+on every refresh: a principal that lost it is planned to get it back, or gets it back during the refresh itself when ` + "`strict`" + ` is set.
+Removing a principal from this resource, or destroying it, does not revoke the permission. It supports importing using the ID 'admin_enforcement',
+which imports the users and groups that currently have the permission.`,
+		Create: resourceSonarqubeAdminEnforcementCreate,
+		Read:   resourceSonarqubeAdminEnforcementRead,
+		Update: resourceSonarqubeAdminEnforcementUpdate,
+		Delete: resourceSonarqubeAdminEnforcementDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeAdminEnforcementImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"login_names": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				AtLeastOneOf: []string{"login_names", "group_names"},
+				Description:  "The logins of the users that must keep the global `admin` permission.",
+			},
+			"group_names": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				AtLeastOneOf: []string{"login_names", "group_names"},
+				Description:  "The names of the groups that must keep the global `admin` permission.",
+			},
+			"strict": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to grant the `admin` permission back during the refresh, instead of planning it. Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeAdminEnforcementCreate(d *schema.ResourceData, m interface{}) error {
+	if err := grantMissingAdminPermissions(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeAdminEnforcementCreate: %+v", err)
+	}
+	d.SetId("admin_enforcement")
+
+	return resourceSonarqubeAdminEnforcementRead(d, m)
+}
+
+func resourceSonarqubeAdminEnforcementRead(d *schema.ResourceData, m interface{}) error {
+	if d.Get("strict").(bool) {
+		if err := grantMissingAdminPermissions(d, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeAdminEnforcementRead: %+v", err)
+		}
+	}
+
+	// The principals without the permission are removed from the state, so it is granted again on the next apply
+	errs := []error{}
+	for attribute, principalType := range map[string]string{"login_names": principalUser, "group_names": principalGroup} {
+		admins, err := listGlobalAdmins(principalType, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeAdminEnforcementRead: %+v", err)
+		}
+		enforced := []interface{}{}
+		for _, principal := range expandStringList(d.Get(attribute)) {
+			if !containsPrincipal(admins, principal) {
+				log.Printf("[WARN][resourceSonarqubeAdminEnforcementRead] The %s '%s' does not have the admin permission anymore", principalType, principal)
+				continue
+			}
+			enforced = append(enforced, principal)
+		}
+		errs = append(errs, d.Set(attribute, enforced))
+	}
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeAdminEnforcementUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := grantMissingAdminPermissions(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeAdminEnforcementUpdate: %+v", err)
+	}
+
+	return resourceSonarqubeAdminEnforcementRead(d, m)
+}
+
+func resourceSonarqubeAdminEnforcementDelete(d *schema.ResourceData, m interface{}) error {
+	// Revoking the permission could lock everybody out
+	return nil
+}
+
+func resourceSonarqubeAdminEnforcementImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() != "admin_enforcement" {
+		return nil, fmt.Errorf("resourceSonarqubeAdminEnforcementImport: the ID must be 'admin_enforcement', got '%s'", d.Id())
+	}
+
+	errs := []error{}
+	for attribute, principalType := range map[string]string{"login_names": principalUser, "group_names": principalGroup} {
+		admins, err := listGlobalAdmins(principalType, m)
+		if err != nil {
+			return nil, fmt.Errorf("resourceSonarqubeAdminEnforcementImport: %+v", err)
+		}
+		errs = append(errs, d.Set(attribute, admins))
+	}
+	errs = append(errs, d.Set("strict", false))
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// grantMissingAdminPermissions grants the global admin permission to the users and groups of the resource that do
+// not have it
+func grantMissingAdminPermissions(d *schema.ResourceData, m interface{}) error {
+	errs := []error{}
+	for attribute, principalType := range map[string]string{"login_names": principalUser, "group_names": principalGroup} {
+		principals := expandStringList(d.Get(attribute))
+		if len(principals) == 0 {
+			continue
+		}
+		admins, err := listGlobalAdmins(principalType, m)
+		if err != nil {
+			return err
+		}
+		for _, principal := range principals {
+			if containsPrincipal(admins, principal) {
+				continue
+			}
+			log.Printf("[INFO][grantMissingAdminPermissions] Granting the admin permission to the %s '%s'", principalType, principal)
+			errs = append(errs, updatePermission("add", principalType, principal, permissionScope{}, permissionAdmin, m))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// listGlobalAdmins returns the names of the users or groups that have the global admin permission
+func listGlobalAdmins(principalType string, m interface{}) ([]string, error) {
+	permissions, err := listScopePermissions(principalType, permissionScope{}, "", m)
+	if err != nil {
+		return nil, err
+	}
+	admins := []string{}
+	for principal, principalPermissions := range permissions {
+		for _, permission := range principalPermissions {
+			if permission == permissionAdmin {
+				admins = append(admins, principal)
+				break
+			}
+		}
+	}
+	return admins, nil
+}
+
+// containsPrincipal returns true when the principal is in the list, as logins and group names are case-insensitive
+func containsPrincipal(principals []string, principal string) bool {
+	for _, name := range principals {
+		if strings.EqualFold(name, principal) {
+			return true
+		}
+	}
+	return false
+}
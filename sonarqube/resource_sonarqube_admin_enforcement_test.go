@@ -0,0 +1,103 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeAdminEnforcementConfig(rnd string, strict bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[1]s"
+		}
+
+		resource "sonarqube_admin_enforcement" "%[1]s" {
+			login_names = ["admin"]
+			group_names = [sonarqube_group.%[1]s.name]
+			strict      = %[2]t
+		}`, rnd, strict)
+}
+
+func TestAccSonarqubeAdminEnforcementBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_admin_enforcement." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeAdminEnforcementConfig(rnd, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "login_names.#", "1"),
+					resource.TestCheckResourceAttr(name, "group_names.#", "1"),
+				),
+			},
+			{
+				// The permission is granted back when it is removed outside of Terraform
+				PreConfig: func() {
+					conf := testAccProviderConfiguration(t)
+					if err := updatePermission("remove", principalGroup, rnd, permissionScope{}, permissionAdmin, conf); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccSonarqubeAdminEnforcementConfig(rnd, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "group_names.#", "1"),
+					resource.TestCheckResourceAttr(name, "strict", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeAdminEnforcementRead(t *testing.T) {
+	tests := []struct {
+		name          string
+		strict        bool
+		wantGroups    int
+		wantGrantCall bool
+	}{
+		{name: "missing permission is planned", strict: false, wantGroups: 0},
+		{name: "missing permission is granted on refresh", strict: true, wantGroups: 1, wantGrantCall: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockSonarQube(t)
+			mockGroupPermissionsAPI(mock, map[string][]string{"sonar-administrators": {"admin"}, "platform": {"scan"}}, 1)
+			mock.respond("GET", "/api/permissions/users", http.StatusOK, `{"paging":{"pageIndex":1,"pageSize":100,"total":1},"users":[{"login":"admin","permissions":["admin"]}]}`)
+			r := resourceSonarqubeAdminEnforcement()
+
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"login_names": []interface{}{"Admin"},
+				"group_names": []interface{}{"platform"},
+				"strict":      tt.strict,
+			})
+			d.SetId("admin_enforcement")
+			if err := r.Read(d, mock.conf("Community", "10.7")); err != nil {
+				t.Fatalf("Read() unexpected error = %v", err)
+			}
+
+			if got := d.Get("login_names").(*schema.Set).Len(); got != 1 {
+				t.Errorf("Read() login_names has %d elements, want 1", got)
+			}
+			if got := d.Get("group_names").(*schema.Set).Len(); got != tt.wantGroups {
+				t.Errorf("Read() group_names has %d elements, want %d", got, tt.wantGroups)
+			}
+			granted := false
+			for _, call := range mock.requests() {
+				if call == "POST /api/permissions/add_group?groupName=platform&permission=admin" {
+					granted = true
+				}
+			}
+			if granted != tt.wantGrantCall {
+				t.Errorf("Read() granted the permission = %t, want %t", granted, tt.wantGrantCall)
+			}
+		})
+	}
+}
@@ -26,7 +26,7 @@ type GetAlmAzure struct {
 func resourceSonarqubeAlmAzure() *schema.Resource {
 	return &schema.Resource{
 		Description: `Provides a Sonarqube Azure Devops Alm/Devops Platform Integration resource. This can be used to create and manage a Alm/Devops
-Platform Integration for Azure Devops.`,
+Platform Integration for Azure Devops. It supports importing using the format '{key}/{personal_access_token}'.`,
 		Create: resourceSonarqubeAlmAzureCreate,
 		Read:   resourceSonarqubeAlmAzureRead,
 		Update: resourceSonarqubeAlmAzureUpdate,
@@ -181,11 +181,9 @@ func resourceSonarqubeAlmAzureDelete(d *schema.ResourceData, m interface{}) erro
 }
 
 func resourceSonarqubeAlmAzureImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	// import id in format {key}/{personal_access_token}
-	importIdComponents := strings.SplitN(d.Id(), "/", 2)
-
-	if len(importIdComponents) != 2 {
-		return nil, fmt.Errorf("resourceSonarqubeAlmAzureImport: Import id: '%+v' is not in format {key}/{personal_access_token}", d.Id())
+	importIdComponents, err := parseImportID(d.Id(), "{key}/{personal_access_token}")
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeAlmAzureImport: %+v", err)
 	}
 
 	// set Id to key for Read
@@ -195,7 +193,7 @@ func resourceSonarqubeAlmAzureImport(d *schema.ResourceData, m interface{}) ([]*
 	}
 
 	// Add personal_access_token from import id
-	err := d.Set("personal_access_token", importIdComponents[1])
+	err = d.Set("personal_access_token", importIdComponents[1])
 
 	return []*schema.ResourceData{d}, err
 }
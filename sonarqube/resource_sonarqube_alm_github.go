@@ -1,6 +1,7 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -25,11 +27,18 @@ type GetAlmGithub struct {
 func resourceSonarqubeAlmGithub() *schema.Resource {
 	return &schema.Resource{
 		Description: `Provides a Sonarqube GitHub Alm/Devops Platform Integration resource. This can be used to create and manage a Alm/Devops
-Platform Integration for GitHub.`,
+Platform Integration for GitHub. The computed ` + "`webhook_url`" + ` and ` + "`webhook_events`" + ` can be passed to the GitHub provider
+to configure the webhook of the GitHub App in the same plan.`,
 		Create: resourceSonarqubeAlmGithubCreate,
 		Read:   resourceSonarqubeAlmGithubRead,
 		Update: resourceSonarqubeAlmGithubUpdate,
 		Delete: resourceSonarqubeAlmGithubDelete,
+		// The key is changed in place, which keeps the bindings of the projects
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return almKeyChangeDiff(d, meta)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
@@ -54,8 +63,7 @@ Platform Integration for GitHub.`,
 			"key": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
-				Description: "Unique key of the GitHUb instance setting. Maximum length: 200",
+				Description: "Unique key of the GitHUb instance setting. Changing it updates the setting in place, so the projects bound to it keep their binding. Maximum length: 200",
 			},
 			"private_key": {
 				Type:        schema.TypeString,
@@ -75,6 +83,34 @@ Platform Integration for GitHub.`,
 				ForceNew:    false,
 				Description: "GitHub App Webhook Secret. Maximum length: 160",
 			},
+			"validate":       almValidateSchema(),
+			"bound_projects": almBoundProjectsSchema(),
+			"sonarqube_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the SonarQube instance, used as the homepage and callback URL of the GitHub App.",
+			},
+			"webhook_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL the GitHub App sends its webhook events to. The webhook is signed with `webhook_secret`.",
+			},
+			"webhook_events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The events the GitHub App must subscribe to.",
+			},
+			"provisioning_webhook_events": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The additional events the GitHub App must subscribe to when GitHub provisioning is enabled with `sonarqube_auth_github`.",
+			},
 		},
 	}
 }
@@ -107,6 +143,17 @@ func resourceSonarqubeAlmGithubCreate(d *schema.ResourceData, m interface{}) err
 
 	d.SetId(d.Get("key").(string))
 
+	if d.Get("validate").(bool) {
+		if err := validateAlmSetting(d.Id(), m); err != nil {
+			// The setting can only be validated once it exists: delete it so that the apply leaves nothing behind
+			if deleteErr := resourceSonarqubeAlmGithubDelete(d, m); deleteErr != nil {
+				return errors.Join(err, deleteErr)
+			}
+			d.SetId("")
+			return err
+		}
+	}
+
 	return resourceSonarqubeAlmGithubRead(d, m)
 }
 
@@ -141,6 +188,10 @@ func resourceSonarqubeAlmGithubRead(d *schema.ResourceData, m interface{}) error
 			errs = append(errs, d.Set("url", value.URL))
 			errs = append(errs, d.Set("app_id", value.AppID))
 			errs = append(errs, d.Set("client_id", value.ClientID))
+			errs = append(errs, d.Set("sonarqube_url", sonarQubeWebURL(m.(*ProviderConfiguration), "", nil)))
+			errs = append(errs, d.Set("webhook_url", sonarQubeWebURL(m.(*ProviderConfiguration), githubAppWebhookPath, nil)))
+			errs = append(errs, d.Set("webhook_events", githubAppWebhookEvents))
+			errs = append(errs, d.Set("provisioning_webhook_events", githubAppProvisioningWebhookEvents))
 			return errors.Join(errs...)
 		}
 	}
@@ -148,17 +199,43 @@ func resourceSonarqubeAlmGithubRead(d *schema.ResourceData, m interface{}) error
 }
 
 func resourceSonarqubeAlmGithubUpdate(d *schema.ResourceData, m interface{}) error {
+	oldKey := d.Id()
+	if err := updateAlmGithub(d, oldKey, d.Get("key").(string), d.Get("webhook_secret").(string), m); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("key").(string))
+
+	if d.Get("validate").(bool) {
+		if err := validateAlmSetting(d.Id(), m); err != nil {
+			// Restore the previous configuration, so that the state still matches the setting. The other attributes
+			// force a new resource, so only the key and the webhook secret can have changed.
+			d.Partial(true)
+			oldWebhookSecret, _ := d.GetChange("webhook_secret")
+			if restoreErr := updateAlmGithub(d, d.Id(), oldKey, oldWebhookSecret.(string), m); restoreErr != nil {
+				return errors.Join(err, restoreErr)
+			}
+			d.SetId(oldKey)
+			return err
+		}
+	}
+
+	return resourceSonarqubeAlmGithubRead(d, m)
+}
+
+// updateAlmGithub updates the GitHub setting with the given key
+func updateAlmGithub(d *schema.ResourceData, key string, newKey string, webhookSecret string, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_settings/update_github"
 	sonarQubeURL.RawQuery = url.Values{
 		"appId":         []string{d.Get("app_id").(string)},
 		"clientId":      []string{d.Get("client_id").(string)},
 		"clientSecret":  []string{d.Get("client_secret").(string)},
-		"key":           []string{d.Id()},
-		"newKey":        []string{d.Get("key").(string)},
+		"key":           []string{key},
+		"newKey":        []string{newKey},
 		"privateKey":    []string{d.Get("private_key").(string)},
 		"url":           []string{d.Get("url").(string)},
-		"webhookSecret": []string{d.Get("webhook_secret").(string)},
+		"webhookSecret": []string{webhookSecret},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -173,7 +250,7 @@ func resourceSonarqubeAlmGithubUpdate(d *schema.ResourceData, m interface{}) err
 	}
 	defer resp.Body.Close()
 
-	return resourceSonarqubeAlmGithubRead(d, m)
+	return nil
 }
 
 func resourceSonarqubeAlmGithubDelete(d *schema.ResourceData, m interface{}) error {
@@ -2,9 +2,11 @@ package sonarqube
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
 
 func init() {
@@ -47,6 +49,8 @@ func TestAccSonarqubeAlmGithubName(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "key", "testAccSonarqubeAlmGithubName"),
 					resource.TestCheckResourceAttr(name, "app_id", "123456"),
 					resource.TestCheckResourceAttr(name, "client_id", "234567"),
+					resource.TestMatchResourceAttr(name, "webhook_url", regexp.MustCompile(`/api/alm_integrations/webhook_github$`)),
+					resource.TestCheckResourceAttr(name, "webhook_events.0", "check_run"),
 				),
 			},
 			{
@@ -60,3 +64,62 @@ func TestAccSonarqubeAlmGithubName(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeAlmGithubKeyChangeConfig(rnd string, key string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_alm_github" "%[1]s" {
+			app_id         = "123456"
+			client_id      = "234567"
+			client_secret  = "secret"
+			key            = "%[2]s"
+			private_key    = "myprivate_key"
+			url            = "https://api.github.com"
+			webhook_secret = "mysecret"
+		}
+
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[1]s"
+			project    = "%[1]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_github_binding" "%[1]s" {
+			alm_setting = sonarqube_alm_github.%[1]s.key
+			project     = sonarqube_project.%[1]s.project
+			repository  = "my-org/%[1]s"
+		}`, rnd, key)
+}
+
+func TestAccSonarqubeAlmGithubKeyChange(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_alm_github." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckGithubBindingSupport(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeAlmGithubKeyChangeConfig(rnd, rnd+"-before"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "key", rnd+"-before"),
+					resource.TestCheckResourceAttr(name, "bound_projects.#", "0"),
+				),
+			},
+			// The key is changed in place, and the binding is kept instead of being replaced
+			{
+				Config: testAccSonarqubeAlmGithubKeyChangeConfig(rnd, rnd+"-after"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("sonarqube_github_binding."+rnd, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "key", rnd+"-after"),
+					resource.TestCheckResourceAttr(name, "bound_projects.#", "1"),
+					resource.TestCheckResourceAttr(name, "bound_projects.0", rnd),
+					resource.TestCheckResourceAttr("sonarqube_github_binding."+rnd, "alm_setting", rnd+"-after"),
+				),
+			},
+		},
+	})
+}
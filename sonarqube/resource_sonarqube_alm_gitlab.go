@@ -1,6 +1,7 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -25,20 +27,26 @@ type GetAlmGitlab struct {
 func resourceSonarqubeAlmGitlab() *schema.Resource {
 	return &schema.Resource{
 		Description: `Provides a Sonarqube GitLab Alm/Devops Platform Integration resource. This can be used to create and manage a Alm/Devops
-Platform Integration for GitLab.`,
+Platform Integration for GitLab. GitLab does not send webhook events to SonarQube: analyses are triggered by GitLab CI,
+which only needs the computed ` + "`sonarqube_url`" + ` as its ` + "`SONAR_HOST_URL`" + ` variable.`,
 		Create: resourceSonarqubeAlmGitlabCreate,
 		Read:   resourceSonarqubeAlmGitlabRead,
 		Update: resourceSonarqubeAlmGitlabUpdate,
 		Delete: resourceSonarqubeAlmGitlabDelete,
+		// The key is changed in place, which keeps the bindings of the projects
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return almKeyChangeDiff(d, meta)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
 			"key": {
 				Type:             schema.TypeString,
 				Required:         true,
-				ForceNew:         true,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(1, 200)),
-				Description:      "Unique key of the GitLab instance setting. Maximum length: 200",
+				Description:      "Unique key of the GitLab instance setting. Changing it updates the setting in place, so the projects bound to it keep their binding. Maximum length: 200",
 			},
 			"personal_access_token": {
 				Type:             schema.TypeString,
@@ -53,6 +61,13 @@ Platform Integration for GitLab.`,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(1, 2000)),
 				Description:      "GitLab API URL. Maximum length: 2000",
 			},
+			"validate":       almValidateSchema(),
+			"bound_projects": almBoundProjectsSchema(),
+			"sonarqube_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the SonarQube instance, to set as the `SONAR_HOST_URL` variable of GitLab CI.",
+			},
 		},
 	}
 }
@@ -81,6 +96,17 @@ func resourceSonarqubeAlmGitlabCreate(d *schema.ResourceData, m interface{}) err
 
 	d.SetId(d.Get("key").(string))
 
+	if d.Get("validate").(bool) {
+		if err := validateAlmSetting(d.Id(), m); err != nil {
+			// The setting can only be validated once it exists: delete it so that the apply leaves nothing behind
+			if deleteErr := resourceSonarqubeAlmGitlabDelete(d, m); deleteErr != nil {
+				return errors.Join(err, deleteErr)
+			}
+			d.SetId("")
+			return err
+		}
+	}
+
 	return resourceSonarqubeAlmGitlabRead(d, m)
 }
 
@@ -111,22 +137,50 @@ func resourceSonarqubeAlmGitlabRead(d *schema.ResourceData, m interface{}) error
 		if d.Id() == value.Key {
 			errKey := d.Set("key", value.Key)
 			errUrl := d.Set("url", value.URL)
+			errSonarQubeUrl := d.Set("sonarqube_url", sonarQubeWebURL(m.(*ProviderConfiguration), "", nil))
 			// The personal_access_token is a secured property that is not returned
 			// d.Set("personal_access_token", value.PersonalAccessToken)
-			return errors.Join(errKey, errUrl)
+			return errors.Join(errKey, errUrl, errSonarQubeUrl)
 		}
 	}
 	return fmt.Errorf("resourceSonarqubeGitlabBindingRead: Failed to find gitlab binding: %+v", d.Id())
 }
 
 func resourceSonarqubeAlmGitlabUpdate(d *schema.ResourceData, m interface{}) error {
+	oldKey := d.Id()
+	err := updateAlmGitlab(oldKey, d.Get("key").(string), d.Get("personal_access_token").(string), d.Get("url").(string), m)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("key").(string))
+
+	if d.Get("validate").(bool) {
+		if err := validateAlmSetting(d.Id(), m); err != nil {
+			// Restore the previous configuration, so that the state still matches the setting
+			d.Partial(true)
+			oldToken, _ := d.GetChange("personal_access_token")
+			oldURL, _ := d.GetChange("url")
+			if restoreErr := updateAlmGitlab(d.Id(), oldKey, oldToken.(string), oldURL.(string), m); restoreErr != nil {
+				return errors.Join(err, restoreErr)
+			}
+			d.SetId(oldKey)
+			return err
+		}
+	}
+
+	return resourceSonarqubeAlmGitlabRead(d, m)
+}
+
+// updateAlmGitlab updates the GitLab setting with the given key
+func updateAlmGitlab(key string, newKey string, personalAccessToken string, gitlabURL string, m interface{}) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_settings/update_gitlab"
 	sonarQubeURL.RawQuery = url.Values{
-		"key":                 []string{d.Id()},
-		"newKey":              []string{d.Get("key").(string)},
-		"personalAccessToken": []string{d.Get("personal_access_token").(string)},
-		"url":                 []string{d.Get("url").(string)},
+		"key":                 []string{key},
+		"newKey":              []string{newKey},
+		"personalAccessToken": []string{personalAccessToken},
+		"url":                 []string{gitlabURL},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -141,7 +195,7 @@ func resourceSonarqubeAlmGitlabUpdate(d *schema.ResourceData, m interface{}) err
 	}
 	defer resp.Body.Close()
 
-	return resourceSonarqubeAlmGitlabRead(d, m)
+	return nil
 }
 
 func resourceSonarqubeAlmGitlabDelete(d *schema.ResourceData, m interface{}) error {
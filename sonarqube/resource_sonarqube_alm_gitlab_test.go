@@ -1,9 +1,15 @@
 package sonarqube
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -49,6 +55,8 @@ func TestAccSonarqubeAlmGitlabName(t *testing.T) {
 				Config: testAccSonarqubeAlmGitlabName(rnd, "testAccSonarqubeAlmGitlabNameUpdate", "654321"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "key", "testAccSonarqubeAlmGitlabNameUpdate"),
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeAlmGitlabNameUpdate"),
+					resource.TestCheckResourceAttr(name, "bound_projects.#", "0"),
 					resource.TestCheckResourceAttr(name, "personal_access_token", "654321"),
 					resource.TestCheckResourceAttr(name, "url", "https://654321.gitlab.com/api/v4"),
 				),
@@ -56,3 +64,125 @@ func TestAccSonarqubeAlmGitlabName(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeAlmGitlabKeyChangeConfig(rnd string, key string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_alm_gitlab" "%[1]s" {
+			personal_access_token = "123456"
+			key                   = "%[2]s"
+			url                   = "https://gitlab.com/api/v4"
+		}
+
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[1]s"
+			project    = "%[1]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_gitlab_binding" "%[1]s" {
+			alm_setting = sonarqube_alm_gitlab.%[1]s.key
+			project     = sonarqube_project.%[1]s.project
+			repository  = "%[1]s"
+		}`, rnd, key)
+}
+
+func TestAccSonarqubeAlmGitlabKeyChange(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_alm_gitlab." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckGitlabBindingSupport(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeAlmGitlabKeyChangeConfig(rnd, rnd+"-before"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "key", rnd+"-before"),
+					resource.TestCheckResourceAttr(name, "bound_projects.#", "0"),
+				),
+			},
+			// The key is changed in place and the plan lists the bound project
+			{
+				Config: testAccSonarqubeAlmGitlabKeyChangeConfig(rnd, rnd+"-after"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "key", rnd+"-after"),
+					resource.TestCheckResourceAttr(name, "bound_projects.#", "1"),
+					resource.TestCheckResourceAttr(name, "bound_projects.0", rnd),
+					resource.TestCheckResourceAttr("sonarqube_gitlab_binding."+rnd, "alm_setting", rnd+"-after"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeAlmGitlabValidateRollback(t *testing.T) {
+	raw := map[string]interface{}{
+		"key":                   "gitlab",
+		"personal_access_token": "token",
+		"url":                   "https://gitlab.com/api/v4",
+		"validate":              true,
+	}
+
+	t.Run("Create deletes the setting", func(t *testing.T) {
+		mock := newMockSonarQube(t)
+		mock.respond("POST", "/api/alm_settings/create_gitlab", http.StatusNoContent, "")
+		mock.respond("GET", "/api/alm_settings/validate", http.StatusBadRequest, `{"errors":[{"msg":"Invalid personal access token"}]}`)
+		mock.respond("POST", "/api/alm_settings/delete", http.StatusNoContent, "")
+
+		d := schema.TestResourceDataRaw(t, resourceSonarqubeAlmGitlab().Schema, raw)
+		err := resourceSonarqubeAlmGitlabCreate(d, mock.conf("community", "10.4"))
+		if err == nil || !strings.Contains(err.Error(), "Invalid personal access token") {
+			t.Fatalf("Create() error = %v, want the validation error", err)
+		}
+		if d.Id() != "" {
+			t.Errorf("Create() id = %q, want it cleared", d.Id())
+		}
+		want := []string{
+			"POST /api/alm_settings/create_gitlab?key=gitlab&personalAccessToken=token&url=https%3A%2F%2Fgitlab.com%2Fapi%2Fv4",
+			"GET /api/alm_settings/validate?key=gitlab",
+			"POST /api/alm_settings/delete?key=gitlab",
+		}
+		if got := mock.requests(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Create() requests = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Update restores the previous configuration", func(t *testing.T) {
+		mock := newMockSonarQube(t)
+		mock.respond("GET", "/api/projects/search", http.StatusOK, `{"paging":{"pageIndex":1,"pageSize":500,"total":0},"components":[]}`)
+		mock.respond("POST", "/api/alm_settings/update_gitlab", http.StatusNoContent, "")
+		mock.respond("GET", "/api/alm_settings/validate", http.StatusBadRequest, `{"errors":[{"msg":"Invalid personal access token"}]}`)
+		conf := mock.conf("community", "10.4")
+
+		r := resourceSonarqubeAlmGitlab()
+		d := schema.TestResourceDataRaw(t, r.Schema, raw)
+		d.SetId("gitlab")
+
+		updated := map[string]interface{}{
+			"key":                   "gitlab-new",
+			"personal_access_token": "wrong",
+			"url":                   "https://gitlab.com/api/v4",
+			"validate":              true,
+		}
+		diff, err := r.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(updated), conf)
+		if err != nil {
+			t.Fatalf("Diff() error = %+v", err)
+		}
+		state, diags := r.Apply(context.Background(), d.State(), diff, conf)
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "Invalid personal access token") {
+			t.Fatalf("Apply() diagnostics = %v, want the validation error", diags)
+		}
+		if state.ID != "gitlab" || state.Attributes["key"] != "gitlab" || state.Attributes["personal_access_token"] != "token" {
+			t.Errorf("Apply() state = %v, want the previous configuration", state.Attributes)
+		}
+		want := []string{
+			"GET /api/projects/search?p=1&ps=500",
+			"POST /api/alm_settings/update_gitlab?key=gitlab&newKey=gitlab-new&personalAccessToken=wrong&url=https%3A%2F%2Fgitlab.com%2Fapi%2Fv4",
+			"GET /api/alm_settings/validate?key=gitlab-new",
+			"POST /api/alm_settings/update_gitlab?key=gitlab-new&newKey=gitlab&personalAccessToken=token&url=https%3A%2F%2Fgitlab.com%2Fapi%2Fv4",
+		}
+		if got := mock.requests(); !reflect.DeepEqual(got, want) {
+			t.Errorf("Update() requests = %v, want %v", got, want)
+		}
+	})
+}
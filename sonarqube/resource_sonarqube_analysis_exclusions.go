@@ -0,0 +1,100 @@
+package sonarqube
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// analysisExclusionsSettings maps the attributes of sonarqube_analysis_exclusions to their setting keys
+var analysisExclusionsSettings = []settingAttribute{
+	{attribute: "exclusions", key: "sonar.exclusions"},
+	{attribute: "inclusions", key: "sonar.inclusions"},
+	{attribute: "test_exclusions", key: "sonar.test.exclusions"},
+	{attribute: "test_inclusions", key: "sonar.test.inclusions"},
+	{attribute: "coverage_exclusions", key: "sonar.coverage.exclusions"},
+	{attribute: "cpd_exclusions", key: "sonar.cpd.exclusions"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeAnalysisExclusions() *schema.Resource {
+	patternList := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: description,
+		}
+	}
+
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Analysis Exclusions resource. This can be used to manage the analysis scope of a project,
+or of all projects when no project is set. Destroying this resource resets the settings to their default value.`,
+		Create: resourceSonarqubeAnalysisExclusionsCreate,
+		Read:   resourceSonarqubeAnalysisExclusionsRead,
+		Update: resourceSonarqubeAnalysisExclusionsUpdate,
+		Delete: resourceSonarqubeAnalysisExclusionsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeAnalysisExclusionsImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The key of the project. When not set, the exclusions apply to all projects. Changing this forces a new resource to be created.",
+			},
+			"exclusions":          patternList("The files excluded from the analysis (`sonar.exclusions`)."),
+			"inclusions":          patternList("The only files included in the analysis (`sonar.inclusions`)."),
+			"test_exclusions":     patternList("The test files excluded from the analysis (`sonar.test.exclusions`)."),
+			"test_inclusions":     patternList("The only test files included in the analysis (`sonar.test.inclusions`)."),
+			"coverage_exclusions": patternList("The files excluded from code coverage (`sonar.coverage.exclusions`)."),
+			"cpd_exclusions":      patternList("The files excluded from duplication detection (`sonar.cpd.exclusions`)."),
+		},
+	}
+}
+
+func resourceSonarqubeAnalysisExclusionsCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	if err := setSettingAttributes(project, analysisExclusionsSettings, d, m, false); err != nil {
+		return err
+	}
+
+	if project == "" {
+		d.SetId("global")
+	} else {
+		d.SetId(project)
+	}
+
+	return resourceSonarqubeAnalysisExclusionsRead(d, m)
+}
+
+func resourceSonarqubeAnalysisExclusionsRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes(d.Get("project").(string), analysisExclusionsSettings, d, m)
+}
+
+func resourceSonarqubeAnalysisExclusionsUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes(d.Get("project").(string), analysisExclusionsSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeAnalysisExclusionsRead(d, m)
+}
+
+func resourceSonarqubeAnalysisExclusionsDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes(d.Get("project").(string), analysisExclusionsSettings, m)
+}
+
+func resourceSonarqubeAnalysisExclusionsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() != "global" {
+		if err := d.Set("project", d.Id()); err != nil {
+			return nil, err
+		}
+	}
+	if err := resourceSonarqubeAnalysisExclusionsRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
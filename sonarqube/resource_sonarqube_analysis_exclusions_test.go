@@ -0,0 +1,56 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeAnalysisExclusionsConfig(rnd string, projectKey string, exclusions []string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_analysis_exclusions" "%[1]s" {
+			project             = sonarqube_project.%[1]s.project
+			exclusions          = %[3]s
+			coverage_exclusions = ["**/mocks/**"]
+		}`, rnd, projectKey, generateHCLList(exclusions))
+}
+
+func TestAccSonarqubeAnalysisExclusionsBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_analysis_exclusions." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeAnalysisExclusionsConfig(rnd, "testAccSonarqubeAnalysisExclusions", []string{"**/generated/**"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "exclusions.#", "1"),
+					resource.TestCheckResourceAttr(name, "exclusions.0", "**/generated/**"),
+					resource.TestCheckResourceAttr(name, "coverage_exclusions.0", "**/mocks/**"),
+					resource.TestCheckResourceAttr(name, "cpd_exclusions.#", "0"),
+				),
+			},
+			{
+				Config: testAccSonarqubeAnalysisExclusionsConfig(rnd, "testAccSonarqubeAnalysisExclusions", []string{"**/generated/**", "**/*.min.js"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "exclusions.#", "2"),
+					resource.TestCheckResourceAttr(name, "exclusions.1", "**/*.min.js"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
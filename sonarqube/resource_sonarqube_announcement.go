@@ -0,0 +1,75 @@
+package sonarqube
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// announcementSettings maps the attributes of sonarqube_announcement to their setting keys
+var announcementSettings = []settingAttribute{
+	{attribute: "message", key: "sonar.announcement.message"},
+	{attribute: "display", key: "sonar.announcement.displayMessage"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeAnnouncement() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Announcement resource. This can be used to display a banner to all users, for example during
+a maintenance window. Destroying this resource removes the banner.`,
+		Create: resourceSonarqubeAnnouncementCreate,
+		Read:   resourceSonarqubeAnnouncementRead,
+		Update: resourceSonarqubeAnnouncementUpdate,
+		Delete: resourceSonarqubeAnnouncementDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeAnnouncementImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"message": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The text of the banner (`sonar.announcement.message`). It supports Markdown links.",
+			},
+			"display": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the banner is displayed (`sonar.announcement.displayMessage`), to keep the message while hiding it. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeAnnouncementCreate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", announcementSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId("announcement")
+
+	return resourceSonarqubeAnnouncementRead(d, m)
+}
+
+func resourceSonarqubeAnnouncementRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes("", announcementSettings, d, m)
+}
+
+func resourceSonarqubeAnnouncementUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", announcementSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeAnnouncementRead(d, m)
+}
+
+func resourceSonarqubeAnnouncementDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes("", announcementSettings, m)
+}
+
+func resourceSonarqubeAnnouncementImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId("announcement")
+	if err := resourceSonarqubeAnnouncementRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,47 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeAnnouncementConfig(rnd string, message string, display bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_announcement" "%[1]s" {
+			message = "%[2]s"
+			display = %[3]t
+		}`, rnd, message, display)
+}
+
+func TestAccSonarqubeAnnouncementBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_announcement." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeAnnouncementConfig(rnd, "Maintenance tonight from 20:00 to 22:00", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "message", "Maintenance tonight from 20:00 to 22:00"),
+					resource.TestCheckResourceAttr(name, "display", "true"),
+				),
+			},
+			{
+				Config: testAccSonarqubeAnnouncementConfig(rnd, "Maintenance postponed", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "message", "Maintenance postponed"),
+					resource.TestCheckResourceAttr(name, "display", "false"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
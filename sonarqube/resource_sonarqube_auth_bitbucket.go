@@ -0,0 +1,100 @@
+package sonarqube
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// authBitbucketSettings maps the attributes of sonarqube_auth_bitbucket to their setting keys
+var authBitbucketSettings = []settingAttribute{
+	{attribute: "client_id", key: "sonar.auth.bitbucket.clientId.secured", secured: true},
+	{attribute: "client_secret", key: "sonar.auth.bitbucket.clientSecret.secured", secured: true},
+	{attribute: "workspaces", key: "sonar.auth.bitbucket.workspaces"},
+	{attribute: "allow_users_to_sign_up", key: "sonar.auth.bitbucket.allowUsersToSignUp"},
+	{attribute: "enabled", key: "sonar.auth.bitbucket.enabled"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeAuthBitbucket() *schema.Resource {
+	return &schema.Resource{
+		Description: authSettingsDescription(`Provides a Sonarqube Bitbucket Authentication resource. This can be used to manage the `+"`sonar.auth.bitbucket.*`"+` settings
+that configure the authentication of users through a Bitbucket Cloud OAuth consumer.`,
+			"`client_id` and `client_secret`"),
+		Create: resourceSonarqubeAuthBitbucketCreate,
+		Read:   resourceSonarqubeAuthBitbucketRead,
+		Update: resourceSonarqubeAuthBitbucketUpdate,
+		Delete: resourceSonarqubeAuthBitbucketDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeAuthBitbucketImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users can log in with Bitbucket. Defaults to `true`.",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The Key of the Bitbucket OAuth consumer. Write-only.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The Secret of the Bitbucket OAuth consumer. Write-only.",
+			},
+			"workspaces": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Only the members of these Bitbucket workspaces can log in. All Bitbucket users can log in when not set.",
+			},
+			"allow_users_to_sign_up": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users without a SonarQube account are created when they log in with Bitbucket. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeAuthBitbucketCreate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", authBitbucketSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId("auth_bitbucket")
+
+	return resourceSonarqubeAuthBitbucketRead(d, m)
+}
+
+func resourceSonarqubeAuthBitbucketRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes("", authBitbucketSettings, d, m)
+}
+
+func resourceSonarqubeAuthBitbucketUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", authBitbucketSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeAuthBitbucketRead(d, m)
+}
+
+func resourceSonarqubeAuthBitbucketDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes("", authBitbucketSettings, m)
+}
+
+func resourceSonarqubeAuthBitbucketImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId("auth_bitbucket")
+	if err := resourceSonarqubeAuthBitbucketRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,49 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeAuthBitbucketConfig(rnd string, workspace string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_auth_bitbucket" "%[1]s" {
+			enabled       = false
+			client_id     = "testAccClientId"
+			client_secret = "testAccClientSecret"
+			workspaces    = ["%[2]s"]
+		}`, rnd, workspace)
+}
+
+func TestAccSonarqubeAuthBitbucketBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_auth_bitbucket." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeAuthBitbucketConfig(rnd, "my-workspace"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "false"),
+					resource.TestCheckTypeSetElemAttr(name, "workspaces.*", "my-workspace"),
+				),
+			},
+			{
+				Config: testAccSonarqubeAuthBitbucketConfig(rnd, "other-workspace"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(name, "workspaces.*", "other-workspace"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_id", "client_secret"},
+			},
+		},
+	})
+}
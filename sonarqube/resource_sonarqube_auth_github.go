@@ -0,0 +1,137 @@
+package sonarqube
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// authGithubSettings maps the attributes of sonarqube_auth_github to their setting keys
+var authGithubSettings = []settingAttribute{
+	{attribute: "api_url", key: "sonar.auth.github.apiUrl"},
+	{attribute: "web_url", key: "sonar.auth.github.webUrl"},
+	{attribute: "app_id", key: "sonar.auth.github.appId"},
+	{attribute: "private_key", key: "sonar.auth.github.privateKey.secured", secured: true},
+	{attribute: "client_id", key: "sonar.auth.github.clientId.secured", secured: true},
+	{attribute: "client_secret", key: "sonar.auth.github.clientSecret.secured", secured: true},
+	{attribute: "organizations", key: "sonar.auth.github.organizations"},
+	{attribute: "allow_users_to_sign_up", key: "sonar.auth.github.allowUsersToSignUp"},
+	{attribute: "groups_sync", key: "sonar.auth.github.groupsSync"},
+	{attribute: "enabled", key: "sonar.auth.github.enabled"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeAuthGithub() *schema.Resource {
+	return &schema.Resource{
+		Description: authSettingsDescription(`Provides a Sonarqube GitHub Authentication resource. This can be used to manage the `+"`sonar.auth.github.*`"+` settings
+that configure the authentication of users through a GitHub App and the synchronization of their GitHub teams.`,
+			"`client_id`, `client_secret` and `private_key`"),
+		Create: resourceSonarqubeAuthGithubCreate,
+		Read:   resourceSonarqubeAuthGithubRead,
+		Update: resourceSonarqubeAuthGithubUpdate,
+		Delete: resourceSonarqubeAuthGithubDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeAuthGithubImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users can log in with GitHub. Defaults to `true`.",
+			},
+			"api_url": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "https://api.github.com/",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "The API URL of the GitHub instance, for example `https://github.company.com/api/v3/` for GitHub Enterprise. Defaults to `https://api.github.com/`.",
+			},
+			"web_url": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "https://github.com/",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "The web URL of the GitHub instance. Defaults to `https://github.com/`.",
+			},
+			"app_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The App ID of the GitHub App, required by SonarQube 10 and later.",
+			},
+			"private_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The private key of the GitHub App in PEM format, required by SonarQube 10 and later. Write-only.",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The Client ID of the GitHub App. Write-only.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The Client Secret of the GitHub App. Write-only.",
+			},
+			"organizations": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Only the members of these GitHub organizations can log in. All GitHub users can log in when not set.",
+			},
+			"allow_users_to_sign_up": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users without a SonarQube account are created when they log in with GitHub. Defaults to `true`.",
+			},
+			"groups_sync": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the GitHub teams of the users are synchronized with the SonarQube groups of the same name at each login. Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeAuthGithubCreate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", authGithubSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId("auth_github")
+
+	return resourceSonarqubeAuthGithubRead(d, m)
+}
+
+func resourceSonarqubeAuthGithubRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes("", authGithubSettings, d, m)
+}
+
+func resourceSonarqubeAuthGithubUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", authGithubSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeAuthGithubRead(d, m)
+}
+
+func resourceSonarqubeAuthGithubDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes("", authGithubSettings, m)
+}
+
+func resourceSonarqubeAuthGithubImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId("auth_github")
+	if err := resourceSonarqubeAuthGithubRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
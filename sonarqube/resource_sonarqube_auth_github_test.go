@@ -0,0 +1,54 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeAuthGithubConfig(rnd string, organization string, allowUsersToSignUp bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_auth_github" "%[1]s" {
+			enabled                = false
+			app_id                 = "12345"
+			client_id              = "testAccClientId"
+			client_secret          = "testAccClientSecret"
+			organizations          = ["%[2]s"]
+			allow_users_to_sign_up = %[3]t
+		}`, rnd, organization, allowUsersToSignUp)
+}
+
+func TestAccSonarqubeAuthGithubBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_auth_github." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeAuthGithubConfig(rnd, "my-org", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "false"),
+					resource.TestCheckResourceAttr(name, "app_id", "12345"),
+					resource.TestCheckResourceAttr(name, "organizations.#", "1"),
+					resource.TestCheckResourceAttr(name, "allow_users_to_sign_up", "true"),
+				),
+			},
+			{
+				Config: testAccSonarqubeAuthGithubConfig(rnd, "other-org", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(name, "organizations.*", "other-org"),
+					resource.TestCheckResourceAttr(name, "allow_users_to_sign_up", "false"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_id", "client_secret", "private_key"},
+			},
+		},
+	})
+}
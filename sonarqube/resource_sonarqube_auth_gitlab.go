@@ -0,0 +1,116 @@
+package sonarqube
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// authGitlabSettings maps the attributes of sonarqube_auth_gitlab to their setting keys
+var authGitlabSettings = []settingAttribute{
+	{attribute: "url", key: "sonar.auth.gitlab.url"},
+	{attribute: "application_id", key: "sonar.auth.gitlab.applicationId.secured", secured: true},
+	{attribute: "secret", key: "sonar.auth.gitlab.secret.secured", secured: true},
+	{attribute: "allow_users_to_sign_up", key: "sonar.auth.gitlab.allowUsersToSignUp"},
+	{attribute: "allowed_groups", key: "sonar.auth.gitlab.allowedGroups"},
+	{attribute: "groups_sync", key: "sonar.auth.gitlab.groupsSync"},
+	{attribute: "enabled", key: "sonar.auth.gitlab.enabled"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeAuthGitlab() *schema.Resource {
+	return &schema.Resource{
+		Description: authSettingsDescription(`Provides a Sonarqube GitLab Authentication resource. This can be used to manage the `+"`sonar.auth.gitlab.*`"+` settings
+that configure the authentication of users through a GitLab OAuth application and the synchronization of their GitLab groups.`,
+			"`application_id` and `secret`"),
+		Create: resourceSonarqubeAuthGitlabCreate,
+		Read:   resourceSonarqubeAuthGitlabRead,
+		Update: resourceSonarqubeAuthGitlabUpdate,
+		Delete: resourceSonarqubeAuthGitlabDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeAuthGitlabImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users can log in with GitLab. Defaults to `true`.",
+			},
+			"url": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "https://gitlab.com",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPorHTTPS),
+				Description:      "The URL of the GitLab instance. Defaults to `https://gitlab.com`.",
+			},
+			"application_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The Application ID of the GitLab OAuth application.",
+			},
+			"secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The Secret of the GitLab OAuth application.",
+			},
+			"allow_users_to_sign_up": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users without a SonarQube account are created when they log in with GitLab. Defaults to `true`.",
+			},
+			"allowed_groups": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Only the members of these GitLab groups, or of their subgroups, can log in. All GitLab users can log in when not set.",
+			},
+			"groups_sync": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the GitLab groups of the users are synchronized with the SonarQube groups of the same name at each login. Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeAuthGitlabCreate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", authGitlabSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId("auth_gitlab")
+
+	return resourceSonarqubeAuthGitlabRead(d, m)
+}
+
+func resourceSonarqubeAuthGitlabRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes("", authGitlabSettings, d, m)
+}
+
+func resourceSonarqubeAuthGitlabUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", authGitlabSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeAuthGitlabRead(d, m)
+}
+
+func resourceSonarqubeAuthGitlabDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes("", authGitlabSettings, m)
+}
+
+func resourceSonarqubeAuthGitlabImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId("auth_gitlab")
+	if err := resourceSonarqubeAuthGitlabRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
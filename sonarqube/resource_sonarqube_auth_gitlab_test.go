@@ -0,0 +1,54 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeAuthGitlabConfig(rnd string, enabled bool, groupsSync bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_auth_gitlab" "%[1]s" {
+			enabled        = %[2]t
+			url            = "https://gitlab.example.com"
+			application_id = "testAccApplicationId"
+			secret         = "testAccSecret"
+			allowed_groups = ["platform", "security"]
+			groups_sync    = %[3]t
+		}`, rnd, enabled, groupsSync)
+}
+
+func TestAccSonarqubeAuthGitlabBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_auth_gitlab." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeAuthGitlabConfig(rnd, false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "false"),
+					resource.TestCheckResourceAttr(name, "url", "https://gitlab.example.com"),
+					resource.TestCheckResourceAttr(name, "allowed_groups.#", "2"),
+					resource.TestCheckResourceAttr(name, "groups_sync", "true"),
+				),
+			},
+			{
+				Config: testAccSonarqubeAuthGitlabConfig(rnd, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+					resource.TestCheckResourceAttr(name, "groups_sync", "false"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"application_id", "secret"},
+			},
+		},
+	})
+}
@@ -0,0 +1,135 @@
+package sonarqube
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// authOidcSettings maps the attributes of sonarqube_auth_oidc to the setting keys of the OpenID Connect plugin
+var authOidcSettings = []settingAttribute{
+	{attribute: "issuer_uri", key: "sonar.auth.oidc.issuerUri"},
+	{attribute: "client_id", key: "sonar.auth.oidc.clientId.secured", secured: true},
+	{attribute: "client_secret", key: "sonar.auth.oidc.clientSecret.secured", secured: true},
+	{attribute: "scopes", key: "sonar.auth.oidc.scopes"},
+	{attribute: "login_strategy", key: "sonar.auth.oidc.loginStrategy"},
+	{attribute: "login_button_text", key: "sonar.auth.oidc.loginButtonText"},
+	{attribute: "allow_users_to_sign_up", key: "sonar.auth.oidc.allowUsersToSignUp"},
+	{attribute: "groups_sync", key: "sonar.auth.oidc.groupsSync"},
+	{attribute: "groups_sync_claim_name", key: "sonar.auth.oidc.groupsSync.claimName"},
+	{attribute: "enabled", key: "sonar.auth.oidc.enabled"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeAuthOidc() *schema.Resource {
+	return &schema.Resource{
+		Description: authSettingsDescription(`Provides a Sonarqube OpenID Connect Authentication resource. This can be used to manage the `+"`sonar.auth.oidc.*`"+` settings
+of the OpenID Connect authentication plugin, which must be installed, for example to log in with Azure AD (Microsoft Entra ID), Keycloak or Okta.`,
+			"`client_id` and `client_secret`"),
+		Create: resourceSonarqubeAuthOidcCreate,
+		Read:   resourceSonarqubeAuthOidcRead,
+		Update: resourceSonarqubeAuthOidcUpdate,
+		Delete: resourceSonarqubeAuthOidcDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeAuthOidcImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users can log in with the OpenID Connect provider. Defaults to `true`.",
+			},
+			"issuer_uri": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithHTTPS),
+				Description:      "The issuer URI of the OpenID Connect provider, for example `https://login.microsoftonline.com/{tenant_id}/v2.0` for Azure AD.",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The ID of the client registered in the OpenID Connect provider. Write-only.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The secret of the client registered in the OpenID Connect provider. Write-only.",
+			},
+			"scopes": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "openid email profile",
+				Description: "The space separated scopes requested from the OpenID Connect provider. Defaults to `openid email profile`.",
+			},
+			"login_strategy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "Preferred username",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"Preferred username", "Email", "Unique", "Same as OpenID Connect login"}, false)),
+				Description:      "How the SonarQube login is derived from the claims of the user. Possible values are `Preferred username`, `Email`, `Unique` and `Same as OpenID Connect login`. Defaults to `Preferred username`.",
+			},
+			"login_button_text": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "OpenID Connect",
+				Description: "The text of the login button. Defaults to `OpenID Connect`.",
+			},
+			"allow_users_to_sign_up": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether users without a SonarQube account are created when they log in. Defaults to `true`.",
+			},
+			"groups_sync": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the groups of the users are synchronized with the SonarQube groups of the same name at each login. Defaults to `false`.",
+			},
+			"groups_sync_claim_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "groups",
+				Description: "The name of the claim that holds the groups of the user. Defaults to `groups`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeAuthOidcCreate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", authOidcSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId("auth_oidc")
+
+	return resourceSonarqubeAuthOidcRead(d, m)
+}
+
+func resourceSonarqubeAuthOidcRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes("", authOidcSettings, d, m)
+}
+
+func resourceSonarqubeAuthOidcUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", authOidcSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeAuthOidcRead(d, m)
+}
+
+func resourceSonarqubeAuthOidcDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes("", authOidcSettings, m)
+}
+
+func resourceSonarqubeAuthOidcImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId("auth_oidc")
+	if err := resourceSonarqubeAuthOidcRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
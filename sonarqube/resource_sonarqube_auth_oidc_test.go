@@ -0,0 +1,53 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeAuthOidcConfig(rnd string, loginStrategy string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_auth_oidc" "%[1]s" {
+			enabled        = false
+			issuer_uri     = "https://login.microsoftonline.com/00000000-0000-0000-0000-000000000000/v2.0"
+			client_id      = "testAccClientId"
+			client_secret  = "testAccClientSecret"
+			login_strategy = "%[2]s"
+			groups_sync    = true
+		}`, rnd, loginStrategy)
+}
+
+func TestAccSonarqubeAuthOidcBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_auth_oidc." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeAuthOidcConfig(rnd, "Preferred username"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "false"),
+					resource.TestCheckResourceAttr(name, "login_strategy", "Preferred username"),
+					resource.TestCheckResourceAttr(name, "groups_sync", "true"),
+					resource.TestCheckResourceAttr(name, "groups_sync_claim_name", "groups"),
+				),
+			},
+			{
+				Config: testAccSonarqubeAuthOidcConfig(rnd, "Email"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "login_strategy", "Email"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_id", "client_secret"},
+			},
+		},
+	})
+}
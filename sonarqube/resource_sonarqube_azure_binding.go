@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -26,15 +27,21 @@ type GetAzureBinding struct {
 func resourceSonarqubeAzureBinding() *schema.Resource {
 	return &schema.Resource{
 		Description: `Provides a Sonarqube Azure Devops binding resource. This can be used to create and manage the binding between an
-Azure Devops repository and a SonarQube project`,
+Azure Devops repository and a SonarQube project. It supports importing using the format '{project}/{project_name}/{repository_name}'.`,
 		Create: resourceSonarqubeAzureBindingCreate,
 		Read:   resourceSonarqubeAzureBindingRead,
+		// Only wait_for_first_analysis can be updated, which does not require an API call
+		Update: resourceSonarqubeAzureBindingRead,
 		Delete: resourceSonarqubeAzureBindingDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeAzureBindingImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
+			"wait_for_first_analysis": waitForFirstAnalysisSchema(),
 			"alm_setting": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -113,6 +120,12 @@ func resourceSonarqubeAzureBindingCreate(d *schema.ResourceData, m interface{})
 	)
 	d.SetId(id)
 
+	if d.Get("wait_for_first_analysis").(bool) && d.IsNewResource() {
+		if err := waitForFirstAnalysis(d.Get("project").(string), d.Timeout(schema.TimeoutCreate), m); err != nil {
+			return err
+		}
+	}
+
 	return resourceSonarqubeAzureBindingRead(d, m)
 }
 
@@ -121,12 +134,14 @@ func resourceSonarqubeAzureBindingRead(d *schema.ResourceData, m interface{}) er
 		return err
 	}
 
-	// id consists of "project/project_name/repository"
-	idSlice := strings.SplitN(d.Id(), "/", 3)
+	parts, err := parseImportID(d.Id(), "{project}/{project_name}/{repository_name}")
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeAzureBindingRead: %+v", err)
+	}
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_settings/get_binding"
 	sonarQubeURL.RawQuery = url.Values{
-		"project": []string{idSlice[0]},
+		"project": []string{parts[0]},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -148,13 +163,13 @@ func resourceSonarqubeAzureBindingRead(d *schema.ResourceData, m interface{}) er
 		return fmt.Errorf("resourceSonarqubeAzureBindingRead: Failed to decode json into struct: %+v", err)
 	}
 
-	if idSlice[1] == BindingReadResponse.Slug &&
-		idSlice[2] == BindingReadResponse.Repository &&
+	if parts[1] == BindingReadResponse.Slug &&
+		parts[2] == BindingReadResponse.Repository &&
 		BindingReadResponse.Alm == "azure" {
 		errs := []error{}
-		errs = append(errs, d.Set("project", idSlice[0]))
-		errs = append(errs, d.Set("project_name", idSlice[1]))
-		errs = append(errs, d.Set("repository_name", idSlice[2]))
+		errs = append(errs, d.Set("project", parts[0]))
+		errs = append(errs, d.Set("project_name", parts[1]))
+		errs = append(errs, d.Set("repository_name", parts[2]))
 		errs = append(errs, d.Set("alm_setting", BindingReadResponse.Key))
 		errs = append(errs, d.Set("monorepo", BindingReadResponse.Monorepo))
 
@@ -190,6 +205,9 @@ func resourceSonarqubeAzureBindingDelete(d *schema.ResourceData, m interface{})
 }
 
 func resourceSonarqubeAzureBindingImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseImportID(d.Id(), "{project}/{project_name}/{repository_name}"); err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeAzureBindingImport: %+v", err)
+	}
 	if err := resourceSonarqubeAzureBindingRead(d, m); err != nil {
 		return nil, err
 	}
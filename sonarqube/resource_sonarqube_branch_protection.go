@@ -0,0 +1,91 @@
+package sonarqube
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// branchProtectionSettings maps the attributes of sonarqube_branch_protection to their setting keys
+var branchProtectionSettings = []settingAttribute{
+	{attribute: "force_authentication", key: "sonar.forceAuthentication"},
+	{attribute: "main_branch_name", key: "sonar.projectCreation.mainBranchName"},
+	{attribute: "branches_to_keep", key: "sonar.dbcleaner.branchesToKeepWhenInactive"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeBranchProtection() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Branch Protection resource. This can be used to centrally manage the instance-wide settings that
+protect analyses from misconfigured scanners: anonymous analyses are refused, new projects get a known main branch and long-lived branches are never purged.
+SonarQube has no setting restricting analyses by token type, grant the ` + "`scan`" + ` permission only to the groups and tokens that must analyze instead.
+Destroying this resource resets the settings to their default value.`,
+		Create: resourceSonarqubeBranchProtectionCreate,
+		Read:   resourceSonarqubeBranchProtectionRead,
+		Update: resourceSonarqubeBranchProtectionUpdate,
+		Delete: resourceSonarqubeBranchProtectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeBranchProtectionImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"force_authentication": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether scanners and users must authenticate (`sonar.forceAuthentication`), which refuses anonymous analyses. Defaults to `true`.",
+			},
+			"main_branch_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "main",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsNotWhiteSpace),
+				Description:      "The name of the main branch of the projects created without one (`sonar.projectCreation.mainBranchName`). Defaults to `main`.",
+			},
+			"branches_to_keep": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				},
+				Description: "The regular expressions of the branches that are never deleted when inactive (`sonar.dbcleaner.branchesToKeepWhenInactive`). SonarQube keeps `main`, `master`, `develop` and `trunk` when not set.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeBranchProtectionCreate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", branchProtectionSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId("branch_protection")
+
+	return resourceSonarqubeBranchProtectionRead(d, m)
+}
+
+func resourceSonarqubeBranchProtectionRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes("", branchProtectionSettings, d, m)
+}
+
+func resourceSonarqubeBranchProtectionUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", branchProtectionSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeBranchProtectionRead(d, m)
+}
+
+func resourceSonarqubeBranchProtectionDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes("", branchProtectionSettings, m)
+}
+
+func resourceSonarqubeBranchProtectionImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId("branch_protection")
+	if err := resourceSonarqubeBranchProtectionRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
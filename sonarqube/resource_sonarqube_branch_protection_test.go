@@ -0,0 +1,49 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeBranchProtectionConfig(rnd string, mainBranchName string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_branch_protection" "%[1]s" {
+			force_authentication = true
+			main_branch_name     = "%[2]s"
+			branches_to_keep     = ["%[2]s", "release/.*"]
+		}`, rnd, mainBranchName)
+}
+
+func TestAccSonarqubeBranchProtectionBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_branch_protection." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeBranchProtectionConfig(rnd, "main"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "force_authentication", "true"),
+					resource.TestCheckResourceAttr(name, "main_branch_name", "main"),
+					resource.TestCheckTypeSetElemAttr(name, "branches_to_keep.*", "release/.*"),
+				),
+			},
+			{
+				Config: testAccSonarqubeBranchProtectionConfig(rnd, "trunk"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "main_branch_name", "trunk"),
+					resource.TestCheckTypeSetElemAttr(name, "branches_to_keep.*", "trunk"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
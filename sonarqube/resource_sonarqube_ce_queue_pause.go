@@ -0,0 +1,156 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// CeInfo for unmarshalling response body of api/ce/info
+type CeInfo struct {
+	WorkersPauseStatus string `json:"workersPauseStatus"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeCeQueuePause() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Compute Engine Queue Pause resource. This pauses the processing of the background tasks queue
+while the resource exists, for example during an upgrade or a database maintenance: new analyses are still queued but not processed.
+Destroying this resource resumes the queue. Use ` + "`depends_on`" + ` and the ` + "`lifecycle`" + ` meta-argument to order it with the
+resources of the maintenance. When the queue is resumed outside of Terraform, the resource is removed from the state and paused again on the next apply.`,
+		Create: resourceSonarqubeCeQueuePauseCreate,
+		Read:   resourceSonarqubeCeQueuePauseRead,
+		Delete: resourceSonarqubeCeQueuePauseDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeCeQueuePauseImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"wait_for_paused": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether to wait until the background tasks in progress have finished and the queue is paused. The wait is limited by the `create` timeout, which defaults to 30 minutes. Defaults to `true`. Changing this forces a new resource to be created.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The pause status of the Compute Engine workers: `PAUSING` while tasks in progress finish, then `PAUSED`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeCeQueuePauseCreate(d *schema.ResourceData, m interface{}) error {
+	if err := changeCeQueueState("pause", m); err != nil {
+		return err
+	}
+
+	d.SetId("ce_queue_pause")
+
+	if d.Get("wait_for_paused").(bool) {
+		err := retry.RetryContext(context.Background(), d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+			info, err := readCeInfoFromApi(m)
+			if err != nil {
+				return retry.NonRetryableError(err)
+			}
+			if info.WorkersPauseStatus != "PAUSED" {
+				log.Printf("[DEBUG][resourceSonarqubeCeQueuePauseCreate] Compute Engine workers are %s", info.WorkersPauseStatus)
+				return retry.RetryableError(fmt.Errorf("resourceSonarqubeCeQueuePauseCreate: the Compute Engine workers are %s", info.WorkersPauseStatus))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceSonarqubeCeQueuePauseRead(d, m)
+}
+
+func resourceSonarqubeCeQueuePauseRead(d *schema.ResourceData, m interface{}) error {
+	info, err := readCeInfoFromApi(m)
+	if err != nil {
+		return err
+	}
+	if info.WorkersPauseStatus == "RESUMED" {
+		log.Printf("[WARN][resourceSonarqubeCeQueuePauseRead] The Compute Engine queue was resumed, removing it from the state")
+		d.SetId("")
+		return nil
+	}
+
+	return d.Set("status", info.WorkersPauseStatus)
+}
+
+func resourceSonarqubeCeQueuePauseDelete(d *schema.ResourceData, m interface{}) error {
+	return changeCeQueueState("resume", m)
+}
+
+func resourceSonarqubeCeQueuePauseImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId("ce_queue_pause")
+	if err := resourceSonarqubeCeQueuePauseRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeCeQueuePauseImport: the Compute Engine queue is not paused")
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// changeCeQueueState pauses or resumes the Compute Engine workers
+func changeCeQueueState(action string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/ce/" + action
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"changeCeQueueState",
+	)
+	if err != nil {
+		return fmt.Errorf("changeCeQueueState: Failed to %s the Compute Engine queue: %+v", action, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func readCeInfoFromApi(m interface{}) (*CeInfo, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/ce/info"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readCeInfoFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readCeInfoFromApi: Failed to read the status of the Compute Engine: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	info := CeInfo{}
+	err = json.NewDecoder(resp.Body).Decode(&info)
+	if err != nil {
+		return nil, fmt.Errorf("readCeInfoFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &info, nil
+}
@@ -0,0 +1,46 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSonarqubeCeQueuePauseBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_ce_queue_pause." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckSonarqubeCeQueueResumed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "sonarqube_ce_queue_pause" "%[1]s" {}`, rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "status", "PAUSED"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_paused"},
+			},
+		},
+	})
+}
+
+func testAccCheckSonarqubeCeQueueResumed(s *terraform.State) error {
+	info, err := readCeInfoFromApi(testAccProvider.Meta())
+	if err != nil {
+		return err
+	}
+	if info.WorkersPauseStatus != "RESUMED" {
+		return fmt.Errorf("the Compute Engine queue is %s, expected it to be resumed", info.WorkersPauseStatus)
+	}
+	return nil
+}
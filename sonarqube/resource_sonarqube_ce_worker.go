@@ -0,0 +1,168 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// CeWorkerCount for unmarshalling response body of api/ce/worker_count
+type CeWorkerCount struct {
+	Value             int  `json:"value"`
+	CanSetWorkerCount bool `json:"canSetWorkerCount"`
+}
+
+// ceWorkerSettings maps the attributes of sonarqube_ce_worker to their setting keys
+var ceWorkerSettings = []settingAttribute{
+	{attribute: "parallel_project_tasks", key: "sonar.ce.parallelProjectTasks"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeCeWorker() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Compute Engine Worker resource. This can be used to manage the number of Compute Engine workers
+and the parallel processing of project tasks in the Enterprise and Data Center editions of SonarQube. Destroying this resource restores
+a single worker and the default settings.`,
+		Create: resourceSonarqubeCeWorkerCreate,
+		Read:   resourceSonarqubeCeWorkerRead,
+		Update: resourceSonarqubeCeWorkerUpdate,
+		Delete: resourceSonarqubeCeWorkerDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeCeWorkerImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"worker_count": {
+				Type:             schema.TypeInt,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 10)),
+				Description:      "The number of Compute Engine workers, between 1 and 10. With the Data Center edition this is the number of workers per application node.",
+			},
+			"parallel_project_tasks": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether background tasks of different branches and pull requests of the same project can be processed in parallel (`sonar.ce.parallelProjectTasks`). Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func checkCeWorkerSupport(conf *ProviderConfiguration) error {
+	edition := strings.ToLower(conf.sonarQubeEdition)
+	if edition != "enterprise" && edition != "data center" {
+		return fmt.Errorf("configuring Compute Engine workers is only supported in the Enterprise and Datacenter editions of SonarQube. You are using: SonarQube %s version %s", conf.sonarQubeEdition, conf.sonarQubeVersion)
+	}
+	return nil
+}
+
+func resourceSonarqubeCeWorkerCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkCeWorkerSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	if err := setCeWorkerCount(d.Get("worker_count").(int), m); err != nil {
+		return err
+	}
+	if err := setSettingAttributes("", ceWorkerSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId("ce_worker")
+
+	return resourceSonarqubeCeWorkerRead(d, m)
+}
+
+func resourceSonarqubeCeWorkerRead(d *schema.ResourceData, m interface{}) error {
+	if err := checkCeWorkerSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/ce/worker_count"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeCeWorkerRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	workerCount := CeWorkerCount{}
+	err = json.NewDecoder(resp.Body).Decode(&workerCount)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeCeWorkerRead: Failed to decode json into struct: %+v", err)
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("worker_count", workerCount.Value))
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	return readSettingAttributes("", ceWorkerSettings, d, m)
+}
+
+func resourceSonarqubeCeWorkerUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("worker_count") {
+		if err := setCeWorkerCount(d.Get("worker_count").(int), m); err != nil {
+			return err
+		}
+	}
+	if err := setSettingAttributes("", ceWorkerSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeCeWorkerRead(d, m)
+}
+
+func resourceSonarqubeCeWorkerDelete(d *schema.ResourceData, m interface{}) error {
+	if err := setCeWorkerCount(1, m); err != nil {
+		return err
+	}
+	return resetSettingAttributes("", ceWorkerSettings, m)
+}
+
+func resourceSonarqubeCeWorkerImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId("ce_worker")
+	if err := resourceSonarqubeCeWorkerRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func setCeWorkerCount(count int, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/ce/set_worker_count"
+	sonarQubeURL.RawQuery = url.Values{
+		"count": []string{strconv.Itoa(count)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"setCeWorkerCount",
+	)
+	if err != nil {
+		return fmt.Errorf("error setting the Compute Engine worker count: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccPreCheckCeWorkerSupport(t *testing.T) {
+	if err := checkCeWorkerSupport(testAccProviderConfiguration(t)); err != nil {
+		t.Skipf("Skipping test of unsupported feature (Compute Engine workers)")
+	}
+}
+
+func testAccSonarqubeCeWorkerConfig(rnd string, workerCount int, parallelProjectTasks bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_ce_worker" "%[1]s" {
+			worker_count           = %[2]d
+			parallel_project_tasks = %[3]t
+		}`, rnd, workerCount, parallelProjectTasks)
+}
+
+func TestAccSonarqubeCeWorkerBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_ce_worker." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckCeWorkerSupport(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeCeWorkerConfig(rnd, 2, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "worker_count", "2"),
+					resource.TestCheckResourceAttr(name, "parallel_project_tasks", "false"),
+				),
+			},
+			{
+				Config: testAccSonarqubeCeWorkerConfig(rnd, 4, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "worker_count", "4"),
+					resource.TestCheckResourceAttr(name, "parallel_project_tasks", "true"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
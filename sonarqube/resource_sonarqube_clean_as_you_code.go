@@ -0,0 +1,168 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// cleanAsYouCodeSettings maps the attributes of sonarqube_clean_as_you_code to their setting keys
+var cleanAsYouCodeSettings = []settingAttribute{
+	{attribute: "ignore_small_changes", key: "sonar.qualitygate.ignoreSmallChanges"},
+}
+
+// regexNumberOfDays matches the value of a NUMBER_OF_DAYS new code definition
+var regexNumberOfDays = regexp.MustCompile(`^\d+$`)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeCleanAsYouCode() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Clean as You Code resource. This can be used to manage the instance-wide settings
+that support the Clean as You Code methodology: the default new code definition and the quality gate behaviour on small changes.
+Destroying this resource restores the SonarQube defaults.`,
+		Create: resourceSonarqubeCleanAsYouCodeCreate,
+		Read:   resourceSonarqubeCleanAsYouCodeRead,
+		Update: resourceSonarqubeCleanAsYouCodeUpdate,
+		Delete: resourceSonarqubeCleanAsYouCodeDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeCleanAsYouCodeImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"new_code_period_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          string(PreviousVersion),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{string(PreviousVersion), string(NumberOfDays)}, false)),
+				Description:      "The default new code definition for all projects. Supported values are `PREVIOUS_VERSION` and `NUMBER_OF_DAYS`. Defaults to `PREVIOUS_VERSION`.",
+			},
+			"new_code_period_value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The number of days of the default new code definition. Must be set when `new_code_period_type` is `NUMBER_OF_DAYS` and unset otherwise.",
+			},
+			"ignore_small_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether duplication and coverage conditions are ignored on new code with fewer than 20 lines, as recommended by Clean as You Code. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeCleanAsYouCodeCreate(d *schema.ResourceData, m interface{}) error {
+	if err := setDefaultNewCodePeriod(d.Get("new_code_period_type").(string), d.Get("new_code_period_value").(string), m); err != nil {
+		return err
+	}
+	if err := setSettingAttributes("", cleanAsYouCodeSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId("clean_as_you_code")
+
+	return resourceSonarqubeCleanAsYouCodeRead(d, m)
+}
+
+func resourceSonarqubeCleanAsYouCodeRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/new_code_periods/show"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeCleanAsYouCodeRead",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	newCodePeriod := NewCodePeriod{}
+	err = json.NewDecoder(resp.Body).Decode(&newCodePeriod)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeCleanAsYouCodeRead: Failed to decode json into struct: %+v", err)
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("new_code_period_type", newCodePeriod.Type))
+	errs = append(errs, d.Set("new_code_period_value", newCodePeriod.Value))
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	return readSettingAttributes("", cleanAsYouCodeSettings, d, m)
+}
+
+func resourceSonarqubeCleanAsYouCodeUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChanges("new_code_period_type", "new_code_period_value") {
+		if err := setDefaultNewCodePeriod(d.Get("new_code_period_type").(string), d.Get("new_code_period_value").(string), m); err != nil {
+			return err
+		}
+	}
+	if err := setSettingAttributes("", cleanAsYouCodeSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeCleanAsYouCodeRead(d, m)
+}
+
+func resourceSonarqubeCleanAsYouCodeDelete(d *schema.ResourceData, m interface{}) error {
+	if err := setDefaultNewCodePeriod(string(PreviousVersion), "", m); err != nil {
+		return err
+	}
+	return resetSettingAttributes("", cleanAsYouCodeSettings, m)
+}
+
+func resourceSonarqubeCleanAsYouCodeImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId("clean_as_you_code")
+	if err := resourceSonarqubeCleanAsYouCodeRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// setDefaultNewCodePeriod sets the instance-wide new code definition
+func setDefaultNewCodePeriod(periodType string, value string, m interface{}) error {
+	if NewCodePeriodType(periodType) == PreviousVersion && value != "" {
+		return fmt.Errorf("setDefaultNewCodePeriod: 'new_code_period_value' must be unset when the 'new_code_period_type' is %s", periodType)
+	}
+	if NewCodePeriodType(periodType) == NumberOfDays && !regexNumberOfDays.MatchString(value) {
+		return fmt.Errorf("setDefaultNewCodePeriod: 'new_code_period_value' must be a numeric string when the 'new_code_period_type' is %s", periodType)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/new_code_periods/set"
+	rawQuery := url.Values{
+		"type": []string{periodType},
+	}
+	if value != "" {
+		rawQuery.Add("value", value)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"setDefaultNewCodePeriod",
+	)
+	if err != nil {
+		return fmt.Errorf("error setting the default new code period: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeCleanAsYouCodeConfig(rnd string, periodType string, periodValue string, ignoreSmallChanges bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_clean_as_you_code" "%[1]s" {
+			new_code_period_type  = "%[2]s"
+			new_code_period_value = "%[3]s"
+			ignore_small_changes  = %[4]t
+		}`, rnd, periodType, periodValue, ignoreSmallChanges)
+}
+
+func TestAccSonarqubeCleanAsYouCodeBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_clean_as_you_code." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeCleanAsYouCodeConfig(rnd, "NUMBER_OF_DAYS", "30", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "new_code_period_type", "NUMBER_OF_DAYS"),
+					resource.TestCheckResourceAttr(name, "new_code_period_value", "30"),
+					resource.TestCheckResourceAttr(name, "ignore_small_changes", "false"),
+				),
+			},
+			{
+				Config: testAccSonarqubeCleanAsYouCodeConfig(rnd, "PREVIOUS_VERSION", "", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "new_code_period_type", "PREVIOUS_VERSION"),
+					resource.TestCheckResourceAttr(name, "new_code_period_value", ""),
+					resource.TestCheckResourceAttr(name, "ignore_small_changes", "true"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
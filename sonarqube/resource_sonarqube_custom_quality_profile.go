@@ -0,0 +1,381 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeCustomQualityProfile() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Custom Quality Profile resource. This creates a quality profile and converges its active rules
+to exactly the listed rules: the missing rules are activated, the rules with another severity or parameter value are activated again,
+and the other rules are deactivated, including the rules activated outside of Terraform. The list of rules can be kept in a JSON
+file and decoded with ` + "`jsondecode`" + `, instead of restoring an XML backup. It supports importing using the format '{language}/{name}',
+which imports the active rules without their parameters.`,
+		Create: resourceSonarqubeCustomQualityProfileCreate,
+		Read:   resourceSonarqubeCustomQualityProfileRead,
+		Update: resourceSonarqubeCustomQualityProfileUpdate,
+		Delete: resourceSonarqubeCustomQualityProfileDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeCustomQualityProfileImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Quality Profile to create. Maximum length 100. Changing this forces a new resource to be created.",
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringLenBetween(0, 100),
+				),
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Quality profile language, for example \"java\" or \"py\". Changing this forces a new resource to be created.",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key of the Quality Profile.",
+			},
+			"rule": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The key of the rule, for example `java:S107`.",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The severity of the rule in the profile. Possible values are: INFO, MINOR, MAJOR, CRITICAL, BLOCKER.",
+							ValidateDiagFunc: validation.ToDiagFunc(
+								validation.StringInSlice([]string{"INFO", "MINOR", "MAJOR", "CRITICAL", "BLOCKER"}, false),
+							),
+						},
+						"params": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The parameter values of the rule in the profile. The parameters that are not listed keep their default value and are not checked for drift.",
+						},
+					},
+				},
+				Description: "The rules the profile activates. Every other rule is deactivated.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeCustomQualityProfileCreate(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/create"
+	sonarQubeURL.RawQuery = url.Values{
+		"name":     []string{d.Get("name").(string)},
+		"language": []string{d.Get("language").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeCustomQualityProfileCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeCustomQualityProfileCreate: Failed to create quality profile '%s': %+v", d.Get("name").(string), err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	qualityProfileResponse := CreateQualityProfileResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&qualityProfileResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeCustomQualityProfileCreate: Failed to decode json into struct: %+v", err)
+	}
+	d.SetId(qualityProfileResponse.Profile.Key)
+
+	if err := applyCustomQualityProfileRules(d, m); err != nil {
+		return errors.Join(fmt.Errorf("resourceSonarqubeCustomQualityProfileCreate: %+v", err), resourceSonarqubeCustomQualityProfileRead(d, m))
+	}
+
+	return resourceSonarqubeCustomQualityProfileRead(d, m)
+}
+
+func resourceSonarqubeCustomQualityProfileRead(d *schema.ResourceData, m interface{}) error {
+	profile, err := findQualityProfileByKeyFromApi(d.Id(), m)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		log.Printf("[WARN][resourceSonarqubeCustomQualityProfileRead] Quality profile '%s' not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	rules, actives, err := searchActiveRulesFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeCustomQualityProfileRead: %+v", err)
+	}
+
+	// Only the parameters of the configuration are read, the others have their default value
+	configured := expandCustomQualityProfileRules(d.Get("rule"))
+	activeRules := []interface{}{}
+	for _, rule := range rules {
+		for _, active := range actives[rule.RuleKey] {
+			if active.QProfile != d.Id() {
+				continue
+			}
+			params := map[string]interface{}{}
+			for _, param := range active.Params {
+				if _, ok := configured[rule.RuleKey].params[param.Key]; ok {
+					params[param.Key] = param.Value
+				}
+			}
+			activeRules = append(activeRules, map[string]interface{}{
+				"key":      rule.RuleKey,
+				"severity": active.Severity,
+				"params":   params,
+			})
+		}
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("name", profile.Name))
+	errs = append(errs, d.Set("language", profile.Language))
+	errs = append(errs, d.Set("key", profile.Key))
+	errs = append(errs, d.Set("rule", activeRules))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeCustomQualityProfileUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := applyCustomQualityProfileRules(d, m); err != nil {
+		return errors.Join(fmt.Errorf("resourceSonarqubeCustomQualityProfileUpdate: %+v", err), resourceSonarqubeCustomQualityProfileRead(d, m))
+	}
+
+	return resourceSonarqubeCustomQualityProfileRead(d, m)
+}
+
+func resourceSonarqubeCustomQualityProfileDelete(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/delete"
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile": []string{d.Get("name").(string)},
+		"language":       []string{d.Get("language").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeCustomQualityProfileDelete",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeCustomQualityProfileDelete: Failed to delete quality profile '%s': %+v", d.Get("name").(string), err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeCustomQualityProfileImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := setQualityProfileImportID(d, m); err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeCustomQualityProfileImport: %+v", err)
+	}
+	if err := resourceSonarqubeCustomQualityProfileRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// customQualityProfileRule is a rule of the configuration of a sonarqube_custom_quality_profile
+type customQualityProfileRule struct {
+	severity string
+	params   map[string]string
+}
+
+// expandCustomQualityProfileRules returns the rule blocks keyed by rule key
+func expandCustomQualityProfileRules(rules interface{}) map[string]customQualityProfileRule {
+	expanded := map[string]customQualityProfileRule{}
+	for _, rule := range rules.(*schema.Set).List() {
+		values := rule.(map[string]interface{})
+		params := map[string]string{}
+		for key, value := range values["params"].(map[string]interface{}) {
+			params[key] = value.(string)
+		}
+		expanded[values["key"].(string)] = customQualityProfileRule{
+			severity: values["severity"].(string),
+			params:   params,
+		}
+	}
+	return expanded
+}
+
+// applyCustomQualityProfileRules converges the active rules of the profile to the configuration. The rules of the
+// server are compared instead of the state, so the rules activated outside of Terraform are deactivated as well.
+func applyCustomQualityProfileRules(d *schema.ResourceData, m interface{}) error {
+	rules, actives, err := searchActiveRulesFromApi(d.Id(), m)
+	if err != nil {
+		return err
+	}
+	current := map[string]ActiveRule{}
+	for _, rule := range rules {
+		for _, active := range actives[rule.RuleKey] {
+			if active.QProfile == d.Id() {
+				current[rule.RuleKey] = active
+			}
+		}
+	}
+	configured := expandCustomQualityProfileRules(d.Get("rule"))
+
+	errs := []error{}
+	for ruleKey := range current {
+		if _, ok := configured[ruleKey]; !ok {
+			errs = append(errs, deactivateQualityProfileRule(d.Id(), ruleKey, m))
+		}
+	}
+	ruleKeys := []string{}
+	for ruleKey := range configured {
+		ruleKeys = append(ruleKeys, ruleKey)
+	}
+	sort.Strings(ruleKeys)
+	for _, ruleKey := range ruleKeys {
+		rule := configured[ruleKey]
+		if active, ok := current[ruleKey]; ok && activeRuleMatches(active, rule) {
+			continue
+		}
+		errs = append(errs, activateQualityProfileRule(d.Id(), ruleKey, rule, m))
+	}
+	return errors.Join(errs...)
+}
+
+// activeRuleMatches returns true when the activation has the severity and the parameter values of the rule
+func activeRuleMatches(active ActiveRule, rule customQualityProfileRule) bool {
+	if active.Severity != rule.severity {
+		return false
+	}
+	for key, value := range rule.params {
+		found := false
+		for _, param := range active.Params {
+			if param.Key == key && param.Value == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// activateQualityProfileRule activates the rule in the profile, or updates its severity and parameters
+func activateQualityProfileRule(qualityProfileKey string, ruleKey string, rule customQualityProfileRule, m interface{}) error {
+	params := []string{}
+	for key, value := range rule.params {
+		params = append(params, key+"="+value)
+	}
+	sort.Strings(params)
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/activate_rule"
+	query := url.Values{
+		"key":      []string{qualityProfileKey},
+		"rule":     []string{ruleKey},
+		"severity": []string{rule.severity},
+	}
+	if len(params) > 0 {
+		query.Set("params", strings.Join(params, ";"))
+	}
+	sonarQubeURL.RawQuery = query.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"activateQualityProfileRule",
+	)
+	if err != nil {
+		return fmt.Errorf("activateQualityProfileRule: Failed to activate rule '%s' in quality profile '%s': %+v", ruleKey, qualityProfileKey, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// deactivateQualityProfileRule deactivates the rule in the profile
+func deactivateQualityProfileRule(qualityProfileKey string, ruleKey string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/deactivate_rule"
+	sonarQubeURL.RawQuery = url.Values{
+		"key":  []string{qualityProfileKey},
+		"rule": []string{ruleKey},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"deactivateQualityProfileRule",
+	)
+	if err != nil {
+		return fmt.Errorf("deactivateQualityProfileRule: Failed to deactivate rule '%s' in quality profile '%s': %+v", ruleKey, qualityProfileKey, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// findQualityProfileByKeyFromApi returns the quality profile with the key, or nil when it does not exist
+func findQualityProfileByKeyFromApi(qualityProfileKey string, m interface{}) (*GetQualityProfile, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"findQualityProfileByKeyFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("findQualityProfileByKeyFromApi: Failed to search quality profiles: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	profiles := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&profiles)
+	if err != nil {
+		return nil, fmt.Errorf("findQualityProfileByKeyFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, profile := range profiles.Profiles {
+		if profile.Key == qualityProfileKey {
+			return &profile, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,125 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeCustomQualityProfileConfig(rnd string, rules string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_custom_quality_profile" "%[1]s" {
+			name     = "%[1]s"
+			language = "java"
+			%[2]s
+		}`, rnd, rules)
+}
+
+func TestAccSonarqubeCustomQualityProfileBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_custom_quality_profile." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeCustomQualityProfileConfig(rnd, `
+					rule {
+						key      = "java:S107"
+						severity = "MAJOR"
+						params   = { max = "5" }
+					}
+					rule {
+						key      = "java:S1068"
+						severity = "MINOR"
+					}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "key"),
+					resource.TestCheckResourceAttr(name, "rule.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":        "java:S107",
+						"severity":   "MAJOR",
+						"params.max": "5",
+					}),
+				),
+			},
+			{
+				Config: testAccSonarqubeCustomQualityProfileConfig(rnd, `
+					rule {
+						key      = "java:S1068"
+						severity = "CRITICAL"
+					}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "rule.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "rule.*", map[string]string{
+						"key":      "java:S1068",
+						"severity": "CRITICAL",
+					}),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "java/" + rnd,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeCustomQualityProfileConverge(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.handle("GET", "/api/rules/search", func(w http.ResponseWriter, r *http.Request) {
+		mockJSON(w, map[string]interface{}{
+			"total": 3, "p": 1, "ps": 500,
+			"rules": []map[string]interface{}{{"key": "java:S107"}, {"key": "java:S1068"}, {"key": "java:S100"}},
+			"actives": map[string]interface{}{
+				"java:S107":  []map[string]interface{}{{"qProfile": "my-profile", "severity": "MAJOR", "params": []map[string]string{{"key": "max", "value": "7"}}}},
+				"java:S1068": []map[string]interface{}{{"qProfile": "my-profile", "severity": "MINOR"}},
+				"java:S100":  []map[string]interface{}{{"qProfile": "my-profile", "severity": "MINOR"}},
+			},
+		})
+	})
+	calls := []string{}
+	mock.handle("POST", "/api/qualityprofiles/activate_rule", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "activate "+r.URL.Query().Get("rule")+" "+r.URL.Query().Get("severity")+" "+r.URL.Query().Get("params"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mock.handle("POST", "/api/qualityprofiles/deactivate_rule", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "deactivate "+r.URL.Query().Get("rule"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	r := resourceSonarqubeCustomQualityProfile()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":     "My Java way",
+		"language": "java",
+		"rule": []interface{}{
+			// Another parameter value
+			map[string]interface{}{"key": "java:S107", "severity": "MAJOR", "params": map[string]interface{}{"max": "5", "ignoreOverridden": "true"}},
+			// Already activated this way
+			map[string]interface{}{"key": "java:S1068", "severity": "MINOR"},
+			// Not activated yet
+			map[string]interface{}{"key": "java:S1192", "severity": "CRITICAL"},
+		},
+	})
+	d.SetId("my-profile")
+
+	if err := applyCustomQualityProfileRules(d, mock.conf("Community", "10.7")); err != nil {
+		t.Fatalf("applyCustomQualityProfileRules() error = %+v", err)
+	}
+	sort.Strings(calls)
+	expected := []string{
+		"activate java:S107 MAJOR ignoreOverridden=true;max=5",
+		"activate java:S1192 CRITICAL ",
+		"deactivate java:S100",
+	}
+	if fmt.Sprint(calls) != fmt.Sprint(expected) {
+		t.Errorf("applyCustomQualityProfileRules() calls = %q, want %q", calls, expected)
+	}
+}
@@ -0,0 +1,95 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// defaultVisibilitySetting stores the visibility of the projects created without an explicit visibility
+const defaultVisibilitySetting = "projects.default.visibility"
+
+// Returns the resource represented by this file.
+func resourceSonarqubeDefaultVisibility() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Default Visibility resource. This can be used to manage the default visibility of new projects.
+Destroying this resource resets the default visibility to ` + "`public`" + `. It supports importing using the ID ` + "`default_visibility`" + `.`,
+		Create: resourceSonarqubeDefaultVisibilityCreate,
+		Read:   resourceSonarqubeDefaultVisibilityRead,
+		Update: resourceSonarqubeDefaultVisibilityCreate,
+		Delete: resourceSonarqubeDefaultVisibilityDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeDefaultVisibilityImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"visibility": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"public", "private"}, false)),
+				Description:      "The default visibility of new projects. Valid values are `public` and `private`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeDefaultVisibilityCreate(d *schema.ResourceData, m interface{}) error {
+	if err := updateDefaultVisibility(d.Get("visibility").(string), m); err != nil {
+		return err
+	}
+
+	d.SetId("default_visibility")
+
+	return resourceSonarqubeDefaultVisibilityRead(d, m)
+}
+
+func resourceSonarqubeDefaultVisibilityRead(d *schema.ResourceData, m interface{}) error {
+	settings, err := getSettingsByKeys("", []string{defaultVisibilitySetting}, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeDefaultVisibilityRead: Failed to read the default project visibility: %+v", err)
+	}
+
+	visibility := "public"
+	if setting, ok := settings[defaultVisibilitySetting]; ok && setting.Value != "" {
+		visibility = setting.Value
+	}
+	return d.Set("visibility", visibility)
+}
+
+func resourceSonarqubeDefaultVisibilityDelete(d *schema.ResourceData, m interface{}) error {
+	return updateDefaultVisibility("public", m)
+}
+
+func resourceSonarqubeDefaultVisibilityImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeDefaultVisibilityRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func updateDefaultVisibility(visibility string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/update_default_visibility"
+	sonarQubeURL.RawQuery = url.Values{
+		"projectVisibility": []string{visibility},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"updateDefaultVisibility",
+	)
+	if err != nil {
+		return fmt.Errorf("updateDefaultVisibility: Failed to set the default project visibility to '%s': %+v", visibility, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeDefaultVisibilityConfig(rnd string, visibility string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_default_visibility" "%[1]s" {
+			visibility = "%[2]s"
+		}`, rnd, visibility)
+}
+
+func TestAccSonarqubeDefaultVisibilityBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_default_visibility." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeDefaultVisibilityConfig(rnd, "private"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "visibility", "private"),
+				),
+			},
+			{
+				Config: testAccSonarqubeDefaultVisibilityConfig(rnd, "public"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "visibility", "public"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "default_visibility",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
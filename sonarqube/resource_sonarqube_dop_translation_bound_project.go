@@ -0,0 +1,276 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DopSetting used in GetDopSettings
+type DopSetting struct {
+	ID   string `json:"id"`
+	Key  string `json:"key"`
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// GetDopSettings for unmarshalling response body of api/v2/dop-translation/dop-settings
+type GetDopSettings struct {
+	DopSettings []DopSetting `json:"dopSettings"`
+}
+
+// CreateBoundProject for marshalling the request body of api/v2/dop-translation/bound-projects
+type CreateBoundProject struct {
+	ProjectKey              string `json:"projectKey"`
+	ProjectName             string `json:"projectName"`
+	DevOpsPlatformSettingID string `json:"devOpsPlatformSettingId"`
+	RepositoryIdentifier    string `json:"repositoryIdentifier"`
+	Monorepo                bool   `json:"monorepo"`
+	NewCodeDefinitionType   string `json:"newCodeDefinitionType,omitempty"`
+	NewCodeDefinitionValue  string `json:"newCodeDefinitionValue,omitempty"`
+}
+
+// CreateBoundProjectResponse for unmarshalling response body of api/v2/dop-translation/bound-projects
+type CreateBoundProjectResponse struct {
+	ProjectID string `json:"projectId"`
+	BindingID string `json:"bindingId"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeDopTranslationBoundProject() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube DevOps Platform bound project resource. This creates a SonarQube project that is already bound to a
+GitHub or GitLab repository in a single call of the ` + "`api/v2/dop-translation/bound-projects`" + ` endpoint of SonarQube 10.5 and above.
+It is an alternative to a ` + "`sonarqube_project`" + ` with a ` + "`sonarqube_github_binding`" + ` or ` + "`sonarqube_gitlab_binding`" + `.
+Destroying this resource deletes the project. It supports importing using the project key.`,
+		Create: resourceSonarqubeDopTranslationBoundProjectCreate,
+		Read:   resourceSonarqubeDopTranslationBoundProjectRead,
+		Delete: resourceSonarqubeDopTranslationBoundProjectDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeDopTranslationBoundProjectImport,
+		},
+		// Enforce the project key conventions of the provider configuration
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateProjectKeyConvention(d, "project", meta)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project to create. Changing this forces a new resource to be created.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the project to create. Changing this forces a new resource to be created.",
+			},
+			"alm_setting": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the GitHub or GitLab ALM setting. Changing this forces a new resource to be created.",
+			},
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The identifier of the repository: the GitHub repository slug (`organization/repository`) or the GitLab project ID. Changing this forces a new resource to be created.",
+			},
+			"monorepo": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Whether the repository contains several projects. Defaults to `false`. Changing this forces a new resource to be created.",
+			},
+			"new_code_definition_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"PREVIOUS_VERSION", "NUMBER_OF_DAYS", "REFERENCE_BRANCH"}, false)),
+				Description:      "The new code definition of the project. Possible values are `PREVIOUS_VERSION`, `NUMBER_OF_DAYS` and `REFERENCE_BRANCH`. Defaults to the global new code definition. Changing this forces a new resource to be created.",
+			},
+			"new_code_definition_value": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"new_code_definition_type"},
+				Description:  "The value of the new code definition, for example the number of days. Changing this forces a new resource to be created.",
+			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the created project.",
+			},
+		},
+	}
+}
+
+func checkDopTranslationSupport(conf *ProviderConfiguration) error {
+	minimumVersion, _ := version.NewVersion("10.5")
+	if conf.sonarQubeVersion.LessThan(minimumVersion) {
+		return fmt.Errorf("minimum required SonarQube version for creating bound projects is %s", minimumVersion)
+	}
+	if strings.ToLower(conf.sonarQubeEdition) == "community" {
+		return fmt.Errorf("creating bound projects is not supported in the Community edition of SonarQube. You are using: SonarQube %s version %s", conf.sonarQubeEdition, conf.sonarQubeVersion)
+	}
+	return nil
+}
+
+func resourceSonarqubeDopTranslationBoundProjectCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkDopTranslationSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	dopSetting, err := readDopSettingFromApi(d.Get("alm_setting").(string), m)
+	if err != nil {
+		return err
+	}
+
+	request := CreateBoundProject{
+		ProjectKey:              d.Get("project").(string),
+		ProjectName:             d.Get("name").(string),
+		DevOpsPlatformSettingID: dopSetting.ID,
+		RepositoryIdentifier:    d.Get("repository").(string),
+		Monorepo:                d.Get("monorepo").(bool),
+		NewCodeDefinitionType:   d.Get("new_code_definition_type").(string),
+		NewCodeDefinitionValue:  d.Get("new_code_definition_value").(string),
+	}
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/bound-projects"
+
+	resp, err := httpRequestHelperV2(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		request,
+		http.StatusCreated,
+		"resourceSonarqubeDopTranslationBoundProjectCreate",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	boundProject := CreateBoundProjectResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&boundProject)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeDopTranslationBoundProjectCreate: Failed to decode json into struct: %+v", err)
+	}
+
+	d.SetId(request.ProjectKey)
+	if err := d.Set("project_id", boundProject.ProjectID); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeDopTranslationBoundProjectRead(d, m)
+}
+
+func resourceSonarqubeDopTranslationBoundProjectRead(d *schema.ResourceData, m interface{}) error {
+	if err := checkDopTranslationSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/components/show"
+	sonarQubeURL.RawQuery = url.Values{
+		"component": []string{d.Id()},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeDopTranslationBoundProjectRead",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			log.Printf("[WARN][resourceSonarqubeDopTranslationBoundProjectRead] Project '%s' not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	project := GetProject{}
+	err = json.NewDecoder(resp.Body).Decode(&project)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeDopTranslationBoundProjectRead: Failed to decode json into struct: %+v", err)
+	}
+
+	binding, err := readProjectBindingFromApi(d.Id(), m)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("project", project.Component.Key))
+	errs = append(errs, d.Set("name", project.Component.Name))
+	errs = append(errs, d.Set("alm_setting", binding.Key))
+	errs = append(errs, d.Set("repository", binding.Repository))
+	errs = append(errs, d.Set("monorepo", binding.Monorepo))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeDopTranslationBoundProjectDelete(d *schema.ResourceData, m interface{}) error {
+	return resourceSonarqubeProjectDelete(d, m)
+}
+
+func resourceSonarqubeDopTranslationBoundProjectImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeDopTranslationBoundProjectRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// readDopSettingFromApi returns the DevOps Platform setting with the given key, which holds the ID needed by the v2 API
+func readDopSettingFromApi(key string, m interface{}) (*DopSetting, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/dop-translation/dop-settings"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readDopSettingFromApi",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	dopSettings := GetDopSettings{}
+	err = json.NewDecoder(resp.Body).Decode(&dopSettings)
+	if err != nil {
+		return nil, fmt.Errorf("readDopSettingFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, dopSetting := range dopSettings.DopSettings {
+		if dopSetting.Key == key {
+			return &dopSetting, nil
+		}
+	}
+	return nil, fmt.Errorf("readDopSettingFromApi: ALM setting '%s' not found", key)
+}
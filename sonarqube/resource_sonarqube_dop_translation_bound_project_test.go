@@ -0,0 +1,63 @@
+package sonarqube
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccPreCheckDopTranslationSupport(t *testing.T) {
+	if err := checkDopTranslationSupport(testAccProviderConfiguration(t)); err != nil {
+		t.Skipf("Skipping test of unsupported feature (DevOps Platform bound projects)")
+	}
+	// SonarQube validates the repository against GitLab, so real credentials are needed
+	if os.Getenv("SONAR_GITLAB_TOKEN") == "" || os.Getenv("SONAR_GITLAB_PROJECT_ID") == "" {
+		t.Skipf("Skipping test of DevOps Platform bound projects, SONAR_GITLAB_TOKEN or SONAR_GITLAB_PROJECT_ID is not set")
+	}
+}
+
+func testAccSonarqubeDopTranslationBoundProjectConfig(rnd string, projectKey string, token string, repository string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_alm_gitlab" "%[1]s" {
+			key                   = "%[1]s"
+			personal_access_token = "%[3]s"
+			url                   = "https://gitlab.com/api/v4"
+		}
+
+		resource "sonarqube_dop_translation_bound_project" "%[1]s" {
+			project     = "%[2]s"
+			name        = "%[2]s"
+			alm_setting = sonarqube_alm_gitlab.%[1]s.key
+			repository  = "%[4]s"
+		}`, rnd, projectKey, token, repository)
+}
+
+func TestAccSonarqubeDopTranslationBoundProjectBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_dop_translation_bound_project." + rnd
+	repository := os.Getenv("SONAR_GITLAB_PROJECT_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckDopTranslationSupport(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeDopTranslationBoundProjectConfig(rnd, "testAccSonarqubeDopTranslationBoundProject", os.Getenv("SONAR_GITLAB_TOKEN"), repository),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "project", "testAccSonarqubeDopTranslationBoundProject"),
+					resource.TestCheckResourceAttr(name, "alm_setting", rnd),
+					resource.TestCheckResourceAttr(name, "repository", repository),
+					resource.TestCheckResourceAttrSet(name, "project_id"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"project_id"},
+			},
+		},
+	})
+}
@@ -0,0 +1,329 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeEphemeralProject() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Ephemeral Project resource. This is designed for preview environments and per pull request pipelines:
+it creates a project together with a project analysis token and an optional quality gate association.
+When ` + "`auto_delete_after`" + ` is set, the plan replaces the project once it has not been analyzed for that long: the apply
+deletes it, and creates a fresh one if it is still part of the configuration. Plans and refreshes never delete it by themselves.`,
+		Create: resourceSonarqubeEphemeralProjectCreate,
+		Read:   resourceSonarqubeEphemeralProjectRead,
+		Update: resourceSonarqubeEphemeralProjectUpdate,
+		Delete: resourceSonarqubeEphemeralProjectDelete,
+		// Enforce the project key conventions of the provider configuration
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateProjectKeyConvention(d, "project", meta)
+			},
+			customizeEphemeralProjectExpiry,
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Key of the project. Maximum length 400. All letters, digits, dash, underscore, period or colon. Changing this forces a new resource to be created.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the project. Defaults to the key of the project. Changing this forces a new resource to be created.",
+			},
+			"visibility": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "private",
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"public", "private"}, false)),
+				Description:      "Whether the project is visible to everyone, or only specific user/groups. Valid values are `public` and `private`. Defaults to `private`. Changing this forces a new resource to be created.",
+			},
+			"quality_gate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the quality gate to associate with the project. When not set, the default quality gate is used.",
+			},
+			"token_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the project analysis token. Defaults to `<project>-analysis`. Changing this forces a new resource to be created.",
+			},
+			"token_expiration_date": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The expiration date of the project analysis token, in the format `YYYY-MM-DD`. Changing this forces a new resource to be created.",
+			},
+			"auto_delete_after": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validateEphemeralProjectDuration),
+				Description:      "Delete the project once it has not been analyzed for this duration, for example `168h`. Projects that were never analyzed expire this long after their creation.",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The project analysis token, to use as `SONAR_TOKEN` in the pipeline.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the project was created by this resource.",
+			},
+			"expired": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the project was not analyzed for `auto_delete_after`. An expired project is replaced by the next apply.",
+			},
+			"last_analysis_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date of the last analysis of the project.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeEphemeralProjectCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	name := d.Get("name").(string)
+	if name == "" {
+		name = project
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/create"
+	sonarQubeURL.RawQuery = url.Values{
+		"name":       []string{name},
+		"project":    []string{project},
+		"visibility": []string{d.Get("visibility").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeEphemeralProjectCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeEphemeralProjectCreate: Failed to create project '%s': %+v", project, err)
+	}
+	resp.Body.Close()
+
+	d.SetId(project)
+	if err := d.Set("created_at", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+
+	if gate := d.Get("quality_gate").(string); gate != "" {
+		if err := selectProjectQualityGate(project, gate, m); err != nil {
+			return err
+		}
+	}
+
+	tokenName := d.Get("token_name").(string)
+	if tokenName == "" {
+		tokenName = project + "-analysis"
+	}
+	token, err := generateProjectAnalysisToken(project, tokenName, d.Get("token_expiration_date").(string), m)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("token_name", tokenName))
+	errs = append(errs, d.Set("token", token))
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeEphemeralProjectRead(d, m)
+}
+
+func resourceSonarqubeEphemeralProjectRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/components/show"
+	sonarQubeURL.RawQuery = url.Values{
+		"component": []string{d.Id()},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeEphemeralProjectRead",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			log.Printf("[WARN][resourceSonarqubeEphemeralProjectRead] Project '%s' not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	project := GetProject{}
+	err = json.NewDecoder(resp.Body).Decode(&project)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeEphemeralProjectRead: Failed to decode json into struct: %+v", err)
+	}
+
+	// The expired project is only deleted by an apply, see customizeEphemeralProjectExpiry
+	expired := false
+	if autoDeleteAfter := d.Get("auto_delete_after").(string); autoDeleteAfter != "" {
+		expired, err = ephemeralProjectExpired(project.Component.AnalysisDate, d.Get("created_at").(string), autoDeleteAfter, time.Now())
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeEphemeralProjectRead: %+v", err)
+		}
+		if expired {
+			log.Printf("[INFO][resourceSonarqubeEphemeralProjectRead] Project '%s' was not analyzed for %s, it will be replaced by the next apply", d.Id(), autoDeleteAfter)
+		}
+	}
+
+	gate, err := readProjectQualityGate(d.Id(), m)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("project", project.Component.Key))
+	errs = append(errs, d.Set("name", project.Component.Name))
+	errs = append(errs, d.Set("visibility", project.Component.Visibility))
+	errs = append(errs, d.Set("last_analysis_date", project.Component.AnalysisDate))
+	errs = append(errs, d.Set("expired", expired))
+	// Keep the quality gate empty when the project uses the default quality gate
+	if _, ok := d.GetOk("quality_gate"); ok {
+		errs = append(errs, d.Set("quality_gate", gate))
+	}
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeEphemeralProjectUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("quality_gate") {
+		project := d.Get("project").(string)
+		if gate := d.Get("quality_gate").(string); gate != "" {
+			if err := selectProjectQualityGate(project, gate, m); err != nil {
+				return err
+			}
+		} else if err := deselectProjectQualityGate(project, m); err != nil {
+			return fmt.Errorf("resourceSonarqubeEphemeralProjectUpdate: %+v", err)
+		}
+	}
+
+	return resourceSonarqubeEphemeralProjectRead(d, m)
+}
+
+func resourceSonarqubeEphemeralProjectDelete(d *schema.ResourceData, m interface{}) error {
+	// The project analysis token is deleted together with the project
+	return resourceSonarqubeProjectDelete(d, m)
+}
+
+// customizeEphemeralProjectExpiry plans the replacement of a project that the last refresh found expired
+func customizeEphemeralProjectExpiry(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.Get("expired").(bool) {
+		return nil
+	}
+	if err := d.SetNew("expired", false); err != nil {
+		return err
+	}
+	return d.ForceNew("expired")
+}
+
+// generateProjectAnalysisToken generates a token that can only analyze the project
+func generateProjectAnalysisToken(project string, name string, expirationDate string, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_tokens/generate"
+	rawQuery := url.Values{
+		"name":       []string{name},
+		"type":       []string{string(ProjectAnalysisToken)},
+		"projectKey": []string{project},
+	}
+	if expirationDate != "" {
+		rawQuery.Add("expirationDate", expirationDate)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"generateProjectAnalysisToken",
+	)
+	if err != nil {
+		return "", fmt.Errorf("generateProjectAnalysisToken: Failed to generate the analysis token of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	token := Token{}
+	err = json.NewDecoder(resp.Body).Decode(&token)
+	if err != nil {
+		return "", fmt.Errorf("generateProjectAnalysisToken: Failed to decode json into struct: %+v", err)
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("generateProjectAnalysisToken: Create response didn't contain the token")
+	}
+
+	return token.Token, nil
+}
+
+func validateEphemeralProjectDuration(i interface{}, k string) ([]string, []error) {
+	value := i.(string)
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return nil, []error{fmt.Errorf("expected %s to be a positive duration, for example `168h`, got '%s'", k, value)}
+	}
+	return nil, nil
+}
+
+// ephemeralProjectExpired tells whether the last analysis, or the creation when the project was never analyzed, is older than autoDeleteAfter
+func ephemeralProjectExpired(analysisDate string, createdAt string, autoDeleteAfter string, now time.Time) (bool, error) {
+	duration, err := time.ParseDuration(autoDeleteAfter)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse auto_delete_after '%s': %+v", autoDeleteAfter, err)
+	}
+
+	var lastActivity time.Time
+	switch {
+	case analysisDate != "":
+		lastActivity, err = time.Parse("2006-01-02T15:04:05-0700", analysisDate)
+	case createdAt != "":
+		lastActivity, err = time.Parse(time.RFC3339, createdAt)
+	default:
+		// Projects imported without a creation date never expire before their first analysis
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to parse the last activity date of the project: %+v", err)
+	}
+
+	return now.Sub(lastActivity) > duration, nil
+}
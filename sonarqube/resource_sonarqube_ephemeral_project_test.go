@@ -0,0 +1,114 @@
+package sonarqube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeEphemeralProjectConfig(rnd string, project string, autoDeleteAfter string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualitygate" "%[1]s" {
+			name = "%[2]s"
+		}
+
+		resource "sonarqube_ephemeral_project" "%[1]s" {
+			project           = "%[2]s"
+			quality_gate      = sonarqube_qualitygate.%[1]s.name
+			auto_delete_after = "%[3]s"
+		}`, rnd, project, autoDeleteAfter)
+}
+
+func TestAccSonarqubeEphemeralProjectBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_ephemeral_project." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeEphemeralProjectConfig(rnd, "testAccSonarqubeEphemeralProject", "168h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "project", "testAccSonarqubeEphemeralProject"),
+					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeEphemeralProject"),
+					resource.TestCheckResourceAttr(name, "visibility", "private"),
+					resource.TestCheckResourceAttr(name, "quality_gate", "testAccSonarqubeEphemeralProject"),
+					resource.TestCheckResourceAttr(name, "token_name", "testAccSonarqubeEphemeralProject-analysis"),
+					resource.TestCheckResourceAttrSet(name, "token"),
+					resource.TestCheckResourceAttrSet(name, "created_at"),
+				),
+			},
+		},
+	})
+}
+
+func TestEphemeralProjectExpired(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		analysisDate    string
+		createdAt       string
+		autoDeleteAfter string
+		expected        bool
+	}{
+		{"recent analysis", "2024-06-14T12:00:00+0000", "2024-01-01T00:00:00Z", "48h", false},
+		{"old analysis", "2024-06-10T12:00:00+0000", "2024-01-01T00:00:00Z", "48h", true},
+		{"never analyzed recent creation", "", "2024-06-15T00:00:00Z", "48h", false},
+		{"never analyzed old creation", "", "2024-06-01T00:00:00Z", "48h", true},
+		{"no dates", "", "", "1h", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expired, err := ephemeralProjectExpired(test.analysisDate, test.createdAt, test.autoDeleteAfter, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %+v", err)
+			}
+			if expired != test.expected {
+				t.Errorf("expected %t, got %t", test.expected, expired)
+			}
+		})
+	}
+}
+
+func TestResourceSonarqubeEphemeralProjectExpiry(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/components/show", http.StatusOK, `{"component":{"key":"preview-42","name":"preview-42","visibility":"private","analysisDate":"2024-01-01T00:00:00+0000"}}`)
+	mock.respond("GET", "/api/qualitygates/get_by_project", http.StatusOK, `{"qualityGate":{"name":"Sonar way","default":true}}`)
+	conf := mock.conf("Community", "10.7")
+	r := resourceSonarqubeEphemeralProject()
+
+	raw := map[string]interface{}{
+		"project":           "preview-42",
+		"auto_delete_after": "24h",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.SetId("preview-42")
+	if err := r.Read(d, conf); err != nil {
+		t.Fatalf("Read() unexpected error = %v", err)
+	}
+	// A refresh only records the expiry, the project is deleted by the apply
+	if strings.Contains(fmt.Sprint(mock.requests()), "/api/projects/delete") {
+		t.Errorf("Read() deleted the project: %v", mock.requests())
+	}
+	if d.Id() != "preview-42" || !d.Get("expired").(bool) {
+		t.Fatalf("Read() state = %v, want the expired project kept in the state", d.State().Attributes)
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), conf)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error = %v", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Errorf("Diff() = %v, want the expired project replaced", diff)
+	}
+}
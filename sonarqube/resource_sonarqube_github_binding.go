@@ -1,6 +1,7 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -26,51 +28,81 @@ type GetBinding struct {
 func resourceSonarqubeGithubBinding() *schema.Resource {
 	return &schema.Resource{
 		Description: `Provides a Sonarqube GitHub binding resource. This can be used to create and manage the binding between a
-GitHub repository and a SonarQube project`,
+GitHub repository and a SonarQube project. It supports importing using the format '{project}/{repository}'.`,
 		Create: resourceSonarqubeGithubBindingCreate,
 		Read:   resourceSonarqubeGithubBindingRead,
+		Update: resourceSonarqubeGithubBindingUpdate,
 		Delete: resourceSonarqubeGithubBindingDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeGithubBindingImport,
 		},
-		// Define the fields of this schema.
-		Schema: map[string]*schema.Schema{
-			"alm_setting": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "GitHub ALM setting key",
-			},
-			"monorepo": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "false",
-				ForceNew:    true,
-				Description: "Is this project part of a monorepo. Default value: false",
-			},
-			"project": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Project key",
-			},
-			"repository": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The full name of your GitHub repository, including the organization, case-sensitive. Maximum length: 256",
-			},
-			"summary_comment_enabled": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "true",
-				ForceNew:    true,
-				Description: "Enable/disable summary in PR discussion tab. Default value: true",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+		// The booleans were stored as strings before version 1
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceSonarqubeGithubBindingV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceSonarqubeGithubBindingStateUpgradeV0,
 			},
 		},
+
+		// Define the fields of this schema.
+		Schema: resourceSonarqubeGithubBindingSchema(),
 	}
 }
 
+func resourceSonarqubeGithubBindingSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"wait_for_first_analysis": waitForFirstAnalysisSchema(),
+		"alm_setting": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "GitHub ALM setting key. Changing this updates the binding in place, for example when the key of the `sonarqube_alm_github` setting is changed.",
+		},
+		"monorepo": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Is this project part of a monorepo. Default value: false",
+		},
+		"project": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Project key",
+		},
+		"repository": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The full name of your GitHub repository, including the organization, case-sensitive. Maximum length: 256",
+		},
+		"summary_comment_enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Enable/disable summary in PR discussion tab. Changing this updates the binding in place, so pull request decoration is not interrupted. Default value: true",
+		},
+	}
+}
+
+// resourceSonarqubeGithubBindingV0 is the schema before version 1, where monorepo and summary_comment_enabled were strings
+func resourceSonarqubeGithubBindingV0() *schema.Resource {
+	s := resourceSonarqubeGithubBindingSchema()
+	s["monorepo"] = &schema.Schema{Type: schema.TypeString, Optional: true}
+	s["summary_comment_enabled"] = &schema.Schema{Type: schema.TypeString, Optional: true}
+	return &schema.Resource{
+		Schema: s,
+	}
+}
+
+func resourceSonarqubeGithubBindingStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	return upgradeStringBoolAttributes(rawState, map[string]bool{"monorepo": false, "summary_comment_enabled": true})
+}
+
 func checkGithubBindingSupport(conf *ProviderConfiguration) error {
 	if strings.ToLower(conf.sonarQubeEdition) == "community" {
 		return fmt.Errorf("GitHub Bindings are not supported in the Community edition of SonarQube. You are using: SonaQube %s version %s", conf.sonarQubeEdition, conf.sonarQubeVersion)
@@ -83,32 +115,26 @@ func resourceSonarqubeGithubBindingCreate(d *schema.ResourceData, m interface{})
 		return err
 	}
 
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_settings/set_github_binding"
-
-	sonarQubeURL.RawQuery = url.Values{
-		"almSetting":            []string{d.Get("alm_setting").(string)},
-		"monorepo":              []string{d.Get("monorepo").(string)},
-		"project":               []string{d.Get("project").(string)},
-		"repository":            []string{d.Get("repository").(string)},
-		"summaryCommentEnabled": []string{d.Get("summary_comment_enabled").(string)},
-	}.Encode()
-
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"POST",
-		sonarQubeURL.String(),
-		http.StatusNoContent,
-		"resourceSonarqubeGithubBindingCreate",
-	)
-	if err != nil {
+	if err := setGithubBinding(
+		d.Get("project").(string),
+		d.Get("alm_setting").(string),
+		d.Get("repository").(string),
+		d.Get("monorepo").(bool),
+		d.Get("summary_comment_enabled").(bool),
+		m,
+	); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
 
 	id := fmt.Sprintf("%v/%v", d.Get("project").(string), d.Get("repository").(string))
 	d.SetId(id)
 
+	if d.Get("wait_for_first_analysis").(bool) && d.IsNewResource() {
+		if err := waitForFirstAnalysis(d.Get("project").(string), d.Timeout(schema.TimeoutCreate), m); err != nil {
+			return err
+		}
+	}
+
 	return resourceSonarqubeGithubBindingRead(d, m)
 }
 
@@ -117,11 +143,14 @@ func resourceSonarqubeGithubBindingRead(d *schema.ResourceData, m interface{}) e
 		return err
 	}
 
-	idSlice := strings.SplitN(d.Id(), "/", 2)
+	parts, err := parseImportID(d.Id(), "{project}/{repository}")
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGithubBindingRead: %+v", err)
+	}
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_settings/get_binding"
 	sonarQubeURL.RawQuery = url.Values{
-		"project": []string{idSlice[0]},
+		"project": []string{parts[0]},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -143,19 +172,40 @@ func resourceSonarqubeGithubBindingRead(d *schema.ResourceData, m interface{}) e
 		return fmt.Errorf("resourceSonarqubeGithubBindingRead: Failed to decode json into struct: %+v", err)
 	}
 	// Loop over all branches to see if the main branch we need exists.
-	if idSlice[1] == BindingReadResponse.Repository && BindingReadResponse.Alm == "github" {
+	if parts[1] == BindingReadResponse.Repository && BindingReadResponse.Alm == "github" {
 		errs := []error{}
-		errs = append(errs, d.Set("project", idSlice[0]))
-		errs = append(errs, d.Set("repository", idSlice[1]))
+		errs = append(errs, d.Set("project", parts[0]))
+		errs = append(errs, d.Set("repository", parts[1]))
 		errs = append(errs, d.Set("alm_setting", BindingReadResponse.Key))
-		errs = append(errs, d.Set("monorepo", strconv.FormatBool(BindingReadResponse.Monorepo)))
-		errs = append(errs, d.Set("summary_comment_enabled", strconv.FormatBool(BindingReadResponse.SummaryCommentEnabled)))
+		errs = append(errs, d.Set("monorepo", BindingReadResponse.Monorepo))
+		errs = append(errs, d.Set("summary_comment_enabled", BindingReadResponse.SummaryCommentEnabled))
 
 		return errors.Join(errs...)
 	}
 	return fmt.Errorf("resourceSonarqubeGithubBindingRead: Failed to find github binding: %+v", d.Id())
 }
 
+func resourceSonarqubeGithubBindingUpdate(d *schema.ResourceData, m interface{}) error {
+	// wait_for_first_analysis only applies to the creation and does not require an API call
+	if d.HasChanges("alm_setting", "monorepo", "summary_comment_enabled") {
+		if err := checkGithubBindingSupport(m.(*ProviderConfiguration)); err != nil {
+			return err
+		}
+		if err := setGithubBinding(
+			d.Get("project").(string),
+			d.Get("alm_setting").(string),
+			d.Get("repository").(string),
+			d.Get("monorepo").(bool),
+			d.Get("summary_comment_enabled").(bool),
+			m,
+		); err != nil {
+			return fmt.Errorf("resourceSonarqubeGithubBindingUpdate: %+v", err)
+		}
+	}
+
+	return resourceSonarqubeGithubBindingRead(d, m)
+}
+
 func resourceSonarqubeGithubBindingDelete(d *schema.ResourceData, m interface{}) error {
 	if err := checkGithubBindingSupport(m.(*ProviderConfiguration)); err != nil {
 		return err
@@ -183,8 +233,38 @@ func resourceSonarqubeGithubBindingDelete(d *schema.ResourceData, m interface{})
 }
 
 func resourceSonarqubeGithubBindingImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseImportID(d.Id(), "{project}/{repository}"); err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeGithubBindingImport: %+v", err)
+	}
 	if err := resourceSonarqubeGithubBindingRead(d, m); err != nil {
 		return nil, err
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// setGithubBinding binds the project to a GitHub repository, or replaces its current binding
+func setGithubBinding(project string, almSetting string, repository string, monorepo bool, summaryCommentEnabled bool, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_settings/set_github_binding"
+	sonarQubeURL.RawQuery = url.Values{
+		"almSetting":            []string{almSetting},
+		"monorepo":              []string{strconv.FormatBool(monorepo)},
+		"project":               []string{project},
+		"repository":            []string{repository},
+		"summaryCommentEnabled": []string{strconv.FormatBool(summaryCommentEnabled)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"setGithubBinding",
+	)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -1,9 +1,15 @@
 package sonarqube
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -19,7 +25,7 @@ func testSweepSonarqubeGithubBinding(r string) error {
 	return nil
 }
 func testAccPreCheckGithubBindingSupport(t *testing.T) {
-	if err := checkGithubBindingSupport(testAccProvider.Meta().(*ProviderConfiguration)); err != nil {
+	if err := checkGithubBindingSupport(testAccProviderConfiguration(t)); err != nil {
 		t.Skipf("Skipping test of unsupported feature (GitHub Binding)")
 	}
 }
@@ -44,10 +50,10 @@ func testAccSonarqubeGithubBindingName(rnd string, projName string, almSetting s
 		}
 		resource "sonarqube_github_binding" "%[1]s" {
 			alm_setting   = "%[3]s"
-			monorepo     = "false"
+			monorepo     = false
 			project = sonarqube_project.%[1]s.project
 			repository   = "%[4]s"
-			summary_comment_enabled = "true"
+			summary_comment_enabled = true
 		    depends_on = [sonarqube_alm_github.%[1]s]
 		}`, rnd, projName, almSetting, repoName)
 }
@@ -118,3 +124,172 @@ func TestAccSonarqubeGithubBindingName(t *testing.T) {
 		},
 	})
 }
+
+// mockBindingAPI serves the bindings of the projects from the mock, keyed by project key
+func mockBindingAPI(mock *mockSonarQube, alm string, bindings map[string]GetBinding) {
+	var mutex sync.Mutex
+
+	mock.handle("GET", "/api/alm_settings/get_binding", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		binding, ok := bindings[r.URL.Query().Get("project")]
+		if !ok {
+			mockError(w, http.StatusNotFound, fmt.Sprintf("Project '%s' is not bound to any DevOps Platform", r.URL.Query().Get("project")))
+			return
+		}
+		mockJSON(w, binding)
+	})
+	mock.handle("POST", "/api/alm_settings/set_"+alm+"_binding", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		query := r.URL.Query()
+		if !strings.HasPrefix(query.Get("almSetting"), "my-alm") {
+			mockError(w, http.StatusNotFound, fmt.Sprintf("DevOps Platform setting with key '%s' cannot be found", query.Get("almSetting")))
+			return
+		}
+		bindings[query.Get("project")] = GetBinding{
+			Key:                   query.Get("almSetting"),
+			Alm:                   alm,
+			Repository:            query.Get("repository"),
+			SummaryCommentEnabled: query.Get("summaryCommentEnabled") == "true",
+			Monorepo:              query.Get("monorepo") == "true",
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mock.handle("POST", "/api/alm_settings/delete_binding", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		delete(bindings, r.URL.Query().Get("project"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func TestResourceSonarqubeGithubBindingLifecycle(t *testing.T) {
+	mock := newMockSonarQube(t)
+	bindings := map[string]GetBinding{}
+	mockBindingAPI(mock, "github", bindings)
+	conf := mock.conf("Developer", "10.7")
+	r := resourceSonarqubeGithubBinding()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"alm_setting":             "my-alm",
+		"project":                 "my_project",
+		"repository":              "my-org/my-repo",
+		"summary_comment_enabled": false,
+	})
+	if err := r.Create(d, conf); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if d.Id() != "my_project/my-org/my-repo" {
+		t.Errorf("Create() ID = %s, want my_project/my-org/my-repo", d.Id())
+	}
+	if d.Get("summary_comment_enabled").(bool) || d.Get("alm_setting").(string) != "my-alm" {
+		t.Errorf("Create() state = %v, want the binding that was set", d.State().Attributes)
+	}
+
+	// The summary comment is updated in place, keeping the binding
+	updated := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"alm_setting":             "my-alm",
+		"project":                 "my_project",
+		"repository":              "my-org/my-repo",
+		"summary_comment_enabled": true,
+	})
+	updated.SetId(d.Id())
+	if err := r.Update(updated, conf); err != nil {
+		t.Fatalf("Update() unexpected error = %v", err)
+	}
+	if !bindings["my_project"].SummaryCommentEnabled || bindings["my_project"].Repository != "my-org/my-repo" {
+		t.Errorf("Update() binding = %+v, want the summary comment enabled on my-org/my-repo", bindings["my_project"])
+	}
+
+	// A binding changed outside of Terraform is not found anymore
+	bindings["my_project"] = GetBinding{Key: "my-alm", Alm: "github", Repository: "my-org/other-repo"}
+	if err := r.Read(d, conf); err == nil || !strings.Contains(err.Error(), "Failed to find github binding") {
+		t.Errorf("Read() error = %v, want an error about the missing binding", err)
+	}
+
+	if err := r.Delete(d, conf); err != nil {
+		t.Fatalf("Delete() unexpected error = %v", err)
+	}
+	if _, ok := bindings["my_project"]; ok {
+		t.Errorf("Delete() did not delete the binding")
+	}
+}
+
+func TestResourceSonarqubeGithubBindingCreateErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		edition string
+		wantErr string
+	}{
+		{
+			name:    "unknown ALM setting",
+			edition: "Developer",
+			wantErr: "DevOps Platform setting with key 'unknown' cannot be found",
+		},
+		{
+			name:    "community edition",
+			edition: "Community",
+			wantErr: "not supported in the Community edition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockSonarQube(t)
+			mockBindingAPI(mock, "github", map[string]GetBinding{})
+			r := resourceSonarqubeGithubBinding()
+
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"alm_setting": "unknown",
+				"project":     "my_project",
+				"repository":  "my-org/my-repo",
+			})
+			err := r.Create(d, mock.conf(tt.edition, "10.7"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %s", err, tt.wantErr)
+			}
+			if d.Id() != "" {
+				t.Errorf("Create() ID = %s, want no ID", d.Id())
+			}
+		})
+	}
+}
+
+func TestResourceSonarqubeGithubBindingUpdateAlmSetting(t *testing.T) {
+	mock := newMockSonarQube(t)
+	bindings := map[string]GetBinding{}
+	mockBindingAPI(mock, "github", bindings)
+	conf := mock.conf("Developer", "10.7")
+	r := resourceSonarqubeGithubBinding()
+
+	raw := map[string]interface{}{
+		"alm_setting": "my-alm",
+		"project":     "my_project",
+		"repository":  "my-org/my-repo",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if err := r.Create(d, conf); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	// Renaming the ALM setting updates the binding in place
+	raw["alm_setting"] = "my-alm-renamed"
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), conf)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error = %v", err)
+	}
+	if diff.RequiresNew() {
+		t.Errorf("Diff() requires a new resource, want the binding updated in place")
+	}
+	state, diags := r.Apply(context.Background(), d.State(), diff, conf)
+	if diags.HasError() {
+		t.Fatalf("Update() unexpected error = %v", diags)
+	}
+	if bindings["my_project"].Key != "my-alm-renamed" || state.Attributes["alm_setting"] != "my-alm-renamed" {
+		t.Errorf("Update() binding = %+v, state = %v, want the renamed ALM setting", bindings["my_project"], state.Attributes)
+	}
+}
@@ -1,6 +1,7 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -16,43 +18,73 @@ import (
 func resourceSonarqubeGitlabBinding() *schema.Resource {
 	return &schema.Resource{
 		Description: `Provides a Sonarqube GitLab binding resource. This can be used to create and manage the binding between a
-GitLab repository and a SonarQube project`,
+GitLab repository and a SonarQube project. It supports importing using the format '{project}/[{repository}]',
+where the repository is read from the binding when it is omitted.`,
 		Create: resourceSonarqubeGitlabBindingCreate,
-		// You can update any project binding with the same API call as the CREATE
-		Update: resourceSonarqubeGitlabBindingCreate,
+		Update: resourceSonarqubeGitlabBindingUpdate,
 		Read:   resourceSonarqubeGitlabBindingRead,
 		Delete: resourceSonarqubeGitlabBindingDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeGitlabBindingImport,
 		},
-		// Define the fields of this schema.
-		Schema: map[string]*schema.Schema{
-			"alm_setting": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "GitLab ALM setting key",
-			},
-			"monorepo": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "false",
-				Description: "Is this project part of a monorepo. Default value: false",
-			},
-			"project": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "SonarQube project key. Changing this will force a new resource to be created",
-			},
-			"repository": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The GitLab project ID",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+		// The booleans were stored as strings before version 1
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceSonarqubeGitlabBindingV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceSonarqubeGitlabBindingStateUpgradeV0,
 			},
 		},
+
+		// Define the fields of this schema.
+		Schema: resourceSonarqubeGitlabBindingSchema(),
 	}
 }
 
+func resourceSonarqubeGitlabBindingSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"wait_for_first_analysis": waitForFirstAnalysisSchema(),
+		"alm_setting": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "GitLab ALM setting key",
+		},
+		"monorepo": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Is this project part of a monorepo. Default value: false",
+		},
+		"project": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "SonarQube project key. Changing this binds the repository to the new key before unbinding the previous one, so the binding follows a project whose key is updated",
+		},
+		"repository": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The GitLab project ID",
+		},
+	}
+}
+
+// resourceSonarqubeGitlabBindingV0 is the schema before version 1, where monorepo was a string
+func resourceSonarqubeGitlabBindingV0() *schema.Resource {
+	s := resourceSonarqubeGitlabBindingSchema()
+	s["monorepo"] = &schema.Schema{Type: schema.TypeString, Optional: true}
+	return &schema.Resource{
+		Schema: s,
+	}
+}
+
+func resourceSonarqubeGitlabBindingStateUpgradeV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	return upgradeStringBoolAttributes(rawState, map[string]bool{"monorepo": false})
+}
+
 func checkGitlabBindingSupport(conf *ProviderConfiguration) error {
 	if strings.ToLower(conf.sonarQubeEdition) == "community" {
 		return fmt.Errorf("GitLab Bindings are not supported in the Community edition of SonarQube. You are using: SonarQube %s version %s", conf.sonarQubeEdition, conf.sonarQubeVersion)
@@ -70,7 +102,7 @@ func resourceSonarqubeGitlabBindingCreate(d *schema.ResourceData, m interface{})
 
 	sonarQubeURL.RawQuery = url.Values{
 		"almSetting": []string{d.Get("alm_setting").(string)},
-		"monorepo":   []string{d.Get("monorepo").(string)},
+		"monorepo":   []string{strconv.FormatBool(d.Get("monorepo").(bool))},
 		"project":    []string{d.Get("project").(string)},
 		"repository": []string{d.Get("repository").(string)},
 	}.Encode()
@@ -90,6 +122,12 @@ func resourceSonarqubeGitlabBindingCreate(d *schema.ResourceData, m interface{})
 	id := fmt.Sprintf("%v/%v", d.Get("project").(string), d.Get("repository").(string))
 	d.SetId(id)
 
+	if d.Get("wait_for_first_analysis").(bool) && d.IsNewResource() {
+		if err := waitForFirstAnalysis(d.Get("project").(string), d.Timeout(schema.TimeoutCreate), m); err != nil {
+			return err
+		}
+	}
+
 	return resourceSonarqubeGitlabBindingRead(d, m)
 }
 
@@ -98,44 +136,50 @@ func resourceSonarqubeGitlabBindingRead(d *schema.ResourceData, m interface{}) e
 		return err
 	}
 
-	idSlice := strings.SplitN(d.Id(), "/", 2)
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_settings/get_binding"
-	sonarQubeURL.RawQuery = url.Values{
-		"project": []string{idSlice[0]},
-	}.Encode()
-
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"GET",
-		sonarQubeURL.String(),
-		http.StatusOK,
-		"resourceSonarqubeGitlabBindingRead",
-	)
+	parts, err := parseImportID(d.Id(), "{project}/{repository}")
 	if err != nil {
-		return err
+		return fmt.Errorf("resourceSonarqubeGitlabBindingRead: %+v", err)
 	}
-	defer resp.Body.Close()
-
-	// Decode response into struct
-	BindingReadResponse := GetBinding{}
-	err = json.NewDecoder(resp.Body).Decode(&BindingReadResponse)
+	BindingReadResponse, err := readProjectBindingFromApi(parts[0], m)
 	if err != nil {
-		return fmt.Errorf("resourceSonarqubeGitlabBindingRead: Failed to decode json into struct: %+v", err)
+		return err
 	}
 	// Loop over all branches to see if the main branch we need exists.
-	if idSlice[1] == BindingReadResponse.Repository && BindingReadResponse.Alm == "gitlab" {
+	if parts[1] == BindingReadResponse.Repository && BindingReadResponse.Alm == "gitlab" {
 		errs := []error{}
-		errs = append(errs, d.Set("project", idSlice[0]))
-		errs = append(errs, d.Set("repository", idSlice[1]))
+		errs = append(errs, d.Set("project", parts[0]))
+		errs = append(errs, d.Set("repository", parts[1]))
 		errs = append(errs, d.Set("alm_setting", BindingReadResponse.Key))
-		errs = append(errs, d.Set("monorepo", strconv.FormatBool(BindingReadResponse.Monorepo)))
+		errs = append(errs, d.Set("monorepo", BindingReadResponse.Monorepo))
 
 		return errors.Join(errs...)
 	}
 	return fmt.Errorf("resourceSonarqubeGitlabBindingRead: Failed to find gitlab binding: %+v", d.Id())
 }
 
+func resourceSonarqubeGitlabBindingUpdate(d *schema.ResourceData, m interface{}) error {
+	// You can update any project binding with the same API call as the CREATE
+	if err := resourceSonarqubeGitlabBindingCreate(d, m); err != nil {
+		return err
+	}
+	if !d.HasChange("project") {
+		return nil
+	}
+
+	// The binding already followed the project when its key was updated, otherwise the previous project is unbound
+	oldProject, _ := d.GetChange("project")
+	binding, err := findProjectBindingFromApi(oldProject.(string), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeGitlabBindingUpdate: %+v", err)
+	}
+	if binding != nil && binding.Alm == "gitlab" {
+		if err := deleteProjectBinding(oldProject.(string), m); err != nil {
+			return fmt.Errorf("resourceSonarqubeGitlabBindingUpdate: %+v", err)
+		}
+	}
+	return nil
+}
+
 func resourceSonarqubeGitlabBindingDelete(d *schema.ResourceData, m interface{}) error {
 	if err := checkGitlabBindingSupport(m.(*ProviderConfiguration)); err != nil {
 		return err
@@ -163,8 +207,70 @@ func resourceSonarqubeGitlabBindingDelete(d *schema.ResourceData, m interface{})
 }
 
 func resourceSonarqubeGitlabBindingImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importIdComponents, err := parseImportID(d.Id(), "{project}/[{repository}]")
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeGitlabBindingImport: %+v", err)
+	}
+
+	// The repository is derived from the binding when only the project key is given
+	if importIdComponents[1] == "" {
+		binding, err := readProjectBindingFromApi(importIdComponents[0], m)
+		if err != nil {
+			return nil, err
+		}
+		if binding.Alm != "gitlab" {
+			return nil, fmt.Errorf("resourceSonarqubeGitlabBindingImport: project '%s' is bound to %s, not gitlab", importIdComponents[0], binding.Alm)
+		}
+		d.SetId(fmt.Sprintf("%v/%v", importIdComponents[0], binding.Repository))
+	}
+
 	if err := resourceSonarqubeGitlabBindingRead(d, m); err != nil {
 		return nil, err
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// readProjectBindingFromApi returns the DevOps Platform binding of a project
+func readProjectBindingFromApi(project string, m interface{}) (*GetBinding, error) {
+	binding, err := findProjectBindingFromApi(project, m)
+	if err != nil {
+		return nil, err
+	}
+	if binding == nil {
+		return nil, fmt.Errorf("readProjectBindingFromApi: project '%s' is not bound to a DevOps Platform", project)
+	}
+	return binding, nil
+}
+
+// findProjectBindingFromApi returns the DevOps Platform binding of a project, or nil when the project is not bound
+func findProjectBindingFromApi(project string, m interface{}) (*GetBinding, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_settings/get_binding"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"findProjectBindingFromApi",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	binding := GetBinding{}
+	err = json.NewDecoder(resp.Body).Decode(&binding)
+	if err != nil {
+		return nil, fmt.Errorf("findProjectBindingFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &binding, nil
+}
@@ -1,9 +1,13 @@
 package sonarqube
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -19,7 +23,7 @@ func testSweepSonarqubeGitlabBinding(r string) error {
 	return nil
 }
 func testAccPreCheckGitlabBindingSupport(t *testing.T) {
-	if err := checkGitlabBindingSupport(testAccProvider.Meta().(*ProviderConfiguration)); err != nil {
+	if err := checkGitlabBindingSupport(testAccProviderConfiguration(t)); err != nil {
 		t.Skipf("Skipping test of unsupported feature (Gitlab Binding)")
 	}
 }
@@ -41,7 +45,7 @@ func testAccSonarqubeGitlabBindingName(rnd string, projName string, almSetting s
 
         resource "sonarqube_gitlab_binding" "%[1]s" {
             alm_setting   = "%[3]s"
-            monorepo     = "false"
+            monorepo     = false
             project = sonarqube_project.%[1]s.project
             repository   = "%[4]s"
             depends_on = [sonarqube_alm_gitlab.%[1]s]
@@ -111,6 +115,136 @@ func TestAccSonarqubeGitlabBindingName(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "alm_setting", "GitLab"),
 				),
 			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "testAccSonarqubeGitlabBindingName",
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
+
+func TestResourceSonarqubeGitlabBindingImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		importID string
+		wantID   string
+		wantErr  string
+	}{
+		{
+			name:     "project and repository",
+			importID: "my_project/123",
+			wantID:   "my_project/123",
+		},
+		{
+			name:     "repository derived from the binding",
+			importID: "my_project",
+			wantID:   "my_project/123",
+		},
+		{
+			name:     "project bound to another platform",
+			importID: "github_project",
+			wantErr:  "is bound to github, not gitlab",
+		},
+		{
+			name:     "project not bound",
+			importID: "unbound_project",
+			wantErr:  "is not bound to a DevOps Platform",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockSonarQube(t)
+			mockBindingAPI(mock, "gitlab", map[string]GetBinding{
+				"my_project":     {Key: "my-alm", Alm: "gitlab", Repository: "123"},
+				"github_project": {Key: "my-github", Alm: "github", Repository: "my-org/my-repo"},
+			})
+			r := resourceSonarqubeGitlabBinding()
+
+			d := r.TestResourceData()
+			d.SetId(tt.importID)
+			_, err := r.Importer.State(d, mock.conf("Developer", "10.7"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Import() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Import() unexpected error = %v", err)
+			}
+			if d.Id() != tt.wantID || d.Get("alm_setting").(string) != "my-alm" {
+				t.Errorf("Import() ID = %s, alm_setting = %s, want %s and my-alm", d.Id(), d.Get("alm_setting"), tt.wantID)
+			}
+		})
+	}
+}
+
+func TestResourceSonarqubeGitlabBindingUpdateProject(t *testing.T) {
+	tests := []struct {
+		name string
+		// renamed updates the key of the project, which keeps its binding, before the binding is updated
+		renamed bool
+	}{
+		{
+			name:    "project key updated",
+			renamed: true,
+		},
+		{
+			name:    "another project",
+			renamed: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockSonarQube(t)
+			bindings := map[string]GetBinding{}
+			mockBindingAPI(mock, "gitlab", bindings)
+			conf := mock.conf("Developer", "10.7")
+			r := resourceSonarqubeGitlabBinding()
+
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"alm_setting": "my-alm",
+				"project":     "old_key",
+				"repository":  "123",
+			})
+			if err := r.Create(d, conf); err != nil {
+				t.Fatalf("Create() unexpected error = %v", err)
+			}
+
+			if tt.renamed {
+				bindings["new_key"] = bindings["old_key"]
+				delete(bindings, "old_key")
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"alm_setting": "my-alm",
+				"project":     "new_key",
+				"repository":  "123",
+			})
+			diff, err := r.Diff(context.Background(), d.State(), config, conf)
+			if err != nil {
+				t.Fatalf("Diff() unexpected error = %v", err)
+			}
+			if diff.RequiresNew() {
+				t.Errorf("Diff() requires a new resource, want the binding updated in place")
+			}
+			state, diags := r.Apply(context.Background(), d.State(), diff, conf)
+			if diags.HasError() {
+				t.Fatalf("Update() unexpected error = %v", diags)
+			}
+
+			if state.ID != "new_key/123" {
+				t.Errorf("Update() ID = %s, want new_key/123", state.ID)
+			}
+			if bindings["new_key"].Repository != "123" {
+				t.Errorf("Update() binding of new_key = %+v, want repository 123", bindings["new_key"])
+			}
+			if _, ok := bindings["old_key"]; ok {
+				t.Errorf("Update() did not unbind old_key")
+			}
+		})
+	}
+}
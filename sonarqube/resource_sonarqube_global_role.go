@@ -0,0 +1,153 @@
+package sonarqube
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// globalRolePresets are the recommended sets of global permissions granted by sonarqube_global_role
+var globalRolePresets = map[string][]string{
+	"administrator":   {"admin", "gateadmin", "profileadmin", "provisioning", "scan"},
+	"quality-manager": {"gateadmin", "profileadmin"},
+	"project-creator": {"provisioning"},
+	"scanner":         {"scan"},
+}
+
+// globalRoleNames returns the names of the presets in a stable order
+func globalRoleNames() []string {
+	names := make([]string, 0, len(globalRolePresets))
+	for name := range globalRolePresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeGlobalRole() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Global Role resource. This grants a recommended set of global permissions to a group:
+
+- ` + "`administrator`" + `: admin, gateadmin, profileadmin, provisioning and scan
+- ` + "`quality-manager`" + `: gateadmin and profileadmin
+- ` + "`project-creator`" + `: provisioning
+- ` + "`scanner`" + `: scan
+
+Destroying this resource revokes all the permissions of the role, including those also granted by another role or resource.
+It supports importing using the format '{role}/{group_name}'.`,
+		Create: resourceSonarqubeGlobalRoleCreate,
+		Read:   resourceSonarqubeGlobalRoleRead,
+		Delete: resourceSonarqubeGlobalRoleDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeGlobalRoleImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"group_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The name of the group that gets the role. Changing this forces a new resource to be created.",
+			},
+			"role": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(globalRoleNames(), false)),
+				Description:      "The role to grant. Possible values are `administrator`, `project-creator`, `quality-manager` and `scanner`. Changing this forces a new resource to be created.",
+			},
+			"permissions": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The global permissions granted by the role.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeGlobalRoleCreate(d *schema.ResourceData, m interface{}) error {
+	group := d.Get("group_name").(string)
+	role := d.Get("role").(string)
+
+	changes := []permissionChange{}
+	for _, permission := range globalRolePresets[role] {
+		changes = append(changes, permissionChange{action: "add", permission: permission})
+	}
+	if err := applyPermissionChanges(principalGroup, group, permissionScope{}, changes, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeGlobalRoleCreate: Failed to grant role '%s' to group '%s': %+v", role, group, err)
+	}
+
+	d.SetId(role + "/" + group)
+
+	return resourceSonarqubeGlobalRoleRead(d, m)
+}
+
+func resourceSonarqubeGlobalRoleRead(d *schema.ResourceData, m interface{}) error {
+	group := d.Get("group_name").(string)
+	role := d.Get("role").(string)
+
+	current, err := getPrincipalPermissions(principalGroup, group, permissionScope{}, m)
+	if err != nil {
+		return err
+	}
+
+	// A role missing one of its permissions is recreated to grant it again
+	if missing, _ := calculatePermissionChanges(current, globalRolePresets[role]); len(missing) > 0 {
+		log.Printf("[WARN][resourceSonarqubeGlobalRoleRead] Group '%s' is missing the permissions %s of role '%s', removing it from the state", group, strings.Join(missing, ", "), role)
+		d.SetId("")
+		return nil
+	}
+
+	return d.Set("permissions", globalRolePresets[role])
+}
+
+func resourceSonarqubeGlobalRoleDelete(d *schema.ResourceData, m interface{}) error {
+	group := d.Get("group_name").(string)
+	role := d.Get("role").(string)
+
+	changes := []permissionChange{}
+	for _, permission := range globalRolePresets[role] {
+		changes = append(changes, permissionChange{action: "remove", permission: permission})
+	}
+	if err := applyPermissionChanges(principalGroup, group, permissionScope{}, changes, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeGlobalRoleDelete: Failed to revoke role '%s' from group '%s': %+v", role, group, err)
+	}
+
+	return nil
+}
+
+func resourceSonarqubeGlobalRoleImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts, err := parseImportID(d.Id(), "{role}/{group_name}")
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeGlobalRoleImport: %+v", err)
+	}
+	if _, ok := globalRolePresets[parts[0]]; !ok {
+		return nil, fmt.Errorf("resourceSonarqubeGlobalRoleImport: unknown role '%s', possible values are: %s", parts[0], strings.Join(globalRoleNames(), ", "))
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("role", parts[0]))
+	errs = append(errs, d.Set("group_name", parts[1]))
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if err := resourceSonarqubeGlobalRoleRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeGlobalRoleImport: group '%s' does not have all the permissions of role '%s'", parts[1], parts[0])
+	}
+	return []*schema.ResourceData{d}, nil
+}
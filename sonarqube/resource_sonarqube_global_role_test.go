@@ -0,0 +1,53 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeGlobalRoleConfig(rnd string, name string, role string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[2]s"
+		}
+
+		resource "sonarqube_global_role" "%[1]s" {
+			group_name = sonarqube_group.%[1]s.name
+			role       = "%[3]s"
+		}`, rnd, name, role)
+}
+
+func TestAccSonarqubeGlobalRoleBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_global_role." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGlobalRoleConfig(rnd, "testAccSonarqubeGlobalRole", "quality-manager"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "quality-manager/testAccSonarqubeGlobalRole"),
+					resource.TestCheckResourceAttr(name, "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr(name, "permissions.*", "gateadmin"),
+					resource.TestCheckTypeSetElemAttr(name, "permissions.*", "profileadmin"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGlobalRoleConfig(rnd, "testAccSonarqubeGlobalRole", "scanner"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "permissions.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "permissions.*", "scan"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
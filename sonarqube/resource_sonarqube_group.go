@@ -1,6 +1,7 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -33,20 +35,50 @@ type Group struct {
 	Permissions  []string `json:"permissions,omitempty"`
 }
 
+// GroupV2 used in GetGroupsV2
+type GroupV2 struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Managed     bool   `json:"managed"`
+	Default     bool   `json:"default"`
+}
+
+// GetGroupsV2 for unmarshalling response body of api/v2/authorizations/groups
+type GetGroupsV2 struct {
+	Groups []GroupV2 `json:"groups"`
+}
+
+// groupsV2MinimumVersion is the first version of SonarQube with the api/v2/authorizations/groups endpoints. Only the
+// update, the deletion and the managed flag use them, the groups are still created and read with api/user_groups.
+const groupsV2MinimumVersion = "10.5"
+
 // Returns the resource represented by this file.
 func resourceSonarqubeGroup() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a Sonarqube Group resource. This can be used to create and manage Sonarqube Groups.",
-		Create:      resourceSonarqubeGroupCreate,
-		Read:        resourceSonarqubeGroupRead,
-		Update:      resourceSonarqubeGroupUpdate,
-		Delete:      resourceSonarqubeGroupDelete,
+		Description: `Provides a Sonarqube Group resource. This can be used to create and manage Sonarqube Groups. From SonarQube 10.5,
+groups are updated and deleted with the ` + "`api/v2/authorizations/groups`" + ` endpoints, which also provide ` + "`managed`" + `. They are
+still created and read with the ` + "`api/user_groups`" + ` endpoints.`,
+		Create: resourceSonarqubeGroupCreate,
+		Read:   resourceSonarqubeGroupRead,
+		Update: resourceSonarqubeGroupUpdate,
+		Delete: resourceSonarqubeGroupDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeGroupImport,
 		},
+		// Groups provisioned from GitHub, GitLab or SCIM cannot be changed, fail at plan time instead of with a 400 at apply
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateGroupResource(d)
+			},
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateResourceOrganization(d, meta)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
+			"organization": organizationSchema(),
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -57,11 +89,17 @@ func resourceSonarqubeGroup() *schema.Resource {
 				Optional:    true,
 				Description: "Description of the Group.",
 			},
+			"managed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the Group is managed by an external provider (GitHub, GitLab or SCIM auto-provisioning). Managed groups cannot be updated or deleted. Only known with SonarQube 10.5 and above.",
+			},
 		},
 	}
 }
 
 func resourceSonarqubeGroupCreate(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/create"
 	sonarQubeURL.RawQuery = url.Values{
@@ -93,6 +131,7 @@ func resourceSonarqubeGroupCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/search"
 	sonarQubeURL.RawQuery = url.Values{
@@ -133,7 +172,17 @@ func resourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
 			// If it does, set the values of that group
 			errName := d.Set("name", value.Name)
 			errDesc := d.Set("description", value.Description)
-			if err := errors.Join(errName, errDesc); err != nil {
+			errManaged := d.Set("managed", false)
+			if supportsV2API(m.(*ProviderConfiguration), groupsV2MinimumVersion) {
+				group, err := findGroupV2FromApi(value.Name, m)
+				if err != nil {
+					return fmt.Errorf("resourceSonarqubeGroupRead: %+v", err)
+				}
+				if group != nil {
+					errManaged = d.Set("managed", group.Managed)
+				}
+			}
+			if err := errors.Join(errName, errDesc, errManaged); err != nil {
 				return err
 			}
 			readSuccess = true
@@ -152,6 +201,36 @@ func resourceSonarqubeGroupRead(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
+	if supportsV2API(m.(*ProviderConfiguration), groupsV2MinimumVersion) {
+		oldName, _ := d.GetChange("name")
+		group, err := readGroupV2FromApi(oldName.(string), m)
+		if err != nil {
+			return err
+		}
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/groups/" + url.PathEscape(group.ID)
+
+		resp, err := httpRequestHelperV2(
+			m.(*ProviderConfiguration).httpClient,
+			"PATCH",
+			sonarQubeURL.String(),
+			map[string]string{
+				"name":        d.Get("name").(string),
+				"description": d.Get("description").(string),
+			},
+			http.StatusOK,
+			"resourceSonarqubeGroupUpdate",
+		)
+		if err != nil {
+			return fmt.Errorf("error updating Sonarqube group: %+v", err)
+		}
+		defer resp.Body.Close()
+
+		return resourceSonarqubeGroupRead(d, m)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/update"
 
@@ -188,6 +267,36 @@ func resourceSonarqubeGroupUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeGroupDelete(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
+	if d.Get("managed").(bool) {
+		return fmt.Errorf("resourceSonarqubeGroupDelete: group '%s' is managed by an external provider and cannot be deleted, remove it from the state instead", d.Get("name").(string))
+	}
+
+	if supportsV2API(m.(*ProviderConfiguration), groupsV2MinimumVersion) {
+		group, err := readGroupV2FromApi(d.Get("name").(string), m)
+		if err != nil {
+			return err
+		}
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/groups/" + url.PathEscape(group.ID)
+
+		resp, err := httpRequestHelperV2(
+			m.(*ProviderConfiguration).httpClient,
+			"DELETE",
+			sonarQubeURL.String(),
+			nil,
+			http.StatusNoContent,
+			"resourceSonarqubeGroupDelete",
+		)
+		if err != nil {
+			return fmt.Errorf("error deleting Sonarqube group: %+v", err)
+		}
+		defer resp.Body.Close()
+
+		return nil
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/delete"
 
@@ -222,3 +331,103 @@ func resourceSonarqubeGroupImport(d *schema.ResourceData, m interface{}) ([]*sch
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// validateGroupResource rejects changes to groups that are managed by an external provider
+func validateGroupResource(d *schema.ResourceDiff) error {
+	if d.Id() == "" || !d.Get("managed").(bool) {
+		return nil
+	}
+	if d.HasChange("name") || d.HasChange("description") {
+		oldName, _ := d.GetChange("name")
+		return fmt.Errorf("group '%s' is managed by an external provider (GitHub, GitLab or SCIM auto-provisioning) and cannot be updated", oldName)
+	}
+	return nil
+}
+
+// readGroupV2FromApi returns the group with the given name from the v2 api, or an error when it does not exist
+func readGroupV2FromApi(name string, m interface{}) (*GroupV2, error) {
+	group, err := findGroupV2FromApi(name, m)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		return nil, fmt.Errorf("readGroupV2FromApi: group '%s' not found", name)
+	}
+	return group, nil
+}
+
+// findGroupV2FromApi returns the group with the given name from the v2 api, or nil when it does not exist
+func findGroupV2FromApi(name string, m interface{}) (*GroupV2, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/authorizations/groups"
+	sonarQubeURL.RawQuery = url.Values{
+		"q":        []string{name},
+		"pageSize": []string{"500"},
+	}.Encode()
+
+	resp, err := httpRequestHelperV2(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		nil,
+		http.StatusOK,
+		"findGroupV2FromApi",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading Sonarqube group: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	groups := GetGroupsV2{}
+	err = json.NewDecoder(resp.Body).Decode(&groups)
+	if err != nil {
+		return nil, fmt.Errorf("findGroupV2FromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, group := range groups.Groups {
+		if group.Name == name {
+			return &group, nil
+		}
+	}
+	return nil, nil
+}
+
+// findGroupFromApi returns the group with exactly this name, or nil when it does not exist
+func findGroupFromApi(name string, m interface{}) (*Group, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"ps": []string{"500"},
+		"q":  []string{name},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"findGroupFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("findGroupFromApi: Failed to search group '%s': %+v", name, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	groups := GetGroup{}
+	err = json.NewDecoder(resp.Body).Decode(&groups)
+	if err != nil {
+		return nil, fmt.Errorf("findGroupFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, group := range groups.Groups {
+		if group.Name == name {
+			return &group, nil
+		}
+	}
+	return nil, nil
+}
@@ -38,16 +38,18 @@ func resourceSonarqubeGroupMember() *schema.Resource {
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The name of the Group to add a member to. Changing this forces a new resource to be created.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The name of the Group to add a member to. Changing this forces a new resource to be created.",
 			},
 			"login_name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The `login_name` of the User to add as a member. Changing this forces a new resource to be created.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The `login_name` of the User to add as a member. Changing this forces a new resource to be created.",
 			},
 		},
 	}
@@ -115,7 +117,7 @@ func resourceSonarqubeGroupMemberRead(d *schema.ResourceData, m interface{}) err
 	}
 	// Loop over all returned members to see if the member we need exists.
 	for _, value := range groupMemberReadResponse.Members {
-		if d.Get("login_name").(string) == value.LoginName {
+		if strings.EqualFold(d.Get("login_name").(string), value.LoginName) {
 			// If it does, set the values of that group membership
 			d.SetId(createGroupMembershipId(d.Get("name").(string), d.Get("login_name").(string)))
 			errName := d.Set("name", d.Get("name").(string))
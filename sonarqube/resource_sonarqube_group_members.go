@@ -0,0 +1,218 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeGroupMembers() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube authoritative Group Members resource. This resource owns the full membership list of a group: users
+that are added outside of this resource are detected as drift and removed on the next apply. Do not combine this resource with
+` + "`sonarqube_group_member`" + ` on the same group. Destroying this resource removes the members known to the state from the group.
+It supports importing using the group name.`,
+		Create: resourceSonarqubeGroupMembersCreate,
+		Read:   resourceSonarqubeGroupMembersRead,
+		Update: resourceSonarqubeGroupMembersCreate,
+		Delete: resourceSonarqubeGroupMembersDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeGroupMembersImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"group": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The name of the group. Changing this forces a new resource to be created.",
+			},
+			"members": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The logins of all the members of the group. Users that are not listed are removed from the group.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeGroupMembersCreate(d *schema.ResourceData, m interface{}) error {
+	group := d.Get("group").(string)
+	current, err := listGroupMembersFromApi(group, m)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersCreate: group '%s' not found, it must be created first, for example with a sonarqube_group resource", group)
+	}
+	desired := expandStringList(d.Get("members"))
+	current = alignLogins(current, desired)
+
+	errs := []error{}
+	for _, login := range desired {
+		if !slices.Contains(current, login) {
+			errs = append(errs, addGroupMember(group, login, m))
+		}
+	}
+	for _, login := range current {
+		if !slices.Contains(desired, login) {
+			errs = append(errs, removeGroupMember(group, login, m))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersCreate: %+v", err)
+	}
+
+	d.SetId(group)
+
+	return resourceSonarqubeGroupMembersRead(d, m)
+}
+
+func resourceSonarqubeGroupMembersRead(d *schema.ResourceData, m interface{}) error {
+	members, err := listGroupMembersFromApi(d.Id(), m)
+	if err != nil {
+		return err
+	}
+	if members == nil {
+		log.Printf("[WARN][resourceSonarqubeGroupMembersRead] Group '%s' not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("group", d.Id()))
+	errs = append(errs, d.Set("members", alignLogins(members, expandStringList(d.Get("members")))))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeGroupMembersDelete(d *schema.ResourceData, m interface{}) error {
+	// Only the members known to the state are removed
+	errs := []error{}
+	for _, login := range expandStringList(d.Get("members")) {
+		errs = append(errs, removeGroupMember(d.Id(), login, m))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupMembersDelete: %+v", err)
+	}
+	return nil
+}
+
+func resourceSonarqubeGroupMembersImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	group := d.Id()
+	if err := resourceSonarqubeGroupMembersRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeGroupMembersImport: group '%s' not found", group)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// listGroupMembersFromApi returns the logins of every page of the members of a group, or nil when the group does not exist
+func listGroupMembersFromApi(group string, m interface{}) ([]string, error) {
+	logins := []string{}
+
+	for page := 1; ; page++ {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/users"
+		sonarQubeURL.RawQuery = url.Values{
+			"name": []string{group},
+			"p":    []string{strconv.Itoa(page)},
+			"ps":   []string{"500"},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"listGroupMembersFromApi",
+		)
+		if err != nil {
+			if resp.StatusCode == http.StatusNotFound {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("listGroupMembersFromApi: Failed to read the members of group '%s': %+v", group, err)
+		}
+
+		// Decode response into struct
+		response := GetGroupMembersResponse{}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("listGroupMembersFromApi: Failed to decode json into struct: %+v", err)
+		}
+
+		for _, member := range response.Members {
+			logins = append(logins, member.LoginName)
+		}
+		if len(response.Members) == 0 || int64(len(logins)) >= response.Paging.Total {
+			break
+		}
+	}
+
+	return logins, nil
+}
+
+// alignLogins renames the logins of the server to the casing of the configuration, as SonarQube matches logins
+// case-insensitively
+func alignLogins(current []string, configured []string) []string {
+	aligned := make([]string, 0, len(current))
+	for _, login := range current {
+		for _, name := range configured {
+			if strings.EqualFold(name, login) {
+				login = name
+				break
+			}
+		}
+		aligned = append(aligned, login)
+	}
+	return aligned
+}
+
+// addGroupMember adds a user to a group
+func addGroupMember(group string, login string, m interface{}) error {
+	return updateGroupMember("add_user", group, login, m)
+}
+
+// removeGroupMember removes a user from a group
+func removeGroupMember(group string, login string, m interface{}) error {
+	return updateGroupMember("remove_user", group, login, m)
+}
+
+func updateGroupMember(action string, group string, login string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/user_groups/" + action
+	sonarQubeURL.RawQuery = url.Values{
+		"name":  []string{group},
+		"login": []string{login},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"updateGroupMember",
+	)
+	if err != nil {
+		return fmt.Errorf("error calling %s with user '%s' on group '%s': %+v", action, login, group, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,168 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeGroupMembersConfig(rnd string, groupName string, members string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s_first" {
+			login_name = "%[1]s-first"
+			name       = "First User"
+			password   = "secret-sauce!"
+		}
+
+		resource "sonarqube_user" "%[1]s_second" {
+			login_name = "%[1]s-second"
+			name       = "Second User"
+			password   = "secret-sauce!"
+		}
+
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[2]s"
+		}
+
+		resource "sonarqube_group_members" "%[1]s" {
+			group   = sonarqube_group.%[1]s.name
+			members = %[3]s
+		}`, rnd, groupName, members)
+}
+
+func TestAccSonarqubeGroupMembersBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_group_members." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGroupMembersConfig(rnd, "testAccSonarqubeGroupMembers", fmt.Sprintf("[sonarqube_user.%[1]s_first.login_name, sonarqube_user.%[1]s_second.login_name]", rnd)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "group", "testAccSonarqubeGroupMembers"),
+					resource.TestCheckResourceAttr(name, "members.#", "2"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGroupMembersConfig(rnd, "testAccSonarqubeGroupMembers", fmt.Sprintf("[sonarqube_user.%[1]s_second.login_name]", rnd)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "members.#", "1"),
+					resource.TestCheckTypeSetElemAttr(name, "members.*", rnd+"-second"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "testAccSonarqubeGroupMembers",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// mockGroupMembersAPI serves the members of the groups from the mock, pageSize members at a time
+func mockGroupMembersAPI(mock *mockSonarQube, groups map[string][]string, pageSize int) {
+	var mutex sync.Mutex
+
+	mock.handle("GET", "/api/user_groups/users", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		members, ok := groups[r.URL.Query().Get("name")]
+		if !ok {
+			mockError(w, http.StatusNotFound, fmt.Sprintf("No group with name '%s'", r.URL.Query().Get("name")))
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("p"))
+		start := min((page-1)*pageSize, len(members))
+		end := min(start+pageSize, len(members))
+		users := []GroupMember{}
+		for _, login := range members[start:end] {
+			users = append(users, GroupMember{LoginName: login})
+		}
+		mockJSON(w, GetGroupMembersResponse{
+			Paging:  Paging{PageIndex: int64(page), PageSize: int64(pageSize), Total: int64(len(members))},
+			Members: users,
+		})
+	})
+	mock.handle("POST", "/api/user_groups/add_user", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		group := r.URL.Query().Get("name")
+		groups[group] = append(groups[group], r.URL.Query().Get("login"))
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mock.handle("POST", "/api/user_groups/remove_user", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		group := r.URL.Query().Get("name")
+		members := []string{}
+		for _, login := range groups[group] {
+			if !strings.EqualFold(login, r.URL.Query().Get("login")) {
+				members = append(members, login)
+			}
+		}
+		groups[group] = members
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func TestResourceSonarqubeGroupMembersLifecycle(t *testing.T) {
+	mock := newMockSonarQube(t)
+	// The members are listed on several pages, and the logins differ in case from the configuration
+	groups := map[string][]string{"developers": {"Alice", "bob", "mallory"}}
+	mockGroupMembersAPI(mock, groups, 2)
+	conf := mock.conf("Community", "10.7")
+	r := resourceSonarqubeGroupMembers()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"group":   "developers",
+		"members": []interface{}{"alice", "bob", "carol"},
+	})
+	if err := r.Create(d, conf); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if got := sortedStrings(groups["developers"]); !reflect.DeepEqual(got, []string{"Alice", "bob", "carol"}) {
+		t.Errorf("Create() members = %v, want [Alice bob carol]", got)
+	}
+	if got := sortedStrings(expandStringList(d.Get("members"))); !reflect.DeepEqual(got, []string{"alice", "bob", "carol"}) {
+		t.Errorf("Create() state members = %v, want the casing of the configuration", got)
+	}
+
+	// Members added outside of Terraform are detected
+	groups["developers"] = append(groups["developers"], "eve")
+	if err := r.Read(d, conf); err != nil {
+		t.Fatalf("Read() unexpected error = %v", err)
+	}
+	if got := sortedStrings(expandStringList(d.Get("members"))); !reflect.DeepEqual(got, []string{"alice", "bob", "carol", "eve"}) {
+		t.Errorf("Read() members = %v, want the drift", got)
+	}
+
+	groups["developers"] = []string{"Alice", "bob", "carol", "other"}
+	if err := r.Delete(d, conf); err != nil {
+		t.Fatalf("Delete() unexpected error = %v", err)
+	}
+	if got := groups["developers"]; !reflect.DeepEqual(got, []string{"other"}) {
+		t.Errorf("Delete() members = %v, want only the members unknown to the state", got)
+	}
+
+	// The resource is removed from the state with the group
+	delete(groups, "developers")
+	if err := r.Read(d, conf); err != nil {
+		t.Fatalf("Read() unexpected error = %v", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("Read() ID = %s, want no ID", d.Id())
+	}
+}
@@ -0,0 +1,215 @@
+package sonarqube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeGroupPermissions() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Group Permissions resource. This resource grants the same project permissions to a group on many projects,
+instead of one ` + "`sonarqube_permissions`" + ` resource per project. Only the listed permissions are managed, other permissions of the group on the projects are left untouched.
+When some projects fail, the projects that were updated are kept in the state and the others are retried on the next apply.
+It supports importing using the format '{group_name}/{project_key},{project_key}', the permissions are read from the first project.`,
+		Create: resourceSonarqubeGroupPermissionsCreate,
+		Read:   resourceSonarqubeGroupPermissionsRead,
+		Update: resourceSonarqubeGroupPermissionsUpdate,
+		Delete: resourceSonarqubeGroupPermissionsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeGroupPermissionsImport,
+		},
+		// Validate the permission names once the plan is known
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if !d.NewValueKnown("permissions") {
+					return nil
+				}
+				conf, _ := meta.(*ProviderConfiguration)
+				return validatePermissionNames(expandPermissions(d.Get("permissions")), conf, true)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"group_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The name of the group that gets the permissions. Changing this forces a new resource to be created.",
+			},
+			"project_keys": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The keys of the projects the permissions are granted on.",
+			},
+			"permissions": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The permissions granted on every project. Possible values are: `admin`, `codeviewer`, `issueadmin`, `securityhotspotadmin`, `scan`, `user`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeGroupPermissionsCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(d.Get("group_name").(string))
+
+	if err := applyGroupPermissions(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupPermissionsCreate: %+v", err)
+	}
+
+	return resourceSonarqubeGroupPermissionsRead(d, m)
+}
+
+func resourceSonarqubeGroupPermissionsRead(d *schema.ResourceData, m interface{}) error {
+	groupName := d.Get("group_name").(string)
+	permissions := expandPermissions(d.Get("permissions"))
+
+	// Projects where the group lost one of the permissions are removed from the state, so they are granted again
+	granted := []interface{}{}
+	for _, projectKey := range expandPermissions(d.Get("project_keys")) {
+		current, err := getPrincipalPermissions(principalGroup, groupName, permissionScope{projectKey: projectKey}, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeGroupPermissionsRead: %+v", err)
+		}
+		if toAdd, _ := calculatePermissionChanges(current, permissions); len(toAdd) > 0 {
+			log.Printf("[WARN][resourceSonarqubeGroupPermissionsRead] Group '%s' is missing the permissions %s on project '%s'", groupName, strings.Join(toAdd, ", "), projectKey)
+			continue
+		}
+		granted = append(granted, projectKey)
+	}
+
+	return d.Set("project_keys", granted)
+}
+
+func resourceSonarqubeGroupPermissionsUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := applyGroupPermissions(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeGroupPermissionsUpdate: %+v", err)
+	}
+
+	return resourceSonarqubeGroupPermissionsRead(d, m)
+}
+
+func resourceSonarqubeGroupPermissionsDelete(d *schema.ResourceData, m interface{}) error {
+	groupName := d.Get("group_name").(string)
+
+	changes := []permissionChange{}
+	for _, permission := range expandPermissions(d.Get("permissions")) {
+		changes = append(changes, permissionChange{action: "remove", permission: permission})
+	}
+
+	errs := []error{}
+	remaining := []interface{}{}
+	for _, projectKey := range expandPermissions(d.Get("project_keys")) {
+		if err := applyPermissionChanges(principalGroup, groupName, permissionScope{projectKey: projectKey}, changes, m); err != nil {
+			errs = append(errs, err)
+			remaining = append(remaining, projectKey)
+		}
+	}
+	if len(errs) > 0 {
+		// Keep the projects that still have the permissions, so the delete can be retried
+		errs = append(errs, d.Set("project_keys", remaining))
+		return fmt.Errorf("resourceSonarqubeGroupPermissionsDelete: %+v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func resourceSonarqubeGroupPermissionsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts, err := parseImportID(d.Id(), "{group_name}/{project_keys}")
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeGroupPermissionsImport: %+v", err)
+	}
+	projectKeys := strings.Split(parts[1], ",")
+
+	permissions, err := getPrincipalPermissions(principalGroup, parts[0], permissionScope{projectKey: projectKeys[0]}, m)
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeGroupPermissionsImport: %+v", err)
+	}
+	if len(permissions) == 0 {
+		return nil, fmt.Errorf("resourceSonarqubeGroupPermissionsImport: group '%s' has no permissions on project '%s'", parts[0], projectKeys[0])
+	}
+
+	d.SetId(parts[0])
+	errs := []error{}
+	errs = append(errs, d.Set("group_name", parts[0]))
+	errs = append(errs, d.Set("project_keys", projectKeys))
+	errs = append(errs, d.Set("permissions", flattenPermissions(&permissions)))
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if err := resourceSonarqubeGroupPermissionsRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyGroupPermissions converges the permissions of the group on every project, one project at a time. The projects
+// that were converged, and the removed projects whose permissions could not be revoked, are written to the state
+// even when other projects fail.
+func applyGroupPermissions(d *schema.ResourceData, m interface{}) error {
+	groupName := d.Get("group_name").(string)
+	oldProjects, newProjects := d.GetChange("project_keys")
+	oldPermissions, newPermissions := d.GetChange("permissions")
+
+	tracked := []interface{}{}
+	errs := []error{}
+
+	oldProjectsSet, newProjectsSet := oldProjects.(*schema.Set), newProjects.(*schema.Set)
+	for _, projectKey := range expandPermissions(oldProjectsSet.Difference(newProjectsSet)) {
+		changes := groupPermissionChanges(expandPermissions(oldPermissions), []string{})
+		if err := applyPermissionChanges(principalGroup, groupName, permissionScope{projectKey: projectKey}, changes, m); err != nil {
+			errs = append(errs, err)
+			tracked = append(tracked, projectKey)
+		}
+	}
+
+	for _, projectKey := range expandPermissions(newProjectsSet) {
+		// New projects start without any of the managed permissions
+		current := []string{}
+		if oldProjectsSet.Contains(projectKey) {
+			current = expandPermissions(oldPermissions)
+		}
+		changes := groupPermissionChanges(current, expandPermissions(newPermissions))
+		if err := applyPermissionChanges(principalGroup, groupName, permissionScope{projectKey: projectKey}, changes, m); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		tracked = append(tracked, projectKey)
+	}
+
+	if len(errs) > 0 {
+		errs = append(errs, d.Set("project_keys", tracked))
+	}
+	return errors.Join(errs...)
+}
+
+// groupPermissionChanges returns the changes that turn the current permissions into the target permissions
+func groupPermissionChanges(current []string, target []string) []permissionChange {
+	toAdd, toRemove := calculatePermissionChanges(current, target)
+	changes := []permissionChange{}
+	for _, permission := range toRemove {
+		changes = append(changes, permissionChange{action: "remove", permission: permission})
+	}
+	for _, permission := range toAdd {
+		changes = append(changes, permissionChange{action: "add", permission: permission})
+	}
+	return changes
+}
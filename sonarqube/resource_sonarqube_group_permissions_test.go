@@ -0,0 +1,79 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeGroupPermissionsConfig(rnd string, projects string, permissions []string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_group" "%[1]s" {
+			name        = "%[1]s"
+			description = "%[1]s"
+		}
+
+		resource "sonarqube_project" "%[1]s" {
+			for_each   = toset(["%[1]s-a", "%[1]s-b", "%[1]s-c"])
+			name       = each.key
+			project    = each.key
+			visibility = "private"
+		}
+
+		resource "sonarqube_group_permissions" "%[1]s" {
+			group_name   = sonarqube_group.%[1]s.name
+			project_keys = [for key in %[2]s : sonarqube_project.%[1]s[key].project]
+			permissions  = %[3]s
+		}`, rnd, projects, generateHCLList(permissions))
+}
+
+func TestAccSonarqubeGroupPermissionsBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	resourceName := "sonarqube_group_permissions." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeGroupPermissionsConfig(rnd, fmt.Sprintf(`["%[1]s-a", "%[1]s-b"]`, rnd), []string{"user", "codeviewer"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "group_name", rnd),
+					resource.TestCheckResourceAttr(resourceName, "project_keys.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "permissions.#", "2"),
+				),
+			},
+			{
+				Config: testAccSonarqubeGroupPermissionsConfig(rnd, fmt.Sprintf(`["%[1]s-b", "%[1]s-c"]`, rnd), []string{"user", "issueadmin"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "project_keys.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "project_keys.*", rnd+"-c"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "permissions.*", "issueadmin"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("%[1]s/%[1]s-b,%[1]s-c", rnd),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestGroupPermissionChanges(t *testing.T) {
+	changes := groupPermissionChanges([]string{"user", "codeviewer"}, []string{"user", "issueadmin"})
+	expected := []permissionChange{
+		{action: "remove", permission: "codeviewer"},
+		{action: "add", permission: "issueadmin"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("groupPermissionChanges() = %v, want %v", changes, expected)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("groupPermissionChanges()[%d] = %v, want %v", i, changes[i], expected[i])
+		}
+	}
+}
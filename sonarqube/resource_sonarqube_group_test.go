@@ -2,8 +2,11 @@ package sonarqube
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -43,6 +46,7 @@ func TestAccSonarqubeGroupBasic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "name", groupName),
 					resource.TestCheckResourceAttr(name, "description", groupDescription),
+					resource.TestCheckResourceAttr(name, "managed", "false"),
 				),
 			},
 		},
@@ -108,3 +112,42 @@ func TestAccSonarqubeGroupImport(t *testing.T) {
 		},
 	})
 }
+
+func TestResourceSonarqubeGroupReadManaged(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/user_groups/search", http.StatusOK, `{"groups":[{"name":"developers","description":"Developers"}]}`)
+	r := resourceSonarqubeGroup()
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		managed bool
+		wantErr string
+	}{
+		{"Managed group", http.StatusOK, `{"groups":[{"id":"1","name":"developers","managed":true}]}`, true, ""},
+		{"Group missing from the v2 api", http.StatusNotFound, `{"message":"Group not found"}`, false, ""},
+		{"Error of the v2 api", http.StatusForbidden, `{"message":"Insufficient privileges"}`, false, "Insufficient privileges"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.respond("GET", "/api/v2/authorizations/groups", tt.status, tt.body)
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "developers"})
+			d.SetId("developers")
+
+			err := r.Read(d, mock.conf("Community", "10.5"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Read() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Read() error = %+v", err)
+			}
+			if d.Get("managed").(bool) != tt.managed {
+				t.Errorf("Read() managed = %v, want %v", d.Get("managed"), tt.managed)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package sonarqube
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// housekeepingGlobalID is the ID of the sonarqube_housekeeping resource that manages the global settings
+const housekeepingGlobalID = "global"
+
+// housekeepingSettings maps the attributes of sonarqube_housekeeping to their setting keys
+var housekeepingSettings = []settingAttribute{
+	{attribute: "inactive_branches_days", key: "sonar.dbcleaner.daysBeforeDeletingInactiveBranchesAndPRs"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeHousekeeping() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Housekeeping resource. This manages after how many days the branches and pull requests that are no
+longer analyzed are deleted, for the whole instance or for one project, which overrides the global setting. The branches that match
+` + "`branches_to_keep`" + ` of ` + "`sonarqube_branch_protection`" + ` are never deleted. Do not combine a project housekeeping with
+` + "`inactive_days_before_deletion`" + ` of ` + "`sonarqube_project_pull_request`" + ` on the same project, they manage the same setting.
+Destroying this resource resets the settings to their default value. It supports importing using ` + "`global`" + ` or the project key.`,
+		Create: resourceSonarqubeHousekeepingCreate,
+		Read:   resourceSonarqubeHousekeepingRead,
+		Update: resourceSonarqubeHousekeepingUpdate,
+		Delete: resourceSonarqubeHousekeepingDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeHousekeepingImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The key of the project. When not set, the global settings are managed. Changing this forces a new resource to be created.",
+			},
+			"inactive_branches_days": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          30,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The number of days after which the branches and pull requests that were not analyzed are deleted (`sonar.dbcleaner.daysBeforeDeletingInactiveBranchesAndPRs`). Must be at least `1`. Defaults to `30`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeHousekeepingCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	if err := setSettingAttributes(project, housekeepingSettings, d, m, false); err != nil {
+		return fmt.Errorf("resourceSonarqubeHousekeepingCreate: %+v", err)
+	}
+
+	if project != "" {
+		d.SetId(project)
+	} else {
+		d.SetId(housekeepingGlobalID)
+	}
+
+	return resourceSonarqubeHousekeepingRead(d, m)
+}
+
+func resourceSonarqubeHousekeepingRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes(d.Get("project").(string), housekeepingSettings, d, m)
+}
+
+func resourceSonarqubeHousekeepingUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes(d.Get("project").(string), housekeepingSettings, d, m, true); err != nil {
+		return fmt.Errorf("resourceSonarqubeHousekeepingUpdate: %+v", err)
+	}
+
+	return resourceSonarqubeHousekeepingRead(d, m)
+}
+
+func resourceSonarqubeHousekeepingDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes(d.Get("project").(string), housekeepingSettings, m)
+}
+
+func resourceSonarqubeHousekeepingImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() != housekeepingGlobalID {
+		if err := d.Set("project", d.Id()); err != nil {
+			return nil, err
+		}
+	}
+	if err := resourceSonarqubeHousekeepingRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,65 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeHousekeepingConfig(rnd string, projectKey string, globalDays int, projectDays int) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_housekeeping" "%[1]s_global" {
+			inactive_branches_days = %[3]d
+		}
+
+		resource "sonarqube_housekeeping" "%[1]s" {
+			project                = sonarqube_project.%[1]s.project
+			inactive_branches_days = %[4]d
+		}`, rnd, projectKey, globalDays, projectDays)
+}
+
+func TestAccSonarqubeHousekeepingBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_housekeeping." + rnd
+	globalName := "sonarqube_housekeeping." + rnd + "_global"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeHousekeepingConfig(rnd, "testAccSonarqubeHousekeeping", 60, 7),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(globalName, "id", "global"),
+					resource.TestCheckResourceAttr(globalName, "inactive_branches_days", "60"),
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeHousekeeping"),
+					resource.TestCheckResourceAttr(name, "inactive_branches_days", "7"),
+				),
+			},
+			{
+				Config: testAccSonarqubeHousekeepingConfig(rnd, "testAccSonarqubeHousekeeping", 45, 14),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(globalName, "inactive_branches_days", "45"),
+					resource.TestCheckResourceAttr(name, "inactive_branches_days", "14"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      globalName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
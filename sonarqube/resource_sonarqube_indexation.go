@@ -0,0 +1,198 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// SystemStatus for unmarshalling response body of api/system/status
+type SystemStatus struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+	Status  string `json:"status"`
+}
+
+// IndexationStatus for unmarshalling response body of api/ce/indexation_status
+type IndexationStatus struct {
+	IsCompleted      bool `json:"isCompleted"`
+	PercentCompleted int  `json:"percentCompleted"`
+	HasFailures      bool `json:"hasFailures"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeIndexation() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Indexation resource. This waits until SonarQube is up and the issues are reindexed, for example
+after a restart or an upgrade that rebuilds the Elasticsearch indexes, so the resources that depend on it do not read partial data.
+Changing ` + "`triggers`" + ` waits again. Destroying this resource only removes it from the state.`,
+		Create: resourceSonarqubeIndexationCreate,
+		Read:   resourceSonarqubeIndexationRead,
+		Delete: resourceSonarqubeIndexationDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary map of values that, when changed, waits again for the indexation, for example the version of SonarQube. Changing this forces a new resource to be created.",
+			},
+			"wait_for_issues_indexation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Whether to also wait until the issues of all projects are reindexed. Otherwise only wait until SonarQube is up. The wait is limited by the `create` timeout, which defaults to 30 minutes. Defaults to `true`. Changing this forces a new resource to be created.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of SonarQube, `UP` once it is ready.",
+			},
+			"issues_indexation_completed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the issues of all projects are indexed.",
+			},
+			"issues_indexation_percent": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The percentage of projects whose issues are indexed.",
+			},
+			"issues_indexation_has_failures": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the indexation of the issues of some projects failed.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeIndexationCreate(d *schema.ResourceData, m interface{}) error {
+	waitForIssues := d.Get("wait_for_issues_indexation").(bool)
+
+	err := retry.RetryContext(context.Background(), d.Timeout(schema.TimeoutCreate), func() *retry.RetryError {
+		// SonarQube does not answer while it restarts, so request errors are retried as well
+		status, err := readSystemStatusFromApi(m)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		if status.Status != "UP" {
+			log.Printf("[DEBUG][resourceSonarqubeIndexationCreate] SonarQube is %s", status.Status)
+			return retry.RetryableError(fmt.Errorf("resourceSonarqubeIndexationCreate: SonarQube is %s", status.Status))
+		}
+		if !waitForIssues {
+			return nil
+		}
+
+		indexation, err := readIndexationStatusFromApi(m)
+		if err != nil {
+			return retry.RetryableError(err)
+		}
+		if !indexation.IsCompleted {
+			log.Printf("[DEBUG][resourceSonarqubeIndexationCreate] The issues indexation is %d%% completed", indexation.PercentCompleted)
+			return retry.RetryableError(fmt.Errorf("resourceSonarqubeIndexationCreate: the issues indexation is %d%% completed", indexation.PercentCompleted))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId("indexation")
+
+	return resourceSonarqubeIndexationRead(d, m)
+}
+
+func resourceSonarqubeIndexationRead(d *schema.ResourceData, m interface{}) error {
+	status, err := readSystemStatusFromApi(m)
+	if err != nil {
+		return err
+	}
+	indexation, err := readIndexationStatusFromApi(m)
+	if err != nil {
+		return err
+	}
+	if indexation.HasFailures {
+		log.Printf("[WARN][resourceSonarqubeIndexationRead] The indexation of the issues of some projects failed")
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("status", status.Status))
+	errs = append(errs, d.Set("issues_indexation_completed", indexation.IsCompleted))
+	errs = append(errs, d.Set("issues_indexation_percent", indexation.PercentCompleted))
+	errs = append(errs, d.Set("issues_indexation_has_failures", indexation.HasFailures))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeIndexationDelete(d *schema.ResourceData, m interface{}) error {
+	// Waiting has no effect to undo
+	return nil
+}
+
+func readSystemStatusFromApi(m interface{}) (*SystemStatus, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/system/status"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readSystemStatusFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readSystemStatusFromApi: Failed to read the status of SonarQube: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	status := SystemStatus{}
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	if err != nil {
+		return nil, fmt.Errorf("readSystemStatusFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &status, nil
+}
+
+func readIndexationStatusFromApi(m interface{}) (*IndexationStatus, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/ce/indexation_status"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readIndexationStatusFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readIndexationStatusFromApi: Failed to read the status of the issues indexation: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	indexation := IndexationStatus{}
+	err = json.NewDecoder(resp.Body).Decode(&indexation)
+	if err != nil {
+		return nil, fmt.Errorf("readIndexationStatusFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &indexation, nil
+}
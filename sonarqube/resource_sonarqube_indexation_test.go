@@ -0,0 +1,32 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSonarqubeIndexationBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_indexation." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "sonarqube_indexation" "%[1]s" {
+						triggers = {
+							version = "1"
+						}
+					}`, rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "status", "UP"),
+					resource.TestCheckResourceAttr(name, "issues_indexation_completed", "true"),
+				),
+			},
+		},
+	})
+}
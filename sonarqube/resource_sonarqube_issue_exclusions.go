@@ -0,0 +1,158 @@
+package sonarqube
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// issueExclusionsSettings maps the block attributes of sonarqube_issue_exclusions to their multi-criteria setting keys
+var issueExclusionsSettings = map[string]string{
+	"ignore":  "sonar.issue.ignore.multicriteria",
+	"enforce": "sonar.issue.enforce.multicriteria",
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeIssueExclusions() *schema.Resource {
+	criteria := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: description,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"rule_key": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The rule key pattern, for example `java:S1195` or `*`.",
+					},
+					"resource_key": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The file path pattern, for example `**/generated/**`.",
+					},
+				},
+			},
+		}
+	}
+
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Issue Exclusions resource. This can be used to manage the multi-criteria issue exclusions of a project,
+or of all projects when no project is set. Destroying this resource removes all the criteria.`,
+		Create: resourceSonarqubeIssueExclusionsCreate,
+		Read:   resourceSonarqubeIssueExclusionsRead,
+		Update: resourceSonarqubeIssueExclusionsUpdate,
+		Delete: resourceSonarqubeIssueExclusionsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeIssueExclusionsImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The key of the project. When not set, the exclusions apply to all projects. Changing this forces a new resource to be created.",
+			},
+			"ignore":  criteria("Issues of the rules matching `rule_key` are ignored in the files matching `resource_key` (`sonar.issue.ignore.multicriteria`)."),
+			"enforce": criteria("Rules matching `rule_key` are only applied to the files matching `resource_key` (`sonar.issue.enforce.multicriteria`)."),
+		},
+	}
+}
+
+func resourceSonarqubeIssueExclusionsCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	for attribute, key := range issueExclusionsSettings {
+		if err := setIssueExclusionCriteria(project, key, d.Get(attribute).(*schema.Set), m); err != nil {
+			return err
+		}
+	}
+
+	if project == "" {
+		d.SetId("global")
+	} else {
+		d.SetId(project)
+	}
+
+	return resourceSonarqubeIssueExclusionsRead(d, m)
+}
+
+func resourceSonarqubeIssueExclusionsRead(d *schema.ResourceData, m interface{}) error {
+	keys := []string{}
+	for _, key := range issueExclusionsSettings {
+		keys = append(keys, key)
+	}
+	settings, err := getSettingsByKeys(d.Get("project").(string), keys, m)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	for attribute, key := range issueExclusionsSettings {
+		errs = append(errs, d.Set(attribute, flattenIssueExclusionCriteria(settings[key].FieldValues)))
+	}
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeIssueExclusionsUpdate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	for attribute, key := range issueExclusionsSettings {
+		if !d.HasChange(attribute) {
+			continue
+		}
+		if err := setIssueExclusionCriteria(project, key, d.Get(attribute).(*schema.Set), m); err != nil {
+			return err
+		}
+	}
+
+	return resourceSonarqubeIssueExclusionsRead(d, m)
+}
+
+func resourceSonarqubeIssueExclusionsDelete(d *schema.ResourceData, m interface{}) error {
+	keys := []string{}
+	for _, key := range issueExclusionsSettings {
+		keys = append(keys, key)
+	}
+	return resetSettings(d.Get("project").(string), keys, m)
+}
+
+func resourceSonarqubeIssueExclusionsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if d.Id() != "global" {
+		if err := d.Set("project", d.Id()); err != nil {
+			return nil, err
+		}
+	}
+	if err := resourceSonarqubeIssueExclusionsRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// setIssueExclusionCriteria replaces all criteria of a multi-criteria setting, or resets it when there are none
+func setIssueExclusionCriteria(component string, key string, criteria *schema.Set, m interface{}) error {
+	if criteria.Len() == 0 {
+		return resetSettings(component, []string{key}, m)
+	}
+
+	fieldValues := make([]map[string]string, 0, criteria.Len())
+	for _, c := range criteria.List() {
+		c := c.(map[string]interface{})
+		fieldValues = append(fieldValues, map[string]string{
+			"ruleKey":     c["rule_key"].(string),
+			"resourceKey": c["resource_key"].(string),
+		})
+	}
+	return setSettingFieldValues(component, key, fieldValues, m)
+}
+
+func flattenIssueExclusionCriteria(fieldValues []map[string]string) []interface{} {
+	criteria := make([]interface{}, 0, len(fieldValues))
+	for _, fieldValue := range fieldValues {
+		criteria = append(criteria, map[string]interface{}{
+			"rule_key":     fieldValue["ruleKey"],
+			"resource_key": fieldValue["resourceKey"],
+		})
+	}
+	return criteria
+}
@@ -0,0 +1,68 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeIssueExclusionsConfig(rnd string, projectKey string, resourceKey string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_issue_exclusions" "%[1]s" {
+			project = sonarqube_project.%[1]s.project
+
+			ignore {
+				rule_key     = "*"
+				resource_key = "%[3]s"
+			}
+
+			enforce {
+				rule_key     = "java:S2068"
+				resource_key = "**/src/main/**"
+			}
+		}`, rnd, projectKey, resourceKey)
+}
+
+func TestAccSonarqubeIssueExclusionsBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_issue_exclusions." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeIssueExclusionsConfig(rnd, "testAccSonarqubeIssueExclusions", "**/generated/**"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "ignore.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs(name, "ignore.*", map[string]string{
+						"rule_key":     "*",
+						"resource_key": "**/generated/**",
+					}),
+					resource.TestCheckResourceAttr(name, "enforce.#", "1"),
+				),
+			},
+			{
+				Config: testAccSonarqubeIssueExclusionsConfig(rnd, "testAccSonarqubeIssueExclusions", "**/vendor/**"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(name, "ignore.*", map[string]string{
+						"rule_key":     "*",
+						"resource_key": "**/vendor/**",
+					}),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package sonarqube
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// issuesAutoAssignSettings maps the attributes of sonarqube_issues_auto_assign to their setting keys
+var issuesAutoAssignSettings = []settingAttribute{
+	{attribute: "default_assignee", key: "sonar.issues.defaultAssigneeLogin"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeIssuesAutoAssign() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Issues Auto Assign resource. SonarQube assigns new issues to the last committer of the line, using the
+SCM accounts of the users, and assigns the issues whose author is not a SonarQube user to the default assignee of the project, which this
+resource manages. The automatic assignment itself depends on the SCM data of the analysis, see ` + "`sonarqube_project_scm`" + `.
+Destroying this resource resets the settings to their default value. It supports importing using the project key.`,
+		Create: resourceSonarqubeIssuesAutoAssignCreate,
+		Read:   resourceSonarqubeIssuesAutoAssignRead,
+		Update: resourceSonarqubeIssuesAutoAssignUpdate,
+		Delete: resourceSonarqubeIssuesAutoAssignDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeIssuesAutoAssignImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project. Changing this forces a new resource to be created.",
+			},
+			"default_assignee": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The login of the user that gets the new issues whose author cannot be matched with a SonarQube user (`sonar.issues.defaultAssigneeLogin`). The user must have the `Browse` permission on the project. When not set, these issues are left unassigned.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeIssuesAutoAssignCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	if err := setSettingAttributes(project, issuesAutoAssignSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId(project)
+
+	return resourceSonarqubeIssuesAutoAssignRead(d, m)
+}
+
+func resourceSonarqubeIssuesAutoAssignRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes(d.Id(), issuesAutoAssignSettings, d, m)
+}
+
+func resourceSonarqubeIssuesAutoAssignUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes(d.Id(), issuesAutoAssignSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeIssuesAutoAssignRead(d, m)
+}
+
+func resourceSonarqubeIssuesAutoAssignDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes(d.Id(), issuesAutoAssignSettings, m)
+}
+
+func resourceSonarqubeIssuesAutoAssignImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("project", d.Id()); err != nil {
+		return nil, err
+	}
+	if err := resourceSonarqubeIssuesAutoAssignRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
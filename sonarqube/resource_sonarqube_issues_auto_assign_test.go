@@ -0,0 +1,59 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeIssuesAutoAssignConfig(rnd string, projectKey string, defaultAssignee string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_user" "%[1]s" {
+			login_name = "%[1]s"
+			name       = "Test User"
+			password   = "secret-Sauce!"
+		}
+
+		resource "sonarqube_issues_auto_assign" "%[1]s" {
+			project          = sonarqube_project.%[1]s.project
+			default_assignee = "%[3]s"
+			depends_on       = [sonarqube_user.%[1]s]
+		}`, rnd, projectKey, defaultAssignee)
+}
+
+func TestAccSonarqubeIssuesAutoAssignBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_issues_auto_assign." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeIssuesAutoAssignConfig(rnd, "testAccSonarqubeIssuesAutoAssign", rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "project", "testAccSonarqubeIssuesAutoAssign"),
+					resource.TestCheckResourceAttr(name, "default_assignee", rnd),
+				),
+			},
+			{
+				Config: testAccSonarqubeIssuesAutoAssignConfig(rnd, "testAccSonarqubeIssuesAutoAssign", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "default_assignee", ""),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
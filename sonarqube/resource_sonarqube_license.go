@@ -0,0 +1,167 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// License for unmarshalling response body of api/editions/show_license
+type License struct {
+	Edition               string   `json:"edition"`
+	ExpiresAt             string   `json:"expiresAt"`
+	IsExpired             bool     `json:"isExpired"`
+	IsValidEdition        bool     `json:"isValidEdition"`
+	IsValidServerId       bool     `json:"isValidServerId"`
+	IsSupported           bool     `json:"isSupported"`
+	HasLicense            bool     `json:"hasLicense"`
+	Type                  string   `json:"type"`
+	ServerId              string   `json:"serverId"`
+	ContactEmail          string   `json:"contactEmail"`
+	MaxLoc                int64    `json:"maxLoc"`
+	Loc                   int64    `json:"loc"`
+	RemainingLocThreshold int64    `json:"remainingLocThreshold"`
+	Features              []string `json:"features"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeLicense() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube License resource. This can be used to manage the license key of a Developer, Enterprise
+or Data Center edition of SonarQube. Destroying this resource only removes it from the state, the license stays installed.`,
+		Create: resourceSonarqubeLicenseCreate,
+		Read:   resourceSonarqubeLicenseRead,
+		Update: resourceSonarqubeLicenseCreate,
+		Delete: resourceSonarqubeLicenseDelete,
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"license": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "The license key to install. The key is not returned by the API, so changes made outside of Terraform cannot be detected.",
+			},
+			"edition": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The edition the installed license is valid for.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The expiration date of the installed license.",
+			},
+			"max_loc": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The maximum number of lines of code allowed by the installed license.",
+			},
+		},
+	}
+}
+
+func checkLicenseSupport(conf *ProviderConfiguration) error {
+	if strings.ToLower(conf.sonarQubeEdition) == "community" {
+		return fmt.Errorf("licenses are not supported in the Community edition of SonarQube. You are using: SonarQube %s version %s", conf.sonarQubeEdition, conf.sonarQubeVersion)
+	}
+	return nil
+}
+
+func resourceSonarqubeLicenseCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkLicenseSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/editions/set_license"
+	sonarQubeURL.RawQuery = url.Values{
+		"license": []string{d.Get("license").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeLicenseCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("error setting Sonarqube license: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	d.SetId("license")
+
+	return resourceSonarqubeLicenseRead(d, m)
+}
+
+func resourceSonarqubeLicenseRead(d *schema.ResourceData, m interface{}) error {
+	if err := checkLicenseSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	license, err := readLicenseFromApi(m)
+	if err != nil {
+		return err
+	}
+
+	if !license.HasLicense {
+		log.Printf("[WARN][resourceSonarqubeLicenseRead] No license installed, removing it from the state")
+		d.SetId("")
+		return nil
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("edition", license.Edition))
+	errs = append(errs, d.Set("expires_at", license.ExpiresAt))
+	errs = append(errs, d.Set("max_loc", license.MaxLoc))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeLicenseDelete(d *schema.ResourceData, m interface{}) error {
+	// There is no API to uninstall a license, so we only remove the resource from the state
+	log.Printf("[DEBUG][resourceSonarqubeLicenseDelete] Removing license from the state, the license stays installed in SonarQube")
+	d.SetId("")
+	return nil
+}
+
+func readLicenseFromApi(m interface{}) (*License, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/editions/show_license"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readLicenseFromApi",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			// show_license returns a 404 when no license has been installed yet
+			return &License{}, nil
+		}
+		return nil, fmt.Errorf("readLicenseFromApi: Failed to call api/editions/show_license: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	license := License{}
+	err = json.NewDecoder(resp.Body).Decode(&license)
+	if err != nil {
+		return nil, fmt.Errorf("readLicenseFromApi: Failed to decode json into struct: %+v", err)
+	}
+	// Older versions do not return the hasLicense flag
+	if license.Edition != "" {
+		license.HasLicense = true
+	}
+
+	return &license, nil
+}
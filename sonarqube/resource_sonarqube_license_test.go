@@ -0,0 +1,44 @@
+package sonarqube
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccPreCheckLicenseSupport(t *testing.T) {
+	if err := checkLicenseSupport(testAccProviderConfiguration(t)); err != nil {
+		t.Skipf("Skipping test of unsupported feature (License)")
+	}
+	if v := os.Getenv("SONAR_LICENSE"); v == "" {
+		t.Skipf("Skipping test of License, SONAR_LICENSE is not set")
+	}
+}
+
+func testAccSonarqubeLicenseConfig(rnd string, license string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_license" "%[1]s" {
+			license = "%[2]s"
+		}`, rnd, license)
+}
+
+func TestAccSonarqubeLicenseBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_license." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckLicenseSupport(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeLicenseConfig(rnd, os.Getenv("SONAR_LICENSE")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "edition"),
+					resource.TestCheckResourceAttrSet(name, "max_loc"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,193 @@
+package sonarqube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubePermissionItem() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Permission Item resource. This resource manages a single permission of a user or group,
+either globally, on a project or on a permission template. It supports importing using the format '{user|group}/{principal}/{permission}/{scope}'
+where scope is ` + "`global`" + ` or a project_key (p_), template_id (t_) or template_name (tn_) with prefixes. Example: group/developers/codeviewer/p_my_project.
+A "/" in the principal is escaped as %2F, for example group/my-org%2Fdevelopers/codeviewer/global.`,
+		Create: resourceSonarqubePermissionItemCreate,
+		Read:   resourceSonarqubePermissionItemRead,
+		Delete: resourceSonarqubePermissionItemDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubePermissionItemImport,
+		},
+		// Validate the permission name against the scope once the plan is known
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validatePermissionItemResource(d, meta)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"login_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"login_name", "group_name"},
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The login of the user that gets the permission. Changing this forces a new resource to be created. Cannot be used with `group_name`.",
+			},
+			"group_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"login_name", "group_name"},
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The name of the group that gets the permission. Changing this forces a new resource to be created. Cannot be used with `login_name`.",
+			},
+			"project_key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"template_id", "template_name"},
+				Description:   "The key of the project the permission is granted on. Changing this forces a new resource to be created. Cannot be used with `template_id` and `template_name`.",
+			},
+			"template_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"project_key", "template_name"},
+				Description:   "The ID of the permission template the permission is added to. Changing this forces a new resource to be created. Cannot be used with `project_key` and `template_name`.",
+			},
+			"template_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"project_key", "template_id"},
+				Description:   "The name of the permission template the permission is added to. Changing this forces a new resource to be created. Cannot be used with `project_key` and `template_id`.",
+			},
+			"permission": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The permission to grant. See `sonarqube_permissions` for the possible values. Changing this forces a new resource to be created.",
+			},
+		},
+	}
+}
+
+// permissionItemID returns the ID of a sonarqube_permission_item resource, which is also its import format. The scope
+// comes last as template names may contain a "/", and the "/" of the principal, for example in the name of a group
+// synchronized from a GitHub team, is escaped as %2F.
+func permissionItemID(principalType string, principal string, permission string, scope permissionScope) string {
+	return strings.Join([]string{principalType, permissionItemPrincipalEscaper.Replace(principal), permission, scope.id()}, "/")
+}
+
+// permissionItemPrincipalEscaper escapes the principal of an ID so that it can be split on "/" and read back with
+// url.PathUnescape
+var permissionItemPrincipalEscaper = strings.NewReplacer("%", "%25", "/", "%2F")
+
+func resourceSonarqubePermissionItemCreate(d *schema.ResourceData, m interface{}) error {
+	principalType, principal := permissionItemPrincipal(d)
+	scope := expandPermissionScope(d)
+	permission := d.Get("permission").(string)
+
+	if err := updatePermission("add", principalType, principal, scope, permission, m); err != nil {
+		return err
+	}
+
+	d.SetId(permissionItemID(principalType, principal, permission, scope))
+
+	return resourceSonarqubePermissionItemRead(d, m)
+}
+
+func resourceSonarqubePermissionItemRead(d *schema.ResourceData, m interface{}) error {
+	principalType, principal := permissionItemPrincipal(d)
+	scope := expandPermissionScope(d)
+	permission := d.Get("permission").(string)
+
+	permissions, err := getPrincipalPermissions(principalType, principal, scope, m)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range permissions {
+		if strings.EqualFold(p, permission) {
+			return d.Set("permission", p)
+		}
+	}
+
+	log.Printf("[WARN][resourceSonarqubePermissionItemRead] Permission '%s' not found for %s '%s' on scope '%s', removing it from the state", permission, principalType, principal, scope.id())
+	d.SetId("")
+	return nil
+}
+
+func resourceSonarqubePermissionItemDelete(d *schema.ResourceData, m interface{}) error {
+	principalType, principal := permissionItemPrincipal(d)
+	scope := expandPermissionScope(d)
+
+	return updatePermission("remove", principalType, principal, scope, d.Get("permission").(string), m)
+}
+
+func resourceSonarqubePermissionItemImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts, err := parseImportID(d.Id(), "{user|group}/{principal}/{permission}/{scope}")
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubePermissionItemImport: %+v. Example: group/developers/codeviewer/p_my_project", err)
+	}
+	if parts[0] != principalUser && parts[0] != principalGroup {
+		return nil, fmt.Errorf("resourceSonarqubePermissionItemImport: invalid principal type '%s', expected 'user' or 'group'", parts[0])
+	}
+
+	principal, err := url.PathUnescape(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubePermissionItemImport: invalid principal '%s', a '/' must be escaped as %%2F and a '%%' as %%25: %+v", parts[1], err)
+	}
+	scope, err := parsePermissionScope(parts[3])
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubePermissionItemImport: %+v", err)
+	}
+
+	errs := []error{}
+	if parts[0] == principalUser {
+		errs = append(errs, d.Set("login_name", principal))
+	} else {
+		errs = append(errs, d.Set("group_name", principal))
+	}
+	errs = append(errs, d.Set("project_key", scope.projectKey))
+	errs = append(errs, d.Set("template_id", scope.templateID))
+	errs = append(errs, d.Set("template_name", scope.templateName))
+	errs = append(errs, d.Set("permission", parts[2]))
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if err := resourceSonarqubePermissionItemRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubePermissionItemImport: permission '%s' not found", parts[2])
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func validatePermissionItemResource(d *schema.ResourceDiff, meta interface{}) error {
+	// An unknown permission cannot be validated until apply
+	if !d.NewValueKnown("permission") {
+		return nil
+	}
+
+	conf, _ := meta.(*ProviderConfiguration)
+	return validatePermissionNames([]string{d.Get("permission").(string)}, conf, permissionsProjectScope(d))
+}
+
+func permissionItemPrincipal(d *schema.ResourceData) (string, string) {
+	if loginName, ok := d.GetOk("login_name"); ok {
+		return principalUser, loginName.(string)
+	}
+	return principalGroup, d.Get("group_name").(string)
+}
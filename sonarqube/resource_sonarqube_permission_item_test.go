@@ -0,0 +1,131 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubePermissionItemConfig(rnd string, name string, permission string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "private"
+		}
+
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[2]s"
+		}
+
+		resource "sonarqube_permission_item" "%[1]s" {
+			group_name  = sonarqube_group.%[1]s.name
+			project_key = sonarqube_project.%[1]s.project
+			permission  = "%[3]s"
+		}`, rnd, name, permission)
+}
+
+func TestAccSonarqubePermissionItemBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_permission_item." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubePermissionItemConfig(rnd, "testAccSonarqubePermissionItem", "codeviewer"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "group/testAccSonarqubePermissionItem/codeviewer/p_testAccSonarqubePermissionItem"),
+					resource.TestCheckResourceAttr(name, "permission", "codeviewer"),
+				),
+			},
+			{
+				Config: testAccSonarqubePermissionItemConfig(rnd, "testAccSonarqubePermissionItem", "issueadmin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "permission", "issueadmin"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubePermissionItemImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		importID string
+		wantErr  string
+	}{
+		{
+			name:     "project key with a colon",
+			importID: "group/developers/codeviewer/p_my:project",
+		},
+		{
+			name:     "colon separated ID",
+			importID: "group:developers:p_my:project:codeviewer",
+			wantErr:  "is not in the format",
+		},
+		{
+			name:     "unknown principal type",
+			importID: "team/developers/codeviewer/p_my:project",
+			wantErr:  "invalid principal type 'team'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockSonarQube(t)
+			mock.respond("GET", "/api/permissions/groups", http.StatusOK, `{"paging":{"pageIndex":1,"pageSize":100,"total":1},"groups":[{"name":"developers","permissions":["codeviewer"]}]}`)
+			r := resourceSonarqubePermissionItem()
+
+			d := r.TestResourceData()
+			d.SetId(tt.importID)
+			_, err := r.Importer.State(d, mock.conf("Community", "10.7"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Import() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Import() unexpected error = %v", err)
+			}
+			if d.Get("group_name") != "developers" || d.Get("project_key") != "my:project" || d.Get("permission") != "codeviewer" {
+				t.Errorf("Import() = %v on %v with %v, want developers on my:project with codeviewer", d.Get("group_name"), d.Get("project_key"), d.Get("permission"))
+			}
+			for _, call := range mock.requests() {
+				if !strings.Contains(call, "projectKey=my%3Aproject") {
+					t.Errorf("Import() request %s, want the project key my:project", call)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceSonarqubePermissionItemPrincipalWithSlash(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/permissions/groups", http.StatusOK, `{"paging":{"pageIndex":1,"pageSize":100,"total":1},"groups":[{"name":"my-org/dev%team","permissions":["codeviewer"]}]}`)
+	r := resourceSonarqubePermissionItem()
+
+	id := permissionItemID(principalGroup, "my-org/dev%team", "codeviewer", permissionScope{projectKey: "my/project"})
+	if id != "group/my-org%2Fdev%25team/codeviewer/p_my/project" {
+		t.Fatalf("permissionItemID() = %s, want the / and %% of the principal escaped", id)
+	}
+
+	d := r.TestResourceData()
+	d.SetId(id)
+	if _, err := r.Importer.State(d, mock.conf("Community", "10.7")); err != nil {
+		t.Fatalf("Import() unexpected error = %v", err)
+	}
+	if d.Get("group_name") != "my-org/dev%team" || d.Get("project_key") != "my/project" || d.Id() != id {
+		t.Errorf("Import() = %v on %v with ID %s, want my-org/dev%%team on my/project", d.Get("group_name"), d.Get("project_key"), d.Id())
+	}
+}
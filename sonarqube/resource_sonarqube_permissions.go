@@ -1,6 +1,7 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -29,7 +31,7 @@ type GroupPermission struct {
 // Returns the resource represented by this file.
 func resourceSonarqubePermissions() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a Sonarqube Permissions resource. This resource can be used to manage global and project permissions. It supports importing using the format 'principal(:scope)' where principal is login_name or group_name or special_group_name and the optional scope is project_key (p_), template_id (t_) or template_name (tn_) with prefixes. Example: group1:tn_test_template_name",
+		Description: "Provides a Sonarqube Permissions resource. This resource can be used to manage global and project permissions. It supports importing using the format '{principal}/[{scope}]' where principal is login_name or group_name or special_group_name and the optional scope is `global` or a project_key (p_), template_id (t_) or template_name (tn_) with prefixes. Example: group1/tn_test_template_name. The format '{principal}:[{scope}]' of previous versions is still accepted when the ID contains no '/'.",
 		Create:      resourceSonarqubePermissionsCreate,
 		Read:        resourceSonarqubePermissionsRead,
 		Update:      resourceSonarqubePermissionsUpdate,
@@ -37,22 +39,33 @@ func resourceSonarqubePermissions() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubePermissionsImport,
 		},
+		// Validate the permission names against the scope once the plan is known
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validatePermissionsResource(d, meta)
+			},
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return refreshPermissionsOnPlan(d, meta)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
 			"login_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ExactlyOneOf: []string{"login_name", "group_name", "special_group_name"},
-				Description:  "The name of the user that should get the specified permissions. Changing this forces a new resource to be created. Cannot be used with `group_name` and `special_group_name`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"login_name", "group_name", "special_group_name"},
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The name of the user that should get the specified permissions. Changing this forces a new resource to be created. Cannot be used with `group_name` and `special_group_name`.",
 			},
 			"group_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ExactlyOneOf: []string{"login_name", "group_name", "special_group_name"},
-				Description:  "The name of the Group that should get the specified permissions. Changing this forces a new resource to be created. Cannot be used with `login_name` and `special_group_name`.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"login_name", "group_name", "special_group_name"},
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The name of the Group that should get the specified permissions. Changing this forces a new resource to be created. Cannot be used with `login_name` and `special_group_name`.",
 			},
 			"special_group_name": {
 				Type:         schema.TypeString,
@@ -94,40 +107,67 @@ func resourceSonarqubePermissions() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Description: "A list of permissions that should be applied. Possible values are: `admin`, `codeviewer`, `issueadmin`, `securityhotspotadmin`, `scan`, `user`.",
+				Description: "A list of permissions that should be applied. Possible values for project and template permissions are: `admin`, `codeviewer`, `issueadmin`, `securityhotspotadmin`, `scan`, `user`. Possible values for global permissions are: `admin`, `gateadmin`, `profileadmin`, `provisioning`, `scan`, `applicationcreator` (Developer edition and above) and `portfoliocreator` (Enterprise edition and above).",
+			},
+			"refresh_on_plan": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to read the current permissions of the user or group while planning, so that permissions removed outside of Terraform show up as a change of `server_permissions` even when the plan does not refresh the state, for example with `-refresh=false`. Defaults to `false`.",
+			},
+			"server_permissions": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The permissions of the user or group in SonarQube, as of the last refresh, or of the last plan when `refresh_on_plan` is set.",
 			},
 		},
 	}
 }
 
+// permissionsID returns the ID of a sonarqube_permissions resource, derived from the principal and the scope so that
+// the same grant always gets the same ID
+func permissionsID(principalType string, principal string, scope permissionScope) string {
+	switch principalType {
+	case principalUser:
+		return fmt.Sprintf("user-%s-%s-permissions", principal, scope.id())
+	case principalGroup:
+		return fmt.Sprintf("group-%s-%s-permissions", principal, scope.id())
+	default:
+		return fmt.Sprintf("project-creator-%s-permissions", scope.id())
+	}
+}
+
 func resourceSonarqubePermissionsImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	parts := strings.Split(d.Id(), ":")
-	if len(parts) > 2 {
-		return nil, fmt.Errorf("resourceSonarqubePermissionsImport: invalid import format, expected 'principal(:scope)' where principal is login_name or group_name or special_group_name and the optional scope is project_key (p_), template_id (t_) or template_name (tn_) with with prefixes. Example: group1:tn_test_template_name")
+	// The format of previous versions was '{principal}:[{scope}]', which is still accepted when the ID has no "/"
+	id := d.Id()
+	if !strings.Contains(id, "/") {
+		id = strings.Replace(id, ":", "/", 1)
+	}
+	parts, err := parseImportID(id, "{principal}/[{scope}]")
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubePermissionsImport: %+v. Example: group1/tn_test_template_name", err)
 	}
 
 	principal := parts[0]
-	scope := ""
-	if len(parts) == 2 {
-		scope = parts[1]
-	}
-
-	if scope != "" {
-		// Determine the scope type (project_key, template_id, template_name)
-		var setError error
-		if strings.HasPrefix(scope, "p_") {
-			setError = d.Set("project_key", scope[2:])
-		} else if strings.HasPrefix(scope, "t_") {
-			setError = d.Set("template_id", scope[2:])
-		} else if strings.HasPrefix(scope, "tn_") {
-			setError = d.Set("template_name", scope[3:])
-		}
-		if setError != nil {
-			return nil, fmt.Errorf("resourceSonarqubePermissionsImport: failed to set permissions: %+v", setError)
-		}
-	} else {
+	scope := parts[1]
+	if scope == "" {
 		scope = "global"
 	}
+	parsedScope, err := parsePermissionScope(scope)
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubePermissionsImport: %+v", err)
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("project_key", parsedScope.projectKey))
+	errs = append(errs, d.Set("template_id", parsedScope.templateID))
+	errs = append(errs, d.Set("template_name", parsedScope.templateName))
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("resourceSonarqubePermissionsImport: failed to set the scope: %+v", err)
+	}
 
 	// Check if the principal is a special group
 	if strings.EqualFold(principal, "project_creator") {
@@ -135,8 +175,7 @@ func resourceSonarqubePermissionsImport(d *schema.ResourceData, m interface{}) (
 			return nil, fmt.Errorf("resourceSonarqubePermissionsImport: failed to set special_group_name: %+v", err)
 		}
 
-		// Generate a deterministic ID
-		d.SetId(fmt.Sprintf("project-creator-%s-permissions", scope))
+		d.SetId(permissionsID(principalProjectCreator, "", parsedScope))
 
 		// Read the current state
 		if err := resourceSonarqubePermissionsRead(d, m); err != nil {
@@ -194,11 +233,10 @@ func resourceSonarqubePermissionsImport(d *schema.ResourceData, m interface{}) (
 		}
 	}
 
-	// Generate a deterministic ID
 	if isUser {
-		d.SetId(fmt.Sprintf("user-%s-%s-permissions", principal, scope))
+		d.SetId(permissionsID(principalUser, principal, parsedScope))
 	} else {
-		d.SetId(fmt.Sprintf("group-%s-%s-permissions", principal, scope))
+		d.SetId(permissionsID(principalGroup, principal, parsedScope))
 	}
 
 	// Read the current state
@@ -210,107 +248,23 @@ func resourceSonarqubePermissionsImport(d *schema.ResourceData, m interface{}) (
 }
 
 func resourceSonarqubePermissionsCreate(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	permissions := expandPermissions(d.Get("permissions"))
+	principalType, principal := permissionsPrincipal(d)
+	scope := expandPermissionScope(d)
 
-	var principalName, scopeValue string
-
-	if loginName, ok := d.GetOk("login_name"); ok {
-		principalName = loginName.(string)
-	} else if groupName, ok := d.GetOk("group_name"); ok {
-		principalName = groupName.(string)
-	}
-
-	if projectKey, ok := d.GetOk("project_key"); ok {
-		scopeValue = "p_" + projectKey.(string)
-	} else if templateID, ok := d.GetOk("template_id"); ok {
-		scopeValue = "t_" + templateID.(string)
-	} else if templateName, ok := d.GetOk("template_name"); ok {
-		scopeValue = "tn_" + templateName.(string)
-	} else {
-		scopeValue = "global"
+	if principalType == principalProjectCreator && !scope.isTemplate() {
+		return fmt.Errorf("resourceSonarqubePermissionsCreate: 'templateId' or 'templateName' must be set when 'special_group_name' is set to 'project_creator'")
 	}
+	permissions := expandPermissions(d.Get("permissions"))
 
-	// build the base query
-	RawQuery := url.Values{}
-
-	// if the permissions should be applied to a project
-	// we append the project_key to the request
-	if projectKey, ok := d.GetOk("project_key"); ok {
-		RawQuery.Add("projectKey", projectKey.(string))
-	}
-
-	// we use different API endpoints and request params
-	// based on the target principal type (group or user or
-	// special group) and if its a direct or template permission
-	if _, ok := d.GetOk("login_name"); ok {
-		// user permission
-		RawQuery.Add("login", d.Get("login_name").(string))
-		if templateID, ok := d.GetOk("template_id"); ok {
-			// template user permission
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/add_user_to_template"
-			RawQuery.Add("templateId", templateID.(string))
-			// name provide instead of id
-		} else if templateName, ok := d.GetOk("template_name"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/add_user_to_template"
-			RawQuery.Add("templateName", templateName.(string))
-		} else {
-			// direct user permission
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/add_user"
-		}
-
-		d.SetId(fmt.Sprintf("user-%s-%s-permissions", principalName, scopeValue))
-	} else if _, ok := d.GetOk("group_name"); ok {
-		// group permission
-		RawQuery.Add("groupName", d.Get("group_name").(string))
-		if templateID, ok := d.GetOk("template_id"); ok {
-			// template user permission
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/add_group_to_template"
-			RawQuery.Add("templateId", templateID.(string))
-			// name provide instead of id
-		} else if templateName, ok := d.GetOk("template_name"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/add_group_to_template"
-			RawQuery.Add("templateName", templateName.(string))
-		} else {
-			// direct user permission
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/add_group"
-		}
-
-		d.SetId(fmt.Sprintf("group-%s-%s-permissions", principalName, scopeValue))
-	} else {
-		// special group permission set to project creator
-		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/add_project_creator_to_template"
-		if templateID, ok := d.GetOk("template_id"); ok {
-			// template project creator permission
-			RawQuery.Add("templateId", templateID.(string))
-		} else if templateName, ok := d.GetOk("template_name"); ok {
-			// name provide instead of id
-			RawQuery.Add("templateName", templateName.(string))
-		} else {
-			return fmt.Errorf("resourceSonarqubePermissionsCreate: 'templateId' or 'templateName' must be set when 'special_group_name' is set to 'project_creator'")
-		}
-
-		d.SetId(fmt.Sprintf("project-creator-%s-permissions", scopeValue))
-	}
+	d.SetId(permissionsID(principalType, principal, scope))
 
-	// loop through all permissions that should be applied
+	changes := []permissionChange{}
 	for _, permission := range permissions {
-		CurrentRawQuery := RawQuery
-		CurrentRawQuery.Del("permission")
-		CurrentRawQuery.Add("permission", permission)
-		sonarQubeURL.RawQuery = CurrentRawQuery.Encode()
-
-		resp, err := httpRequestHelper(
-			m.(*ProviderConfiguration).httpClient,
-			"POST",
-			sonarQubeURL.String(),
-			http.StatusNoContent,
-			"resourceSonarqubePermissionsCreate",
-		)
-		if err != nil {
-			return fmt.Errorf("error creating Sonarqube permission: %+v", err)
-		}
-		defer resp.Body.Close()
+		changes = append(changes, permissionChange{action: "add", permission: permission})
+	}
+	if err := applyPermissionChanges(principalType, principal, scope, changes, m); err != nil {
+		d.SetId("")
+		return fmt.Errorf("error creating Sonarqube permission: %+v", err)
 	}
 
 	return resourceSonarqubePermissionsRead(d, m)
@@ -375,7 +329,8 @@ func resourceSonarqubePermissionsRead(d *schema.ResourceData, m interface{}) err
 			if strings.EqualFold(value.Login, loginName.(string)) {
 				errName := d.Set("login_name", value.Login)
 				errPerms := d.Set("permissions", flattenPermissions(&value.Permissions))
-				return errors.Join(errName, errPerms)
+				errServerPerms := d.Set("server_permissions", flattenPermissions(&value.Permissions))
+				return errors.Join(errName, errPerms, errServerPerms)
 			}
 		}
 
@@ -421,7 +376,8 @@ func resourceSonarqubePermissionsRead(d *schema.ResourceData, m interface{}) err
 			if strings.EqualFold(value.Name, groupName) {
 				errGroup := d.Set("group_name", value.Name)
 				errPerms := d.Set("permissions", flattenPermissions(&value.Permissions))
-				return errors.Join(errGroup, errPerms)
+				errServerPerms := d.Set("server_permissions", flattenPermissions(&value.Permissions))
+				return errors.Join(errGroup, errPerms, errServerPerms)
 			}
 		}
 	} else {
@@ -459,6 +415,7 @@ func resourceSonarqubePermissionsRead(d *schema.ResourceData, m interface{}) err
 				errs := []error{}
 				errs = append(errs, d.Set("special_group_name", "project_creator"))
 				errs = append(errs, d.Set("permissions", flattenProjectCreatorPermissions(&value.Permissions)))
+				errs = append(errs, d.Set("server_permissions", flattenProjectCreatorPermissions(&value.Permissions)))
 				return errors.Join(errs...)
 			}
 		}
@@ -468,253 +425,64 @@ func resourceSonarqubePermissionsRead(d *schema.ResourceData, m interface{}) err
 }
 
 func resourceSonarqubePermissionsUpdate(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeBasePath := sonarQubeURL.Path
+	principalType, principal := permissionsPrincipal(d)
+	scope := expandPermissionScope(d)
 
 	currentFlatPermissions, targetFlatPermissions := d.GetChange("permissions")
 	currentPermissions := expandPermissions(currentFlatPermissions)
-	targetPermissions := expandPermissions(targetFlatPermissions)
-
-	RawQuery := url.Values{
-		"ps": []string{"100"},
+	// The state may be older than the permissions read while planning
+	if d.Get("refresh_on_plan").(bool) && principalType != principalProjectCreator {
+		serverPermissions, err := getPrincipalPermissions(principalType, principal, scope, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubePermissionsUpdate: %+v", err)
+		}
+		currentPermissions = serverPermissions
 	}
+	toAddPermissions, toRemovePermissions := calculatePermissionChanges(currentPermissions, expandPermissions(targetFlatPermissions))
 
-	if projectKey, ok := d.GetOk("project_key"); ok {
-		RawQuery.Add("projectKey", projectKey.(string))
+	changes := []permissionChange{}
+	for _, permission := range toRemovePermissions {
+		changes = append(changes, permissionChange{action: "remove", permission: permission})
 	}
-
-	if loginName, ok := d.GetOk("login_name"); ok {
-
-		toAddPermissions, toRemovePermissions := calculatePermissionChanges(currentPermissions, targetPermissions)
-
-		RawQuery = url.Values{}
-
-		if projectKey, ok := d.GetOk("project_key"); ok {
-			RawQuery.Add("projectKey", projectKey.(string))
-		}
-
-		RawQuery.Add("login", loginName.(string))
-		if templateID, ok := d.GetOk("template_id"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/remove_user_from_template"
-			RawQuery.Add("templateId", templateID.(string))
-		} else if templateName, ok := d.GetOk("template_name"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/remove_user_from_template"
-			RawQuery.Add("templateName", templateName.(string))
-		} else {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/remove_user"
-		}
-
-		for _, perm := range toRemovePermissions {
-			RawQuery.Set("permission", perm)
-			sonarQubeURL.RawQuery = RawQuery.Encode()
-
-			resp, err := httpRequestHelper(
-				m.(*ProviderConfiguration).httpClient,
-				"POST",
-				sonarQubeURL.String(),
-				http.StatusNoContent,
-				"resourceSonarqubePermissionsUpdate",
-			)
-			if err != nil {
-				return fmt.Errorf("resourceSonarqubePermissionsUpdate: Error removing Sonarqube permissions: %+v", err)
-			}
-			defer resp.Body.Close()
-		}
-
-		RawQuery = url.Values{}
-
-		if projectKey, ok := d.GetOk("project_key"); ok {
-			RawQuery.Add("projectKey", projectKey.(string))
-		}
-
-		RawQuery.Add("login", loginName.(string))
-		if templateID, ok := d.GetOk("template_id"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/add_user_to_template"
-			RawQuery.Add("templateId", templateID.(string))
-		} else if templateName, ok := d.GetOk("template_name"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/add_user_to_template"
-			RawQuery.Add("templateName", templateName.(string))
-		} else {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/add_user"
-		}
-
-		for _, perm := range toAddPermissions {
-			RawQuery.Set("permission", perm)
-			sonarQubeURL.RawQuery = RawQuery.Encode()
-
-			resp, err := httpRequestHelper(
-				m.(*ProviderConfiguration).httpClient,
-				"POST",
-				sonarQubeURL.String(),
-				http.StatusNoContent,
-				"resourceSonarqubePermissionsUpdate",
-			)
-			if err != nil {
-				return fmt.Errorf("resourceSonarqubePermissionsUpdate: Error adding Sonarqube permissions: %+v", err)
-			}
-			defer resp.Body.Close()
-		}
-	} else if groupName, ok := d.GetOk("group_name"); ok {
-		toAddPermissions, toRemovePermissions := calculatePermissionChanges(currentPermissions, targetPermissions)
-
-		RawQuery = url.Values{}
-
-		if projectKey, ok := d.GetOk("project_key"); ok {
-			RawQuery.Add("projectKey", projectKey.(string))
-		}
-
-		RawQuery.Add("groupName", groupName.(string))
-		if templateID, ok := d.GetOk("template_id"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/remove_group_from_template"
-			RawQuery.Add("templateId", templateID.(string))
-		} else if templateName, ok := d.GetOk("template_name"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/remove_group_from_template"
-			RawQuery.Add("templateName", templateName.(string))
-		} else {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/remove_group"
-		}
-
-		for _, perm := range toRemovePermissions {
-			RawQuery.Set("permission", perm)
-			sonarQubeURL.RawQuery = RawQuery.Encode()
-
-			resp, err := httpRequestHelper(
-				m.(*ProviderConfiguration).httpClient,
-				"POST",
-				sonarQubeURL.String(),
-				http.StatusNoContent,
-				"resourceSonarqubePermissionsUpdate",
-			)
-			if err != nil {
-				return fmt.Errorf("resourceSonarqubePermissionsUpdate: Error removing Sonarqube permissions: %+v", err)
-			}
-			defer resp.Body.Close()
-		}
-
-		RawQuery = url.Values{}
-
-		if projectKey, ok := d.GetOk("project_key"); ok {
-			RawQuery.Add("projectKey", projectKey.(string))
-		}
-
-		RawQuery.Add("groupName", groupName.(string))
-		if templateID, ok := d.GetOk("template_id"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/add_group_to_template"
-			RawQuery.Add("templateId", templateID.(string))
-		} else if templateName, ok := d.GetOk("template_name"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/add_group_to_template"
-			RawQuery.Add("templateName", templateName.(string))
-		} else {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeBasePath, "/") + "/api/permissions/add_group"
-		}
-
-		for _, perm := range toAddPermissions {
-			RawQuery.Set("permission", perm)
-			sonarQubeURL.RawQuery = RawQuery.Encode()
-
-			resp, err := httpRequestHelper(
-				m.(*ProviderConfiguration).httpClient,
-				"POST",
-				sonarQubeURL.String(),
-				http.StatusNoContent,
-				"resourceSonarqubePermissionsUpdate",
-			)
-			if err != nil {
-				return fmt.Errorf("resourceSonarqubePermissionsUpdate: Error adding Sonarqube permissions: %+v", err)
-			}
-			defer resp.Body.Close()
-		}
-	} else {
-		return fmt.Errorf("resourceSonarqubePermissionsUpdate: Didn't find any identification")
+	for _, permission := range toAddPermissions {
+		changes = append(changes, permissionChange{action: "add", permission: permission})
+	}
+	if err := applyPermissionChanges(principalType, principal, scope, changes, m); err != nil {
+		return fmt.Errorf("resourceSonarqubePermissionsUpdate: Error updating Sonarqube permissions: %+v", err)
 	}
 
 	return resourceSonarqubePermissionsRead(d, m)
 }
 
 func resourceSonarqubePermissionsDelete(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	permissions := expandPermissions(d.Get("permissions"))
-
-	// build the base query
-	RawQuery := url.Values{}
-
-	// if the permissions should be applied to a project
-	// we append the project_key to the request
-	if projectKey, ok := d.GetOk("project_key"); ok {
-		RawQuery.Add("projectKey", projectKey.(string))
+	principalType, principal := permissionsPrincipal(d)
+	scope := expandPermissionScope(d)
+	if principalType == principalProjectCreator && !scope.isTemplate() {
+		return fmt.Errorf("resourceSonarqubePermissionsDelete: 'templateId' or 'templateName' must be set when 'special_group_name' is set to 'project_creator'")
 	}
 
-	// we use different API endpoints and request params
-	// based on the target principal type (group or user
-	// or special group)
-	if _, ok := d.GetOk("login_name"); ok {
-		// permission target is USER
-		if templateID, ok := d.GetOk("template_id"); ok {
-			// template user permission
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/remove_user_from_template"
-			RawQuery.Add("templateId", templateID.(string))
-		} else if templateName, ok := d.GetOk("template_name"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/remove_user_from_template"
-			RawQuery.Add("templateName", templateName.(string))
-		} else {
-			// direct user permission
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/remove_user"
-		}
-		RawQuery.Add("login", d.Get("login_name").(string))
-		sonarQubeURL.RawQuery = RawQuery.Encode()
-
-	} else if _, ok := d.GetOk("group_name"); ok {
-		// permission target is GROUP
-		if templateID, ok := d.GetOk("template_id"); ok {
-			// template group permission
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/remove_group_from_template"
-			RawQuery.Add("templateId", templateID.(string))
-		} else if templateName, ok := d.GetOk("template_name"); ok {
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/remove_group_from_template"
-			RawQuery.Add("templateName", templateName.(string))
-		} else {
-			// direct group permission
-			sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/remove_group"
-		}
-		RawQuery.Add("groupName", d.Get("group_name").(string))
-		sonarQubeURL.RawQuery = RawQuery.Encode()
-	} else {
-		// permission target is SPECIAL GROUP set to project creator
-		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/remove_project_creator_from_template"
-		if templateID, ok := d.GetOk("template_id"); ok {
-			// template project creator permission
-			RawQuery.Add("templateId", templateID.(string))
-		} else if templateName, ok := d.GetOk("template_name"); ok {
-			// name provide instead of id
-			RawQuery.Add("templateName", templateName.(string))
-		} else {
-			return fmt.Errorf("resourceSonarqubePermissionsDelete: 'templateId' or 'templateName' must be set when 'special_group_name' is set to 'project_creator'")
-		}
+	changes := []permissionChange{}
+	for _, permission := range expandPermissions(d.Get("permissions")) {
+		changes = append(changes, permissionChange{action: "remove", permission: permission})
 	}
-
-	// loop through all permissions that should be applied
-	for _, permission := range permissions {
-		CurrentRawQuery := RawQuery
-		CurrentRawQuery.Del("permission")
-		CurrentRawQuery.Add("permission", permission)
-		sonarQubeURL.RawQuery = CurrentRawQuery.Encode()
-
-		resp, err := httpRequestHelper(
-			m.(*ProviderConfiguration).httpClient,
-			"POST",
-			sonarQubeURL.String(),
-			http.StatusNoContent,
-			"resourceSonarqubePermissionsDelete",
-		)
-		if err != nil {
-			return fmt.Errorf("resourceSonarqubePermissionsDelete: error creating Sonarqube permission: %+v", err)
-		}
-		defer resp.Body.Close()
+	if err := applyPermissionChanges(principalType, principal, scope, changes, m); err != nil {
+		return fmt.Errorf("resourceSonarqubePermissionsDelete: error deleting Sonarqube permission: %+v", err)
 	}
 
 	return nil
 }
 
+// permissionsPrincipal returns the principal type and name of a sonarqube_permissions resource
+func permissionsPrincipal(d *schema.ResourceData) (string, string) {
+	if loginName, ok := d.GetOk("login_name"); ok {
+		return principalUser, loginName.(string)
+	}
+	if groupName, ok := d.GetOk("group_name"); ok {
+		return principalGroup, groupName.(string)
+	}
+	return principalProjectCreator, ""
+}
+
 func expandPermissions(flatPermissions interface{}) []string {
 	switch v := flatPermissions.(type) {
 	case *schema.Set:
@@ -787,3 +555,92 @@ func flattenProjectCreatorPermissions(input *[]PermissionTemplatePermission) []i
 
 	return flatPermissions
 }
+
+// validatePermissionNames returns an error listing every permission that is not supported for the scope
+func validatePermissionNames(permissions []string, conf *ProviderConfiguration, projectScope bool) error {
+	if conf != nil && conf.skipPermissionValidation {
+		return nil
+	}
+
+	supported := supportedPermissions(conf, projectScope)
+	scope := "global"
+	if projectScope {
+		scope = "project and template"
+	}
+
+	unsupported := []string{}
+	for _, permission := range permissions {
+		found := false
+		for _, s := range supported {
+			if strings.EqualFold(permission, s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unsupported = append(unsupported, permission)
+		}
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("validatePermissionNames: the permissions %s are not supported as %s permissions, supported permissions are: %s", strings.Join(unsupported, ", "), scope, strings.Join(supported, ", "))
+	}
+	return nil
+}
+
+func validatePermissionsResource(d *schema.ResourceDiff, meta interface{}) error {
+	// Unknown permissions cannot be validated until apply
+	if !d.NewValueKnown("permissions") {
+		return nil
+	}
+
+	conf, _ := meta.(*ProviderConfiguration)
+	return validatePermissionNames(expandPermissions(d.Get("permissions")), conf, permissionsProjectScope(d))
+}
+
+// refreshPermissionsOnPlan reads the current permissions of the user or group of an existing resource, and plans an
+// update when they differ from the permissions known to the state. The permissions of the project creator are only
+// read on refresh.
+func refreshPermissionsOnPlan(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.Get("refresh_on_plan").(bool) || d.HasChange("login_name") || d.HasChange("group_name") {
+		return nil
+	}
+
+	principalType, principal := principalUser, d.Get("login_name").(string)
+	if principal == "" {
+		principalType, principal = principalGroup, d.Get("group_name").(string)
+	}
+	if principal == "" {
+		return nil
+	}
+	scope := permissionScope{
+		projectKey:   d.Get("project_key").(string),
+		templateID:   d.Get("template_id").(string),
+		templateName: d.Get("template_name").(string),
+	}
+
+	current, err := getPrincipalPermissions(principalType, principal, scope, meta)
+	if err != nil {
+		return fmt.Errorf("refreshPermissionsOnPlan: %+v", err)
+	}
+	toAdd, toRemove := calculatePermissionChanges(expandPermissions(d.Get("server_permissions")), current)
+	if len(toAdd) == 0 && len(toRemove) == 0 {
+		return nil
+	}
+	return d.SetNew("server_permissions", flattenPermissions(&current))
+}
+
+// permissionsProjectScope returns true when a project or template is configured. The scope is derived from the
+// configuration, as the project or template may not be known yet, or from the planned values without a configuration.
+func permissionsProjectScope(d *schema.ResourceDiff) bool {
+	rawConfig := d.GetRawConfig()
+	for _, attribute := range []string{"project_key", "template_id", "template_name"} {
+		if rawConfig.IsNull() {
+			if d.Get(attribute).(string) != "" {
+				return true
+			}
+		} else if !rawConfig.GetAttr(attribute).IsNull() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,394 @@
+package sonarqube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubePermissionsAuthoritative() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube authoritative Permissions resource. This resource owns the complete permission list of the listed
+users and groups, and of the project creator of a permission template, on a scope (global, a project or a permission template): their
+permissions granted outside of this resource are detected as drift and revoked on the next apply, and removing a principal from the
+configuration revokes its permissions. With ` + "`exclusive`" + `, the resource owns the permissions of every user and group of the scope instead.
+Do not combine this resource with ` + "`sonarqube_permissions`" + ` for the same principals. It supports importing using the scope as ID: ` + "`global`" + `,
+or a project_key (p_), template_id (t_) or template_name (tn_) with prefixes. Example: p_my_project. Importing reads every principal of the
+scope and sets ` + "`exclusive`" + ` to true.`,
+		Create: resourceSonarqubePermissionsAuthoritativeCreate,
+		Read:   resourceSonarqubePermissionsAuthoritativeRead,
+		Update: resourceSonarqubePermissionsAuthoritativeUpdate,
+		Delete: resourceSonarqubePermissionsAuthoritativeDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubePermissionsAuthoritativeImport,
+		},
+		// Validate the permission names against the scope once the plan is known
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validatePermissionsAuthoritativeResource(d, meta)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project_key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"template_id", "template_name"},
+				Description:   "The key of the project whose permissions are managed. Changing this forces a new resource to be created. Cannot be used with `template_id` and `template_name`.",
+			},
+			"template_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"project_key", "template_name"},
+				Description:   "The ID of the permission template whose permissions are managed. Changing this forces a new resource to be created. Cannot be used with `project_key` and `template_name`.",
+			},
+			"template_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"project_key", "template_id"},
+				Description:   "The name of the permission template whose permissions are managed. Changing this forces a new resource to be created. Cannot be used with `project_key` and `template_id`.",
+			},
+			"group": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The groups whose permissions on the scope are managed. With `exclusive`, this is the complete list of groups with permissions on the scope, and the groups that are not listed lose all their permissions, including the `Anyone` group.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the group.",
+						},
+						"permissions": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The permissions of the group.",
+						},
+					},
+				},
+			},
+			"user": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The users whose permissions on the scope are managed. With `exclusive`, this is the complete list of users with permissions on the scope, and the users that are not listed lose all their permissions.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"login": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The login of the user.",
+						},
+						"permissions": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+							Description: "The permissions of the user.",
+						},
+					},
+				},
+			},
+			"project_creator_permissions": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The permissions of the project creator on the permission template. Can only be used with `template_id` or `template_name`. They are managed when the list is not empty, or always with `exclusive`.",
+			},
+			"exclusive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the resource owns the permissions of every user and group of the scope, revoking the permissions of the principals that are not listed. On the global scope and on a project, at least one group or user must then have the `admin` permission, and destroying the resource keeps the `admin` permissions so that the administrators are not locked out. Switching it off releases the principals that are not listed without revoking their permissions. Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubePermissionsAuthoritativeCreate(d *schema.ResourceData, m interface{}) error {
+	if err := applyPermissionsAuthoritative(d, m); err != nil {
+		return err
+	}
+
+	d.SetId(expandPermissionScope(d).id())
+
+	return resourceSonarqubePermissionsAuthoritativeRead(d, m)
+}
+
+func resourceSonarqubePermissionsAuthoritativeRead(d *schema.ResourceData, m interface{}) error {
+	scope := expandPermissionScope(d)
+	exclusive := d.Get("exclusive").(bool)
+
+	errs := []error{}
+	for principalType, attribute := range map[string]string{principalGroup: "group", principalUser: "user"} {
+		current, err := listScopePermissions(principalType, scope, "", m)
+		if err != nil {
+			return err
+		}
+		nameAttribute := principalNameAttribute(principalType)
+		configured := expandAuthoritativePermissions(d.Get(attribute).(*schema.Set), nameAttribute)
+		current = alignPrincipalNames(current, configured)
+		if !exclusive {
+			current = filterPrincipalPermissions(current, configured)
+		}
+		errs = append(errs, d.Set(attribute, flattenAuthoritativePermissions(current, nameAttribute)))
+	}
+
+	if scope.isTemplate() && (exclusive || d.Get("project_creator_permissions").(*schema.Set).Len() > 0) {
+		template, err := findPermissionTemplateFromApi(scope.templateID, scope.templateName, m)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, d.Set("project_creator_permissions", flattenProjectCreatorPermissions(&template.Permissions)))
+	}
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubePermissionsAuthoritativeUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := applyPermissionsAuthoritative(d, m); err != nil {
+		return err
+	}
+
+	return resourceSonarqubePermissionsAuthoritativeRead(d, m)
+}
+
+func resourceSonarqubePermissionsAuthoritativeDelete(d *schema.ResourceData, m interface{}) error {
+	scope := expandPermissionScope(d)
+	// Revoking every admin permission of an exclusive resource would lock the administrators out
+	keepAdmin := d.Get("exclusive").(bool) && !scope.isTemplate()
+
+	// Only the permissions known to the state are revoked
+	for principalType, attribute := range map[string]string{principalGroup: "group", principalUser: "user"} {
+		current := expandAuthoritativePermissions(d.Get(attribute).(*schema.Set), principalNameAttribute(principalType))
+		desired := map[string][]string{}
+		if keepAdmin {
+			for principal, permissions := range current {
+				if hasPermission(permissions, permissionAdmin) {
+					desired[principal] = []string{permissionAdmin}
+				}
+			}
+		}
+		if err := convergePermissions(principalType, scope, current, desired, m); err != nil {
+			return err
+		}
+	}
+
+	if scope.isTemplate() {
+		current := map[string][]string{"": expandPermissions(d.Get("project_creator_permissions"))}
+		if err := convergePermissions(principalProjectCreator, scope, current, map[string][]string{}, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resourceSonarqubePermissionsAuthoritativeImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	scope, err := parsePermissionScope(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubePermissionsAuthoritativeImport: %+v", err)
+	}
+
+	// The configuration is unknown, so every principal of the scope is imported
+	errs := []error{}
+	errs = append(errs, d.Set("project_key", scope.projectKey))
+	errs = append(errs, d.Set("template_id", scope.templateID))
+	errs = append(errs, d.Set("template_name", scope.templateName))
+	errs = append(errs, d.Set("exclusive", true))
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if err := resourceSonarqubePermissionsAuthoritativeRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func validatePermissionsAuthoritativeResource(d *schema.ResourceDiff, meta interface{}) error {
+	// Unknown permissions cannot be validated until apply
+	if !d.NewValueKnown("group") || !d.NewValueKnown("user") || !d.NewValueKnown("project_creator_permissions") {
+		return nil
+	}
+
+	template := d.Get("template_id").(string) != "" || d.Get("template_name").(string) != ""
+	projectScope := d.Get("project_key").(string) != "" || template
+	conf, _ := meta.(*ProviderConfiguration)
+
+	errs := []error{}
+	hasAdmin := false
+	for principalType, attribute := range map[string]string{principalGroup: "group", principalUser: "user"} {
+		for _, permissions := range expandAuthoritativePermissions(d.Get(attribute).(*schema.Set), principalNameAttribute(principalType)) {
+			errs = append(errs, validatePermissionNames(permissions, conf, projectScope))
+			hasAdmin = hasAdmin || hasPermission(permissions, permissionAdmin)
+		}
+	}
+
+	if projectCreatorPermissions := expandPermissions(d.Get("project_creator_permissions")); len(projectCreatorPermissions) > 0 {
+		if !template {
+			errs = append(errs, fmt.Errorf("project_creator_permissions can only be set with template_id or template_name"))
+		}
+		errs = append(errs, validatePermissionNames(projectCreatorPermissions, conf, true))
+	}
+
+	if d.Get("exclusive").(bool) && !template && !hasAdmin {
+		errs = append(errs, fmt.Errorf("exclusive permissions must grant the admin permission to at least one group or user, otherwise the administrators are locked out of the scope"))
+	}
+	return errors.Join(errs...)
+}
+
+func principalNameAttribute(principalType string) string {
+	if principalType == principalUser {
+		return "login"
+	}
+	return "name"
+}
+
+// convergePermissions grants and revokes permissions until every principal has exactly the desired permissions.
+// Permissions are granted before they are revoked so that administrators do not lock themselves out halfway.
+func convergePermissions(principalType string, scope permissionScope, current map[string][]string, desired map[string][]string, m interface{}) error {
+	for principal, permissions := range desired {
+		toAdd, _ := calculatePermissionChanges(current[principal], permissions)
+		changes := []permissionChange{}
+		for _, permission := range toAdd {
+			changes = append(changes, permissionChange{action: "add", permission: permission})
+		}
+		if err := applyPermissionChanges(principalType, principal, scope, changes, m); err != nil {
+			return err
+		}
+	}
+	for principal, permissions := range current {
+		_, toRemove := calculatePermissionChanges(permissions, desired[principal])
+		changes := []permissionChange{}
+		for _, permission := range toRemove {
+			changes = append(changes, permissionChange{action: "remove", permission: permission})
+		}
+		if err := applyPermissionChanges(principalType, principal, scope, changes, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyPermissionsAuthoritative converges the permissions of the managed principals: the listed ones, the ones removed
+// from the configuration, and with exclusive every principal of the scope
+func applyPermissionsAuthoritative(d *schema.ResourceData, m interface{}) error {
+	scope := expandPermissionScope(d)
+	exclusive := d.Get("exclusive").(bool)
+	// The principals that are no longer listed are released, not revoked, when exclusive is switched off
+	wasExclusive, _ := d.GetChange("exclusive")
+	release := wasExclusive.(bool) && !exclusive
+
+	for principalType, attribute := range map[string]string{principalGroup: "group", principalUser: "user"} {
+		nameAttribute := principalNameAttribute(principalType)
+		oldBlocks, newBlocks := d.GetChange(attribute)
+		previous := expandAuthoritativePermissions(oldBlocks.(*schema.Set), nameAttribute)
+		desired := expandAuthoritativePermissions(newBlocks.(*schema.Set), nameAttribute)
+
+		managed := map[string][]string{}
+		for principal, permissions := range desired {
+			managed[principal] = permissions
+		}
+		if !release {
+			for principal, permissions := range previous {
+				managed[principal] = permissions
+			}
+		}
+
+		current, err := listScopePermissions(principalType, scope, "", m)
+		if err != nil {
+			return err
+		}
+		current = alignPrincipalNames(current, managed)
+		if !exclusive {
+			current = filterPrincipalPermissions(current, managed)
+		}
+		if err := convergePermissions(principalType, scope, current, desired, m); err != nil {
+			return err
+		}
+	}
+
+	oldPermissions, newPermissions := d.GetChange("project_creator_permissions")
+	desired := expandPermissions(newPermissions)
+	if scope.isTemplate() && (exclusive || len(desired) > 0 || oldPermissions.(*schema.Set).Len() > 0) {
+		template, err := findPermissionTemplateFromApi(scope.templateID, scope.templateName, m)
+		if err != nil {
+			return err
+		}
+		current := map[string][]string{"": expandPermissions(flattenProjectCreatorPermissions(&template.Permissions))}
+		if err := convergePermissions(principalProjectCreator, scope, current, map[string][]string{"": desired}, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filterPrincipalPermissions returns the permissions of the principals of the configuration, which already have its
+// casing
+func filterPrincipalPermissions(current map[string][]string, configured map[string][]string) map[string][]string {
+	filtered := make(map[string][]string, len(configured))
+	for principal, permissions := range current {
+		if _, ok := configured[principal]; ok {
+			filtered[principal] = permissions
+		}
+	}
+	return filtered
+}
+
+// alignPrincipalNames renames the principals of the server to the casing of the configuration, as SonarQube matches
+// logins and group names case-insensitively
+func alignPrincipalNames(current map[string][]string, configured map[string][]string) map[string][]string {
+	aligned := make(map[string][]string, len(current))
+	for principal, permissions := range current {
+		for name := range configured {
+			if strings.EqualFold(name, principal) {
+				principal = name
+				break
+			}
+		}
+		aligned[principal] = append(aligned[principal], permissions...)
+	}
+	return aligned
+}
+
+func expandAuthoritativePermissions(blocks *schema.Set, nameAttribute string) map[string][]string {
+	permissions := make(map[string][]string)
+	for _, block := range blocks.List() {
+		block := block.(map[string]interface{})
+		permissions[block[nameAttribute].(string)] = expandPermissions(block["permissions"])
+	}
+	return permissions
+}
+
+func flattenAuthoritativePermissions(permissions map[string][]string, nameAttribute string) []interface{} {
+	names := make([]string, 0, len(permissions))
+	for name := range permissions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	blocks := make([]interface{}, 0, len(permissions))
+	for _, name := range names {
+		principalPermissions := permissions[name]
+		blocks = append(blocks, map[string]interface{}{
+			nameAttribute: name,
+			"permissions": flattenPermissions(&principalPermissions),
+		})
+	}
+	return blocks
+}
@@ -0,0 +1,219 @@
+package sonarqube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubePermissionsAuthoritativeConfig(rnd string, projectKey string, groupPermissions []string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "private"
+		}
+
+		resource "sonarqube_group" "%[1]s" {
+			name = "%[2]s"
+		}
+
+		resource "sonarqube_permissions_authoritative" "%[1]s" {
+			project_key = sonarqube_project.%[1]s.project
+			exclusive   = true
+
+			group {
+				name        = sonarqube_group.%[1]s.name
+				permissions = %[3]s
+			}
+
+			user {
+				login       = "admin"
+				permissions = ["admin", "codeviewer", "issueadmin", "securityhotspotadmin", "user"]
+			}
+		}`, rnd, projectKey, generateHCLList(groupPermissions))
+}
+
+func TestAccSonarqubePermissionsAuthoritativeBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_permissions_authoritative." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubePermissionsAuthoritativeConfig(rnd, "testAccSonarqubePermissionsAuthoritative", []string{"codeviewer", "user"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "p_testAccSonarqubePermissionsAuthoritative"),
+					resource.TestCheckResourceAttr(name, "group.#", "1"),
+					resource.TestCheckResourceAttr(name, "group.0.permissions.#", "2"),
+					resource.TestCheckResourceAttr(name, "user.#", "1"),
+				),
+			},
+			{
+				Config: testAccSonarqubePermissionsAuthoritativeConfig(rnd, "testAccSonarqubePermissionsAuthoritative", []string{"user"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "group.0.permissions.#", "1"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAlignPrincipalNames(t *testing.T) {
+	current := map[string][]string{"devteam": {"user"}, "sonar-administrators": {"admin"}}
+	configured := map[string][]string{"DevTeam": {"user", "codeviewer"}}
+
+	expected := map[string][]string{"DevTeam": {"user"}, "sonar-administrators": {"admin"}}
+	if got := alignPrincipalNames(current, configured); !reflect.DeepEqual(got, expected) {
+		t.Errorf("alignPrincipalNames() = %v, want %v", got, expected)
+	}
+}
+
+func TestResourceSonarqubePermissionsAuthoritativeConfiguredPrincipals(t *testing.T) {
+	mock := newMockSonarQube(t)
+	groups := map[string][]string{"sonar-administrators": {"admin"}, "developers": {"codeviewer", "user"}}
+	mockGroupPermissionsAPI(mock, groups, 100)
+	mock.respond("GET", "/api/permissions/users", http.StatusOK, `{"paging":{"pageIndex":1,"pageSize":100,"total":0},"users":[]}`)
+	conf := mock.conf("Community", "10.7")
+	r := resourceSonarqubePermissionsAuthoritative()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project_key": "my_project",
+		"group":       []interface{}{map[string]interface{}{"name": "developers", "permissions": []interface{}{"user"}}},
+	})
+	if err := r.Create(d, conf); err != nil {
+		t.Fatalf("Create() error = %+v", err)
+	}
+	if !reflect.DeepEqual(groups["developers"], []string{"user"}) || len(groups["sonar-administrators"]) != 1 {
+		t.Errorf("Create() permissions = %v, want user for developers only", groups)
+	}
+	if d.Get("group").(*schema.Set).Len() != 1 {
+		t.Errorf("Read() group = %v, want only the configured group", d.Get("group"))
+	}
+
+	// Removing a group from the configuration revokes its permissions, and only its permissions
+	raw := map[string]interface{}{"project_key": "my_project"}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), conf)
+	if err != nil {
+		t.Fatalf("Diff() error = %+v", err)
+	}
+	if _, err := r.Apply(context.Background(), d.State(), diff, conf); err != nil {
+		t.Fatalf("Apply() error = %+v", err)
+	}
+	if len(groups["developers"]) != 0 || len(groups["sonar-administrators"]) != 1 {
+		t.Errorf("Update() permissions = %v, want none for developers only", groups)
+	}
+}
+
+func TestResourceSonarqubePermissionsAuthoritativeExclusiveLockout(t *testing.T) {
+	r := resourceSonarqubePermissionsAuthoritative()
+	conf := &ProviderConfiguration{skipPermissionValidation: true}
+
+	tests := []struct {
+		name    string
+		raw     map[string]interface{}
+		wantErr bool
+	}{
+		{
+			name: "exclusive without admin",
+			raw: map[string]interface{}{
+				"exclusive": true,
+				"group":     []interface{}{map[string]interface{}{"name": "developers", "permissions": []interface{}{"scan"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "exclusive with admin",
+			raw: map[string]interface{}{
+				"exclusive": true,
+				"group":     []interface{}{map[string]interface{}{"name": "sonar-administrators", "permissions": []interface{}{"admin"}}},
+			},
+		},
+		{
+			name: "not exclusive without admin",
+			raw: map[string]interface{}{
+				"group": []interface{}{map[string]interface{}{"name": "developers", "permissions": []interface{}{"scan"}}},
+			},
+		},
+		{
+			name: "exclusive template without admin",
+			raw: map[string]interface{}{
+				"exclusive":     true,
+				"template_name": "default",
+			},
+		},
+		{
+			name: "project creator without template",
+			raw: map[string]interface{}{
+				"project_key":                 "my_project",
+				"project_creator_permissions": []interface{}{"admin"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.raw), conf)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Diff() error = %v, want an error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceSonarqubePermissionsAuthoritativeProjectCreator(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/permissions/template_groups", http.StatusOK, `{"paging":{"pageIndex":1,"pageSize":100,"total":0},"groups":[]}`)
+	mock.respond("GET", "/api/permissions/template_users", http.StatusOK, `{"paging":{"pageIndex":1,"pageSize":100,"total":0},"users":[]}`)
+	projectCreator := map[string]bool{"admin": true, "scan": true}
+	mock.handle("GET", "/api/permissions/search_templates", func(w http.ResponseWriter, r *http.Request) {
+		permissions := []PermissionTemplatePermission{}
+		for _, key := range []string{"admin", "codeviewer", "scan"} {
+			permissions = append(permissions, PermissionTemplatePermission{Key: key, WithProjectCreator: projectCreator[key]})
+		}
+		mockJSON(w, GetPermissionTemplates{PermissionTemplates: []PermissionTemplate{{ID: "AU-default", Name: "default", Permissions: permissions}}})
+	})
+	mock.handle("POST", "/api/permissions/add_project_creator_to_template", func(w http.ResponseWriter, r *http.Request) {
+		projectCreator[r.URL.Query().Get("permission")] = true
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mock.handle("POST", "/api/permissions/remove_project_creator_from_template", func(w http.ResponseWriter, r *http.Request) {
+		projectCreator[r.URL.Query().Get("permission")] = false
+		w.WriteHeader(http.StatusNoContent)
+	})
+	r := resourceSonarqubePermissionsAuthoritative()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"template_name":               "default",
+		"project_creator_permissions": []interface{}{"admin", "codeviewer"},
+	})
+	if err := r.Create(d, mock.conf("Community", "10.7")); err != nil {
+		t.Fatalf("Create() error = %+v", err)
+	}
+	if !projectCreator["admin"] || !projectCreator["codeviewer"] || projectCreator["scan"] {
+		t.Errorf("Create() project creator permissions = %v, want admin and codeviewer", projectCreator)
+	}
+	for _, call := range mock.requests() {
+		if strings.HasPrefix(call, "POST") && !strings.Contains(call, "templateName=default") {
+			t.Errorf("Create() request %s, want the template", call)
+		}
+	}
+	if got := expandPermissions(d.Get("project_creator_permissions")); len(got) != 2 {
+		t.Errorf("Read() project_creator_permissions = %v, want admin and codeviewer", got)
+	}
+}
@@ -7,8 +7,14 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -42,7 +48,8 @@ type PermissionTemplatePermission struct {
 func resourceSonarqubePermissionTemplate() *schema.Resource {
 	return &schema.Resource{
 		Description: `Provides a Sonarqube Permission template resource. This can be used to create and manage Sonarqube Permission
-templates.`,
+templates. SonarQube refuses to create a project whose key matches the patterns of several templates, so the templates whose
+pattern may overlap with this one are listed in ` + "`overlapping_templates`" + ` and logged as a warning.`,
 		Create: resourceSonarqubePermissionTemplateCreate,
 		Read:   resourceSonarqubePermissionTemplateRead,
 		Update: resourceSonarqubePermissionTemplateUpdate,
@@ -65,15 +72,24 @@ templates.`,
 				Description: "Description of the Template.",
 			},
 			"project_key_pattern": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "The project key pattern. Must be a valid Java regular expression.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateProjectKeyPattern,
+				Description:      "The project key pattern. Must be a valid Java regular expression, which must match the whole project key.",
 			},
 			"default": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Description: "Set the template as the default. This can only be set for one template.",
 			},
+			"overlapping_templates": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The names of the other templates whose project key pattern matches the same keys as this one, for example `team-.*` and `team-a.*`. The overlaps are detected from the literal prefixes of the patterns, so not all of them are found.",
+			},
 		},
 	}
 }
@@ -157,10 +173,18 @@ func resourceSonarqubePermissionTemplateRead(d *schema.ResourceData, m interface
 			log.Printf("[DEBUG][resourceSonarqubePermissionTemplateRead] Found PermissionTemplate with ID '%s'", value.ID)
 			// If it does, set the values of that template
 			d.SetId(value.ID)
+			overlapping, err := findOverlappingPermissionTemplates(value, m)
+			if err != nil {
+				return err
+			}
+			if len(overlapping) > 0 {
+				log.Printf("[WARN][resourceSonarqubePermissionTemplateRead] The project key pattern of template '%s' overlaps with templates %s: SonarQube cannot create the projects whose key matches several templates", value.Name, strings.Join(overlapping, ", "))
+			}
 			errName := d.Set("name", value.Name)
 			errDesc := d.Set("description", value.Description)
 			errProj := d.Set("project_key_pattern", value.ProjectKeyPattern)
-			return errors.Join(errName, errDesc, errProj)
+			errOverlap := d.Set("overlapping_templates", overlapping)
+			return errors.Join(errName, errDesc, errProj, errOverlap)
 		}
 	}
 
@@ -261,3 +285,96 @@ func resourceSonarqubePermissionTemplateSetDefault(sonarQubeURL url.URL, templat
 	defer resp.Body.Close()
 	return nil
 }
+
+// validateProjectKeyPattern checks that the project key pattern compiles. Go regular expressions do not support some
+// features of the Java ones used by SonarQube, which only produce a warning.
+func validateProjectKeyPattern(v interface{}, path cty.Path) diag.Diagnostics {
+	_, err := syntax.Parse(v.(string), syntax.Perl)
+	if err == nil {
+		return nil
+	}
+
+	// Lookarounds, backreferences, possessive quantifiers and POSIX classes such as \p{Alpha}
+	javaOnly := []syntax.ErrorCode{syntax.ErrInvalidPerlOp, syntax.ErrInvalidNamedCapture, syntax.ErrInvalidEscape, syntax.ErrInvalidRepeatOp, syntax.ErrInvalidCharRange}
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) && slices.Contains(javaOnly, syntaxErr.Code) {
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       "The project key pattern cannot be validated",
+			Detail:        fmt.Sprintf("The pattern uses features of Java regular expressions that cannot be checked before SonarQube uses it: %s", err),
+			AttributePath: path,
+		}}
+	}
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "Invalid project key pattern",
+		Detail:        fmt.Sprintf("The project key pattern is not a valid regular expression: %s", err),
+		AttributePath: path,
+	}}
+}
+
+// findOverlappingPermissionTemplates returns the sorted names of the other templates whose project key pattern overlaps
+func findOverlappingPermissionTemplates(template PermissionTemplate, m interface{}) ([]string, error) {
+	overlapping := []string{}
+	if template.ProjectKeyPattern == "" {
+		return overlapping, nil
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/search_templates"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"findOverlappingPermissionTemplates",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("findOverlappingPermissionTemplates: Failed to read Sonarqube permission templates: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	permissionTemplates := GetPermissionTemplates{}
+	err = json.NewDecoder(resp.Body).Decode(&permissionTemplates)
+	if err != nil {
+		return nil, fmt.Errorf("findOverlappingPermissionTemplates: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, other := range permissionTemplates.PermissionTemplates {
+		if other.ID != template.ID && projectKeyPatternsOverlap(template.ProjectKeyPattern, other.ProjectKeyPattern) {
+			overlapping = append(overlapping, other.Name)
+		}
+	}
+	sort.Strings(overlapping)
+	return overlapping, nil
+}
+
+// projectKeyPatternsOverlap returns true when a project key built from the literal prefix of one pattern matches both
+// patterns. Patterns that cannot be compiled as Go regular expressions are only compared as strings.
+func projectKeyPatternsOverlap(a string, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if a == b {
+		return true
+	}
+
+	// SonarQube matches the whole project key
+	patternA, errA := regexp.Compile("^(?:" + a + ")$")
+	patternB, errB := regexp.Compile("^(?:" + b + ")$")
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	for _, pattern := range []string{a, b} {
+		prefix, _ := regexp.MustCompile(pattern).LiteralPrefix()
+		for _, key := range []string{prefix, prefix + "x", prefix + "0"} {
+			if patternA.MatchString(key) && patternB.MatchString(key) {
+				return true
+			}
+		}
+	}
+	return false
+}
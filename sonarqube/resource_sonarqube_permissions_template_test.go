@@ -2,8 +2,13 @@ package sonarqube
 
 import (
 	"fmt"
+	"net/http"
+	"reflect"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -99,3 +104,77 @@ func TestAccSonarqubePermissionTemplateDefaultTemplate(t *testing.T) {
 		},
 	})
 }
+
+func TestValidateProjectKeyPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		severity diag.Severity
+		wantDiag bool
+	}{
+		{pattern: "team-a-.*", wantDiag: false},
+		{pattern: "(?i)TEAM-[a-z]+", wantDiag: false},
+		{pattern: "team-(?!legacy).*", severity: diag.Warning, wantDiag: true},
+		{pattern: "(team)-\\1", severity: diag.Warning, wantDiag: true},
+		{pattern: "team-[a-", severity: diag.Error, wantDiag: true},
+		{pattern: "team-(a", severity: diag.Error, wantDiag: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			diags := validateProjectKeyPattern(tt.pattern, cty.GetAttrPath("project_key_pattern"))
+			if !tt.wantDiag {
+				if len(diags) > 0 {
+					t.Fatalf("validateProjectKeyPattern() = %v, want no diagnostics", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || diags[0].Severity != tt.severity {
+				t.Errorf("validateProjectKeyPattern() = %v, want one diagnostic of severity %v", diags, tt.severity)
+			}
+		})
+	}
+}
+
+func TestProjectKeyPatternsOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "team-.*", b: "team-a.*", want: true},
+		{a: "team-a.*", b: "team-.*", want: true},
+		{a: "team-a-.+", b: "team-a-[0-9]+", want: true},
+		{a: "team-a-.*", b: "team-b-.*", want: false},
+		{a: "(?!x).*", b: "(?!x).*", want: true},
+		{a: ".*", b: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			if got := projectKeyPatternsOverlap(tt.a, tt.b); got != tt.want {
+				t.Errorf("projectKeyPatternsOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceSonarqubePermissionTemplateOverlappingTemplates(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/permissions/search_templates", http.StatusOK, `{"permissionTemplates": [
+		{"id": "1", "name": "team-a", "projectKeyPattern": "team-a-.*"},
+		{"id": "2", "name": "teams", "projectKeyPattern": "team-.*"},
+		{"id": "3", "name": "team-b", "projectKeyPattern": "team-b-.*"},
+		{"id": "4", "name": "default"}
+	]}`)
+	r := resourceSonarqubePermissionTemplate()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "team-a",
+	})
+	d.SetId("1")
+	if err := r.Read(d, mock.conf("Community", "10.7")); err != nil {
+		t.Fatalf("Read() unexpected error = %v", err)
+	}
+	if got := expandStringList(d.Get("overlapping_templates")); !reflect.DeepEqual(got, []string{"teams"}) {
+		t.Errorf("Read() overlapping_templates = %v, want [teams]", got)
+	}
+}
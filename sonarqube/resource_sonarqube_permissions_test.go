@@ -1,9 +1,20 @@
 package sonarqube
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -202,6 +213,56 @@ func TestAccSonarqubePermissionGroupUpdate(t *testing.T) {
 	})
 }
 
+func testAccSonarqubePermissionRefreshOnPlanConfig(id string, groupName string, permissions []string) string {
+	formattedPermissions := generateHCLList(permissions)
+	return fmt.Sprintf(`
+		resource "sonarqube_group" "%[1]s" {
+			name        = "%[2]s"
+			description = "%[2]s"
+		}
+
+		resource "sonarqube_permissions" "%[1]s" {
+			group_name      = sonarqube_group.%[1]s.name
+			permissions     = %[3]s
+			refresh_on_plan = true
+		}`, id, groupName, formattedPermissions)
+}
+
+func TestAccSonarqubePermissionRefreshOnPlan(t *testing.T) {
+	rnd := generateRandomResourceName()
+	resourceName := "sonarqube_permissions." + rnd
+	groupName := "refresh-on-plan-test-group"
+	permissions := []string{"profileadmin", "gateadmin"}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubePermissionRefreshOnPlanConfig(rnd, groupName, permissions),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "permissions.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "server_permissions.#", "2"),
+				),
+			},
+			// Remove a permission outside of Terraform, it is granted again
+			{
+				PreConfig: func() {
+					changes := []permissionChange{{action: "remove", permission: "gateadmin"}}
+					if err := applyPermissionChanges(principalGroup, groupName, permissionScope{}, changes, testAccProvider.Meta()); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccSonarqubePermissionRefreshOnPlanConfig(rnd, groupName, permissions),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "server_permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "server_permissions.*", "gateadmin"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSonarqubePermissionImportUser(t *testing.T) {
 	rnd := generateRandomResourceName()
 	resourceName := "sonarqube_permissions." + rnd
@@ -253,3 +314,368 @@ func TestAccSonarqubePermissionImportGroup(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSonarqubePermissionsInvalidScope(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubePermissionGroupNameConfig(rnd, "testAccSonarqubePermissions", []string{"codeviewer"}),
+				ExpectError: regexp.MustCompile("are not supported as global permissions"),
+			},
+		},
+	})
+}
+
+func TestValidatePermissionNames(t *testing.T) {
+	tests := []struct {
+		name           string
+		permissions    []string
+		edition        string
+		version        string
+		projectScope   bool
+		skipValidation bool
+		expectError    bool
+	}{
+		{
+			name:        "global permissions",
+			permissions: []string{"admin", "gateadmin", "profileadmin", "provisioning"},
+			edition:     "Community",
+		},
+		{
+			name:        "project permission on global scope",
+			permissions: []string{"codeviewer"},
+			edition:     "Community",
+			expectError: true,
+		},
+		{
+			name:         "project permissions",
+			permissions:  []string{"admin", "codeviewer", "issueadmin", "securityhotspotadmin", "scan", "user"},
+			edition:      "Community",
+			projectScope: true,
+		},
+		{
+			name:         "global permission on project scope",
+			permissions:  []string{"gateadmin"},
+			edition:      "Community",
+			projectScope: true,
+			expectError:  true,
+		},
+		{
+			name:        "case insensitive",
+			permissions: []string{"GateAdmin"},
+			edition:     "Community",
+		},
+		{
+			name:        "applicationcreator on community",
+			permissions: []string{"applicationcreator"},
+			edition:     "Community",
+			expectError: true,
+		},
+		{
+			name:        "applicationcreator on developer",
+			permissions: []string{"applicationcreator"},
+			edition:     "Developer",
+		},
+		{
+			name:        "portfoliocreator on developer",
+			permissions: []string{"portfoliocreator"},
+			edition:     "Developer",
+			expectError: true,
+		},
+		{
+			name:        "portfoliocreator on enterprise",
+			permissions: []string{"portfoliocreator"},
+			edition:     "Enterprise",
+		},
+		{
+			name:         "securityhotspotadmin before 8.1",
+			permissions:  []string{"securityhotspotadmin"},
+			edition:      "Community",
+			version:      "8.0",
+			projectScope: true,
+			expectError:  true,
+		},
+		{
+			name:         "securityhotspotadmin on 9.9",
+			permissions:  []string{"securityhotspotadmin"},
+			edition:      "Community",
+			version:      "9.9",
+			projectScope: true,
+		},
+		{
+			name:           "unknown permission with validation disabled",
+			permissions:    []string{"custompermission"},
+			edition:        "Community",
+			skipValidation: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &ProviderConfiguration{sonarQubeEdition: tt.edition, skipPermissionValidation: tt.skipValidation}
+			if tt.version != "" {
+				conf.sonarQubeVersion, _ = version.NewVersion(tt.version)
+			}
+			err := validatePermissionNames(tt.permissions, conf, tt.projectScope)
+			if (err != nil) != tt.expectError {
+				t.Errorf("validatePermissionNames(%v) returned error %v, expected error: %t", tt.permissions, err, tt.expectError)
+			}
+		})
+	}
+}
+
+// mockGroupPermissionsAPI serves the group permissions of the groups from the mock, with pages of pageSize groups
+func mockGroupPermissionsAPI(mock *mockSonarQube, groups map[string][]string, pageSize int) {
+	var mutex sync.Mutex
+
+	mock.handle("GET", "/api/permissions/groups", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		names := []string{}
+		for name := range groups {
+			if strings.Contains(strings.ToLower(name), strings.ToLower(r.URL.Query().Get("q"))) {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("p"))
+		if page == 0 {
+			page = 1
+		}
+		response := GetGroupPermissions{Paging: Paging{PageIndex: int64(page), PageSize: int64(pageSize), Total: int64(len(names))}}
+		for i := (page - 1) * pageSize; i < len(names) && i < page*pageSize; i++ {
+			response.Groups = append(response.Groups, GroupPermission{Name: names[i], Permissions: groups[names[i]]})
+		}
+		mockJSON(w, response)
+	})
+
+	update := func(add bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			group, permission := r.URL.Query().Get("groupName"), r.URL.Query().Get("permission")
+			if permission == "unknown" {
+				mockError(w, http.StatusBadRequest, "Value of parameter 'permission' (unknown) must be one of: [admin, codeviewer, issueadmin, securityhotspotadmin, scan, user]")
+				return
+			}
+			permissions := []string{}
+			for _, p := range groups[group] {
+				if p != permission {
+					permissions = append(permissions, p)
+				}
+			}
+			if add {
+				permissions = append(permissions, permission)
+			}
+			groups[group] = permissions
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+	mock.handle("POST", "/api/permissions/add_group", update(true))
+	mock.handle("POST", "/api/permissions/remove_group", update(false))
+}
+
+func TestResourceSonarqubePermissionsGroupLifecycle(t *testing.T) {
+	mock := newMockSonarQube(t)
+	groups := map[string][]string{"sonar-administrators": {"admin"}}
+	mockGroupPermissionsAPI(mock, groups, 1)
+	conf := mock.conf("Community", "10.7")
+	r := resourceSonarqubePermissions()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"group_name":  "developers",
+		"project_key": "my_project",
+		"permissions": []interface{}{"user", "codeviewer"},
+	})
+	if err := r.Create(d, conf); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if d.Id() != "group-developers-p_my_project-permissions" {
+		t.Errorf("Create() ID = %s, want group-developers-p_my_project-permissions", d.Id())
+	}
+	if got := expandPermissions(d.Get("server_permissions")); !reflect.DeepEqual(sortedStrings(got), []string{"codeviewer", "user"}) {
+		t.Errorf("Create() server_permissions = %v, want [codeviewer user]", got)
+	}
+
+	// The update removes the permissions that are no longer wanted, and adds the new ones
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"group_name":  "developers",
+		"project_key": "my_project",
+		"permissions": []interface{}{"user", "issueadmin"},
+	})
+	diff, err := r.Diff(context.Background(), d.State(), config, conf)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error = %v", err)
+	}
+	state, diags := r.Apply(context.Background(), d.State(), diff, conf)
+	if diags.HasError() {
+		t.Fatalf("Update() unexpected error = %v", diags)
+	}
+	d = r.Data(state)
+	if got := sortedStrings(groups["developers"]); !reflect.DeepEqual(got, []string{"issueadmin", "user"}) {
+		t.Errorf("Update() server permissions = %v, want [issueadmin user]", got)
+	}
+
+	if err := r.Delete(d, conf); err != nil {
+		t.Fatalf("Delete() unexpected error = %v", err)
+	}
+	if len(groups["developers"]) != 0 {
+		t.Errorf("Delete() left the permissions %v", groups["developers"])
+	}
+	if len(groups["sonar-administrators"]) != 1 {
+		t.Errorf("Delete() changed the permissions of another group: %v", groups["sonar-administrators"])
+	}
+}
+
+// A replacement with create_before_destroy creates the permissions while the previous resource still has them
+func TestResourceSonarqubePermissionsCreateExisting(t *testing.T) {
+	mock := newMockSonarQube(t)
+	groups := map[string][]string{"admins-dev": {"admin"}, "dev": {"scan", "user"}}
+	mockGroupPermissionsAPI(mock, groups, 100)
+	conf := mock.conf("Community", "10.7")
+	r := resourceSonarqubePermissions()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"group_name":  "dev",
+		"permissions": []interface{}{"user", "scan"},
+	})
+	if err := r.Create(d, conf); err != nil {
+		t.Fatalf("Create() error = %+v", err)
+	}
+	if d.Id() != "group-dev-global-permissions" {
+		t.Errorf("Create() ID = %s, want group-dev-global-permissions", d.Id())
+	}
+	if got := expandPermissions(d.Get("permissions")); len(got) != 2 {
+		t.Errorf("Create() permissions = %v, want scan and user", got)
+	}
+}
+
+func TestResourceSonarqubePermissionsCreateErrorBody(t *testing.T) {
+	mock := newMockSonarQube(t)
+	groups := map[string][]string{}
+	mockGroupPermissionsAPI(mock, groups, 100)
+	conf := mock.conf("Community", "10.7")
+	r := resourceSonarqubePermissions()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"group_name":  "developers",
+		"permissions": []interface{}{"scan", "unknown"},
+	})
+	err := r.Create(d, conf)
+	if err == nil || !strings.Contains(err.Error(), "Value of parameter 'permission' (unknown) must be one of") {
+		t.Fatalf("Create() error = %v, want the error message of the response body", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("Create() ID = %s, want no ID", d.Id())
+	}
+	// The permissions added before the failure are rolled back
+	if len(groups["developers"]) != 0 {
+		t.Errorf("Create() left the permissions %v", groups["developers"])
+	}
+}
+
+func TestResourceSonarqubePermissionsReadNotFound(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/permissions/users", http.StatusOK, `{"paging":{"pageIndex":1,"pageSize":100,"total":0},"users":[]}`)
+	conf := mock.conf("Community", "10.7")
+	r := resourceSonarqubePermissions()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"login_name":  "john.doe",
+		"permissions": []interface{}{"admin"},
+	})
+	d.SetId("john.doe:")
+	if err := r.Read(d, conf); err == nil || !strings.Contains(err.Error(), "Unable to find") {
+		t.Fatalf("Read() error = %v, want an error about the missing permissions", err)
+	}
+}
+
+func sortedStrings(values []string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func TestResourceSonarqubePermissionsCaseInsensitiveNames(t *testing.T) {
+	r := resourceSonarqubePermissions()
+	state := &terraform.InstanceState{
+		ID: "group-devteam-p_my_project-permissions",
+		Attributes: map[string]string{
+			"id":            "group-devteam-p_my_project-permissions",
+			"group_name":    "devteam",
+			"project_key":   "My_Project",
+			"permissions.#": "1",
+			"permissions.0": "user",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"group_name":  "DevTeam",
+		"project_key": "My_Project",
+		"permissions": []interface{}{"user"},
+	})
+
+	// The casing written back by the Read does not replace the resource
+	diff, err := r.Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error = %v", err)
+	}
+	if diff.RequiresNew() {
+		t.Errorf("Diff() should not replace the resource")
+	}
+	if _, ok := diff.Attributes["group_name"]; ok {
+		t.Errorf("Diff() should suppress the change of group_name")
+	}
+
+	// Project keys are case-sensitive
+	config = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"group_name":  "devteam",
+		"project_key": "my_project",
+		"permissions": []interface{}{"user"},
+	})
+	diff, err = r.Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error = %v", err)
+	}
+	if !diff.RequiresNew() {
+		t.Errorf("Diff() should replace the resource when the case of project_key changes")
+	}
+}
+
+func TestResourceSonarqubePermissionsImport(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/users/search", http.StatusOK, `{"paging":{"pageIndex":1,"pageSize":100,"total":0},"users":[]}`)
+	mockGroupPermissionsAPI(mock, map[string][]string{"developers": {"codeviewer"}}, 100)
+	r := resourceSonarqubePermissions()
+
+	d := r.TestResourceData()
+	d.SetId("developers/p_my:project")
+	if _, err := r.Importer.State(d, mock.conf("Community", "10.7")); err != nil {
+		t.Fatalf("Import() error = %+v", err)
+	}
+	if d.Id() != "group-developers-p_my:project-permissions" || d.Get("group_name") != "developers" || d.Get("project_key") != "my:project" {
+		t.Errorf("Import() ID = %s, group_name = %v, project_key = %v", d.Id(), d.Get("group_name"), d.Get("project_key"))
+	}
+
+	// The format of previous versions
+	d = r.TestResourceData()
+	d.SetId("developers:p_my:project")
+	if _, err := r.Importer.State(d, mock.conf("Community", "10.7")); err != nil {
+		t.Fatalf("Import() error = %+v", err)
+	}
+	if d.Id() != "group-developers-p_my:project-permissions" || d.Get("group_name") != "developers" || d.Get("project_key") != "my:project" {
+		t.Errorf("Import() ID = %s, group_name = %v, project_key = %v", d.Id(), d.Get("group_name"), d.Get("project_key"))
+	}
+
+	d = r.TestResourceData()
+	d.SetId("developers/my_project")
+	if _, err := r.Importer.State(d, mock.conf("Community", "10.7")); err == nil || !strings.Contains(err.Error(), "invalid permission scope") {
+		t.Errorf("Import() error = %v, want an invalid permission scope", err)
+	}
+}
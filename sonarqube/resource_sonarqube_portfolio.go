@@ -28,6 +28,15 @@ type Portfolio struct {
 	Tags             []string           `json:"tags,omitempty"`
 	Regexp           string             `json:"regexp,omitempty"`
 	SelectedProjects []PortfolioProject `json:"selectedProjects,omitempty"`
+	SubViews         []PortfolioSubView `json:"subViews,omitempty"`
+}
+
+// PortfolioSubView is a sub-portfolio, or a reference to another portfolio or application, of a portfolio
+type PortfolioSubView struct {
+	Key         string `json:"key"`
+	OriginalKey string `json:"originalKey,omitempty"`
+	Name        string `json:"name"`
+	Qualifier   string `json:"qualifier"`
 }
 
 // Portfolio project
@@ -76,6 +85,11 @@ func resourceSonarqubePortfolio() *schema.Resource {
 				ForceNew:    false,
 				Description: "The name of the Portfolio to create",
 			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the page of the Portfolio in the SonarQube UI.",
+			},
 			"description": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -357,6 +371,9 @@ func resourceSonarqubePortfolioRead(d *schema.ResourceData, m interface{}) error
 	if err != nil {
 		return err
 	}
+	if err := d.Set("url", sonarQubeWebURL(m.(*ProviderConfiguration), "/portfolio", url.Values{"id": []string{portfolioReadResponse.Key}})); err != nil {
+		return err
+	}
 	return updateResourceDataFromPortfolioReadResponse(d, portfolioReadResponse)
 }
 
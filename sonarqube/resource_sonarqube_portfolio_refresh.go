@@ -0,0 +1,138 @@
+package sonarqube
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubePortfolioRefresh() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Portfolio Refresh resource. This triggers the recomputation of a portfolio or application with api/views/refresh,
+or of all portfolios and applications, for example after changing their structure. The refresh is triggered when the resource is created
+and every time ` + "`triggers`" + ` changes. Destroying this resource only removes it from the state.`,
+		Create: resourceSonarqubePortfolioRefreshCreate,
+		Read:   resourceSonarqubePortfolioRefreshRead,
+		Update: resourceSonarqubePortfolioRefreshUpdate,
+		Delete: resourceSonarqubePortfolioRefreshDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The key of the portfolio or application to refresh. When not set, all portfolios and applications are refreshed. Changing this forces a new resource to be created.",
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary map of values that, when changed, triggers a new refresh. For example the `id` of the portfolios and applications whose structure changed.",
+			},
+			"wait_for_completion": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to wait until the background tasks of the refresh have finished. The wait is limited by the `create` and `update` timeouts, which default to 30 minutes.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubePortfolioRefreshCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkPortfolioSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	if err := refreshPortfolio(d, d.Timeout(schema.TimeoutCreate), m); err != nil {
+		return err
+	}
+
+	if key := d.Get("key").(string); key != "" {
+		d.SetId(key)
+	} else {
+		d.SetId("all")
+	}
+
+	return resourceSonarqubePortfolioRefreshRead(d, m)
+}
+
+func resourceSonarqubePortfolioRefreshRead(d *schema.ResourceData, m interface{}) error {
+	// A refresh is only triggered on create and update, there is nothing to read
+	return nil
+}
+
+func resourceSonarqubePortfolioRefreshUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("triggers") {
+		if err := refreshPortfolio(d, d.Timeout(schema.TimeoutUpdate), m); err != nil {
+			return err
+		}
+	}
+
+	return resourceSonarqubePortfolioRefreshRead(d, m)
+}
+
+func resourceSonarqubePortfolioRefreshDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+func refreshPortfolio(d *schema.ResourceData, timeout time.Duration, m interface{}) error {
+	key := d.Get("key").(string)
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/views/refresh"
+	if key != "" {
+		sonarQubeURL.RawQuery = url.Values{
+			"key": []string{key},
+		}.Encode()
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"refreshPortfolio",
+	)
+	if err != nil {
+		return fmt.Errorf("refreshPortfolio: Failed to refresh portfolio '%s': %+v", key, err)
+	}
+	defer resp.Body.Close()
+
+	if !d.Get("wait_for_completion").(bool) {
+		return nil
+	}
+	return waitForBackgroundTasks(key, timeout, m)
+}
+
+// waitForBackgroundTasks polls api/ce/activity_status until no background task of the component, or of any
+// component when it is empty, is pending or in progress
+func waitForBackgroundTasks(component string, timeout time.Duration, m interface{}) error {
+	return retry.RetryContext(context.Background(), timeout, func() *retry.RetryError {
+		status, err := readCeActivityStatusFromApi(component, m)
+		if err != nil {
+			return retry.NonRetryableError(err)
+		}
+
+		if status.Pending > 0 || status.InProgress > 0 {
+			log.Printf("[DEBUG][waitForBackgroundTasks] %d background tasks pending and %d in progress for component '%s'", status.Pending, status.InProgress, component)
+			return retry.RetryableError(fmt.Errorf("waitForBackgroundTasks: %d background tasks pending and %d in progress", status.Pending, status.InProgress))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,49 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubePortfolioRefreshConfig(rnd string, key string, description string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_portfolio" "%[1]s" {
+			key         = "%[2]s"
+			name        = "%[2]s"
+			description = "%[3]s"
+		}
+
+		resource "sonarqube_portfolio_refresh" "%[1]s" {
+			key                 = sonarqube_portfolio.%[1]s.key
+			wait_for_completion = true
+			triggers = {
+				description = sonarqube_portfolio.%[1]s.description
+			}
+		}`, rnd, key, description)
+}
+
+func TestAccSonarqubePortfolioRefreshBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_portfolio_refresh." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckPortfolioSupport(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubePortfolioRefreshConfig(rnd, "testAccSonarqubePortfolioRefresh", "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubePortfolioRefresh"),
+				),
+			},
+			{
+				Config: testAccSonarqubePortfolioRefreshConfig(rnd, "testAccSonarqubePortfolioRefresh", "second"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "triggers.description", "second"),
+				),
+			},
+		},
+	})
+}
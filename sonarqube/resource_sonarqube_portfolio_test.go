@@ -21,7 +21,7 @@ func testSweepSonarqubePortfolioSweeper(r string) error {
 	return nil
 }
 func testAccPreCheckPortfolioSupport(t *testing.T) {
-	if err := checkPortfolioSupport(testAccProvider.Meta().(*ProviderConfiguration)); err != nil {
+	if err := checkPortfolioSupport(testAccProviderConfiguration(t)); err != nil {
 		t.Skipf("Skipping test of unsupported feature (Portfolio)")
 	}
 }
@@ -93,6 +93,7 @@ func TestAccSonarqubePortfolioBasic(t *testing.T) {
 					resource.TestCheckResourceAttr(name, "qualifier", "VW"), // Qualifier for Portfolios seems to always be "VW" (views)
 					resource.TestCheckResourceAttr(name, "description", "testAccSonarqubePortfolioDescription"),
 					resource.TestCheckResourceAttr(name, "visibility", "public"),
+					resource.TestMatchResourceAttr(name, "url", regexp.MustCompile(`/portfolio\?id=testAccSonarqubePortfolioKey$`)),
 					resource.TestCheckResourceAttr(name, "setting.#", "0"),
 				),
 			},
@@ -1,6 +1,7 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,7 +9,9 @@ import (
 	"net/url"
 	"slices"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -52,9 +55,23 @@ func resourceSonarqubeProject() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeProjectImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+		// Enforce the project key conventions of the provider configuration
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateProjectKeyConvention(d, "project", meta)
+			},
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateResourceOrganization(d, meta)
+			},
+			customizeProjectTagsAll,
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
+			"organization": organizationSchema(),
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -64,7 +81,7 @@ func resourceSonarqubeProject() *schema.Resource {
 			"project": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Key of the project. Maximum length 400. All letters, digits, dash, underscore, period or colon.",
+				Description: "Key of the project. Maximum length 400. All letters, digits, dash, underscore, period or colon. Changing this renames the project in place, keeping its history.",
 			},
 			"visibility": {
 				Type:        schema.TypeString,
@@ -79,8 +96,22 @@ func resourceSonarqubeProject() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Description: "A list of tags to put on the project.",
+				Description: "A list of tags to put on the project. The `default_project_tags` of the provider are added to them.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the overview page of the project in the SonarQube UI.",
+			},
+			"tags_all": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "All the tags of the project, including the `default_project_tags` of the provider.",
 			},
+			"wait_for_first_analysis": waitForFirstAnalysisSchema(),
 			"setting": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -127,10 +158,7 @@ func projectSetTags(d *schema.ResourceData, m interface{}, sonarQubeURL url.URL)
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_tags/set"
 
 	// TODO: Create a helper file for convertListToCSV or something. This is used in Portfolio too
-	var tags []string
-	for _, v := range d.Get("tags").([]interface{}) {
-		tags = append(tags, fmt.Sprint(v))
-	}
+	tags := mergeProjectTags(expandStringList(d.Get("tags")), m.(*ProviderConfiguration).defaultProjectTags)
 	tagsCSV := strings.Trim(strings.Join(strings.Fields(fmt.Sprint(tags)), ","), "[]")
 	sonarQubeURL.RawQuery = url.Values{
 		"project": []string{d.Get("project").(string)},
@@ -153,6 +181,7 @@ func projectSetTags(d *schema.ResourceData, m interface{}, sonarQubeURL url.URL)
 }
 
 func resourceSonarqubeProjectCreate(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/create"
 
@@ -194,10 +223,17 @@ func resourceSonarqubeProjectCreate(d *schema.ResourceData, m interface{}) error
 		return fmt.Errorf("resourceSonarqubeProjectCreate: Failed to sync project settings: %+v", err)
 	}
 
+	if d.Get("wait_for_first_analysis").(bool) {
+		if err := waitForFirstAnalysis(d.Id(), d.Timeout(schema.TimeoutCreate), m); err != nil {
+			return err
+		}
+	}
+
 	return resourceSonarqubeProjectRead(d, m)
 }
 
 func resourceSonarqubeProjectRead(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/components/show"
 	sonarQubeURL.RawQuery = url.Values{
@@ -228,7 +264,8 @@ func resourceSonarqubeProjectRead(d *schema.ResourceData, m interface{}) error {
 	errName := d.Set("name", projectReadResponse.Component.Name)
 	errProject := d.Set("project", projectReadResponse.Component.Key)
 	errVisibility := d.Set("visibility", projectReadResponse.Component.Visibility)
-	if err := errors.Join(errName, errProject, errVisibility); err != nil {
+	errURL := d.Set("url", sonarQubeWebURL(m.(*ProviderConfiguration), "/dashboard", url.Values{"id": []string{projectReadResponse.Component.Key}}))
+	if err := errors.Join(errName, errProject, errVisibility, errURL); err != nil {
 		return err
 	}
 
@@ -268,20 +305,34 @@ func resourceSonarqubeProjectRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if len(projectReadResponse.Component.Tags) > 0 {
-		err = d.Set("tags", projectReadResponse.Component.Tags)
+		// The default tags of the provider are only reported in tags when they are also configured on the resource
+		tags := []string{}
+		configured := expandStringList(d.Get("tags"))
+		for _, tag := range projectReadResponse.Component.Tags {
+			if !slices.Contains(m.(*ProviderConfiguration).defaultProjectTags, tag) || slices.Contains(configured, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		err = d.Set("tags", tags)
+	}
+	if err == nil {
+		err = d.Set("tags_all", projectReadResponse.Component.Tags)
 	}
 
 	return err
 }
 
 func resourceSonarqubeProjectUpdate(d *schema.ResourceData, m interface{}) error {
-	// handle default updates (api/users/update)
-	if d.HasChange("visibility") {
+	m = withResourceOrganization(d, m)
+	// handle project key updates (api/projects/update_key) first, the other updates use the new key
+	if d.HasChange("project") {
+		oldKey, newKey := d.GetChange("project")
+
 		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/update_visibility"
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/update_key"
 		sonarQubeURL.RawQuery = url.Values{
-			"project":    []string{d.Get("project").(string)},
-			"visibility": []string{d.Get("visibility").(string)},
+			"from": []string{oldKey.(string)},
+			"to":   []string{newKey.(string)},
 		}.Encode()
 
 		resp, err := httpRequestHelper(
@@ -292,27 +343,21 @@ func resourceSonarqubeProjectUpdate(d *schema.ResourceData, m interface{}) error
 			"resourceSonarqubeProjectUpdate",
 		)
 		if err != nil {
-			return fmt.Errorf("error updating Sonarqube project: %+v", err)
+			return fmt.Errorf("error updating Sonarqube project key: %+v", err)
 		}
 		defer resp.Body.Close()
-	}
 
-	if d.HasChanges("tags") {
-		err := projectSetTags(d, m, m.(*ProviderConfiguration).sonarQubeURL)
-		if err != nil {
-			return fmt.Errorf("error updating Sonarqube selection mode: %+v", err)
-		}
+		// Update the id like in github provider (https://github.com/integrations/terraform-provider-github/blob/b7e63d63c59b9b1df9c6d05204bdaa1b349e8c8a/github/resource_github_repository.go#L746-L750)
+		d.SetId(newKey.(string))
 	}
 
-	// handle project key updates (api/projects/update_key)
-	if d.HasChange("project") {
-		oldKey, newKey := d.GetChange("project")
-
+	// handle default updates (api/users/update)
+	if d.HasChange("visibility") {
 		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/update_key"
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/update_visibility"
 		sonarQubeURL.RawQuery = url.Values{
-			"from": []string{oldKey.(string)},
-			"to":   []string{newKey.(string)},
+			"project":    []string{d.Get("project").(string)},
+			"visibility": []string{d.Get("visibility").(string)},
 		}.Encode()
 
 		resp, err := httpRequestHelper(
@@ -323,12 +368,16 @@ func resourceSonarqubeProjectUpdate(d *schema.ResourceData, m interface{}) error
 			"resourceSonarqubeProjectUpdate",
 		)
 		if err != nil {
-			return fmt.Errorf("error updating Sonarqube project key: %+v", err)
+			return fmt.Errorf("error updating Sonarqube project: %+v", err)
 		}
 		defer resp.Body.Close()
+	}
 
-		// Update the id like in github provider (https://github.com/integrations/terraform-provider-github/blob/b7e63d63c59b9b1df9c6d05204bdaa1b349e8c8a/github/resource_github_repository.go#L746-L750)
-		d.SetId(newKey.(string))
+	if d.HasChanges("tags", "tags_all") {
+		err := projectSetTags(d, m, m.(*ProviderConfiguration).sonarQubeURL)
+		if err != nil {
+			return fmt.Errorf("error updating Sonarqube selection mode: %+v", err)
+		}
 	}
 
 	if d.HasChange("setting") {
@@ -342,6 +391,7 @@ func resourceSonarqubeProjectUpdate(d *schema.ResourceData, m interface{}) error
 }
 
 func resourceSonarqubeProjectDelete(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/delete"
 	sonarQubeURL.RawQuery = url.Values{
@@ -363,6 +413,52 @@ func resourceSonarqubeProjectDelete(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
+// mergeProjectTags returns the tags of a project followed by the default tags of the provider that it does not have
+func mergeProjectTags(tags []string, defaultTags []string) []string {
+	merged := slices.Clone(tags)
+	for _, tag := range defaultTags {
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+// customizeProjectTagsAll plans tags_all from the tags of the resource and the default tags of the provider, so
+// that changing default_project_tags updates the projects
+func customizeProjectTagsAll(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	conf, ok := meta.(*ProviderConfiguration)
+	if !ok || !d.NewValueKnown("tags") {
+		return nil
+	}
+	merged := mergeProjectTags(expandStringList(d.Get("tags")), conf.defaultProjectTags)
+	current := expandStringList(d.Get("tags_all"))
+	if d.Id() != "" && len(merged) == len(current) && !slices.ContainsFunc(merged, func(tag string) bool { return !slices.Contains(current, tag) }) {
+		return nil
+	}
+	return d.SetNew("tags_all", merged)
+}
+
+// validateProjectKeyConvention checks a new project key against the project_key_prefix and enforce_key_regex of the provider
+func validateProjectKeyConvention(d *schema.ResourceDiff, attribute string, meta interface{}) error {
+	conf, ok := meta.(*ProviderConfiguration)
+	if !ok || !d.HasChange(attribute) || !d.NewValueKnown(attribute) {
+		return nil
+	}
+
+	return checkProjectKeyConvention(d.Get(attribute).(string), conf)
+}
+
+func checkProjectKeyConvention(key string, conf *ProviderConfiguration) error {
+	if conf.projectKeyPrefix != "" && !strings.HasPrefix(key, conf.projectKeyPrefix) {
+		return fmt.Errorf("the project key '%s' does not start with the prefix '%s' required by the provider configuration", key, conf.projectKeyPrefix)
+	}
+	if conf.projectKeyRegex != nil && !conf.projectKeyRegex.MatchString(key) {
+		return fmt.Errorf("the project key '%s' does not match the regular expression '%s' required by the provider configuration", key, conf.projectKeyRegex.String())
+	}
+	return nil
+}
+
 func resourceSonarqubeProjectImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	// As per the docs, use the id to make the read work as intended (https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/import)
 	err := d.Set("project", d.Id())
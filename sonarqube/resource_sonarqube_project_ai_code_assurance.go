@@ -0,0 +1,159 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetContainsAiCode for unmarshalling response body of api/projects/get_contains_ai_code
+type GetContainsAiCode struct {
+	ContainsAiCode bool `json:"containsAiCode"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeProjectAiCodeAssurance() *schema.Resource {
+	return &schema.Resource{
+		Description: "Provides a Sonarqube Project AI Code Assurance resource. This can be used to flag a project as containing AI generated code. Requires SonarQube version >= 10.7.",
+		Create:      resourceSonarqubeProjectAiCodeAssuranceCreate,
+		Read:        resourceSonarqubeProjectAiCodeAssuranceRead,
+		Update:      resourceSonarqubeProjectAiCodeAssuranceCreate,
+		Delete:      resourceSonarqubeProjectAiCodeAssuranceDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeProjectAiCodeAssuranceImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project. Changing this forces a new resource to be created.",
+			},
+			"contains_ai_code": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the project contains AI generated code. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func checkAiCodeAssuranceSupport(conf *ProviderConfiguration) error {
+	minimumVersion, _ := version.NewVersion("10.7")
+	if conf.sonarQubeVersion.LessThan(minimumVersion) {
+		return fmt.Errorf("minimum required SonarQube version for AI code assurance is %s", minimumVersion)
+	}
+	return nil
+}
+
+func resourceSonarqubeProjectAiCodeAssuranceCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkAiCodeAssuranceSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	err := setProjectContainsAiCode(d.Get("project").(string), d.Get("contains_ai_code").(bool), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectAiCodeAssuranceCreate: %+v", err)
+	}
+
+	d.SetId(d.Get("project").(string))
+
+	return resourceSonarqubeProjectAiCodeAssuranceRead(d, m)
+}
+
+func resourceSonarqubeProjectAiCodeAssuranceRead(d *schema.ResourceData, m interface{}) error {
+	if err := checkAiCodeAssuranceSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/get_contains_ai_code"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{d.Id()},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeProjectAiCodeAssuranceRead",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			log.Printf("[WARN][resourceSonarqubeProjectAiCodeAssuranceRead] Project '%s' not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("resourceSonarqubeProjectAiCodeAssuranceRead: Failed to read AI code flag of project %s: %+v", d.Id(), err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	containsAiCodeResponse := GetContainsAiCode{}
+	err = json.NewDecoder(resp.Body).Decode(&containsAiCodeResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectAiCodeAssuranceRead: Failed to decode json into struct: %+v", err)
+	}
+
+	errProject := d.Set("project", d.Id())
+	errContainsAiCode := d.Set("contains_ai_code", containsAiCodeResponse.ContainsAiCode)
+	return errors.Join(errProject, errContainsAiCode)
+}
+
+func resourceSonarqubeProjectAiCodeAssuranceDelete(d *schema.ResourceData, m interface{}) error {
+	if err := checkAiCodeAssuranceSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	err := setProjectContainsAiCode(d.Id(), false, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectAiCodeAssuranceDelete: %+v", err)
+	}
+	return nil
+}
+
+func resourceSonarqubeProjectAiCodeAssuranceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	project := d.Id()
+	if err := resourceSonarqubeProjectAiCodeAssuranceRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeProjectAiCodeAssuranceImport: project '%s' not found", project)
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func setProjectContainsAiCode(project string, containsAiCode bool, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/set_contains_ai_code"
+	sonarQubeURL.RawQuery = url.Values{
+		"project":        []string{project},
+		"containsAiCode": []string{strconv.FormatBool(containsAiCode)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"setProjectContainsAiCode",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set AI code flag of project %s: %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
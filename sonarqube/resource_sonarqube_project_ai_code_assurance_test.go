@@ -0,0 +1,75 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccPreCheckAiCodeAssuranceSupport(t *testing.T) {
+	if err := checkAiCodeAssuranceSupport(testAccProviderConfiguration(t)); err != nil {
+		t.Skipf("Skipping test of unsupported feature (AI Code Assurance)")
+	}
+}
+
+func testAccSonarqubeProjectAiCodeAssuranceConfig(rnd string, projectKey string, containsAiCode bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_project_ai_code_assurance" "%[1]s" {
+			project          = sonarqube_project.%[1]s.project
+			contains_ai_code = %[3]t
+		}`, rnd, projectKey, containsAiCode)
+}
+
+func TestAccSonarqubeProjectAiCodeAssuranceBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_project_ai_code_assurance." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckAiCodeAssuranceSupport(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectAiCodeAssuranceConfig(rnd, "testAccSonarqubeProjectAiCode", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "project", "testAccSonarqubeProjectAiCode"),
+					resource.TestCheckResourceAttr(name, "contains_ai_code", "true"),
+				),
+			},
+			{
+				Config: testAccSonarqubeProjectAiCodeAssuranceConfig(rnd, "testAccSonarqubeProjectAiCode", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "contains_ai_code", "false"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeProjectAiCodeAssuranceReadDeletedProject(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/projects/get_contains_ai_code", http.StatusNotFound, `{"errors":[{"msg":"Project 'my_project' not found"}]}`)
+	r := resourceSonarqubeProjectAiCodeAssurance()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project": "my_project", "contains_ai_code": true})
+	d.SetId("my_project")
+	if err := r.Read(d, mock.conf("Developer", "10.7")); err != nil {
+		t.Fatalf("Read() error = %+v", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("Read() should remove the resource of a deleted project, got ID '%s'", d.Id())
+	}
+}
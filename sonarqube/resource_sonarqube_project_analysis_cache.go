@@ -0,0 +1,162 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// projectAnalysisCacheSettings maps the attributes of sonarqube_project_analysis_cache to their setting keys
+var projectAnalysisCacheSettings = []settingAttribute{
+	{attribute: "enabled", key: "sonar.analysisCache.enabled"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeProjectAnalysisCache() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Project Analysis Cache resource. This manages whether the scanners of a project use the analysis
+cache, which lets an analysis skip the files that did not change since the previous one, and purges the cache with api/analysis_cache/clear
+every time ` + "`purge_triggers`" + ` is set or changes, for example after upgrading the analyzers of a large
+monorepo. Purging requires SonarQube 9.4 or later. Destroying this resource resets the setting to its default value.`,
+		Create: resourceSonarqubeProjectAnalysisCacheCreate,
+		Read:   resourceSonarqubeProjectAnalysisCacheRead,
+		Update: resourceSonarqubeProjectAnalysisCacheUpdate,
+		Delete: resourceSonarqubeProjectAnalysisCacheDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeProjectAnalysisCacheImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project. Changing this forces a new resource to be created.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the scanners use the analysis cache (`sonar.analysisCache.enabled`). Defaults to `true`.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The branch whose cache is purged. When not set, the cache of every branch of the project is purged.",
+			},
+			"purge_triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary map of values that, when changed, purges the analysis cache. For example the version of the analyzers. The cache is not purged when this is not set.",
+			},
+		},
+	}
+}
+
+func checkAnalysisCacheSupport(conf *ProviderConfiguration) error {
+	minimumVersion, _ := version.NewVersion("9.4")
+	if conf.sonarQubeVersion.LessThan(minimumVersion) {
+		return fmt.Errorf("minimum required SonarQube version for purging the analysis cache is %s", minimumVersion)
+	}
+	return nil
+}
+
+func resourceSonarqubeProjectAnalysisCacheCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	if err := setSettingAttributes(project, projectAnalysisCacheSettings, d, m, false); err != nil {
+		return err
+	}
+	if len(d.Get("purge_triggers").(map[string]interface{})) > 0 {
+		if err := clearAnalysisCache(project, d.Get("branch").(string), m); err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectAnalysisCacheCreate: %+v", err)
+		}
+	}
+
+	d.SetId(project)
+
+	return resourceSonarqubeProjectAnalysisCacheRead(d, m)
+}
+
+func resourceSonarqubeProjectAnalysisCacheRead(d *schema.ResourceData, m interface{}) error {
+	settings, err := getSettingsByKeys(d.Id(), []string{"sonar.analysisCache.enabled"}, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectAnalysisCacheRead: %+v", err)
+	}
+
+	// The scanners use the cache unless the setting disables it
+	enabled := true
+	if setting, ok := settings["sonar.analysisCache.enabled"]; ok && setting.Value != "" {
+		enabled, err = strconv.ParseBool(setting.Value)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectAnalysisCacheRead: Failed to convert setting 'sonar.analysisCache.enabled': %+v", err)
+		}
+	}
+	return d.Set("enabled", enabled)
+}
+
+func resourceSonarqubeProjectAnalysisCacheUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes(d.Id(), projectAnalysisCacheSettings, d, m, true); err != nil {
+		return err
+	}
+	if d.HasChange("purge_triggers") && len(d.Get("purge_triggers").(map[string]interface{})) > 0 {
+		if err := clearAnalysisCache(d.Id(), d.Get("branch").(string), m); err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectAnalysisCacheUpdate: %+v", err)
+		}
+	}
+
+	return resourceSonarqubeProjectAnalysisCacheRead(d, m)
+}
+
+func resourceSonarqubeProjectAnalysisCacheDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes(d.Id(), projectAnalysisCacheSettings, m)
+}
+
+func resourceSonarqubeProjectAnalysisCacheImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("project", d.Id()); err != nil {
+		return nil, err
+	}
+	if err := resourceSonarqubeProjectAnalysisCacheRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// clearAnalysisCache purges the analysis cache of a branch of the project, or of all its branches when the branch is empty
+func clearAnalysisCache(project string, branch string, m interface{}) error {
+	if err := checkAnalysisCacheSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/analysis_cache/clear"
+	rawQuery := url.Values{
+		"project": []string{project},
+	}
+	if branch != "" {
+		rawQuery.Add("branch", branch)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"clearAnalysisCache",
+	)
+	if err != nil {
+		return fmt.Errorf("clearAnalysisCache: Failed to purge the analysis cache of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package sonarqube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectAnalysisCacheConfig(rnd string, enabled bool, trigger string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[1]s"
+			project    = "%[1]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_project_analysis_cache" "%[1]s" {
+			project        = sonarqube_project.%[1]s.project
+			enabled        = %[2]t
+			purge_triggers = { analyzers = "%[3]s" }
+		}`, rnd, enabled, trigger)
+}
+
+func TestAccSonarqubeProjectAnalysisCacheBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_project_analysis_cache." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckMinimumVersion(t, "9.4")
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectAnalysisCacheConfig(rnd, true, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccSonarqubeProjectAnalysisCacheConfig(rnd, false, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "false"),
+					resource.TestCheckResourceAttr(name, "purge_triggers.analyzers", "2"),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"purge_triggers"},
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeProjectAnalysisCacheUpdatePurges(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("POST", "/api/analysis_cache/clear", http.StatusNoContent, "")
+	mock.respond("GET", "/api/settings/values", http.StatusOK, `{"settings":[]}`)
+	r := resourceSonarqubeProjectAnalysisCache()
+
+	state := &terraform.InstanceState{
+		ID: "my_project",
+		Attributes: map[string]string{
+			"id":                       "my_project",
+			"project":                  "my_project",
+			"enabled":                  "true",
+			"branch":                   "main",
+			"purge_triggers.%":         "1",
+			"purge_triggers.analyzers": "1",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project":        "my_project",
+		"branch":         "main",
+		"purge_triggers": map[string]interface{}{"analyzers": "2"},
+	})
+
+	// The analysis cache cannot be purged before 9.4
+	conf := mock.conf("Community", "9.3")
+	diff, err := r.Diff(context.Background(), state, config, conf)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error = %v", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Update(d, conf); err == nil {
+		t.Fatalf("Update() should fail before SonarQube 9.4")
+	}
+
+	if err := r.Update(d, mock.conf("Community", "10.7")); err != nil {
+		t.Fatalf("Update() error = %+v", err)
+	}
+	expected := []string{
+		"POST /api/analysis_cache/clear?branch=main&project=my_project",
+		"GET /api/settings/values?component=my_project&keys=sonar.analysisCache.enabled",
+	}
+	if got := mock.requests(); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Update() requests = %v, want %v", got, expected)
+	}
+	if !d.Get("enabled").(bool) {
+		t.Errorf("enabled should default to true when the setting is not set")
+	}
+}
@@ -0,0 +1,301 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ProjectAnalysisEventResponse for unmarshalling response body of api/project_analyses/create_event and update_event
+type ProjectAnalysisEventResponse struct {
+	Event struct {
+		Key      string `json:"key"`
+		Analysis string `json:"analysis"`
+		Category string `json:"category"`
+		Name     string `json:"name"`
+	} `json:"event"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeProjectAnalysisEvent() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Project Analysis Event resource. This can be used to stamp an analysis of a project with a version
+or another custom event, for example from a release pipeline. An analysis can only have one ` + "`VERSION`" + ` event.
+Events are removed by SonarQube together with their analysis when it is purged by the housekeeping.
+It supports importing using the format '{project}/{event_key}/[{branch}]'.`,
+		Create: resourceSonarqubeProjectAnalysisEventCreate,
+		Read:   resourceSonarqubeProjectAnalysisEventRead,
+		Update: resourceSonarqubeProjectAnalysisEventUpdate,
+		Delete: resourceSonarqubeProjectAnalysisEventDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeProjectAnalysisEventImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project. Changing this forces a new resource to be created.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The branch of the analysis. When not set, the main branch is used. Changing this forces a new resource to be created.",
+			},
+			"analysis": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The key of the analysis. When not set, the most recent analysis of the branch is used. Changing this forces a new resource to be created.",
+			},
+			"category": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "VERSION",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"VERSION", "OTHER"}, false)),
+				Description:      "The category of the event. Possible values are `VERSION` and `OTHER`. Defaults to `VERSION`. Changing this forces a new resource to be created.",
+			},
+			"name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(1, 400)),
+				Description:      "The name of the event, for example the released version. Maximum length 400.",
+			},
+			"date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date of the analysis of the event.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeProjectAnalysisEventCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	analysis := d.Get("analysis").(string)
+	if analysis == "" {
+		latest, err := readLatestProjectAnalysisFromApi(project, d.Get("branch").(string), m)
+		if err != nil {
+			return err
+		}
+		analysis = latest
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_analyses/create_event"
+	sonarQubeURL.RawQuery = url.Values{
+		"analysis": []string{analysis},
+		"category": []string{d.Get("category").(string)},
+		"name":     []string{d.Get("name").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeProjectAnalysisEventCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectAnalysisEventCreate: Failed to create the event of analysis '%s': %+v", analysis, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	eventResponse := ProjectAnalysisEventResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&eventResponse)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectAnalysisEventCreate: Failed to decode json into struct: %+v", err)
+	}
+
+	d.SetId(eventResponse.Event.Key)
+
+	return resourceSonarqubeProjectAnalysisEventRead(d, m)
+}
+
+func resourceSonarqubeProjectAnalysisEventRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	analysis, event, err := findProjectAnalysisEvent(project, d.Get("branch").(string), d.Get("category").(string), d.Id(), m)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		log.Printf("[WARN][resourceSonarqubeProjectAnalysisEventRead] Event '%s' of project '%s' not found, removing it from the state", d.Id(), project)
+		d.SetId("")
+		return nil
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("analysis", analysis.Key))
+	errs = append(errs, d.Set("date", analysis.Date))
+	errs = append(errs, d.Set("category", event.Category))
+	errs = append(errs, d.Set("name", event.Name))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeProjectAnalysisEventUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("name") {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_analyses/update_event"
+		sonarQubeURL.RawQuery = url.Values{
+			"event": []string{d.Id()},
+			"name":  []string{d.Get("name").(string)},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"resourceSonarqubeProjectAnalysisEventUpdate",
+		)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectAnalysisEventUpdate: Failed to rename event '%s': %+v", d.Id(), err)
+		}
+		defer resp.Body.Close()
+	}
+
+	return resourceSonarqubeProjectAnalysisEventRead(d, m)
+}
+
+func resourceSonarqubeProjectAnalysisEventDelete(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_analyses/delete_event"
+	sonarQubeURL.RawQuery = url.Values{
+		"event": []string{d.Id()},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resourceSonarqubeProjectAnalysisEventDelete",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			// The analysis was already purged together with its events
+			return nil
+		}
+		return fmt.Errorf("resourceSonarqubeProjectAnalysisEventDelete: Failed to delete event '%s': %+v", d.Id(), err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resourceSonarqubeProjectAnalysisEventImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts, err := parseImportID(d.Id(), "{project}/{event_key}/[{branch}]")
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeProjectAnalysisEventImport: %+v", err)
+	}
+
+	// The category is read from the event itself
+	_, event, err := findProjectAnalysisEvent(parts[0], parts[2], "", parts[1], m)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, fmt.Errorf("resourceSonarqubeProjectAnalysisEventImport: event '%s' of project '%s' not found", parts[1], parts[0])
+	}
+
+	d.SetId(parts[1])
+	errs := []error{}
+	errs = append(errs, d.Set("project", parts[0]))
+	errs = append(errs, d.Set("branch", parts[2]))
+	errs = append(errs, d.Set("category", event.Category))
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if err := resourceSonarqubeProjectAnalysisEventRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// readLatestProjectAnalysisFromApi returns the key of the most recent analysis of a branch
+func readLatestProjectAnalysisFromApi(project string, branch string, m interface{}) (string, error) {
+	analyses, err := searchProjectAnalysesPage(project, branch, "", 1, 1, m)
+	if err != nil {
+		return "", err
+	}
+	if len(analyses.Analyses) == 0 {
+		return "", fmt.Errorf("readLatestProjectAnalysisFromApi: project '%s' has no analysis yet", project)
+	}
+	return analyses.Analyses[0].Key, nil
+}
+
+// findProjectAnalysisEvent pages through the analyses of a branch to find an event and its analysis.
+// The event is nil when it does not exist anymore.
+func findProjectAnalysisEvent(project string, branch string, category string, eventKey string, m interface{}) (*ProjectAnalysis, *ProjectAnalysisEvent, error) {
+	for page := 1; ; page++ {
+		analyses, err := searchProjectAnalysesPage(project, branch, category, page, 500, m)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, analysis := range analyses.Analyses {
+			for _, event := range analysis.Events {
+				if event.Key == eventKey {
+					return &analysis, &event, nil
+				}
+			}
+		}
+
+		if analyses.Paging.PageSize == 0 || analyses.Paging.PageIndex*analyses.Paging.PageSize >= analyses.Paging.Total {
+			return nil, nil, nil
+		}
+	}
+}
+
+func searchProjectAnalysesPage(project string, branch string, category string, page int, pageSize int, m interface{}) (*GetProjectAnalyses, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_analyses/search"
+	rawQuery := url.Values{
+		"project": []string{project},
+		"p":       []string{strconv.Itoa(page)},
+		"ps":      []string{strconv.Itoa(pageSize)},
+	}
+	if branch != "" {
+		rawQuery.Add("branch", branch)
+	}
+	if category != "" {
+		rawQuery.Add("category", category)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"searchProjectAnalysesPage",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("searchProjectAnalysesPage: Failed to search the analyses of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	analyses := GetProjectAnalyses{}
+	err = json.NewDecoder(resp.Body).Decode(&analyses)
+	if err != nil {
+		return nil, fmt.Errorf("searchProjectAnalysesPage: Failed to decode json into struct: %+v", err)
+	}
+
+	return &analyses, nil
+}
@@ -0,0 +1,38 @@
+package sonarqube
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectAnalysisEventConfig(rnd string, projectKey string, name string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name    = "%[2]s"
+			project = "%[2]s"
+		}
+
+		resource "sonarqube_project_analysis_event" "%[1]s" {
+			project = sonarqube_project.%[1]s.project
+			name    = "%[3]s"
+		}`, rnd, projectKey, name)
+}
+
+// The test instance has no scanner, so only the error for a project that was never analyzed is checked
+func TestAccSonarqubeProjectAnalysisEventWithoutAnalysis(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeProjectAnalysisEventConfig(rnd, "testAccSonarqubeProjectAnalysisEvent", "1.0.0"),
+				ExpectError: regexp.MustCompile("has no analysis yet"),
+			},
+		},
+	})
+}
@@ -0,0 +1,150 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GetProjectBadgeToken for unmarshalling response body of api/project_badges/token
+type GetProjectBadgeToken struct {
+	Token string `json:"token"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeProjectBadge() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Project Badge resource. This can be used to read and rotate the token that gives access to the badges of a project,
+which is needed to display the badges of private projects. Destroying this resource only removes it from the state.`,
+		Create: resourceSonarqubeProjectBadgeCreate,
+		Read:   resourceSonarqubeProjectBadgeRead,
+		Update: resourceSonarqubeProjectBadgeUpdate,
+		Delete: resourceSonarqubeProjectBadgeDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeProjectBadgeImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project. Changing this forces a new resource to be created.",
+			},
+			"rotation_triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary map of values that, when changed, renews the badge token. For example the `id` of a `time_rotating` resource.",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token to add as `token` query parameter to the badge URLs of the project.",
+			},
+			"quality_gate_badge_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The URL of the quality gate badge of the project, including the token.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeProjectBadgeCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(d.Get("project").(string))
+
+	return resourceSonarqubeProjectBadgeRead(d, m)
+}
+
+func resourceSonarqubeProjectBadgeRead(d *schema.ResourceData, m interface{}) error {
+	token, err := readProjectBadgeToken(d.Id(), m)
+	if err != nil {
+		return err
+	}
+
+	badgeURL := sonarQubeWebURL(m.(*ProviderConfiguration), "/api/project_badges/quality_gate", url.Values{
+		"project": []string{d.Id()},
+		"token":   []string{token},
+	})
+
+	errs := []error{}
+	errs = append(errs, d.Set("project", d.Id()))
+	errs = append(errs, d.Set("token", token))
+	errs = append(errs, d.Set("quality_gate_badge_url", badgeURL))
+	return errors.Join(errs...)
+}
+
+// readProjectBadgeToken returns the token that gives access to the badges of a project
+func readProjectBadgeToken(project string, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_badges/token"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readProjectBadgeToken",
+	)
+	if err != nil {
+		return "", fmt.Errorf("readProjectBadgeToken: Failed to read the badge token of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	token := GetProjectBadgeToken{}
+	err = json.NewDecoder(resp.Body).Decode(&token)
+	if err != nil {
+		return "", fmt.Errorf("readProjectBadgeToken: Failed to decode json into struct: %+v", err)
+	}
+	return token.Token, nil
+}
+
+func resourceSonarqubeProjectBadgeUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("rotation_triggers") {
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_badges/renew_token"
+		sonarQubeURL.RawQuery = url.Values{
+			"project": []string{d.Id()},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarQubeURL.String(),
+			http.StatusNoContent,
+			"resourceSonarqubeProjectBadgeUpdate",
+		)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectBadgeUpdate: Failed to renew the badge token of project '%s': %+v", d.Id(), err)
+		}
+		defer resp.Body.Close()
+	}
+
+	return resourceSonarqubeProjectBadgeRead(d, m)
+}
+
+func resourceSonarqubeProjectBadgeDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+func resourceSonarqubeProjectBadgeImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeProjectBadgeRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,64 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccSonarqubeProjectBadgeConfig(rnd string, projectKey string, rotation string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "private"
+		}
+
+		resource "sonarqube_project_badge" "%[1]s" {
+			project = sonarqube_project.%[1]s.project
+			rotation_triggers = {
+				rotation = "%[3]s"
+			}
+		}`, rnd, projectKey, rotation)
+}
+
+func TestAccSonarqubeProjectBadgeBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_project_badge." + rnd
+	var firstToken string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectBadgeConfig(rnd, "testAccSonarqubeProjectBadge", "first"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(name, "token"),
+					resource.TestCheckResourceAttrSet(name, "quality_gate_badge_url"),
+					func(s *terraform.State) error {
+						firstToken = s.RootModule().Resources[name].Primary.Attributes["token"]
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccSonarqubeProjectBadgeConfig(rnd, "testAccSonarqubeProjectBadge", "second"),
+				Check: func(s *terraform.State) error {
+					if token := s.RootModule().Resources[name].Primary.Attributes["token"]; token == firstToken {
+						return fmt.Errorf("expected the badge token to be renewed")
+					}
+					return nil
+				},
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"rotation_triggers"},
+			},
+		},
+	})
+}
@@ -0,0 +1,365 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeProjectFromTemplate() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Project From Template resource. This onboards a project in one step: it creates the project,
+applies a permission template, and associates a quality gate, quality profiles and a new code definition, instead of combining
+` + "`sonarqube_project`" + ` with one resource per setting. When one of the steps fails during the creation, the project is deleted again,
+so a failed apply does not leave a half configured project behind. The quality gate, profiles and new code definition can be changed
+afterwards; changing the permission template applies the new template again. It supports importing using the project key.`,
+		Create: resourceSonarqubeProjectFromTemplateCreate,
+		Read:   resourceSonarqubeProjectFromTemplateRead,
+		Update: resourceSonarqubeProjectFromTemplateUpdate,
+		Delete: resourceSonarqubeProjectFromTemplateDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeProjectFromTemplateImport,
+		},
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateProjectKeyConvention(d, "project", meta)
+			},
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if !d.NewValueKnown("new_code_period_value") {
+					return nil
+				}
+				return validateProjectNewCodePeriod(d.Get("new_code_period_type").(string), d.Get("new_code_period_value").(string))
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Key of the project. Maximum length 400. All letters, digits, dash, underscore, period or colon. Changing this forces a new resource to be created.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The name of the project. Defaults to the key of the project. Changing this forces a new resource to be created.",
+			},
+			"visibility": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "private",
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"public", "private"}, false)),
+				Description:      "Whether the project is visible to everyone, or only specific user/groups. Valid values are `public` and `private`. Defaults to `private`. Changing this forces a new resource to be created.",
+			},
+			"permission_template": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the permission template applied to the project. Changing this applies the new template, which adds its permissions to the project.",
+			},
+			"quality_gate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the quality gate to associate with the project. When not set, the default quality gate is used.",
+			},
+			"quality_profiles": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The names of the quality profiles, keyed by language, for example `{ java = \"My Java\" }`. The other languages use their default profile.",
+			},
+			"new_code_period_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{string(PreviousVersion), string(NumberOfDays), string(ReferenceBranch)}, false)),
+				Description:      "The new code definition of the project. Supported values are `PREVIOUS_VERSION`, `NUMBER_OF_DAYS` and `REFERENCE_BRANCH`. When not set, the project inherits the instance definition.",
+			},
+			"new_code_period_value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The number of days for `NUMBER_OF_DAYS`, or the name of the branch for `REFERENCE_BRANCH`. Must be unset for `PREVIOUS_VERSION`.",
+			},
+		},
+	}
+}
+
+// validateProjectNewCodePeriod checks that the value is set only for the new code definitions that take one
+func validateProjectNewCodePeriod(periodType string, value string) error {
+	switch NewCodePeriodType(periodType) {
+	case NumberOfDays, ReferenceBranch:
+		if value == "" {
+			return fmt.Errorf("'new_code_period_value' must be set when the 'new_code_period_type' is %s", periodType)
+		}
+	default:
+		if value != "" {
+			return fmt.Errorf("'new_code_period_value' must be unset when the 'new_code_period_type' is '%s'", periodType)
+		}
+	}
+	return nil
+}
+
+func resourceSonarqubeProjectFromTemplateCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	name := d.Get("name").(string)
+	if name == "" {
+		name = project
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/create"
+	sonarQubeURL.RawQuery = url.Values{
+		"name":       []string{name},
+		"project":    []string{project},
+		"visibility": []string{d.Get("visibility").(string)},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeProjectFromTemplateCreate",
+	)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectFromTemplateCreate: Failed to create project '%s': %+v", project, err)
+	}
+	resp.Body.Close()
+	d.SetId(project)
+
+	if err := configureProjectFromTemplate(d, m); err != nil {
+		// Roll back, so the next apply starts again from a clean project
+		if errDelete := resourceSonarqubeProjectDelete(d, m); errDelete != nil {
+			return fmt.Errorf("resourceSonarqubeProjectFromTemplateCreate: %+v", errors.Join(err, fmt.Errorf("failed to delete project '%s' after the error: %+v", project, errDelete)))
+		}
+		d.SetId("")
+		return fmt.Errorf("resourceSonarqubeProjectFromTemplateCreate: project '%s' was deleted after the error: %+v", project, err)
+	}
+
+	return resourceSonarqubeProjectFromTemplateRead(d, m)
+}
+
+// configureProjectFromTemplate applies the permission template, the quality gate, the quality profiles and the new code
+// definition of a project that was just created
+func configureProjectFromTemplate(d *schema.ResourceData, m interface{}) error {
+	project := d.Id()
+	if err := applyPermissionTemplate(project, d.Get("permission_template").(string), m); err != nil {
+		return err
+	}
+	if gate := d.Get("quality_gate").(string); gate != "" {
+		if err := selectProjectQualityGate(project, gate, m); err != nil {
+			return err
+		}
+	}
+	if err := applyProjectQualityProfiles(d, "quality_profiles", m); err != nil {
+		return err
+	}
+	if periodType := d.Get("new_code_period_type").(string); periodType != "" {
+		if err := setNewCodePeriod(project, "", periodType, d.Get("new_code_period_value").(string), m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceSonarqubeProjectFromTemplateRead(d *schema.ResourceData, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/components/show"
+	sonarQubeURL.RawQuery = url.Values{
+		"component": []string{d.Id()},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeProjectFromTemplateRead",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			log.Printf("[WARN][resourceSonarqubeProjectFromTemplateRead] Project '%s' not found, removing it from the state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	project := GetProject{}
+	err = json.NewDecoder(resp.Body).Decode(&project)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectFromTemplateRead: Failed to decode json into struct: %+v", err)
+	}
+
+	gate, err := readProjectQualityGate(d.Id(), m)
+	if err != nil {
+		return err
+	}
+	profiles, err := readProjectQualityProfilesFromApi(d.Id(), m)
+	if err != nil {
+		return err
+	}
+	period, err := readNewCodePeriod(d.Id(), "", m)
+	if err != nil {
+		return err
+	}
+
+	// Only the listed languages are managed, the profiles of the other languages are ignored
+	currentProfiles := map[string]interface{}{}
+	for language := range d.Get("quality_profiles").(map[string]interface{}) {
+		for _, profile := range profiles {
+			if profile.Language == language {
+				currentProfiles[language] = profile.Name
+			}
+		}
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("project", project.Component.Key))
+	errs = append(errs, d.Set("name", project.Component.Name))
+	errs = append(errs, d.Set("visibility", project.Component.Visibility))
+	errs = append(errs, d.Set("quality_profiles", currentProfiles))
+	// Keep the quality gate empty when the project uses the default quality gate
+	if _, ok := d.GetOk("quality_gate"); ok {
+		errs = append(errs, d.Set("quality_gate", gate))
+	}
+	if period.Inherited {
+		errs = append(errs, d.Set("new_code_period_type", ""))
+		errs = append(errs, d.Set("new_code_period_value", ""))
+	} else {
+		errs = append(errs, d.Set("new_code_period_type", period.Type))
+		errs = append(errs, d.Set("new_code_period_value", period.Value))
+	}
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeProjectFromTemplateUpdate(d *schema.ResourceData, m interface{}) error {
+	project := d.Id()
+	if d.HasChange("permission_template") {
+		if err := applyPermissionTemplate(project, d.Get("permission_template").(string), m); err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectFromTemplateUpdate: %+v", err)
+		}
+	}
+	if d.HasChange("quality_gate") {
+		var err error
+		if gate := d.Get("quality_gate").(string); gate != "" {
+			err = selectProjectQualityGate(project, gate, m)
+		} else {
+			err = deselectProjectQualityGate(project, m)
+		}
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectFromTemplateUpdate: %+v", err)
+		}
+	}
+	if d.HasChange("quality_profiles") {
+		if err := applyProjectQualityProfiles(d, "quality_profiles", m); err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectFromTemplateUpdate: %+v", err)
+		}
+	}
+	if d.HasChanges("new_code_period_type", "new_code_period_value") {
+		var err error
+		if periodType := d.Get("new_code_period_type").(string); periodType != "" {
+			err = setNewCodePeriod(project, "", periodType, d.Get("new_code_period_value").(string), m)
+		} else {
+			err = unsetNewCodePeriod(project, "", m)
+		}
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectFromTemplateUpdate: %+v", err)
+		}
+	}
+
+	return resourceSonarqubeProjectFromTemplateRead(d, m)
+}
+
+func resourceSonarqubeProjectFromTemplateDelete(d *schema.ResourceData, m interface{}) error {
+	return resourceSonarqubeProjectDelete(d, m)
+}
+
+func resourceSonarqubeProjectFromTemplateImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	profiles, err := readProjectQualityProfilesFromApi(d.Id(), m)
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeProjectFromTemplateImport: %+v", err)
+	}
+	if profiles == nil {
+		return nil, fmt.Errorf("resourceSonarqubeProjectFromTemplateImport: project '%s' not found", d.Id())
+	}
+
+	associated := map[string]interface{}{}
+	for _, profile := range profiles {
+		if !profile.IsDefault {
+			associated[profile.Language] = profile.Name
+		}
+	}
+
+	// The permission template is not recorded on the project, it is applied again by the next apply
+	if err := d.Set("quality_profiles", associated); err != nil {
+		return nil, err
+	}
+	if err := resourceSonarqubeProjectFromTemplateRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyPermissionTemplate applies a permission template to a project, which adds the permissions of the template
+func applyPermissionTemplate(project string, template string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/permissions/apply_template"
+	sonarQubeURL.RawQuery = url.Values{
+		"projectKey":   []string{project},
+		"templateName": []string{template},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"applyPermissionTemplate",
+	)
+	if err != nil {
+		return fmt.Errorf("applyPermissionTemplate: Failed to apply permission template '%s' to project '%s': %+v", template, project, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// deselectProjectQualityGate makes the project use the default quality gate again
+func deselectProjectQualityGate(project string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/deselect"
+	sonarQubeURL.RawQuery = url.Values{
+		"projectKey": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"deselectProjectQualityGate",
+	)
+	if err != nil {
+		return fmt.Errorf("deselectProjectQualityGate: Failed to reset the quality gate of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectFromTemplateConfig(rnd string, periodType string, periodValue string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_permission_template" "%[1]s" {
+			name = "%[1]s"
+		}
+
+		resource "sonarqube_qualitygate" "%[1]s" {
+			name = "%[1]s"
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[1]s"
+			language = "java"
+		}
+
+		resource "sonarqube_project_from_template" "%[1]s" {
+			project               = "%[1]s"
+			permission_template   = sonarqube_permission_template.%[1]s.name
+			quality_gate          = sonarqube_qualitygate.%[1]s.name
+			quality_profiles      = { java = sonarqube_qualityprofile.%[1]s.name }
+			new_code_period_type  = "%[2]s"
+			new_code_period_value = "%[3]s"
+		}`, rnd, periodType, periodValue)
+}
+
+func TestAccSonarqubeProjectFromTemplateBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_project_from_template." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectFromTemplateConfig(rnd, "NUMBER_OF_DAYS", "30"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "project", rnd),
+					resource.TestCheckResourceAttr(name, "visibility", "private"),
+					resource.TestCheckResourceAttr(name, "quality_gate", rnd),
+					resource.TestCheckResourceAttr(name, "quality_profiles.java", rnd),
+					resource.TestCheckResourceAttr(name, "new_code_period_type", "NUMBER_OF_DAYS"),
+					resource.TestCheckResourceAttr(name, "new_code_period_value", "30"),
+				),
+			},
+			{
+				Config: testAccSonarqubeProjectFromTemplateConfig(rnd, "PREVIOUS_VERSION", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "new_code_period_type", "PREVIOUS_VERSION"),
+					resource.TestCheckResourceAttr(name, "new_code_period_value", ""),
+				),
+			},
+			{
+				ResourceName:            name,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"permission_template", "quality_gate"},
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeProjectFromTemplateRollback(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("POST", "/api/projects/create", http.StatusOK, `{"project":{"key":"my_project"}}`)
+	mock.handle("POST", "/api/permissions/apply_template", func(w http.ResponseWriter, r *http.Request) {
+		mockError(w, http.StatusNotFound, "Permission template with name 'missing' is not found")
+	})
+	mock.respond("POST", "/api/projects/delete", http.StatusNoContent, "")
+
+	r := resourceSonarqubeProjectFromTemplate()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project":             "my_project",
+		"permission_template": "missing",
+		"quality_gate":        "My Gate",
+	})
+	err := r.Create(d, mock.conf("Community", "10.7"))
+	if err == nil || !strings.Contains(err.Error(), "was deleted after the error") {
+		t.Fatalf("Create() error = %v, want the rollback error", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("Create() id = %q, want the project removed from the state", d.Id())
+	}
+
+	expected := []string{
+		"POST /api/projects/create?name=my_project&project=my_project&visibility=private",
+		"POST /api/permissions/apply_template?projectKey=my_project&templateName=missing",
+		"POST /api/projects/delete?project=my_project",
+	}
+	if fmt.Sprint(mock.requests()) != fmt.Sprint(expected) {
+		t.Errorf("requests = %v, want %v", mock.requests(), expected)
+	}
+}
+
+func TestValidateProjectNewCodePeriod(t *testing.T) {
+	tests := []struct {
+		periodType string
+		value      string
+		valid      bool
+	}{
+		{"", "", true},
+		{"PREVIOUS_VERSION", "", true},
+		{"PREVIOUS_VERSION", "30", false},
+		{"NUMBER_OF_DAYS", "30", true},
+		{"NUMBER_OF_DAYS", "", false},
+		{"REFERENCE_BRANCH", "main", true},
+		{"REFERENCE_BRANCH", "", false},
+	}
+
+	for _, test := range tests {
+		err := validateProjectNewCodePeriod(test.periodType, test.value)
+		if (err == nil) != test.valid {
+			t.Errorf("validateProjectNewCodePeriod(%q, %q) error = %v, want valid %t", test.periodType, test.value, err, test.valid)
+		}
+	}
+}
@@ -30,7 +30,7 @@ type Branches struct {
 // Returns the resource represented by this file.
 func resourceSonarqubeProjectMainBranch() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a Sonarqube Project main branch resource. This can be used to create and manage a Sonarqube Projects main branch.",
+		Description: "Provides a Sonarqube Project main branch resource. This can be used to create and manage a Sonarqube Projects main branch. It supports importing using the format '{project}/{name}'.",
 		Create:      resourceSonarqubeProjectMainBranchCreate,
 		Read:        resourceSonarqubeProjectMainBranchRead,
 		Delete:      resourceSonarqubeProjectMainBranchDelete,
@@ -83,11 +83,14 @@ func resourceSonarqubeProjectMainBranchCreate(d *schema.ResourceData, m interfac
 }
 
 func resourceSonarqubeProjectMainBranchRead(d *schema.ResourceData, m interface{}) error {
-	idSlice := strings.SplitN(d.Id(), "/", 2)
+	parts, err := parseImportID(d.Id(), "{project}/{name}")
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectMainBranchRead: %+v", err)
+	}
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_branches/list"
 	sonarQubeURL.RawQuery = url.Values{
-		"project": []string{idSlice[0]},
+		"project": []string{parts[0]},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -110,8 +113,8 @@ func resourceSonarqubeProjectMainBranchRead(d *schema.ResourceData, m interface{
 	}
 	// Loop over all branches to see if the main branch we need exists.
 	for _, value := range branchReadResponse.Branches {
-		if idSlice[1] == value.Name && value.IsMain {
-			errProject := d.Set("project", idSlice[0])
+		if parts[1] == value.Name && value.IsMain {
+			errProject := d.Set("project", parts[0])
 			errName := d.Set("name", value.Name)
 			return errors.Join(errProject, errName)
 		}
@@ -144,6 +147,9 @@ func resourceSonarqubeProjectMainBranchDelete(d *schema.ResourceData, m interfac
 }
 
 func resourceSonarqubeProjectMainBranchImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseImportID(d.Id(), "{project}/{name}"); err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeProjectMainBranchImport: %+v", err)
+	}
 	if err := resourceSonarqubeProjectMainBranchRead(d, m); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,284 @@
+package sonarqube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeProjectMonorepoGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Project Monorepo Group resource. This binds several SonarQube projects to the same GitHub or GitLab
+repository as a monorepo, instead of one ` + "`sonarqube_github_binding`" + ` or ` + "`sonarqube_gitlab_binding`" + ` resource per project.
+Monorepo bindings require the Enterprise edition or above. When some projects fail, the projects that were bound are kept in the state
+and the others are retried on the next apply. It supports importing using the format '{alm_setting}/{repository}'.`,
+		Create: resourceSonarqubeProjectMonorepoGroupCreate,
+		Read:   resourceSonarqubeProjectMonorepoGroupRead,
+		Update: resourceSonarqubeProjectMonorepoGroupUpdate,
+		Delete: resourceSonarqubeProjectMonorepoGroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeProjectMonorepoGroupImport,
+		},
+		// Fail at plan time on editions without monorepo support
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				conf, ok := meta.(*ProviderConfiguration)
+				if !ok || conf == nil {
+					return nil
+				}
+				return checkMonorepoSupport(conf)
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"alm": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"github", "gitlab"}, false)),
+				Description:      "The DevOps platform of the repository. Possible values are `github` and `gitlab`. Changing this forces a new resource to be created.",
+			},
+			"alm_setting": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The key of the ALM setting of the DevOps platform.",
+			},
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The repository: the full name of the GitHub repository, including the organization, or the GitLab project ID.",
+			},
+			"project_keys": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The keys of the projects analyzed from the repository.",
+			},
+			"summary_comment_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether to add a summary in the pull request discussion tab. Only used for GitHub. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func checkMonorepoSupport(conf *ProviderConfiguration) error {
+	edition := strings.ToLower(conf.sonarQubeEdition)
+	if edition != "enterprise" && edition != "data center" {
+		return fmt.Errorf("monorepo bindings are only supported in the Enterprise and Datacenter editions of SonarQube. You are using: SonarQube %s version %s", conf.sonarQubeEdition, conf.sonarQubeVersion)
+	}
+	return nil
+}
+
+func resourceSonarqubeProjectMonorepoGroupCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkMonorepoSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("alm_setting").(string), d.Get("repository").(string)))
+
+	if err := applyMonorepoBindings(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectMonorepoGroupCreate: %+v", err)
+	}
+
+	return resourceSonarqubeProjectMonorepoGroupRead(d, m)
+}
+
+func resourceSonarqubeProjectMonorepoGroupRead(d *schema.ResourceData, m interface{}) error {
+	almSetting := d.Get("alm_setting").(string)
+	repository := d.Get("repository").(string)
+
+	// Projects that are no longer bound to the repository are removed from the state, so they are bound again
+	bound := []interface{}{}
+	for _, projectKey := range expandStringList(d.Get("project_keys")) {
+		binding, err := findProjectBindingFromApi(projectKey, m)
+		if err != nil {
+			return fmt.Errorf("resourceSonarqubeProjectMonorepoGroupRead: %+v", err)
+		}
+		if binding == nil || binding.Key != almSetting || binding.Repository != repository || !binding.Monorepo {
+			log.Printf("[WARN][resourceSonarqubeProjectMonorepoGroupRead] Project '%s' is not bound to repository '%s' as a monorepo", projectKey, repository)
+			continue
+		}
+		bound = append(bound, projectKey)
+	}
+	if len(bound) == 0 {
+		log.Printf("[WARN][resourceSonarqubeProjectMonorepoGroupRead] No project is bound to repository '%s', removing it from the state", repository)
+		d.SetId("")
+		return nil
+	}
+
+	return d.Set("project_keys", bound)
+}
+
+func resourceSonarqubeProjectMonorepoGroupUpdate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(fmt.Sprintf("%s/%s", d.Get("alm_setting").(string), d.Get("repository").(string)))
+
+	if err := applyMonorepoBindings(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectMonorepoGroupUpdate: %+v", err)
+	}
+
+	return resourceSonarqubeProjectMonorepoGroupRead(d, m)
+}
+
+func resourceSonarqubeProjectMonorepoGroupDelete(d *schema.ResourceData, m interface{}) error {
+	errs := []error{}
+	remaining := []interface{}{}
+	for _, projectKey := range expandStringList(d.Get("project_keys")) {
+		if err := deleteProjectBinding(projectKey, m); err != nil {
+			errs = append(errs, err)
+			remaining = append(remaining, projectKey)
+		}
+	}
+	if len(errs) > 0 {
+		// Keep the projects that are still bound, so the delete can be retried
+		errs = append(errs, d.Set("project_keys", remaining))
+		return fmt.Errorf("resourceSonarqubeProjectMonorepoGroupDelete: %+v", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func resourceSonarqubeProjectMonorepoGroupImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts, err := parseImportID(d.Id(), "{alm_setting}/{repository}")
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeProjectMonorepoGroupImport: %+v", err)
+	}
+
+	bindings, err := listAlmSettingBindings(parts[0], m)
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeProjectMonorepoGroupImport: %+v", err)
+	}
+	projectKeys := []string{}
+	alm := ""
+	summaryCommentEnabled := true
+	for _, b := range bindings {
+		if b.binding.Repository == parts[1] && b.binding.Monorepo {
+			projectKeys = append(projectKeys, b.project.Key)
+			alm = b.binding.Alm
+			summaryCommentEnabled = b.binding.SummaryCommentEnabled
+		}
+	}
+	if len(projectKeys) == 0 {
+		return nil, fmt.Errorf("resourceSonarqubeProjectMonorepoGroupImport: no project is bound to repository '%s' of ALM setting '%s' as a monorepo", parts[1], parts[0])
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("alm", alm))
+	errs = append(errs, d.Set("alm_setting", parts[0]))
+	errs = append(errs, d.Set("repository", parts[1]))
+	errs = append(errs, d.Set("project_keys", projectKeys))
+	errs = append(errs, d.Set("summary_comment_enabled", summaryCommentEnabled))
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyMonorepoBindings binds every project to the repository, and deletes the binding of the removed projects. The
+// projects that were bound, and the removed projects whose binding could not be deleted, are written to the state
+// even when other projects fail.
+func applyMonorepoBindings(d *schema.ResourceData, m interface{}) error {
+	oldProjects, newProjects := d.GetChange("project_keys")
+	oldProjectsSet, newProjectsSet := oldProjects.(*schema.Set), newProjects.(*schema.Set)
+	// Bindings are only sent again when they change
+	rebind := d.HasChanges("alm_setting", "repository", "summary_comment_enabled")
+
+	tracked := []interface{}{}
+	errs := []error{}
+
+	for _, projectKey := range expandStringList(oldProjectsSet.Difference(newProjectsSet)) {
+		if err := deleteProjectBinding(projectKey, m); err != nil {
+			errs = append(errs, err)
+			tracked = append(tracked, projectKey)
+		}
+	}
+
+	for _, projectKey := range expandStringList(newProjectsSet) {
+		if oldProjectsSet.Contains(projectKey) && !rebind {
+			tracked = append(tracked, projectKey)
+			continue
+		}
+		if err := setMonorepoBinding(d, projectKey, m); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		tracked = append(tracked, projectKey)
+	}
+
+	if len(errs) > 0 {
+		errs = append(errs, d.Set("project_keys", tracked))
+	}
+	return errors.Join(errs...)
+}
+
+// setMonorepoBinding binds the project to the repository of the resource as a monorepo
+func setMonorepoBinding(d *schema.ResourceData, projectKey string, m interface{}) error {
+	alm := d.Get("alm").(string)
+	rawQuery := url.Values{
+		"almSetting": []string{d.Get("alm_setting").(string)},
+		"monorepo":   []string{"true"},
+		"project":    []string{projectKey},
+		"repository": []string{d.Get("repository").(string)},
+	}
+	if alm == "github" {
+		rawQuery.Add("summaryCommentEnabled", strconv.FormatBool(d.Get("summary_comment_enabled").(bool)))
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_settings/set_" + alm + "_binding"
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"setMonorepoBinding",
+	)
+	if err != nil {
+		return fmt.Errorf("setMonorepoBinding: Failed to bind project '%s': %+v", projectKey, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// deleteProjectBinding deletes the DevOps Platform binding of the project
+func deleteProjectBinding(projectKey string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/alm_settings/delete_binding"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{projectKey},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"deleteProjectBinding",
+	)
+	if err != nil {
+		return fmt.Errorf("deleteProjectBinding: Failed to delete the binding of project '%s': %+v", projectKey, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
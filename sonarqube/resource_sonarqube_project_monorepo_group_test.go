@@ -0,0 +1,70 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccPreCheckMonorepoSupport(t *testing.T) {
+	if err := checkMonorepoSupport(testAccProviderConfiguration(t)); err != nil {
+		t.Skipf("Skipping test of unsupported feature (Monorepo bindings)")
+	}
+}
+
+func testAccSonarqubeProjectMonorepoGroupConfig(rnd string, projects []string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_alm_gitlab" "%[1]s" {
+			personal_access_token = "123456"
+			key                   = "%[1]s"
+			url                   = "https://gitlab.com/api/v4"
+		}
+
+		resource "sonarqube_project" "%[1]s" {
+			for_each   = toset(["%[1]s-backend", "%[1]s-frontend", "%[1]s-docs"])
+			name       = each.value
+			project    = each.value
+			visibility = "public"
+		}
+
+		resource "sonarqube_project_monorepo_group" "%[1]s" {
+			alm          = "gitlab"
+			alm_setting  = sonarqube_alm_gitlab.%[1]s.key
+			repository   = "123"
+			project_keys = %[2]s
+
+			depends_on = [sonarqube_project.%[1]s]
+		}`, rnd, generateHCLList(projects))
+}
+
+func TestAccSonarqubeProjectMonorepoGroup(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_project_monorepo_group." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckMonorepoSupport(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectMonorepoGroupConfig(rnd, []string{rnd + "-backend", rnd + "-frontend"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", rnd+"/123"),
+					resource.TestCheckResourceAttr(name, "project_keys.#", "2"),
+				),
+			},
+			{
+				Config: testAccSonarqubeProjectMonorepoGroupConfig(rnd, []string{rnd + "-backend", rnd + "-docs"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "project_keys.#", "2"),
+					resource.TestCheckTypeSetElemAttr(name, "project_keys.*", rnd+"-docs"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,311 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeProjectNewCodeBaseline() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Project New Code Baseline resource. This sets the new code definitions that make the first analysis
+of every new branch of a project compare with a reference branch, while the main branch keeps its own baseline, instead of configuring
+each branch in the UI. The project new code definition is set to ` + "`REFERENCE_BRANCH`" + ` and the main branch gets its own definition.
+Destroying this resource unsets both, so the project and its main branch inherit the instance definition again.
+It supports importing using the project key.`,
+		Create: resourceSonarqubeProjectNewCodeBaselineCreate,
+		Read:   resourceSonarqubeProjectNewCodeBaselineRead,
+		Update: resourceSonarqubeProjectNewCodeBaselineUpdate,
+		Delete: resourceSonarqubeProjectNewCodeBaselineDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeProjectNewCodeBaselineImport,
+		},
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				if !d.NewValueKnown("main_branch_value") {
+					return nil
+				}
+				return validateMainBranchNewCodePeriod(d.Get("main_branch_type").(string), d.Get("main_branch_value").(string))
+			},
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project. Changing this forces a new resource to be created.",
+			},
+			"reference_branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The branch the new branches compare with, from their first analysis. Defaults to the main branch of the project.",
+			},
+			"main_branch_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          string(PreviousVersion),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{string(PreviousVersion), string(NumberOfDays)}, false)),
+				Description:      "The new code definition of the main branch. Supported values are `PREVIOUS_VERSION` and `NUMBER_OF_DAYS`. Defaults to `PREVIOUS_VERSION`.",
+			},
+			"main_branch_value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The number of days of the new code definition of the main branch. Must be set when `main_branch_type` is `NUMBER_OF_DAYS` and unset otherwise.",
+			},
+			"main_branch": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the main branch of the project.",
+			},
+		},
+	}
+}
+
+func validateMainBranchNewCodePeriod(periodType string, value string) error {
+	if NewCodePeriodType(periodType) == PreviousVersion && value != "" {
+		return fmt.Errorf("'main_branch_value' must be unset when the 'main_branch_type' is %s", periodType)
+	}
+	if NewCodePeriodType(periodType) == NumberOfDays && !regexNumberOfDays.MatchString(value) {
+		return fmt.Errorf("'main_branch_value' must be a numeric string when the 'main_branch_type' is %s", periodType)
+	}
+	return nil
+}
+
+func resourceSonarqubeProjectNewCodeBaselineCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	d.SetId(project)
+
+	if err := applyProjectNewCodeBaseline(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectNewCodeBaselineCreate: %+v", err)
+	}
+
+	return resourceSonarqubeProjectNewCodeBaselineRead(d, m)
+}
+
+func resourceSonarqubeProjectNewCodeBaselineRead(d *schema.ResourceData, m interface{}) error {
+	project := d.Id()
+	mainBranch, err := findProjectMainBranch(project, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectNewCodeBaselineRead: %+v", err)
+	}
+
+	projectPeriod, err := readNewCodePeriod(project, "", m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectNewCodeBaselineRead: %+v", err)
+	}
+	if projectPeriod.Inherited || NewCodePeriodType(projectPeriod.Type) != ReferenceBranch {
+		log.Printf("[WARN][resourceSonarqubeProjectNewCodeBaselineRead] The new code definition of project '%s' is no longer a reference branch, removing it from the state", project)
+		d.SetId("")
+		return nil
+	}
+	mainPeriod, err := readNewCodePeriod(project, mainBranch, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectNewCodeBaselineRead: %+v", err)
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("project", project))
+	errs = append(errs, d.Set("main_branch", mainBranch))
+	errs = append(errs, d.Set("reference_branch", projectPeriod.Value))
+	// The main branch inherits the reference branch when its own definition was unset
+	if mainPeriod.Inherited {
+		errs = append(errs, d.Set("main_branch_type", ""))
+		errs = append(errs, d.Set("main_branch_value", ""))
+	} else {
+		errs = append(errs, d.Set("main_branch_type", mainPeriod.Type))
+		errs = append(errs, d.Set("main_branch_value", mainPeriod.Value))
+	}
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeProjectNewCodeBaselineUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := applyProjectNewCodeBaseline(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectNewCodeBaselineUpdate: %+v", err)
+	}
+
+	return resourceSonarqubeProjectNewCodeBaselineRead(d, m)
+}
+
+func resourceSonarqubeProjectNewCodeBaselineDelete(d *schema.ResourceData, m interface{}) error {
+	project := d.Id()
+	mainBranch, err := findProjectMainBranch(project, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectNewCodeBaselineDelete: %+v", err)
+	}
+
+	errMain := unsetNewCodePeriod(project, mainBranch, m)
+	errProject := unsetNewCodePeriod(project, "", m)
+	return errors.Join(errMain, errProject)
+}
+
+func resourceSonarqubeProjectNewCodeBaselineImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeProjectNewCodeBaselineRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeProjectNewCodeBaselineImport: the new code definition of project '%s' is not a reference branch", d.Get("project").(string))
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyProjectNewCodeBaseline sets the main branch definition first, so the main branch never compares with itself
+func applyProjectNewCodeBaseline(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	mainBranch, err := findProjectMainBranch(project, m)
+	if err != nil {
+		return err
+	}
+
+	if err := setNewCodePeriod(project, mainBranch, d.Get("main_branch_type").(string), d.Get("main_branch_value").(string), m); err != nil {
+		return err
+	}
+
+	referenceBranch := d.Get("reference_branch").(string)
+	if referenceBranch == "" {
+		referenceBranch = mainBranch
+	}
+	return setNewCodePeriod(project, "", string(ReferenceBranch), referenceBranch, m)
+}
+
+// findProjectMainBranch returns the name of the main branch of the project
+func findProjectMainBranch(project string, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/project_branches/list"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"findProjectMainBranch",
+	)
+	if err != nil {
+		return "", fmt.Errorf("findProjectMainBranch: Failed to list the branches of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	branches := GetBranches{}
+	err = json.NewDecoder(resp.Body).Decode(&branches)
+	if err != nil {
+		return "", fmt.Errorf("findProjectMainBranch: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, branch := range branches.Branches {
+		if branch.IsMain {
+			return branch.Name, nil
+		}
+	}
+	return "", fmt.Errorf("findProjectMainBranch: project '%s' has no main branch", project)
+}
+
+// setNewCodePeriod sets the new code definition of a project, or of a branch when it is set
+func setNewCodePeriod(project string, branch string, periodType string, value string, m interface{}) error {
+	rawQuery := url.Values{
+		"project": []string{project},
+		"type":    []string{periodType},
+	}
+	if branch != "" {
+		rawQuery.Add("branch", branch)
+	}
+	if value != "" {
+		rawQuery.Add("value", value)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/new_code_periods/set"
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"setNewCodePeriod",
+	)
+	if err != nil {
+		return fmt.Errorf("setNewCodePeriod: Failed to set the new code definition of project '%s' branch '%s': %+v", project, branch, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// readNewCodePeriod returns the new code definition of a project, or of a branch when it is set
+func readNewCodePeriod(project string, branch string, m interface{}) (*NewCodePeriod, error) {
+	rawQuery := url.Values{
+		"project": []string{project},
+	}
+	if branch != "" {
+		rawQuery.Add("branch", branch)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/new_code_periods/show"
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readNewCodePeriod",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readNewCodePeriod: Failed to read the new code definition of project '%s' branch '%s': %+v", project, branch, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	period := NewCodePeriod{}
+	err = json.NewDecoder(resp.Body).Decode(&period)
+	if err != nil {
+		return nil, fmt.Errorf("readNewCodePeriod: Failed to decode json into struct: %+v", err)
+	}
+
+	return &period, nil
+}
+
+// unsetNewCodePeriod unsets the new code definition of a project, or of a branch when it is set
+func unsetNewCodePeriod(project string, branch string, m interface{}) error {
+	rawQuery := url.Values{
+		"project": []string{project},
+	}
+	if branch != "" {
+		rawQuery.Add("branch", branch)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/new_code_periods/unset"
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"unsetNewCodePeriod",
+	)
+	if err != nil {
+		return fmt.Errorf("unsetNewCodePeriod: Failed to unset the new code definition of project '%s' branch '%s': %+v", project, branch, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectNewCodeBaselineConfig(rnd string, mainBranchType string, mainBranchValue string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[1]s"
+			project    = "%[1]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_project_new_code_baseline" "%[1]s" {
+			project           = sonarqube_project.%[1]s.project
+			main_branch_type  = "%[2]s"
+			main_branch_value = "%[3]s"
+		}`, rnd, mainBranchType, mainBranchValue)
+}
+
+func TestAccSonarqubeProjectNewCodeBaselineBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_project_new_code_baseline." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectNewCodeBaselineConfig(rnd, "PREVIOUS_VERSION", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "main_branch", "main"),
+					resource.TestCheckResourceAttr(name, "reference_branch", "main"),
+					resource.TestCheckResourceAttr(name, "main_branch_type", "PREVIOUS_VERSION"),
+				),
+			},
+			{
+				Config: testAccSonarqubeProjectNewCodeBaselineConfig(rnd, "NUMBER_OF_DAYS", "30"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "main_branch_type", "NUMBER_OF_DAYS"),
+					resource.TestCheckResourceAttr(name, "main_branch_value", "30"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestValidateMainBranchNewCodePeriod(t *testing.T) {
+	tests := []struct {
+		name        string
+		periodType  string
+		value       string
+		expectError bool
+	}{
+		{name: "previous version", periodType: "PREVIOUS_VERSION"},
+		{name: "previous version with value", periodType: "PREVIOUS_VERSION", value: "30", expectError: true},
+		{name: "number of days", periodType: "NUMBER_OF_DAYS", value: "30"},
+		{name: "number of days without value", periodType: "NUMBER_OF_DAYS", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMainBranchNewCodePeriod(tt.periodType, tt.value)
+			if (err != nil) != tt.expectError {
+				t.Errorf("validateMainBranchNewCodePeriod() returned error %v, expected error: %t", err, tt.expectError)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package sonarqube
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// projectPullRequestSettings maps the attributes of sonarqube_project_pull_request to their setting keys
+var projectPullRequestSettings = []settingAttribute{
+	{attribute: "provider_name", key: "sonar.pullrequest.provider"},
+	{attribute: "github_summary_comment", key: "sonar.pullrequest.github.summary_comment"},
+	{attribute: "inactive_days_before_deletion", key: "sonar.dbcleaner.daysBeforeDeletingInactiveBranchesAndPRs"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeProjectPullRequest() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Project Pull Request resource. This can be used to manage the pull request decoration settings of a project.
+Projects bound with a ` + "`sonarqube_github_binding`" + `, ` + "`sonarqube_gitlab_binding`" + ` or ` + "`sonarqube_azure_binding`" + ` get their provider from the binding.
+Destroying this resource resets the settings to their default value.`,
+		Create: resourceSonarqubeProjectPullRequestCreate,
+		Read:   resourceSonarqubeProjectPullRequestRead,
+		Update: resourceSonarqubeProjectPullRequestUpdate,
+		Delete: resourceSonarqubeProjectPullRequestDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeProjectPullRequestImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project. Changing this forces a new resource to be created.",
+			},
+			"provider_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"GitHub", "GitLab", "Azure DevOps", "Bitbucket Server"}, false)),
+				Description:      "The provider used to decorate pull requests (`sonar.pullrequest.provider`). Possible values are `GitHub`, `GitLab`, `Azure DevOps` and `Bitbucket Server`.",
+			},
+			"github_summary_comment": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether a summary comment is added to GitHub pull requests (`sonar.pullrequest.github.summary_comment`). Defaults to `true`.",
+			},
+			"inactive_days_before_deletion": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          30,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The number of days after which inactive branches and pull requests are deleted (`sonar.dbcleaner.daysBeforeDeletingInactiveBranchesAndPRs`). Defaults to `30`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeProjectPullRequestCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	if err := setSettingAttributes(project, projectPullRequestSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId(project)
+
+	return resourceSonarqubeProjectPullRequestRead(d, m)
+}
+
+func resourceSonarqubeProjectPullRequestRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes(d.Id(), projectPullRequestSettings, d, m)
+}
+
+func resourceSonarqubeProjectPullRequestUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes(d.Id(), projectPullRequestSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeProjectPullRequestRead(d, m)
+}
+
+func resourceSonarqubeProjectPullRequestDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes(d.Id(), projectPullRequestSettings, m)
+}
+
+func resourceSonarqubeProjectPullRequestImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("project", d.Id()); err != nil {
+		return nil, err
+	}
+	if err := resourceSonarqubeProjectPullRequestRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,56 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectPullRequestConfig(rnd string, projectKey string, summaryComment bool, inactiveDays int) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_project_pull_request" "%[1]s" {
+			project                       = sonarqube_project.%[1]s.project
+			provider_name                 = "GitHub"
+			github_summary_comment        = %[3]t
+			inactive_days_before_deletion = %[4]d
+		}`, rnd, projectKey, summaryComment, inactiveDays)
+}
+
+func TestAccSonarqubeProjectPullRequestBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_project_pull_request." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectPullRequestConfig(rnd, "testAccSonarqubeProjectPullRequest", false, 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "provider_name", "GitHub"),
+					resource.TestCheckResourceAttr(name, "github_summary_comment", "false"),
+					resource.TestCheckResourceAttr(name, "inactive_days_before_deletion", "10"),
+				),
+			},
+			{
+				Config: testAccSonarqubeProjectPullRequestConfig(rnd, "testAccSonarqubeProjectPullRequest", true, 60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "github_summary_comment", "true"),
+					resource.TestCheckResourceAttr(name, "inactive_days_before_deletion", "60"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
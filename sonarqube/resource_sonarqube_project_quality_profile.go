@@ -0,0 +1,232 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeProjectQualityProfile() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Project Quality Profile resource. This associates a project with one quality profile per language,
+instead of one ` + "`sonarqube_qualityprofile_project_association`" + ` resource per language. It is authoritative for the listed languages:
+a profile changed outside of Terraform is associated again on the next apply. The other languages keep their profile. Removing a language,
+or destroying this resource, makes the project use the default profile of the language again. It supports importing using the project key,
+which imports the languages whose profile is not the default one.`,
+		Create: resourceSonarqubeProjectQualityProfileCreate,
+		Read:   resourceSonarqubeProjectQualityProfileRead,
+		Update: resourceSonarqubeProjectQualityProfileUpdate,
+		Delete: resourceSonarqubeProjectQualityProfileDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeProjectQualityProfileImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project. Changing this forces a new resource to be created.",
+			},
+			"profiles": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The names of the quality profiles, keyed by language, for example `{ java = \"My Java\", ts = \"My TS\" }`. The languages are the keys listed by `api/languages/list`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeProjectQualityProfileCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(d.Get("project").(string))
+
+	if err := applyProjectQualityProfiles(d, "profiles", m); err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectQualityProfileCreate: %+v", err)
+	}
+
+	return resourceSonarqubeProjectQualityProfileRead(d, m)
+}
+
+func resourceSonarqubeProjectQualityProfileRead(d *schema.ResourceData, m interface{}) error {
+	profiles, err := readProjectQualityProfilesFromApi(d.Id(), m)
+	if err != nil {
+		return err
+	}
+	if profiles == nil {
+		log.Printf("[WARN][resourceSonarqubeProjectQualityProfileRead] Project '%s' not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// Only the listed languages are managed, the profiles of the other languages are ignored
+	current := map[string]interface{}{}
+	for language := range d.Get("profiles").(map[string]interface{}) {
+		for _, profile := range profiles {
+			if profile.Language == language {
+				current[language] = profile.Name
+			}
+		}
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("project", d.Id()))
+	errs = append(errs, d.Set("profiles", current))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeProjectQualityProfileUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := applyProjectQualityProfiles(d, "profiles", m); err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectQualityProfileUpdate: %+v", err)
+	}
+
+	return resourceSonarqubeProjectQualityProfileRead(d, m)
+}
+
+func resourceSonarqubeProjectQualityProfileDelete(d *schema.ResourceData, m interface{}) error {
+	errs := []error{}
+	for language, profile := range d.Get("profiles").(map[string]interface{}) {
+		errs = append(errs, updateProjectQualityProfile("remove_project", d.Id(), language, profile.(string), m))
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("resourceSonarqubeProjectQualityProfileDelete: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceSonarqubeProjectQualityProfileImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	profiles, err := readProjectQualityProfilesFromApi(d.Id(), m)
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeProjectQualityProfileImport: %+v", err)
+	}
+	if profiles == nil {
+		return nil, fmt.Errorf("resourceSonarqubeProjectQualityProfileImport: project '%s' not found", d.Id())
+	}
+
+	associated := map[string]interface{}{}
+	for _, profile := range profiles {
+		if !profile.IsDefault {
+			associated[profile.Language] = profile.Name
+		}
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("project", d.Id()))
+	errs = append(errs, d.Set("profiles", associated))
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// applyProjectQualityProfiles associates the changed profiles of the attribute, and the default profile of the removed
+// languages. The profiles that could not be changed are written back to the state, so they are retried on the next apply.
+func applyProjectQualityProfiles(d *schema.ResourceData, attribute string, m interface{}) error {
+	project := d.Get("project").(string)
+	oldProfiles, newProfiles := d.GetChange(attribute)
+	oldMap, newMap := oldProfiles.(map[string]interface{}), newProfiles.(map[string]interface{})
+
+	applied := map[string]interface{}{}
+	errs := []error{}
+	for language, profile := range oldMap {
+		if _, ok := newMap[language]; ok {
+			continue
+		}
+		if err := updateProjectQualityProfile("remove_project", project, language, profile.(string), m); err != nil {
+			errs = append(errs, err)
+			applied[language] = profile
+		}
+	}
+	for language, profile := range newMap {
+		if oldMap[language] == profile {
+			applied[language] = profile
+			continue
+		}
+		if err := updateProjectQualityProfile("add_project", project, language, profile.(string), m); err != nil {
+			errs = append(errs, err)
+			if oldProfile, ok := oldMap[language]; ok {
+				applied[language] = oldProfile
+			}
+			continue
+		}
+		applied[language] = profile
+	}
+
+	if len(errs) > 0 {
+		errs = append(errs, d.Set(attribute, applied))
+	}
+	return errors.Join(errs...)
+}
+
+// updateProjectQualityProfile associates (add_project) or dissociates (remove_project) a quality profile and a project
+func updateProjectQualityProfile(action string, project string, language string, qualityProfile string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/" + action
+	sonarQubeURL.RawQuery = url.Values{
+		"language":       []string{language},
+		"project":        []string{project},
+		"qualityProfile": []string{qualityProfile},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"updateProjectQualityProfile",
+	)
+	if err != nil {
+		return fmt.Errorf("updateProjectQualityProfile: Failed to %s for the %s profile '%s' of project '%s': %+v", action, language, qualityProfile, project, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// readProjectQualityProfilesFromApi returns the quality profile the project uses for each language, or nil when the
+// project does not exist
+func readProjectQualityProfilesFromApi(project string, m interface{}) ([]GetQualityProfile, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readProjectQualityProfilesFromApi",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("readProjectQualityProfilesFromApi: Failed to read the quality profiles of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	profiles := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&profiles)
+	if err != nil {
+		return nil, fmt.Errorf("readProjectQualityProfilesFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	if profiles.Profiles == nil {
+		return []GetQualityProfile{}, nil
+	}
+	return profiles.Profiles, nil
+}
@@ -0,0 +1,130 @@
+package sonarqube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectQualityProfileConfig(rnd string, profiles string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[1]s"
+			project    = "%[1]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s_js" {
+			name     = "%[1]s-js"
+			language = "js"
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s_java" {
+			name     = "%[1]s-java"
+			language = "java"
+		}
+
+		resource "sonarqube_project_quality_profile" "%[1]s" {
+			project  = sonarqube_project.%[1]s.project
+			profiles = %[2]s
+		}`, rnd, profiles)
+}
+
+func TestAccSonarqubeProjectQualityProfileBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_project_quality_profile." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectQualityProfileConfig(rnd, fmt.Sprintf(`{
+					js   = sonarqube_qualityprofile.%[1]s_js.name
+					java = sonarqube_qualityprofile.%[1]s_java.name
+				}`, rnd)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "profiles.%", "2"),
+					resource.TestCheckResourceAttr(name, "profiles.js", rnd+"-js"),
+					resource.TestCheckResourceAttr(name, "profiles.java", rnd+"-java"),
+				),
+			},
+			{
+				Config: testAccSonarqubeProjectQualityProfileConfig(rnd, fmt.Sprintf(`{
+					js = sonarqube_qualityprofile.%[1]s_js.name
+				}`, rnd)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "profiles.%", "1"),
+					resource.TestCheckResourceAttr(name, "profiles.js", rnd+"-js"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeProjectQualityProfileUpdate(t *testing.T) {
+	mock := newMockSonarQube(t)
+	calls := []string{}
+	for _, action := range []string{"add_project", "remove_project"} {
+		action := action
+		mock.handle("POST", "/api/qualityprofiles/"+action, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("qualityProfile") == "Missing" {
+				mockError(w, http.StatusNotFound, "Quality Profile for language 'py' and name 'Missing' does not exist")
+				return
+			}
+			calls = append(calls, action+" "+r.URL.Query().Get("language")+" "+r.URL.Query().Get("qualityProfile"))
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+	r := resourceSonarqubeProjectQualityProfile()
+
+	state := &terraform.InstanceState{
+		ID: "my_project",
+		Attributes: map[string]string{
+			"id":            "my_project",
+			"project":       "my_project",
+			"profiles.%":    "3",
+			"profiles.java": "My Java",
+			"profiles.js":   "My JS",
+			"profiles.py":   "My Python",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"project":  "my_project",
+		"profiles": map[string]interface{}{"java": "My Java", "ts": "My TS", "py": "Missing"},
+	})
+	conf := mock.conf("Community", "10.7")
+	diff, err := r.Diff(context.Background(), state, config, conf)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error = %v", err)
+	}
+	d, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := applyProjectQualityProfiles(d, "profiles", conf); err == nil {
+		t.Fatalf("applyProjectQualityProfiles() should return the error of the missing profile")
+	}
+	sort.Strings(calls)
+	expected := []string{"add_project ts My TS", "remove_project js My JS"}
+	if fmt.Sprint(calls) != fmt.Sprint(expected) {
+		t.Errorf("applyProjectQualityProfiles() calls = %v, want %v", calls, expected)
+	}
+	// The profile that could not be changed is kept, so it is retried on the next apply
+	profiles := d.Get("profiles").(map[string]interface{})
+	if profiles["py"] != "My Python" || profiles["ts"] != "My TS" || profiles["java"] != "My Java" || len(profiles) != 3 {
+		t.Errorf("applyProjectQualityProfiles() profiles = %v", profiles)
+	}
+}
@@ -0,0 +1,92 @@
+package sonarqube
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// projectScmSettings maps the attributes of sonarqube_project_scm to their setting keys
+var projectScmSettings = []settingAttribute{
+	{attribute: "provider_key", key: "sonar.scm.provider"},
+	{attribute: "disabled", key: "sonar.scm.disabled"},
+	{attribute: "url", key: "sonar.links.scm"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeProjectScm() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Project SCM resource. This can be used to manage the SCM settings of a project.
+Destroying this resource resets the settings to their default value.`,
+		Create: resourceSonarqubeProjectScmCreate,
+		Read:   resourceSonarqubeProjectScmRead,
+		Update: resourceSonarqubeProjectScmUpdate,
+		Delete: resourceSonarqubeProjectScmDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeProjectScmImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project. Changing this forces a new resource to be created.",
+			},
+			"provider_key": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"git", "svn"}, false)),
+				Description:      "The key of the SCM provider (`sonar.scm.provider`), for example `git`. Only needed when SonarQube cannot detect it automatically.",
+			},
+			"disabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the SCM sensor is disabled (`sonar.scm.disabled`). Defaults to `false`.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The URL of the source repository (`sonar.links.scm`).",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeProjectScmCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	if err := setSettingAttributes(project, projectScmSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId(project)
+
+	return resourceSonarqubeProjectScmRead(d, m)
+}
+
+func resourceSonarqubeProjectScmRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes(d.Id(), projectScmSettings, d, m)
+}
+
+func resourceSonarqubeProjectScmUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes(d.Id(), projectScmSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeProjectScmRead(d, m)
+}
+
+func resourceSonarqubeProjectScmDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes(d.Id(), projectScmSettings, m)
+}
+
+func resourceSonarqubeProjectScmImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("project", d.Id()); err != nil {
+		return nil, err
+	}
+	if err := resourceSonarqubeProjectScmRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
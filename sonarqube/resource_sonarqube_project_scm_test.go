@@ -0,0 +1,56 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectScmConfig(rnd string, projectKey string, disabled bool, url string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_project_scm" "%[1]s" {
+			project      = sonarqube_project.%[1]s.project
+			provider_key = "git"
+			disabled     = %[3]t
+			url          = "%[4]s"
+		}`, rnd, projectKey, disabled, url)
+}
+
+func TestAccSonarqubeProjectScmBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_project_scm." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectScmConfig(rnd, "testAccSonarqubeProjectScm", false, "https://github.com/example/one"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "provider_key", "git"),
+					resource.TestCheckResourceAttr(name, "disabled", "false"),
+					resource.TestCheckResourceAttr(name, "url", "https://github.com/example/one"),
+				),
+			},
+			{
+				Config: testAccSonarqubeProjectScmConfig(rnd, "testAccSonarqubeProjectScm", true, "https://github.com/example/two"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "disabled", "true"),
+					resource.TestCheckResourceAttr(name, "url", "https://github.com/example/two"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -1,11 +1,18 @@
 package sonarqube
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 )
@@ -17,9 +24,22 @@ func init() {
 	})
 }
 
-// TODO: implement sweeper to clean up projects: https://www.terraform.io/docs/extend/testing/acceptance-tests/sweepers.html
+// testSweepSonarqubeProjectSweeper deletes the projects left behind by the acceptance tests
 func testSweepSonarqubeProjectSweeper(r string) error {
-	return nil
+	m, err := sharedConfigForSweepers()
+	if err != nil {
+		return err
+	}
+
+	projects, err := searchProjectsByKeyPrefix("testAcc", "", m)
+	if err != nil {
+		return err
+	}
+	keys := []string{}
+	for _, project := range projects {
+		keys = append(keys, project.Key)
+	}
+	return bulkDeleteProjects(keys, m)
 }
 
 func testAccSonarqubeProjectBasicConfig(rnd string, name string, project string, visibility string) string {
@@ -130,6 +150,7 @@ func TestAccSonarqubeProjectBasic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "project", "testAccSonarqubeProject"),
 					resource.TestCheckResourceAttr(name, "visibility", "public"),
+					resource.TestMatchResourceAttr(name, "url", regexp.MustCompile(`/dashboard\?id=testAccSonarqubeProject$`)),
 				),
 			},
 			{
@@ -161,6 +182,40 @@ func TestAccSonarqubeProjectBasic(t *testing.T) {
 	})
 }
 
+func TestAccSonarqubeProjectKeyAndTagsUpdate(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_project." + rnd
+	tags := []string{"tag1", "tag2"}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectBasicConfig(rnd, "testAccSonarqubeProject", "testAccSonarqubeProject", "public"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeProject"),
+					resource.TestCheckResourceAttr(name, "project", "testAccSonarqubeProject"),
+				),
+			},
+			{
+				// The tags are updated after the project is renamed
+				Config: testAccSonarqubeProjectTagsConfig(rnd, "testAccSonarqubeProject", "testAccSonarqubeProjectRenamed", "public", tags),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(name, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "id", "testAccSonarqubeProjectRenamed"),
+					resource.TestCheckResourceAttr(name, "project", "testAccSonarqubeProjectRenamed"),
+					resource.TestCheckResourceAttr(name, "tags.#", "2"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccSonarqubeProjectVisibilityUpdate(t *testing.T) {
 	rnd := generateRandomResourceName()
 	name := "sonarqube_project." + rnd
@@ -293,6 +348,35 @@ func TestAccSonarqubeProjectKeyUpdate(t *testing.T) {
 	})
 }
 
+func testAccSonarqubeProjectWaitForFirstAnalysisConfig(rnd string, name string, project string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name                    = "%[2]s"
+			project                 = "%[3]s"
+			wait_for_first_analysis = true
+
+			timeouts {
+				create = "10s"
+			}
+		}`, rnd, name, project)
+}
+
+func TestAccSonarqubeProjectWaitForFirstAnalysisTimeout(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// No scanner runs during the tests, so the wait must time out
+				Config:      testAccSonarqubeProjectWaitForFirstAnalysisConfig(rnd, "testAccSonarqubeProjectWait", "testAccSonarqubeProjectWait"),
+				ExpectError: regexp.MustCompile("no analysis processed yet"),
+			},
+		},
+	})
+}
+
 func TestAccSonarqubeProjectSettingsCreate(t *testing.T) {
 	rnd := generateRandomResourceName()
 	name := "sonarqube_project." + rnd
@@ -388,7 +472,7 @@ func TestAccSonarqubeProjectSettingsFieldValues(t *testing.T) {
 	var settingValuesKey string
 	var settingValuesKeyData []string
 	// Some settings are not available in community edition
-	if strings.ToLower(testAccProvider.Meta().(*ProviderConfiguration).sonarQubeEdition) == "community" {
+	if strings.ToLower(testAccProviderConfiguration(t).sonarQubeEdition) == "community" {
 		settingValuesKey = "sonar.terraform.file.suffixes"
 		settingValuesKeyData = []string{".tf", ".tfvars", ".hcl"}
 	} else {
@@ -421,3 +505,120 @@ func TestAccSonarqubeProjectSettingsFieldValues(t *testing.T) {
 	})
 
 }
+
+func TestCheckProjectKeyConvention(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    *ProviderConfiguration
+		key     string
+		wantErr bool
+	}{
+		{"no convention", &ProviderConfiguration{}, "anything", false},
+		{"matching prefix", &ProviderConfiguration{projectKeyPrefix: "team-a-"}, "team-a-api", false},
+		{"missing prefix", &ProviderConfiguration{projectKeyPrefix: "team-a-"}, "team-b-api", true},
+		{"matching regex", &ProviderConfiguration{projectKeyRegex: regexp.MustCompile("^[a-z-]+$")}, "team-a-api", false},
+		{"not matching regex", &ProviderConfiguration{projectKeyRegex: regexp.MustCompile("^[a-z-]+$")}, "Team_A", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkProjectKeyConvention(tt.key, tt.conf); (err != nil) != tt.wantErr {
+				t.Errorf("checkProjectKeyConvention() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMergeProjectTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		tags        []string
+		defaultTags []string
+		want        []string
+	}{
+		{"no default tags", []string{"api"}, nil, []string{"api"}},
+		{"only default tags", []string{}, []string{"team-a"}, []string{"team-a"}},
+		{"merged", []string{"api", "java"}, []string{"team-a", "owned"}, []string{"api", "java", "team-a", "owned"}},
+		{"default tag also configured", []string{"team-a", "api"}, []string{"team-a"}, []string{"team-a", "api"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeProjectTags(tt.tags, tt.defaultTags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeProjectTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceSonarqubeProjectDefaultTags(t *testing.T) {
+	mock := newMockSonarQube(t)
+	var mutex sync.Mutex
+	tags := []string{}
+	mock.respond("POST", "/api/projects/create", http.StatusOK, `{"project":{"key":"my-project","name":"My Project","qualifier":"TRK"}}`)
+	mock.respond("GET", "/api/settings/values", http.StatusOK, `{"settings":[]}`)
+	mock.handle("POST", "/api/project_tags/set", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		tags = strings.Split(r.URL.Query().Get("tags"), ",")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mock.handle("GET", "/api/components/show", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		mockJSON(w, GetProject{Component: ProjectComponent{Key: "my-project", Name: "My Project", Visibility: "public", Tags: tags}})
+	})
+	conf := mock.conf("Community", "10.7")
+	conf.defaultProjectTags = []string{"team-a", "owned"}
+	r := resourceSonarqubeProject()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":    "My Project",
+		"project": "my-project",
+		"tags":    []interface{}{"api", "owned"},
+	})
+	if err := r.Create(d, conf); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if want := []string{"api", "owned", "team-a"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("Create() tags = %v, want %v", tags, want)
+	}
+	// The default tags are not reported in tags, unless they are also configured on the resource
+	if got := expandStringList(d.Get("tags")); !reflect.DeepEqual(got, []string{"api", "owned"}) {
+		t.Errorf("Create() state tags = %v, want [api owned]", got)
+	}
+	if got := sortedStrings(expandStringList(d.Get("tags_all"))); !reflect.DeepEqual(got, []string{"api", "owned", "team-a"}) {
+		t.Errorf("Create() state tags_all = %v, want [api owned team-a]", got)
+	}
+
+	// Changing the default tags of the provider updates the project, without changes to its configuration
+	conf.defaultProjectTags = []string{"team-b"}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":    "My Project",
+		"project": "my-project",
+		"tags":    []interface{}{"api", "owned"},
+	})
+	diff, err := r.Diff(context.Background(), d.State(), config, conf)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error = %v", err)
+	}
+	if diff.Empty() {
+		t.Fatalf("Diff() is empty, want tags_all updated")
+	}
+	state, diags := r.Apply(context.Background(), d.State(), diff, conf)
+	if diags.HasError() {
+		t.Fatalf("Update() unexpected error = %v", diags)
+	}
+	if want := []string{"api", "owned", "team-b"}; !reflect.DeepEqual(tags, want) {
+		t.Errorf("Update() tags = %v, want %v", tags, want)
+	}
+	if state.Attributes["tags.#"] != "2" || state.Attributes["tags_all.#"] != "3" {
+		t.Errorf("Update() state = %v, want 2 tags and 3 tags_all", state.Attributes)
+	}
+
+	// Without changes, the plan is empty
+	d = r.Data(state)
+	if diff, err := r.Diff(context.Background(), d.State(), config, conf); err != nil || !diff.Empty() {
+		t.Errorf("Diff() = %v, %v, want an empty diff", diff, err)
+	}
+}
@@ -0,0 +1,224 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ProjectSearchResult for unmarshalling a single project of api/projects/search
+type ProjectSearchResult struct {
+	Key              string `json:"key"`
+	Name             string `json:"name"`
+	Visibility       string `json:"visibility"`
+	LastAnalysisDate string `json:"lastAnalysisDate"`
+}
+
+// GetProjectsSearch for unmarshalling response body of api/projects/search
+type GetProjectsSearch struct {
+	Paging     Paging                `json:"paging"`
+	Components []ProjectSearchResult `json:"components"`
+}
+
+// projectsBulkDeleteBatchSize limits the number of project keys sent in a single api/projects/bulk_delete request
+const projectsBulkDeleteBatchSize = 50
+
+// projectsCleanupMinimumPrefixLength prevents a short or empty key_prefix from matching every project of the instance
+const projectsCleanupMinimumPrefixLength = 2
+
+// Returns the resource represented by this file.
+func resourceSonarqubeProjectsCleanup() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Projects Cleanup resource. This deletes all the projects whose key starts with a prefix with api/projects/bulk_delete,
+for example to clean up the projects created for every branch of an ephemeral preview environment.
+The projects are deleted when the resource is created and every time ` + "`triggers`" + ` changes. Destroying this resource only removes it from the state.`,
+		Create: resourceSonarqubeProjectsCleanupCreate,
+		Read:   resourceSonarqubeProjectsCleanupRead,
+		Update: resourceSonarqubeProjectsCleanupUpdate,
+		Delete: resourceSonarqubeProjectsCleanupDelete,
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"key_prefix": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringLenBetween(projectsCleanupMinimumPrefixLength, 400)),
+				Description:      "Delete the projects whose key starts with this prefix. It must have at least 2 characters. Changing this forces a new resource to be created.",
+			},
+			"analyzed_before": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only delete the projects whose last analysis is older than this date, for example `2024-01-31`.",
+			},
+			"exclude": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The keys of the projects matching the prefix that must be kept.",
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary map of values that, when changed, deletes the matching projects again. For example the `id` of a `time_rotating` resource.",
+			},
+			"deleted_projects": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The keys of the projects deleted by the last run.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeProjectsCleanupCreate(d *schema.ResourceData, m interface{}) error {
+	if err := cleanupProjects(d, m); err != nil {
+		return err
+	}
+
+	d.SetId(d.Get("key_prefix").(string))
+
+	return resourceSonarqubeProjectsCleanupRead(d, m)
+}
+
+func resourceSonarqubeProjectsCleanupRead(d *schema.ResourceData, m interface{}) error {
+	// The projects are only deleted on create and update, there is nothing to read
+	return nil
+}
+
+func resourceSonarqubeProjectsCleanupUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("triggers") {
+		if err := cleanupProjects(d, m); err != nil {
+			return err
+		}
+	}
+
+	return resourceSonarqubeProjectsCleanupRead(d, m)
+}
+
+func resourceSonarqubeProjectsCleanupDelete(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+// cleanupProjects deletes the projects matching the configuration of the resource and records their keys
+func cleanupProjects(d *schema.ResourceData, m interface{}) error {
+	prefix := d.Get("key_prefix").(string)
+	if len(prefix) < projectsCleanupMinimumPrefixLength {
+		return fmt.Errorf("cleanupProjects: key_prefix '%s' must have at least %d characters, it would delete every project", prefix, projectsCleanupMinimumPrefixLength)
+	}
+	projects, err := searchProjectsByKeyPrefix(prefix, d.Get("analyzed_before").(string), m)
+	if err != nil {
+		return err
+	}
+
+	exclude := d.Get("exclude").(*schema.Set)
+	keys := []string{}
+	for _, project := range projects {
+		if !exclude.Contains(project.Key) {
+			keys = append(keys, project.Key)
+		}
+	}
+
+	if err := bulkDeleteProjects(keys, m); err != nil {
+		return err
+	}
+	return d.Set("deleted_projects", keys)
+}
+
+// searchProjectsByKeyPrefix returns the projects whose key starts with the prefix. The cleanup resource enforces
+// projectsCleanupMinimumPrefixLength, only other callers pass "" to list all projects.
+// api/projects/search matches the query anywhere in the key or name, so the results are filtered on the prefix.
+func searchProjectsByKeyPrefix(prefix string, analyzedBefore string, m interface{}) ([]ProjectSearchResult, error) {
+	projects := []ProjectSearchResult{}
+	for page := 1; ; page++ {
+		rawQuery := url.Values{
+			"ps": []string{"500"},
+			"p":  []string{strconv.Itoa(page)},
+		}
+		if prefix != "" {
+			rawQuery.Add("q", prefix)
+		}
+		if analyzedBefore != "" {
+			rawQuery.Add("analyzedBefore", analyzedBefore)
+		}
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/search"
+		sonarQubeURL.RawQuery = rawQuery.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"GET",
+			sonarQubeURL.String(),
+			http.StatusOK,
+			"searchProjectsByKeyPrefix",
+		)
+		if err != nil {
+			return nil, fmt.Errorf("searchProjectsByKeyPrefix: Failed to search projects matching '%s': %+v", prefix, err)
+		}
+
+		// Decode response into struct
+		search := GetProjectsSearch{}
+		err = json.NewDecoder(resp.Body).Decode(&search)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("searchProjectsByKeyPrefix: Failed to decode json into struct: %+v", err)
+		}
+
+		for _, project := range search.Components {
+			if strings.HasPrefix(project.Key, prefix) {
+				projects = append(projects, project)
+			}
+		}
+
+		if search.Paging.PageSize == 0 || search.Paging.PageIndex*search.Paging.PageSize >= search.Paging.Total {
+			break
+		}
+	}
+
+	return projects, nil
+}
+
+// bulkDeleteProjects deletes the projects in batches with api/projects/bulk_delete
+func bulkDeleteProjects(keys []string, m interface{}) error {
+	for start := 0; start < len(keys); start += projectsBulkDeleteBatchSize {
+		end := start + projectsBulkDeleteBatchSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/projects/bulk_delete"
+		sonarQubeURL.RawQuery = url.Values{
+			"projects": []string{strings.Join(keys[start:end], ",")},
+		}.Encode()
+
+		resp, err := httpRequestHelper(
+			m.(*ProviderConfiguration).httpClient,
+			"POST",
+			sonarQubeURL.String(),
+			http.StatusNoContent,
+			"bulkDeleteProjects",
+		)
+		if err != nil {
+			return fmt.Errorf("bulkDeleteProjects: Failed to delete projects: %+v", err)
+		}
+		resp.Body.Close()
+	}
+
+	return nil
+}
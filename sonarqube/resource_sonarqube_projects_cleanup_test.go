@@ -0,0 +1,72 @@
+package sonarqube
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeProjectsCleanupConfig(rnd string, prefix string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s_keep" {
+			name    = "%[2]s-keep"
+			project = "%[2]s-keep"
+		}
+
+		resource "sonarqube_project" "%[1]s_delete" {
+			name    = "%[2]s-delete"
+			project = "%[2]s-delete"
+		}
+
+		resource "sonarqube_projects_cleanup" "%[1]s" {
+			key_prefix = "%[2]s"
+			exclude    = [sonarqube_project.%[1]s_keep.project]
+			triggers = {
+				project = sonarqube_project.%[1]s_delete.project
+			}
+		}`, rnd, prefix)
+}
+
+func TestAccSonarqubeProjectsCleanupBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_projects_cleanup." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeProjectsCleanupConfig(rnd, "testAccSonarqubeProjectsCleanup"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "deleted_projects.#", "1"),
+					resource.TestCheckResourceAttr(name, "deleted_projects.0", "testAccSonarqubeProjectsCleanup-delete"),
+				),
+				// The deleted project is recreated on the next plan
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeProjectsCleanupEmptyPrefix(t *testing.T) {
+	r := resourceSonarqubeProjectsCleanup()
+	for _, prefix := range []string{"", "a"} {
+		if diags := r.Schema["key_prefix"].ValidateDiagFunc(prefix, nil); !diags.HasError() {
+			t.Errorf("key_prefix validation of '%s' has no error, want an error", prefix)
+		}
+	}
+
+	// The requests are refused even when the validation is bypassed
+	mock := newMockSonarQube(t)
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"key_prefix": ""})
+	err := r.Create(d, mock.conf("Community", "10.7"))
+	if err == nil || !strings.Contains(err.Error(), "it would delete every project") {
+		t.Errorf("Create() error = %v, want an error about the prefix", err)
+	}
+	if len(mock.requests()) > 0 {
+		t.Errorf("Create() requests = %v, want none", mock.requests())
+	}
+}
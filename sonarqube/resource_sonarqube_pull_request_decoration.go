@@ -0,0 +1,140 @@
+package sonarqube
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubePullRequestDecoration() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Pull Request Decoration resource. This manages whether SonarQube posts the analysis summary as a
+comment on the pull requests of a project bound to GitHub, without recreating the binding. The binding must already exist, for
+example created when the project was imported from GitHub, and is only updated in place: use ` + "`summary_comment_enabled`" + ` of
+` + "`sonarqube_github_binding`" + ` instead when the binding is managed by Terraform. The DevOps Platform API has no such toggle for the
+other platforms. Destroying this resource enables the summary comment again. It supports importing using the project key.`,
+		Create: resourceSonarqubePullRequestDecorationCreate,
+		Read:   resourceSonarqubePullRequestDecorationRead,
+		Update: resourceSonarqubePullRequestDecorationUpdate,
+		Delete: resourceSonarqubePullRequestDecorationDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubePullRequestDecorationImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project bound to GitHub. Changing this forces a new resource to be created.",
+			},
+			"summary_comment_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the summary of the analysis is posted as a comment on the pull requests. Defaults to `true`.",
+			},
+			"alm_setting": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key of the ALM setting of the binding.",
+			},
+			"repository": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The GitHub repository of the binding.",
+			},
+		},
+	}
+}
+
+// setPullRequestDecoration sets the summary comment toggle of the GitHub binding of the project, keeping the rest of the binding
+func setPullRequestDecoration(project string, summaryCommentEnabled bool, m interface{}) error {
+	if err := checkGithubBindingSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	binding, err := findProjectBindingFromApi(project, m)
+	if err != nil {
+		return err
+	}
+	if binding == nil {
+		return fmt.Errorf("project '%s' is not bound to a DevOps Platform", project)
+	}
+	if binding.Alm != "github" {
+		return fmt.Errorf("project '%s' is bound to %s, pull request decoration can only be toggled for GitHub bindings", project, binding.Alm)
+	}
+
+	return setGithubBinding(project, binding.Key, binding.Repository, binding.Monorepo, summaryCommentEnabled, m)
+}
+
+func resourceSonarqubePullRequestDecorationCreate(d *schema.ResourceData, m interface{}) error {
+	project := d.Get("project").(string)
+	if err := setPullRequestDecoration(project, d.Get("summary_comment_enabled").(bool), m); err != nil {
+		return fmt.Errorf("resourceSonarqubePullRequestDecorationCreate: %+v", err)
+	}
+
+	d.SetId(project)
+
+	return resourceSonarqubePullRequestDecorationRead(d, m)
+}
+
+func resourceSonarqubePullRequestDecorationRead(d *schema.ResourceData, m interface{}) error {
+	binding, err := findProjectBindingFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubePullRequestDecorationRead: %+v", err)
+	}
+	if binding == nil || binding.Alm != "github" {
+		log.Printf("[WARN][resourceSonarqubePullRequestDecorationRead] Project '%s' is not bound to GitHub anymore, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("project", d.Id()))
+	errs = append(errs, d.Set("summary_comment_enabled", binding.SummaryCommentEnabled))
+	errs = append(errs, d.Set("alm_setting", binding.Key))
+	errs = append(errs, d.Set("repository", binding.Repository))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubePullRequestDecorationUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("summary_comment_enabled") {
+		if err := setPullRequestDecoration(d.Id(), d.Get("summary_comment_enabled").(bool), m); err != nil {
+			return fmt.Errorf("resourceSonarqubePullRequestDecorationUpdate: %+v", err)
+		}
+	}
+
+	return resourceSonarqubePullRequestDecorationRead(d, m)
+}
+
+func resourceSonarqubePullRequestDecorationDelete(d *schema.ResourceData, m interface{}) error {
+	binding, err := findProjectBindingFromApi(d.Id(), m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubePullRequestDecorationDelete: %+v", err)
+	}
+	// Nothing to restore when the binding was removed with the project or replaced
+	if binding == nil || binding.Alm != "github" || binding.SummaryCommentEnabled {
+		return nil
+	}
+
+	if err := setGithubBinding(d.Id(), binding.Key, binding.Repository, binding.Monorepo, true, m); err != nil {
+		return fmt.Errorf("resourceSonarqubePullRequestDecorationDelete: %+v", err)
+	}
+	return nil
+}
+
+func resourceSonarqubePullRequestDecorationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	project := d.Id()
+	if err := resourceSonarqubePullRequestDecorationRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubePullRequestDecorationImport: project '%s' is not bound to GitHub", project)
+	}
+	return []*schema.ResourceData{d}, nil
+}
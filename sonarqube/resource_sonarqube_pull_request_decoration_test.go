@@ -0,0 +1,90 @@
+package sonarqube
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourceSonarqubePullRequestDecorationLifecycle(t *testing.T) {
+	mock := newMockSonarQube(t)
+	bindings := map[string]GetBinding{
+		"my_project": {Key: "my-alm", Alm: "github", Repository: "my-org/my-repo", Monorepo: true, SummaryCommentEnabled: true},
+	}
+	mockBindingAPI(mock, "github", bindings)
+	conf := mock.conf("Developer", "10.7")
+	r := resourceSonarqubePullRequestDecoration()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project":                 "my_project",
+		"summary_comment_enabled": false,
+	})
+	if err := r.Create(d, conf); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	want := GetBinding{Key: "my-alm", Alm: "github", Repository: "my-org/my-repo", Monorepo: true, SummaryCommentEnabled: false}
+	if bindings["my_project"] != want {
+		t.Errorf("Create() binding = %+v, want %+v", bindings["my_project"], want)
+	}
+	if d.Id() != "my_project" || d.Get("repository").(string) != "my-org/my-repo" || d.Get("summary_comment_enabled").(bool) {
+		t.Errorf("Create() state = %v, want the binding that was set", d.State().Attributes)
+	}
+
+	if err := r.Delete(d, conf); err != nil {
+		t.Fatalf("Delete() unexpected error = %v", err)
+	}
+	if !bindings["my_project"].SummaryCommentEnabled || bindings["my_project"].Repository != "my-org/my-repo" {
+		t.Errorf("Delete() binding = %+v, want the summary comment enabled again", bindings["my_project"])
+	}
+
+	// The decoration is removed from the state with the binding
+	delete(bindings, "my_project")
+	if err := r.Read(d, conf); err != nil {
+		t.Fatalf("Read() unexpected error = %v", err)
+	}
+	if d.Id() != "" {
+		t.Errorf("Read() ID = %s, want no ID", d.Id())
+	}
+}
+
+func TestResourceSonarqubePullRequestDecorationCreateErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		bindings map[string]GetBinding
+		wantErr  string
+	}{
+		{
+			name:     "project not bound",
+			bindings: map[string]GetBinding{},
+			wantErr:  "project 'my_project' is not bound to a DevOps Platform",
+		},
+		{
+			name: "project bound to GitLab",
+			bindings: map[string]GetBinding{
+				"my_project": {Key: "my-alm", Alm: "gitlab", Repository: "1234"},
+			},
+			wantErr: "can only be toggled for GitHub bindings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockSonarQube(t)
+			mockBindingAPI(mock, "github", tt.bindings)
+			r := resourceSonarqubePullRequestDecoration()
+
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"project":                 "my_project",
+				"summary_comment_enabled": false,
+			})
+			err := r.Create(d, mock.conf("Developer", "10.7"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %s", err, tt.wantErr)
+			}
+			if d.Id() != "" {
+				t.Errorf("Create() ID = %s, want no ID", d.Id())
+			}
+		})
+	}
+}
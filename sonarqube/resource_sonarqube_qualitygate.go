@@ -1,15 +1,18 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"net/url"
 	"sort"
 	"strings"
 	"sync"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -56,20 +59,56 @@ func resourceSonarqubeQualityGate() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeQualityGateImport,
 		},
+		// The organization can only be set with SonarCloud
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateResourceOrganization(d, meta)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
+			"organization": organizationSchema(),
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
 				Description: "The name of the Quality Gate to create. Maximum length 100.",
 			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the page of the Quality Gate in the SonarQube UI.",
+			},
 			"copy_from": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				ForceNew:      true,
-				ConflictsWith: []string{"condition"},
-				Description:   "Name of an existing Quality Gate to copy from.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name of an existing Quality Gate to copy from. Without `condition` blocks, the conditions of the copy are not managed. With `condition` blocks, they are merged over the copied conditions by metric to tweak a golden gate per team: a block adds a condition or overrides the copied condition of its metric, and the other copied conditions are kept. Removing a block restores the condition of the source gate, or deletes the condition when the source gate has none.",
+			},
+			"source_conditions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The current conditions of the `copy_from` Quality Gate, to detect when the source has changed since the copy.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"metric": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"op": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"threshold": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 			"is_default": {
 				Type:        schema.TypeBool,
@@ -126,6 +165,7 @@ func resourceSonarqubeQualityGate() *schema.Resource {
 }
 
 func resourceSonarqubeQualityGateCreate(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 
 	copying_gate := false
@@ -173,9 +213,9 @@ func resourceSonarqubeQualityGateCreate(d *schema.ResourceData, m interface{}) e
 	}
 
 	// SonarQube 9.9 and above will automatically create "Clean as you code" conditions for new quality gates
-	// If we are not copying a gate then we need to synchronise the conditions from the newly created gate with
-	// the ones declared on the terraform resource
-	if !copying_gate {
+	// If we are not copying a gate, or the copy declares its own conditions, then we need to synchronise the conditions
+	// from the newly created gate with the ones declared on the terraform resource
+	if _, hasConditions := d.GetOk("condition"); !copying_gate || hasConditions {
 		changes, err := synchronizeConditions(d, m, &qualityGateReadResponse.Conditions)
 		if err != nil {
 			return fmt.Errorf("resourceSonarqubeQualityGateCreate: Failed to synchronise quality gate conditions: %+v", err)
@@ -196,15 +236,22 @@ func resourceSonarqubeQualityGateCreate(d *schema.ResourceData, m interface{}) e
 		}
 	}
 
-	return updateResourceDataFromQualityGateReadResponse(d, qualityGateReadResponse)
+	if err := updateResourceDataFromQualityGateReadResponse(d, qualityGateReadResponse, m); err != nil {
+		return err
+	}
+	return setQualityGateSourceConditions(d, m)
 }
 
 func resourceSonarqubeQualityGateRead(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	qualityGateReadResponse, err := readQualityGateFromApi(d, m)
 	if err != nil {
 		return err
 	}
-	if err := updateResourceDataFromQualityGateReadResponse(d, qualityGateReadResponse); err != nil {
+	if err := updateResourceDataFromQualityGateReadResponse(d, qualityGateReadResponse, m); err != nil {
+		return err
+	}
+	if err := setQualityGateSourceConditions(d, m); err != nil {
 		return err
 	}
 	// Api returns if true if set as default is available. when is_default=true setAsDefault=false so is_default=true
@@ -214,9 +261,11 @@ func resourceSonarqubeQualityGateRead(d *schema.ResourceData, m interface{}) err
 var lock_update_default sync.Mutex
 
 func resourceSonarqubeQualityGateUpdate(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	_, copied_gate := d.GetOk("copy_from")
+	_, has_conditions := d.GetOk("condition")
 
-	if _, has_conditions := d.GetOk("condition"); !(copied_gate || has_conditions) {
+	if !(copied_gate || has_conditions) {
 		return fmt.Errorf("resourceQualityGateCreate: either copy_from or at least one condition block must be specified for a quality gate")
 	}
 
@@ -235,8 +284,9 @@ func resourceSonarqubeQualityGateUpdate(d *schema.ResourceData, m interface{}) e
 
 	conditionsChanged := false
 
-	// We only need to update the conditions if this is not a copied gate - they will still exist from when it was created originally
-	if !copied_gate {
+	// We only need to update the conditions if this is not a copied gate, or a copied gate that declares its own conditions.
+	// The conditions of other copied gates will still exist from when it was created originally
+	if !copied_gate || has_conditions {
 		conditionsChanged, err = synchronizeConditions(d, m, &qualityGateReadResponse.Conditions)
 		if err != nil {
 			return fmt.Errorf("resourceSonarqubeQualityGateUpdate: Failed to synchronise quality gate conditions: %+v", err)
@@ -270,10 +320,14 @@ func resourceSonarqubeQualityGateUpdate(d *schema.ResourceData, m interface{}) e
 		}
 	}
 
-	return updateResourceDataFromQualityGateReadResponse(d, qualityGateReadResponse)
+	if err := updateResourceDataFromQualityGateReadResponse(d, qualityGateReadResponse, m); err != nil {
+		return err
+	}
+	return setQualityGateSourceConditions(d, m)
 }
 
 func resourceSonarqubeQualityGateDelete(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/destroy"
 
@@ -340,11 +394,15 @@ func setDefaultQualityGate(d *schema.ResourceData, m interface{}, setDefault boo
 }
 
 func readQualityGateFromApi(d *schema.ResourceData, m interface{}) (*GetQualityGate, error) {
+	return readQualityGateByNameFromApi(d.Id(), m)
+}
+
+func readQualityGateByNameFromApi(name string, m interface{}) (*GetQualityGate, error) {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/show"
 
 	sonarQubeURL.RawQuery = url.Values{
-		"name": []string{d.Id()},
+		"name": []string{name},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -394,8 +452,14 @@ func synchronizeConditions(d *schema.ResourceData, m interface{}, apiQualityGate
 		}
 	}
 
-	// Determine if any conditions have been removed and delete them
-	err := removeDeletedConditions(apiQualityGateConditions, qualityGateConditions, m, &changed)
+	// Determine if any conditions have been removed and delete them. A copied gate keeps the conditions of its source
+	// that are not configured.
+	var err error
+	if source, copiedGate := d.GetOk("copy_from"); copiedGate {
+		err = restoreRemovedConditions(d, source.(string), apiQualityGateConditions, qualityGateConditions, m, &changed)
+	} else {
+		err = removeDeletedConditions(apiQualityGateConditions, qualityGateConditions, m, &changed)
+	}
 	if err != nil {
 		return changed, err
 	}
@@ -457,17 +521,101 @@ func removeDeletedConditions(apiQualityGateConditions *[]ReadQualityGateConditio
 	return nil
 }
 
-func updateResourceDataFromQualityGateReadResponse(d *schema.ResourceData, qualityGateReadResponse *GetQualityGate) error {
+// restoreRemovedConditions resets the conditions removed from the configuration of a copied gate to the condition of
+// the source gate, or deletes them when the source gate has no condition on their metric
+func restoreRemovedConditions(d *schema.ResourceData, source string, apiQualityGateConditions *[]ReadQualityGateConditionsResponse, qualityGateConditions []interface{}, m interface{}, changed *bool) error {
+	previousConditions, _ := d.GetChange("condition")
+	removed := []ReadQualityGateConditionsResponse{}
+	for _, apiCondition := range *apiQualityGateConditions {
+		if conditionsHaveMetric(previousConditions.([]interface{}), apiCondition.Metric) && !conditionsHaveMetric(qualityGateConditions, apiCondition.Metric) {
+			removed = append(removed, apiCondition)
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	sourceGate, err := readQualityGateByNameFromApi(source, m)
+	if err != nil {
+		return fmt.Errorf("restoreRemovedConditions: Failed to read the source quality gate '%s': %+v", source, err)
+	}
+	for _, apiCondition := range removed {
+		restored := false
+		for _, sourceCondition := range sourceGate.Conditions {
+			if sourceCondition.Metric != apiCondition.Metric {
+				continue
+			}
+			if sourceCondition.OP != apiCondition.OP || sourceCondition.Error != apiCondition.Error {
+				if err := updateCondition(apiCondition.ID, sourceCondition.Metric, sourceCondition.OP, sourceCondition.Error, m); err != nil {
+					return fmt.Errorf("restoreRemovedConditions: Failed to restore condition '%s': %+v", apiCondition.Metric, err)
+				}
+				*changed = true
+			}
+			restored = true
+			break
+		}
+		if !restored {
+			if err := deleteCondition(apiCondition.ID, m); err != nil {
+				return fmt.Errorf("restoreRemovedConditions: Failed to delete condition '%s': %+v", apiCondition.Metric, err)
+			}
+			*changed = true
+		}
+	}
+	return nil
+}
+
+func conditionsHaveMetric(conditions []interface{}, metric string) bool {
+	for _, condition := range conditions {
+		if condition.(map[string]interface{})["metric"] == metric {
+			return true
+		}
+	}
+	return false
+}
+
+func updateResourceDataFromQualityGateReadResponse(d *schema.ResourceData, qualityGateReadResponse *GetQualityGate, m interface{}) error {
 	d.SetId(qualityGateReadResponse.Name)
 	errs := []error{}
 	errs = append(errs, d.Set("name", qualityGateReadResponse.Name))
-	// Copied gates do not have condition blocks so we don't want to populate from the API.
-	if _, copiedGate := d.GetOk("copy_from"); !copiedGate {
+	// SonarCloud shows the quality gates by ID under the organization
+	webPath := "/quality_gates/show/" + qualityGateReadResponse.Name
+	if organization := resourceOrganization(d, m); organization != "" {
+		webPath = "/organizations/" + organization + "/quality_gates/show/" + qualityGateReadResponse.ID
+	}
+	errs = append(errs, d.Set("url", sonarQubeWebURL(m.(*ProviderConfiguration), webPath, nil)))
+	// Copied gates without condition blocks do not manage their conditions so we don't want to populate from the API.
+	// Copied gates with condition blocks only manage the conditions of the configured metrics.
+	_, copiedGate := d.GetOk("copy_from")
+	conditions, hasConditions := d.GetOk("condition")
+	if !copiedGate {
 		errs = append(errs, d.Set("condition", flattenReadQualityGateConditionsResponse(&qualityGateReadResponse.Conditions)))
+	} else if hasConditions {
+		configured := []ReadQualityGateConditionsResponse{}
+		for _, apiCondition := range qualityGateReadResponse.Conditions {
+			if conditionsHaveMetric(conditions.([]interface{}), apiCondition.Metric) {
+				configured = append(configured, apiCondition)
+			}
+		}
+		errs = append(errs, d.Set("condition", flattenReadQualityGateConditionsResponse(&configured)))
 	}
 	return errors.Join(errs...)
 }
 
+// setQualityGateSourceConditions records the current conditions of the copy_from quality gate
+func setQualityGateSourceConditions(d *schema.ResourceData, m interface{}) error {
+	source, ok := d.GetOk("copy_from")
+	if !ok {
+		return d.Set("source_conditions", []interface{}{})
+	}
+
+	sourceGate, err := readQualityGateByNameFromApi(source.(string), m)
+	if err != nil {
+		log.Printf("[WARN][setQualityGateSourceConditions] Failed to read the source quality gate '%s' of '%s': %+v", source, d.Id(), err)
+		return d.Set("source_conditions", []interface{}{})
+	}
+	return d.Set("source_conditions", flattenReadQualityGateConditionsResponse(&sourceGate.Conditions))
+}
+
 func createCondition(qualityGateName string, metric string, op string, threshold string, m interface{}) (string, error) {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/create_condition"
@@ -23,7 +23,7 @@ type GetQualityGateAssociation struct {
 // Returns the resource represented by this file.
 func resourceSonarqubeQualityGateProjectAssociation() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a Sonarqube Quality Gate Project association resource. This can be used to associate a Quality Gate to a Project",
+		Description: "Provides a Sonarqube Quality Gate Project association resource. This can be used to associate a Quality Gate to a Project. It supports importing using the format '{gatename}/{projectkey}'.",
 		Create:      resourceSonarqubeQualityGateProjectAssociationCreate,
 		Read:        resourceSonarqubeQualityGateProjectAssociationRead,
 		Delete:      resourceSonarqubeQualityGateProjectAssociationDelete,
@@ -82,12 +82,15 @@ func resourceSonarqubeQualityGateProjectAssociationCreate(d *schema.ResourceData
 }
 
 func resourceSonarqubeQualityGateProjectAssociationRead(d *schema.ResourceData, m interface{}) error {
-	idSlice := strings.Split(d.Id(), "/")
+	parts, err := parseImportID(d.Id(), "{gatename}/{projectkey}")
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityGateProjectAssociationRead: %+v", err)
+	}
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/get_by_project"
 
 	sonarQubeURL.RawQuery = url.Values{
-		"project": []string{idSlice[1]},
+		"project": []string{parts[1]},
 	}.Encode()
 
 	resp, err := httpRequestHelper(
@@ -109,7 +112,7 @@ func resourceSonarqubeQualityGateProjectAssociationRead(d *schema.ResourceData,
 		return fmt.Errorf("resourceSonarqubeQualityGateProjectAssociationRead: Failed to decode json into struct: %+v", err)
 	}
 
-	errKey := d.Set("projectkey", idSlice[1])
+	errKey := d.Set("projectkey", parts[1])
 	errName := d.Set("gatename", qualityGateAssociationReadResponse.QualityGate.Name)
 	return errors.Join(errKey, errName)
 }
@@ -139,8 +142,65 @@ func resourceSonarqubeQualityGateProjectAssociationDelete(d *schema.ResourceData
 }
 
 func resourceSonarqubeQualityGateProjectAssociationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseImportID(d.Id(), "{gatename}/{projectkey}"); err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeQualityGateProjectAssociationImport: %+v", err)
+	}
 	if err := resourceSonarqubeQualityGateProjectAssociationRead(d, m); err != nil {
 		return nil, err
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// selectProjectQualityGate associates the quality gate with the project
+func selectProjectQualityGate(project string, gate string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/select"
+	sonarQubeURL.RawQuery = url.Values{
+		"gateName":   []string{gate},
+		"projectKey": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"selectProjectQualityGate",
+	)
+	if err != nil {
+		return fmt.Errorf("selectProjectQualityGate: Failed to associate quality gate '%s' with project '%s': %+v", gate, project, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// readProjectQualityGate returns the name of the quality gate of the project
+func readProjectQualityGate(project string, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualitygates/get_by_project"
+	sonarQubeURL.RawQuery = url.Values{
+		"project": []string{project},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readProjectQualityGate",
+	)
+	if err != nil {
+		return "", fmt.Errorf("readProjectQualityGate: Failed to read the quality gate of project '%s': %+v", project, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	association := GetQualityGateAssociation{}
+	err = json.NewDecoder(resp.Body).Decode(&association)
+	if err != nil {
+		return "", fmt.Errorf("readProjectQualityGate: Failed to decode json into struct: %+v", err)
+	}
+
+	return association.QualityGate.Name, nil
+}
@@ -1,6 +1,7 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -11,6 +12,8 @@ import (
 	"testing"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
@@ -277,6 +280,79 @@ func TestAccSonarqubeQualitygateCopy(t *testing.T) {
 	})
 }
 
+func testAccSonarqubeQualitygateCopyWithConditionsConfig(rnd string, baseName string, copyName string, threshold string) string {
+	return fmt.Sprintf(`
+	resource "sonarqube_qualitygate" "%[2]s" {
+		name = "%[2]s"
+
+		condition {
+			metric    = "comment_lines_density"
+			threshold = "68"
+			op        = "LT"
+		}
+	}
+
+	resource "sonarqube_qualitygate" "%[1]s" {
+		name      = "%[3]s"
+		copy_from = sonarqube_qualitygate.%[2]s.name
+
+		condition {
+			metric    = "new_coverage"
+			threshold = "%[4]s"
+			op        = "LT"
+		}
+	}`, rnd, baseName, copyName, threshold)
+}
+
+// Copy a quality gate and tweak its conditions, the conditions of the source stay available
+func TestAccSonarqubeQualitygateCopyWithConditions(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualitygate." + rnd
+
+	baseGateName := "baseGateWithConditions"
+	copyName := baseGateName + "-copy"
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualitygateCopyWithConditionsConfig(rnd, baseGateName, copyName, "80"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "condition.#", "1"),
+					resource.TestCheckResourceAttr(name, "condition.0.metric", "new_coverage"),
+					resource.TestCheckResourceAttr(name, "source_conditions.#", "1"),
+					resource.TestCheckResourceAttr(name, "source_conditions.0.metric", "comment_lines_density"),
+					checkQualityGateHasCondition(copyName, "comment_lines_density"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualitygateCopyWithConditionsConfig(rnd, baseGateName, copyName, "90"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "condition.0.threshold", "90"),
+				),
+			},
+		},
+	})
+}
+
+// checkQualityGateHasCondition checks that the quality gate has a condition on the metric, including the conditions
+// that are not managed by the resource
+func checkQualityGateHasCondition(gateName string, metric string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conf := testAccProvider.Meta().(*ProviderConfiguration)
+		gate, err := readQualityGateByNameFromApi(gateName, conf)
+		if err != nil {
+			return fmt.Errorf("checkQualityGateHasCondition: %+v", err)
+		}
+		for _, condition := range gate.Conditions {
+			if condition.Metric == metric {
+				return nil
+			}
+		}
+		return fmt.Errorf("checkQualityGateHasCondition: quality gate '%s' has no condition on '%s': %+v", gateName, metric, gate.Conditions)
+	}
+}
+
 func checkSonarWayIsDefault(s *terraform.State) error {
 
 	sonarQubeURL := fmt.Sprintf("%[1]s/api/qualitygates/show?name=Sonar%%20way", strings.TrimSuffix(os.Getenv("SONAR_HOST"), "/"))
@@ -310,3 +386,134 @@ func checkSonarWayIsDefault(s *terraform.State) error {
 	}
 	return nil
 }
+
+func TestResourceSonarqubeQualityGateURL(t *testing.T) {
+	tests := []struct {
+		name         string
+		organization string
+		want         string
+	}{
+		{"sonarqube", "", "/quality_gates/show/Team%20Gate"},
+		{"sonarcloud", "my-org", "/organizations/my-org/quality_gates/show/42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockSonarQube(t)
+			mock.respond("GET", "/api/qualitygates/show", http.StatusOK, `{"id": "42", "name": "Team Gate", "conditions": [], "actions": {"setAsDefault": true}}`)
+			conf := mock.conf("Community", "10.7")
+			conf.sonarCloudOrganization = tt.organization
+			r := resourceSonarqubeQualityGate()
+
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "Team Gate"})
+			d.SetId("Team Gate")
+			if err := r.Read(d, conf); err != nil {
+				t.Fatalf("Read() unexpected error = %v", err)
+			}
+			if got := d.Get("url").(string); got != mock.server.URL+tt.want {
+				t.Errorf("Read() url = %s, want %s", got, mock.server.URL+tt.want)
+			}
+		})
+	}
+}
+
+// mockQualityGateAPI serves the quality gates of the map from the mock, keyed by name
+func mockQualityGateAPI(mock *mockSonarQube, gates map[string][]ReadQualityGateConditionsResponse) {
+	nextID := 100
+	mock.handle("GET", "/api/qualitygates/show", func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		mockJSON(w, GetQualityGate{Name: name, Conditions: gates[name], Actions: QualityGateActions{SetAsDefault: true}})
+	})
+	mock.handle("POST", "/api/qualitygates/copy", func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Query().Get("name")
+		for _, condition := range gates[r.URL.Query().Get("sourceName")] {
+			nextID++
+			condition.ID = strconv.Itoa(nextID)
+			gates[name] = append(gates[name], condition)
+		}
+		mockJSON(w, CreateQualityGateResponse{Name: name})
+	})
+	mock.handle("POST", "/api/qualitygates/create_condition", func(w http.ResponseWriter, r *http.Request) {
+		nextID++
+		query := r.URL.Query()
+		condition := ReadQualityGateConditionsResponse{ID: strconv.Itoa(nextID), Metric: query.Get("metric"), OP: query.Get("op"), Error: query.Get("error")}
+		gates[query.Get("gateName")] = append(gates[query.Get("gateName")], condition)
+		mockJSON(w, condition)
+	})
+	mock.handle("POST", "/api/qualitygates/update_condition", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		for _, conditions := range gates {
+			for i := range conditions {
+				if conditions[i].ID == query.Get("id") {
+					conditions[i].OP, conditions[i].Error = query.Get("op"), query.Get("error")
+				}
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mock.handle("POST", "/api/qualitygates/delete_condition", func(w http.ResponseWriter, r *http.Request) {
+		for name, conditions := range gates {
+			kept := []ReadQualityGateConditionsResponse{}
+			for _, condition := range conditions {
+				if condition.ID != r.URL.Query().Get("id") {
+					kept = append(kept, condition)
+				}
+			}
+			gates[name] = kept
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// The conditions of a copied gate are merged over the conditions of the source by metric
+func TestResourceSonarqubeQualityGateCopyMergeConditions(t *testing.T) {
+	mock := newMockSonarQube(t)
+	gates := map[string][]ReadQualityGateConditionsResponse{
+		"Golden": {
+			{ID: "1", Metric: "comment_lines_density", OP: "LT", Error: "68"},
+			{ID: "2", Metric: "new_coverage", OP: "LT", Error: "70"},
+		},
+	}
+	mockQualityGateAPI(mock, gates)
+	conf := mock.conf("Community", "10.7")
+	r := resourceSonarqubeQualityGate()
+
+	condition := func(metric string, threshold string) map[string]interface{} {
+		return map[string]interface{}{"metric": metric, "op": "LT", "threshold": threshold}
+	}
+	raw := map[string]interface{}{
+		"name":      "Team",
+		"copy_from": "Golden",
+		"condition": []interface{}{condition("new_coverage", "80")},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	if err := r.Create(d, conf); err != nil {
+		t.Fatalf("Create() error = %+v", err)
+	}
+	thresholds := func() map[string]string {
+		result := map[string]string{}
+		for _, condition := range gates["Team"] {
+			result[condition.Metric] = condition.Error
+		}
+		return result
+	}
+	if got := thresholds(); len(got) != 2 || got["comment_lines_density"] != "68" || got["new_coverage"] != "80" {
+		t.Errorf("Create() conditions = %v, want the copied comment_lines_density and new_coverage overridden to 80", got)
+	}
+	if got := d.Get("condition").([]interface{}); len(got) != 1 {
+		t.Errorf("Create() condition = %v, want only the configured condition", got)
+	}
+
+	// Removing the block restores the condition of the source
+	raw["condition"] = []interface{}{condition("new_duplicated_lines_density", "3")}
+	diff, err := r.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(raw), conf)
+	if err != nil {
+		t.Fatalf("Diff() error = %+v", err)
+	}
+	if _, err := r.Apply(context.Background(), d.State(), diff, conf); err != nil {
+		t.Fatalf("Apply() error = %+v", err)
+	}
+	if got := thresholds(); len(got) != 3 || got["comment_lines_density"] != "68" || got["new_coverage"] != "70" || got["new_duplicated_lines_density"] != "3" {
+		t.Errorf("Update() conditions = %v, want new_coverage restored to 70", got)
+	}
+}
@@ -38,18 +38,20 @@ The feature is available on SonarQube 9.2 or newer.`,
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
 			"login_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ExactlyOneOf: []string{"login_name", "group_name"},
-				Description:  "The name of the User to associate. Either `group_name` or `login_name` should be provided.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"login_name", "group_name"},
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The name of the User to associate. Either `group_name` or `login_name` should be provided.",
 			},
 			"group_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ForceNew:     true,
-				ExactlyOneOf: []string{"login_name", "group_name"},
-				Description:  "The name of the Group to associate. Either `group_name` or `login_name` should be provided.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"login_name", "group_name"},
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The name of the Group to associate. Either `group_name` or `login_name` should be provided.",
 			},
 			"gatename": {
 				Type:        schema.TypeString,
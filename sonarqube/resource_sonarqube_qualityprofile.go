@@ -1,6 +1,7 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -61,16 +63,26 @@ type GetQualityProfileActions struct {
 // Returns the resource represented by this file.
 func resourceSonarqubeQualityProfile() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a Sonarqube Quality Profile resource. This can be used to create and manage Sonarqube Quality Profiles.",
+		Description: "Provides a Sonarqube Quality Profile resource. This can be used to create and manage Sonarqube Quality Profiles. It supports importing using the format '{language}/{name}', or the key of the Quality Profile as in previous versions.",
 		Create:      resourceSonarqubeQualityProfileCreate,
 		Read:        resourceSonarqubeQualityProfileRead,
 		Delete:      resourceSonarqubeQualityProfileDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeQualityProfileImport,
 		},
+		// Validate the language against the languages of the installed plugins
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateQualityProfileLanguage(d, meta)
+			},
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateResourceOrganization(d, meta)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
+			"organization": organizationSchema(),
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -80,6 +92,11 @@ func resourceSonarqubeQualityProfile() *schema.Resource {
 					validation.StringLenBetween(0, 100),
 				),
 			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the page of the Quality Profile in the SonarQube UI.",
+			},
 			"key": {
 				Type:        schema.TypeString,
 				Description: "ID of the Sonarqube Quality Profile",
@@ -89,7 +106,7 @@ func resourceSonarqubeQualityProfile() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "Quality profile language. Must be one of \"cs\", \"css\", \"flex\", \"go\", \"java\", \"js\", \"jsp\", \"kotlin\", \"php\", \"py\", \"ruby\", \"scala\", \"ts\", \"vbnet\", \"web\", \"xml\"",
+				Description: "Quality profile language, for example \"java\" or \"py\". Must be one of the languages of the installed plugins, see the `sonarqube_languages` data source.",
 			},
 			"is_default": {
 				Type:        schema.TypeBool,
@@ -108,7 +125,31 @@ func resourceSonarqubeQualityProfile() *schema.Resource {
 	}
 }
 
+// validateQualityProfileLanguage checks at plan time that the server supports the language of a new quality profile
+func validateQualityProfileLanguage(d *schema.ResourceDiff, meta interface{}) error {
+	conf, ok := meta.(*ProviderConfiguration)
+	if !ok || !d.HasChange("language") || !d.NewValueKnown("language") {
+		return nil
+	}
+
+	languages, err := readLanguagesFromApi("", conf)
+	if err != nil {
+		return err
+	}
+
+	language := d.Get("language").(string)
+	keys := make([]string, 0, len(languages.Languages))
+	for _, l := range languages.Languages {
+		if l.Key == language {
+			return nil
+		}
+		keys = append(keys, l.Key)
+	}
+	return fmt.Errorf("language '%s' is not supported by this SonarQube server. Supported languages are: %s", language, strings.Join(keys, ", "))
+}
+
 func resourceSonarqubeQualityProfileCreate(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/create"
 
@@ -152,6 +193,7 @@ func resourceSonarqubeQualityProfileCreate(d *schema.ResourceData, m interface{}
 }
 
 func resourceSonarqubeQualityProfileRead(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
 
@@ -182,6 +224,14 @@ func resourceSonarqubeQualityProfileRead(d *schema.ResourceData, m interface{})
 			errs = append(errs, d.Set("language", value.Language))
 			errs = append(errs, d.Set("key", value.Key))
 			errs = append(errs, d.Set("is_default", value.IsDefault))
+			webPath := "/profiles/show"
+			if organization := resourceOrganization(d, m); organization != "" {
+				webPath = "/organizations/" + organization + "/quality_profiles/show"
+			}
+			errs = append(errs, d.Set("url", sonarQubeWebURL(m.(*ProviderConfiguration), webPath, url.Values{
+				"language": []string{value.Language},
+				"name":     []string{value.Name},
+			})))
 			return errors.Join(errs...)
 		}
 	}
@@ -190,6 +240,7 @@ func resourceSonarqubeQualityProfileRead(d *schema.ResourceData, m interface{})
 }
 
 func resourceSonarqubeQualityProfileDelete(d *schema.ResourceData, m interface{}) error {
+	m = withResourceOrganization(d, m)
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/delete"
 
@@ -219,12 +270,73 @@ func resourceSonarqubeQualityProfileDelete(d *schema.ResourceData, m interface{}
 }
 
 func resourceSonarqubeQualityProfileImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := setQualityProfileImportID(d, m); err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileImport: %+v", err)
+	}
 	if err := resourceSonarqubeQualityProfileRead(d, m); err != nil {
 		return nil, err
 	}
 	return []*schema.ResourceData{d}, nil
 }
 
+// setQualityProfileImportID replaces an import ID in the format '{language}/{name}' with the key of the quality profile,
+// which is the ID of the quality profile resources. An ID without "/" is the key itself, the format of older versions.
+func setQualityProfileImportID(d *schema.ResourceData, m interface{}) error {
+	if !strings.Contains(d.Id(), "/") {
+		return nil
+	}
+	parts, err := parseImportID(d.Id(), "{language}/{name}")
+	if err != nil {
+		return err
+	}
+
+	profile, err := findQualityProfileFromApi(parts[0], parts[1], m)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return fmt.Errorf("quality profile '%s' for language '%s' not found", parts[1], parts[0])
+	}
+	d.SetId(profile.Key)
+	return nil
+}
+
+// findQualityProfileFromApi returns the quality profile with the name for the language, or nil when it does not exist
+func findQualityProfileFromApi(language string, name string, m interface{}) (*GetQualityProfile, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/search"
+	sonarQubeURL.RawQuery = url.Values{
+		"language":       []string{language},
+		"qualityProfile": []string{name},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"findQualityProfileFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("findQualityProfileFromApi: Failed to search quality profiles: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	profiles := GetQualityProfileList{}
+	err = json.NewDecoder(resp.Body).Decode(&profiles)
+	if err != nil {
+		return nil, fmt.Errorf("findQualityProfileFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, profile := range profiles.Profiles {
+		if profile.Language == language && profile.Name == name {
+			return &profile, nil
+		}
+	}
+	return nil, nil
+}
+
 func setDefaultQualityProfile(d *schema.ResourceData, m interface{}, setDefault bool) error {
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/set_default"
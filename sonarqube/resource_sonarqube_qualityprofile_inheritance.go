@@ -0,0 +1,193 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// QualityProfileInheritanceEntry is a profile of the response of api/qualityprofiles/inheritance
+type QualityProfileInheritanceEntry struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Parent string `json:"parent"`
+}
+
+// GetQualityProfileInheritance for unmarshalling response body of api/qualityprofiles/inheritance
+type GetQualityProfileInheritance struct {
+	Profile   QualityProfileInheritanceEntry   `json:"profile"`
+	Ancestors []QualityProfileInheritanceEntry `json:"ancestors"`
+	Children  []QualityProfileInheritanceEntry `json:"children"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeQualityProfileInheritance() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Quality Profile Inheritance resource. This sets the parent of a quality profile with api/qualityprofiles/change_parent
+and enforces it: when the profile is detached from its parent or attached to another one outside of Terraform, the next apply sets the parent again.
+Destroying this resource detaches the profile from its parent. It supports importing using the format '{language}/{quality_profile}'.`,
+		Create: resourceSonarqubeQualityProfileInheritanceCreate,
+		Read:   resourceSonarqubeQualityProfileInheritanceRead,
+		Update: resourceSonarqubeQualityProfileInheritanceUpdate,
+		Delete: resourceSonarqubeQualityProfileInheritanceDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeQualityProfileInheritanceImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"quality_profile": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the quality profile. Changing this forces a new resource to be created.",
+			},
+			"language": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The language of the quality profile and its parent. Changing this forces a new resource to be created.",
+			},
+			"parent": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the parent quality profile, for example `Sonar way`.",
+			},
+			"parent_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key of the parent quality profile.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeQualityProfileInheritanceCreate(d *schema.ResourceData, m interface{}) error {
+	profile := d.Get("quality_profile").(string)
+	language := d.Get("language").(string)
+
+	if err := changeQualityProfileParent(profile, language, d.Get("parent").(string), m); err != nil {
+		return err
+	}
+
+	d.SetId(language + "/" + profile)
+
+	return resourceSonarqubeQualityProfileInheritanceRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileInheritanceRead(d *schema.ResourceData, m interface{}) error {
+	profile := d.Get("quality_profile").(string)
+	language := d.Get("language").(string)
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/inheritance"
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile": []string{profile},
+		"language":       []string{language},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"resourceSonarqubeQualityProfileInheritanceRead",
+	)
+	if err != nil {
+		if resp.StatusCode == http.StatusNotFound {
+			log.Printf("[WARN][resourceSonarqubeQualityProfileInheritanceRead] Quality profile '%s' for language '%s' not found, removing it from the state", profile, language)
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	inheritance := GetQualityProfileInheritance{}
+	err = json.NewDecoder(resp.Body).Decode(&inheritance)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeQualityProfileInheritanceRead: Failed to decode json into struct: %+v", err)
+	}
+
+	// A detached profile has no parent, which shows as a change of parent in the next plan
+	parent := QualityProfileInheritanceEntry{}
+	for _, ancestor := range inheritance.Ancestors {
+		if ancestor.Key == inheritance.Profile.Parent {
+			parent = ancestor
+			break
+		}
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("parent", parent.Name))
+	errs = append(errs, d.Set("parent_key", parent.Key))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeQualityProfileInheritanceUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("parent") {
+		if err := changeQualityProfileParent(d.Get("quality_profile").(string), d.Get("language").(string), d.Get("parent").(string), m); err != nil {
+			return err
+		}
+	}
+
+	return resourceSonarqubeQualityProfileInheritanceRead(d, m)
+}
+
+func resourceSonarqubeQualityProfileInheritanceDelete(d *schema.ResourceData, m interface{}) error {
+	return changeQualityProfileParent(d.Get("quality_profile").(string), d.Get("language").(string), "", m)
+}
+
+func resourceSonarqubeQualityProfileInheritanceImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts, err := parseImportID(d.Id(), "{language}/{quality_profile}")
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileInheritanceImport: %+v", err)
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("language", parts[0]))
+	errs = append(errs, d.Set("quality_profile", parts[1]))
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if err := resourceSonarqubeQualityProfileInheritanceRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileInheritanceImport: quality profile '%s' for language '%s' not found", parts[1], parts[0])
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// changeQualityProfileParent sets the parent of a quality profile, an empty parent detaches the profile
+func changeQualityProfileParent(profile string, language string, parent string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/qualityprofiles/change_parent"
+	sonarQubeURL.RawQuery = url.Values{
+		"qualityProfile":       []string{profile},
+		"language":             []string{language},
+		"parentQualityProfile": []string{parent},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"changeQualityProfileParent",
+	)
+	if err != nil {
+		return fmt.Errorf("changeQualityProfileParent: Failed to set the parent of quality profile '%s' to '%s': %+v", profile, parent, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeQualityProfileInheritanceConfig(rnd string, name string, parent string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_qualityprofile" "%[1]s" {
+			name     = "%[2]s"
+			language = "js"
+		}
+
+		resource "sonarqube_qualityprofile" "%[1]s_parent" {
+			name     = "%[2]s-parent"
+			language = "js"
+		}
+
+		resource "sonarqube_qualityprofile_inheritance" "%[1]s" {
+			quality_profile = sonarqube_qualityprofile.%[1]s.name
+			language        = "js"
+			parent          = %[3]s
+		}`, rnd, name, parent)
+}
+
+func TestAccSonarqubeQualityProfileInheritanceBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_qualityprofile_inheritance." + rnd
+	profileName := "testAccSonarqubeQualityProfileInheritance"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeQualityProfileInheritanceConfig(rnd, profileName, `"Sonar way"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "parent", "Sonar way"),
+					resource.TestCheckResourceAttrSet(name, "parent_key"),
+				),
+			},
+			{
+				Config: testAccSonarqubeQualityProfileInheritanceConfig(rnd, profileName, fmt.Sprintf("sonarqube_qualityprofile.%s_parent.name", rnd)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "parent", profileName+"-parent"),
+				),
+			},
+			{
+				// Detaching the profile outside of Terraform is reverted by the next apply
+				PreConfig: func() {
+					if err := changeQualityProfileParent(profileName, "js", "", testAccProvider.Meta()); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccSonarqubeQualityProfileInheritanceConfig(rnd, profileName, fmt.Sprintf("sonarqube_qualityprofile.%s_parent.name", rnd)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "parent", profileName+"-parent"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateId:     "js/" + profileName,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -29,7 +29,7 @@ type GetQualityProfileProjectAssociationResults struct {
 // Returns the resource represented by this file.
 func resourceSonarqubeQualityProfileProjectAssociation() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a Sonarqube Quality Profile Project association resource. This can be used to associate a Quality Profile to a Project",
+		Description: "Provides a Sonarqube Quality Profile Project association resource. This can be used to associate a Quality Profile to a Project. It supports importing using the format '{quality_profile}/{project}/{language}'.",
 		Create:      resourceSonarqubeQualityProfileProjectAssociationCreate,
 		Read:        resourceSonarqubeQualityProfileProjectAssociationRead,
 		Delete:      resourceSonarqubeQualityProfileProjectAssociationDelete,
@@ -205,6 +205,9 @@ func resourceSonarqubeQualityProfileProjectAssociationDelete(d *schema.ResourceD
 }
 
 func resourceSonarqubeQualityProfileProjectAssociationImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := parseImportID(d.Id(), "{quality_profile}/{project}/{language}"); err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeQualityProfileProjectAssociationImport: %+v", err)
+	}
 	if err := resourceSonarqubeQualityProfileProjectAssociationRead(d, m); err != nil {
 		return nil, err
 	}
@@ -2,6 +2,9 @@ package sonarqube
 
 import (
 	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -39,11 +42,13 @@ func TestAccSonarqubeQualityProfileBasic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfile"),
 					resource.TestCheckResourceAttr(name, "language", "js"),
+					resource.TestCheckResourceAttrSet(name, "url"),
 				),
 			},
 			{
 				ResourceName:      name,
 				ImportState:       true,
+				ImportStateId:     "js/testAccSonarqubeQualityProfile",
 				ImportStateVerify: true,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(name, "name", "testAccSonarqubeQualityProfile"),
@@ -54,3 +59,52 @@ func TestAccSonarqubeQualityProfileBasic(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSonarqubeQualityProfileUnsupportedLanguage(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccSonarqubeQualityProfileBasicConfig(rnd, "testAccSonarqubeQualityProfile", "cobolx"),
+				ExpectError: regexp.MustCompile("language 'cobolx' is not supported by this SonarQube server"),
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeQualityProfileImport(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/qualityprofiles/search", http.StatusOK, `{"profiles":[
+		{"key":"AU-java","name":"my/profile","language":"java"},
+		{"key":"AU-js","name":"my/profile","language":"js"}
+	]}`)
+	r := resourceSonarqubeQualityProfile()
+
+	d := r.TestResourceData()
+	d.SetId("js/my/profile")
+	if _, err := r.Importer.State(d, mock.conf("Community", "10.7")); err != nil {
+		t.Fatalf("Import() error = %+v", err)
+	}
+	if d.Id() != "AU-js" || d.Get("name") != "my/profile" || d.Get("language") != "js" {
+		t.Errorf("Import() ID = %s, name = %v, language = %v, want AU-js, my/profile and js", d.Id(), d.Get("name"), d.Get("language"))
+	}
+
+	// The key is still accepted, as in previous versions
+	d = r.TestResourceData()
+	d.SetId("AU-java")
+	if _, err := r.Importer.State(d, mock.conf("Community", "10.7")); err != nil {
+		t.Fatalf("Import() error = %+v", err)
+	}
+	if d.Id() != "AU-java" || d.Get("language") != "java" {
+		t.Errorf("Import() ID = %s, language = %v, want AU-java and java", d.Id(), d.Get("language"))
+	}
+
+	d = r.TestResourceData()
+	d.SetId("js/")
+	if _, err := r.Importer.State(d, mock.conf("Community", "10.7")); err == nil || !strings.Contains(err.Error(), "is not in the format '{language}/{name}'") {
+		t.Errorf("Import() error = %v, want the import format", err)
+	}
+}
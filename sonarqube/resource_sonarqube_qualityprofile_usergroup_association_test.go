@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -20,12 +19,7 @@ func testSweepSonarqubeQualityprofileUsergroupAssociationSweeper(r string) error
 	return nil
 }
 func testAccPreCheckQualityProfilePermissionFeature(t *testing.T) {
-	sonarQubeVersion := testAccProvider.Meta().(*ProviderConfiguration).sonarQubeVersion
-
-	minimumVersion, _ := version.NewVersion("9.2")
-	if sonarQubeVersion.LessThan(minimumVersion) {
-		t.Skipf("Skipping test of unsupported feature")
-	}
+	testAccPreCheckMinimumVersion(t, "9.2")
 }
 
 func testAccSonarqubeQualityprofileGroupAssociationProfileName(rnd string, name string, language string) string {
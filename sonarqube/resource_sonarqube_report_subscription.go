@@ -0,0 +1,257 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// GetGovernanceReportStatus for unmarshalling response body of api/governance_reports/status
+type GetGovernanceReportStatus struct {
+	CanDownload        bool   `json:"canDownload"`
+	CanSubscribe       bool   `json:"canSubscribe"`
+	Subscribed         bool   `json:"subscribed"`
+	ComponentFrequency string `json:"componentFrequency"`
+	GlobalFrequency    string `json:"globalFrequency"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeReportSubscription() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Report Subscription resource. This can be used to subscribe the user of the provider to the
+PDF report emails of a project or portfolio in the Enterprise and Data Center editions of SonarQube, and to set how often the report is sent.
+The PDF report includes the security reports of the component, such as its OWASP Top 10 and CWE Top 25 categories: SonarQube has no
+separate subscription for them. It supports importing using the format '{component}/[{branch}]'.`,
+		Create: resourceSonarqubeReportSubscriptionCreate,
+		Read:   resourceSonarqubeReportSubscriptionRead,
+		Update: resourceSonarqubeReportSubscriptionUpdate,
+		Delete: resourceSonarqubeReportSubscriptionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeReportSubscriptionImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"component": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the project or portfolio. Changing this forces a new resource to be created.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The branch of the project. Defaults to the main branch. Changing this forces a new resource to be created.",
+			},
+			"frequency": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"Daily", "Weekly", "Monthly"}, false)),
+				Description:      "How often the report is sent for this component. Possible values are `Daily`, `Weekly` and `Monthly`. When not set, the global frequency is used.",
+			},
+		},
+	}
+}
+
+func checkReportSubscriptionSupport(conf *ProviderConfiguration) error {
+	edition := strings.ToLower(conf.sonarQubeEdition)
+	if edition != "enterprise" && edition != "data center" {
+		return fmt.Errorf("report subscriptions are only supported in the Enterprise and Datacenter editions of SonarQube. You are using: SonarQube %s version %s", conf.sonarQubeEdition, conf.sonarQubeVersion)
+	}
+	return nil
+}
+
+func resourceSonarqubeReportSubscriptionCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkReportSubscriptionSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	component := d.Get("component").(string)
+	branch := d.Get("branch").(string)
+	status, err := readReportStatusFromApi(component, branch, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeReportSubscriptionCreate: %+v", err)
+	}
+	// For example when the component is an application, or the user of the provider cannot browse it
+	if !status.CanSubscribe {
+		return fmt.Errorf("resourceSonarqubeReportSubscriptionCreate: the user of the provider cannot subscribe to the report of '%s'", component)
+	}
+	if err := updateReportSubscription("subscribe", component, branch, m); err != nil {
+		return err
+	}
+	if frequency, ok := d.GetOk("frequency"); ok {
+		if err := setReportFrequency(component, branch, frequency.(string), m); err != nil {
+			return err
+		}
+	}
+
+	if branch == "" {
+		d.SetId(component)
+	} else {
+		d.SetId(component + "/" + branch)
+	}
+
+	return resourceSonarqubeReportSubscriptionRead(d, m)
+}
+
+func resourceSonarqubeReportSubscriptionRead(d *schema.ResourceData, m interface{}) error {
+	if err := checkReportSubscriptionSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	component := d.Get("component").(string)
+	branch := d.Get("branch").(string)
+
+	status, err := readReportStatusFromApi(component, branch, m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeReportSubscriptionRead: %+v", err)
+	}
+
+	if !status.Subscribed {
+		log.Printf("[WARN][resourceSonarqubeReportSubscriptionRead] Not subscribed to the report of '%s', removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	frequency := status.ComponentFrequency
+	if frequency == "" {
+		frequency = status.GlobalFrequency
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("frequency", frequency))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeReportSubscriptionUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("frequency") {
+		if err := setReportFrequency(d.Get("component").(string), d.Get("branch").(string), d.Get("frequency").(string), m); err != nil {
+			return err
+		}
+	}
+
+	return resourceSonarqubeReportSubscriptionRead(d, m)
+}
+
+func resourceSonarqubeReportSubscriptionDelete(d *schema.ResourceData, m interface{}) error {
+	return updateReportSubscription("unsubscribe", d.Get("component").(string), d.Get("branch").(string), m)
+}
+
+func resourceSonarqubeReportSubscriptionImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importIdComponents, err := parseImportID(d.Id(), "{component}/[{branch}]")
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeReportSubscriptionImport: %+v", err)
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("component", importIdComponents[0]))
+	errs = append(errs, d.Set("branch", importIdComponents[1]))
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if err := resourceSonarqubeReportSubscriptionRead(d, m); err != nil {
+		return nil, err
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("resourceSonarqubeReportSubscriptionImport: no report subscription found for '%s'", importIdComponents[0])
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// readReportStatusFromApi returns the report status of a component for the user of the provider
+func readReportStatusFromApi(component string, branch string, m interface{}) (*GetGovernanceReportStatus, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/governance_reports/status"
+	RawQuery := url.Values{
+		"componentKey": []string{component},
+	}
+	if branch != "" {
+		RawQuery.Add("branch", branch)
+	}
+	sonarQubeURL.RawQuery = RawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readReportStatusFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readReportStatusFromApi: Failed to read the report status of '%s': %+v", component, err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	status := GetGovernanceReportStatus{}
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	if err != nil {
+		return nil, fmt.Errorf("readReportStatusFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &status, nil
+}
+
+// updateReportSubscription subscribes or unsubscribes the current user to the report of a component
+func updateReportSubscription(action string, component string, branch string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/governance_reports/" + action
+	RawQuery := url.Values{
+		"project": []string{component},
+	}
+	if branch != "" {
+		RawQuery.Add("branch", branch)
+	}
+	sonarQubeURL.RawQuery = RawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"updateReportSubscription",
+	)
+	if err != nil {
+		return fmt.Errorf("error calling %s on the report of '%s': %+v", action, component, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func setReportFrequency(component string, branch string, frequency string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/governance_reports/update_frequency"
+	RawQuery := url.Values{
+		"componentKey": []string{component},
+		"frequency":    []string{frequency},
+	}
+	if branch != "" {
+		RawQuery.Add("branch", branch)
+	}
+	sonarQubeURL.RawQuery = RawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"setReportFrequency",
+	)
+	if err != nil {
+		return fmt.Errorf("error setting the report frequency of '%s': %+v", component, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
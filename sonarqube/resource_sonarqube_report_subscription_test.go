@@ -0,0 +1,79 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccPreCheckReportSubscriptionSupport(t *testing.T) {
+	if err := checkReportSubscriptionSupport(testAccProviderConfiguration(t)); err != nil {
+		t.Skipf("Skipping test of unsupported feature (report subscriptions)")
+	}
+}
+
+func testAccSonarqubeReportSubscriptionConfig(rnd string, projectKey string, frequency string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name       = "%[2]s"
+			project    = "%[2]s"
+			visibility = "public"
+		}
+
+		resource "sonarqube_report_subscription" "%[1]s" {
+			component = sonarqube_project.%[1]s.project
+			frequency = "%[3]s"
+		}`, rnd, projectKey, frequency)
+}
+
+func TestAccSonarqubeReportSubscriptionBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_report_subscription." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckReportSubscriptionSupport(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeReportSubscriptionConfig(rnd, "testAccSonarqubeReportSubscription", "Weekly"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "component", "testAccSonarqubeReportSubscription"),
+					resource.TestCheckResourceAttr(name, "frequency", "Weekly"),
+				),
+			},
+			{
+				Config: testAccSonarqubeReportSubscriptionConfig(rnd, "testAccSonarqubeReportSubscription", "Daily"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "frequency", "Daily"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeReportSubscriptionCannotSubscribe(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/governance_reports/status", http.StatusOK, `{"canDownload":true,"canSubscribe":false,"subscribed":false,"globalFrequency":"Monthly"}`)
+	r := resourceSonarqubeReportSubscription()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"component": "my_application",
+	})
+	err := r.Create(d, mock.conf("Enterprise", "10.7"))
+	if err == nil || !strings.Contains(err.Error(), "cannot subscribe to the report of 'my_application'") {
+		t.Fatalf("Create() error = %v, want an error about the subscription", err)
+	}
+	expected := []string{"GET /api/governance_reports/status?componentKey=my_application"}
+	if got := mock.requests(); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("Create() requests = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,257 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// scannerEngineMinimumVersion is the first version that serves the scanner engine with api/v2/analysis/engine
+const scannerEngineMinimumVersion = "10.6"
+
+// scannerEngineSettings maps the attributes of sonarqube_scanner_engine to their setting keys
+var scannerEngineSettings = []settingAttribute{
+	{attribute: "download_only_required_analyzers", key: "sonar.plugins.downloadOnlyRequired"},
+}
+
+// ScannerEngine for unmarshalling response body of api/v2/analysis/engine
+type ScannerEngine struct {
+	Filename string `json:"filename"`
+	Sha256   string `json:"sha256"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeScannerEngine() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Scanner Engine resource. From SonarQube 10.6, the scanners download the scanner engine and the
+analyzers from the server, so the versions used by CI are those of the server. This resource pins them declaratively: the apply
+fails when the server serves another scanner engine than ` + "`engine_sha256`" + `, or other versions of the analyzers in
+` + "`pinned_analyzers`" + `, for example after an upgrade, and the drift shows in the plans. It also manages whether the scanners
+only download the analyzers of the languages found in the project. SonarQube cannot serve another engine or analyzer version than
+the installed ones: upgrade them on the server, or with ` + "`sonarqube_plugin`" + `, then update the pins. Destroying this resource
+resets the setting to its default value.`,
+		Create: resourceSonarqubeScannerEngineCreate,
+		Read:   resourceSonarqubeScannerEngineRead,
+		Update: resourceSonarqubeScannerEngineUpdate,
+		Delete: resourceSonarqubeScannerEngineDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeScannerEngineImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"download_only_required_analyzers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the scanners only download the analyzers required by the languages of the project (`sonar.plugins.downloadOnlyRequired`). Defaults to `true`.",
+			},
+			"engine_sha256": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				DiffSuppressFunc: suppressCaseDiff,
+				Description:      "The SHA-256 checksum of the scanner engine that must be served. When not set, it is the checksum of the engine served by SonarQube.",
+			},
+			"engine_filename": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The file name of the scanner engine served by SonarQube, which contains its version.",
+			},
+			"pinned_analyzers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The versions of the analyzers that must be installed, keyed by plugin key, as listed in `analyzers`.",
+			},
+			"analyzers": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The versions of all the installed plugins, keyed by plugin key, as returned by api/plugins/installed.",
+			},
+		},
+	}
+}
+
+func checkScannerEngineSupport(conf *ProviderConfiguration) error {
+	if !supportsV2API(conf, scannerEngineMinimumVersion) {
+		return fmt.Errorf("minimum required SonarQube version for serving the scanner engine is %s", scannerEngineMinimumVersion)
+	}
+	return nil
+}
+
+func resourceSonarqubeScannerEngineCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkScannerEnginePins(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeScannerEngineCreate: %+v", err)
+	}
+	if err := setSettingAttributes("", scannerEngineSettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId("scanner_engine")
+
+	return resourceSonarqubeScannerEngineRead(d, m)
+}
+
+func resourceSonarqubeScannerEngineRead(d *schema.ResourceData, m interface{}) error {
+	if err := checkScannerEngineSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+	engine, err := readScannerEngineFromApi(m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeScannerEngineRead: %+v", err)
+	}
+	analyzers, err := readInstalledPluginVersionsFromApi(m)
+	if err != nil {
+		return fmt.Errorf("resourceSonarqubeScannerEngineRead: %+v", err)
+	}
+
+	// The pins are read from the server, so a different version shows as a drift
+	pinnedAnalyzers := map[string]interface{}{}
+	for key := range d.Get("pinned_analyzers").(map[string]interface{}) {
+		pinnedAnalyzers[key] = analyzers[key]
+	}
+	analyzersMap := map[string]interface{}{}
+	for key, version := range analyzers {
+		analyzersMap[key] = version
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("engine_sha256", engine.Sha256))
+	errs = append(errs, d.Set("engine_filename", engine.Filename))
+	errs = append(errs, d.Set("pinned_analyzers", pinnedAnalyzers))
+	errs = append(errs, d.Set("analyzers", analyzersMap))
+	errs = append(errs, readSettingAttributes("", scannerEngineSettings, d, m))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeScannerEngineUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := checkScannerEnginePins(d, m); err != nil {
+		return fmt.Errorf("resourceSonarqubeScannerEngineUpdate: %+v", err)
+	}
+	if err := setSettingAttributes("", scannerEngineSettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeScannerEngineRead(d, m)
+}
+
+func resourceSonarqubeScannerEngineDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes("", scannerEngineSettings, m)
+}
+
+func resourceSonarqubeScannerEngineImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId("scanner_engine")
+	if err := resourceSonarqubeScannerEngineRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// checkScannerEnginePins returns an error listing the pinned engine and analyzers that the server does not serve
+func checkScannerEnginePins(d *schema.ResourceData, m interface{}) error {
+	if err := checkScannerEngineSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	mismatches := []string{}
+	if sha256, ok := d.GetOk("engine_sha256"); ok {
+		engine, err := readScannerEngineFromApi(m)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(engine.Sha256, sha256.(string)) {
+			mismatches = append(mismatches, fmt.Sprintf("the scanner engine is %s with checksum %s, not %s", engine.Filename, engine.Sha256, sha256))
+		}
+	}
+
+	pinned := d.Get("pinned_analyzers").(map[string]interface{})
+	if len(pinned) > 0 {
+		analyzers, err := readInstalledPluginVersionsFromApi(m)
+		if err != nil {
+			return err
+		}
+		for key, version := range pinned {
+			installed, ok := analyzers[key]
+			if !ok {
+				mismatches = append(mismatches, fmt.Sprintf("analyzer '%s' is not installed", key))
+			} else if installed != version.(string) {
+				mismatches = append(mismatches, fmt.Sprintf("analyzer '%s' is at version %s, not %s", key, installed, version))
+			}
+		}
+	}
+
+	if len(mismatches) > 0 {
+		sort.Strings(mismatches)
+		return fmt.Errorf("SonarQube does not serve the pinned versions: %s", strings.Join(mismatches, ", "))
+	}
+	return nil
+}
+
+// readScannerEngineFromApi returns the metadata of the scanner engine served to the scanners
+func readScannerEngineFromApi(m interface{}) (*ScannerEngine, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/analysis/engine"
+
+	resp, err := httpRequestHelperV2(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		nil,
+		http.StatusOK,
+		"readScannerEngineFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readScannerEngineFromApi: Failed to read the scanner engine: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	engine := ScannerEngine{}
+	err = json.NewDecoder(resp.Body).Decode(&engine)
+	if err != nil {
+		return nil, fmt.Errorf("readScannerEngineFromApi: Failed to decode json into struct: %+v", err)
+	}
+	return &engine, nil
+}
+
+// readInstalledPluginVersionsFromApi returns the versions of the installed plugins, keyed by plugin key
+func readInstalledPluginVersionsFromApi(m interface{}) (map[string]string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/plugins/installed"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readInstalledPluginVersionsFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readInstalledPluginVersionsFromApi: Failed to list the installed plugins: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	installedPlugins := GetInstalledPlugins{}
+	err = json.NewDecoder(resp.Body).Decode(&installedPlugins)
+	if err != nil {
+		return nil, fmt.Errorf("readInstalledPluginVersionsFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	versions := map[string]string{}
+	for _, plugin := range installedPlugins.Plugins {
+		versions[plugin.Key] = plugin.Version
+	}
+	return versions, nil
+}
@@ -0,0 +1,117 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeScannerEngineConfig(rnd string, downloadOnlyRequired bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_scanner_engine" "%[1]s" {
+			download_only_required_analyzers = %[2]t
+		}`, rnd, downloadOnlyRequired)
+}
+
+func TestAccSonarqubeScannerEngineBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_scanner_engine." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t)
+			testAccPreCheckMinimumVersion(t, scannerEngineMinimumVersion)
+		},
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeScannerEngineConfig(rnd, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "download_only_required_analyzers", "false"),
+					resource.TestCheckResourceAttrSet(name, "engine_sha256"),
+					resource.TestCheckResourceAttrSet(name, "engine_filename"),
+				),
+			},
+			{
+				Config: testAccSonarqubeScannerEngineConfig(rnd, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "download_only_required_analyzers", "true"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestResourceSonarqubeScannerEnginePins(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name:    "pins served",
+			version: "10.7",
+			config: map[string]interface{}{
+				"engine_sha256":    "ABC123",
+				"pinned_analyzers": map[string]interface{}{"java": "8.1"},
+			},
+		},
+		{
+			name:    "pins not served",
+			version: "10.7",
+			config: map[string]interface{}{
+				"engine_sha256":    "def456",
+				"pinned_analyzers": map[string]interface{}{"java": "8.0", "kotlin": "2.20"},
+			},
+			wantErr: "SonarQube does not serve the pinned versions: analyzer 'java' is at version 8.1, not 8.0, analyzer 'kotlin' is not installed, the scanner engine is sonarqube-scanner-engine-10.7.jar with checksum abc123, not def456",
+		},
+		{
+			name:    "before 10.6",
+			version: "10.5",
+			config:  map[string]interface{}{},
+			wantErr: "minimum required SonarQube version for serving the scanner engine is 10.6",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockSonarQube(t)
+			mock.respond("GET", "/api/v2/analysis/engine", http.StatusOK, `{"filename":"sonarqube-scanner-engine-10.7.jar","sha256":"abc123"}`)
+			mock.respond("GET", "/api/plugins/installed", http.StatusOK, `{"plugins":[{"key":"java","version":"8.1"},{"key":"python","version":"4.2"}]}`)
+			mock.respond("POST", "/api/settings/set", http.StatusNoContent, "")
+			mock.respond("GET", "/api/settings/values", http.StatusOK, `{"settings":[{"key":"sonar.plugins.downloadOnlyRequired","value":"true"}]}`)
+			r := resourceSonarqubeScannerEngine()
+
+			d := schema.TestResourceDataRaw(t, r.Schema, tt.config)
+			err := r.Create(d, mock.conf("Developer", tt.version))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Create() error = %v, want %s", err, tt.wantErr)
+				}
+				if strings.Contains(fmt.Sprint(mock.requests()), "/api/settings/set") {
+					t.Errorf("Create() set the settings although the pins are not served: %v", mock.requests())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Create() unexpected error = %v", err)
+			}
+			if d.Get("engine_filename").(string) != "sonarqube-scanner-engine-10.7.jar" || d.Get("analyzers.python").(string) != "4.2" {
+				t.Errorf("Create() state = %v, want the engine and the analyzers served", d.State().Attributes)
+			}
+			if d.Get("pinned_analyzers.java").(string) != "8.1" || !d.Get("download_only_required_analyzers").(bool) {
+				t.Errorf("Create() state = %v, want the pins and the setting", d.State().Attributes)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+package sonarqube
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// GenerateSecretKeyResponse for unmarshalling response body of api/settings/generate_secret_key
+type GenerateSecretKeyResponse struct {
+	SecretKey string `json:"secretKey"`
+}
+
+// CheckSecretKeyResponse for unmarshalling response body of api/settings/check_secret_key
+type CheckSecretKeyResponse struct {
+	SecretKeyAvailable bool `json:"secretKeyAvailable"`
+}
+
+// EncryptResponse for unmarshalling response body of api/settings/encrypt
+type EncryptResponse struct {
+	EncryptedValue string `json:"encryptedValue"`
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeSettingsEncryption() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Settings Encryption resource. This can be used to generate the secret key of the instance and to encrypt
+setting values, such as the SMTP password, with it. The encrypted values can be passed to ` + "`sonarqube_setting`" + ` instead of the plain values.
+A generated secret key has to be installed on the SonarQube server, in the file referenced by ` + "`sonar.secretKeyPath`" + `, before values can be encrypted,
+so the key is usually generated and the values encrypted by two separate resources. Changing ` + "`triggers`" + ` generates a new key and encrypts the values again.
+Destroying this resource only removes it from the state.`,
+		Create: resourceSonarqubeSettingsEncryptionCreate,
+		Read:   resourceSonarqubeSettingsEncryptionRead,
+		Update: resourceSonarqubeSettingsEncryptionUpdate,
+		Delete: resourceSonarqubeSettingsEncryptionDelete,
+		// Encrypting is not deterministic, only the changed values get a new encrypted value
+		CustomizeDiff: customdiff.All(
+			customdiff.ComputedIf("encrypted_values", func(_ context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+				return d.HasChange("values")
+			}),
+		),
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"generate_secret_key": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether to generate a new secret key, returned in `secret_key`. Defaults to `false`. Changing this forces a new resource to be created.",
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary map of values that, when changed, rotates the secret key and encrypts the values again. Changing this forces a new resource to be created.",
+			},
+			"values": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The plain values to encrypt with the secret key installed on the server, keyed by an arbitrary name.",
+			},
+			"encrypted_values": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The encrypted values, keyed by the name given in `values`. For example `{aes-gcm}CCGCFg4Xpm6r+PiJb1Swfg==`.",
+			},
+			"secret_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The generated secret key, empty unless `generate_secret_key` is `true`.",
+			},
+			"secret_key_available": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether a secret key is installed on the server, which is required to encrypt values.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeSettingsEncryptionCreate(d *schema.ResourceData, m interface{}) error {
+	if d.Get("generate_secret_key").(bool) {
+		secretKey, err := generateSecretKey(m)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("secret_key", secretKey); err != nil {
+			return err
+		}
+	}
+
+	encrypted, err := encryptSettingValues(d.Get("values").(map[string]interface{}), map[string]interface{}{}, map[string]interface{}{}, m)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("encrypted_values", encrypted); err != nil {
+		return err
+	}
+
+	d.SetId("settings_encryption")
+
+	return resourceSonarqubeSettingsEncryptionRead(d, m)
+}
+
+func resourceSonarqubeSettingsEncryptionRead(d *schema.ResourceData, m interface{}) error {
+	available, err := checkSecretKey(m)
+	if err != nil {
+		return err
+	}
+	return d.Set("secret_key_available", available)
+}
+
+func resourceSonarqubeSettingsEncryptionUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("values") {
+		oldValues, newValues := d.GetChange("values")
+		oldEncrypted, _ := d.GetChange("encrypted_values")
+		encrypted, err := encryptSettingValues(newValues.(map[string]interface{}), oldValues.(map[string]interface{}), oldEncrypted.(map[string]interface{}), m)
+		if err != nil {
+			return err
+		}
+		if err := d.Set("encrypted_values", encrypted); err != nil {
+			return err
+		}
+	}
+
+	return resourceSonarqubeSettingsEncryptionRead(d, m)
+}
+
+func resourceSonarqubeSettingsEncryptionDelete(d *schema.ResourceData, m interface{}) error {
+	// The secret key and the encrypted values only live on the server and in the configuration of the settings
+	return nil
+}
+
+// encryptSettingValues encrypts the values that are new or changed, and keeps the previous encrypted value of the others
+func encryptSettingValues(values map[string]interface{}, previousValues map[string]interface{}, previousEncrypted map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	encrypted := map[string]interface{}{}
+	errs := []error{}
+	for name, value := range values {
+		if previous, ok := previousEncrypted[name]; ok && previousValues[name] == value {
+			encrypted[name] = previous
+			continue
+		}
+		encryptedValue, err := encryptSettingValue(value.(string), m)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to encrypt value '%s': %+v", name, err))
+			continue
+		}
+		encrypted[name] = encryptedValue
+	}
+	return encrypted, errors.Join(errs...)
+}
+
+func encryptSettingValue(value string, m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/encrypt"
+	sonarQubeURL.RawQuery = url.Values{
+		"value": []string{value},
+	}.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"encryptSettingValue",
+	)
+	if err != nil {
+		return "", fmt.Errorf("encryptSettingValue: Failed to encrypt the value, check that the secret key is installed on the server: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	encryptResponse := EncryptResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&encryptResponse)
+	if err != nil {
+		return "", fmt.Errorf("encryptSettingValue: Failed to decode json into struct: %+v", err)
+	}
+
+	return encryptResponse.EncryptedValue, nil
+}
+
+func generateSecretKey(m interface{}) (string, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/generate_secret_key"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"generateSecretKey",
+	)
+	if err != nil {
+		return "", fmt.Errorf("generateSecretKey: Failed to generate a secret key: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	secretKeyResponse := GenerateSecretKeyResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&secretKeyResponse)
+	if err != nil {
+		return "", fmt.Errorf("generateSecretKey: Failed to decode json into struct: %+v", err)
+	}
+
+	return secretKeyResponse.SecretKey, nil
+}
+
+func checkSecretKey(m interface{}) (bool, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/check_secret_key"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"checkSecretKey",
+	)
+	if err != nil {
+		return false, fmt.Errorf("checkSecretKey: Failed to check the secret key: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	checkResponse := CheckSecretKeyResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&checkResponse)
+	if err != nil {
+		return false, fmt.Errorf("checkSecretKey: Failed to decode json into struct: %+v", err)
+	}
+
+	return checkResponse.SecretKeyAvailable, nil
+}
@@ -0,0 +1,44 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSonarqubeSettingsEncryptionGenerateSecretKey(t *testing.T) {
+	rnd := generateRandomResourceName()
+	resourceName := "sonarqube_settings_encryption." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "sonarqube_settings_encryption" "%[1]s" {
+						generate_secret_key = true
+					}`, rnd),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "secret_key"),
+					resource.TestCheckResourceAttrSet(resourceName, "secret_key_available"),
+				),
+			},
+		},
+	})
+}
+
+func TestEncryptSettingValuesKeepsUnchangedValues(t *testing.T) {
+	values := map[string]interface{}{"smtp_password": "secret"}
+	previousEncrypted := map[string]interface{}{"smtp_password": "{aes-gcm}CCGCFg4Xpm6r+PiJb1Swfg=="}
+
+	// The value did not change, so the encryption endpoint is not called
+	encrypted, err := encryptSettingValues(values, values, previousEncrypted, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if encrypted["smtp_password"] != previousEncrypted["smtp_password"] {
+		t.Errorf("encryptSettingValues() = %v, want %v", encrypted, previousEncrypted)
+	}
+}
@@ -0,0 +1,69 @@
+package sonarqube
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// telemetrySettings maps the attributes of sonarqube_telemetry to their setting keys
+var telemetrySettings = []settingAttribute{
+	{attribute: "enabled", key: "sonar.telemetry.enable"},
+}
+
+// Returns the resource represented by this file.
+func resourceSonarqubeTelemetry() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Telemetry resource. This can be used to enable or disable the telemetry SonarQube sends to SonarSource,
+which many organizations must disable by policy. Destroying this resource restores the SonarQube default, which enables the telemetry.`,
+		Create: resourceSonarqubeTelemetryCreate,
+		Read:   resourceSonarqubeTelemetryRead,
+		Update: resourceSonarqubeTelemetryUpdate,
+		Delete: resourceSonarqubeTelemetryDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeTelemetryImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether SonarQube sends telemetry to SonarSource (`sonar.telemetry.enable`). Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeTelemetryCreate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", telemetrySettings, d, m, false); err != nil {
+		return err
+	}
+
+	d.SetId("telemetry")
+
+	return resourceSonarqubeTelemetryRead(d, m)
+}
+
+func resourceSonarqubeTelemetryRead(d *schema.ResourceData, m interface{}) error {
+	return readSettingAttributes("", telemetrySettings, d, m)
+}
+
+func resourceSonarqubeTelemetryUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := setSettingAttributes("", telemetrySettings, d, m, true); err != nil {
+		return err
+	}
+
+	return resourceSonarqubeTelemetryRead(d, m)
+}
+
+func resourceSonarqubeTelemetryDelete(d *schema.ResourceData, m interface{}) error {
+	return resetSettingAttributes("", telemetrySettings, m)
+}
+
+func resourceSonarqubeTelemetryImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.SetId("telemetry")
+	if err := resourceSonarqubeTelemetryRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,44 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeTelemetryConfig(rnd string, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_telemetry" "%[1]s" {
+			enabled = %[2]t
+		}`, rnd, enabled)
+}
+
+func TestAccSonarqubeTelemetryBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_telemetry." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeTelemetryConfig(rnd, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "false"),
+				),
+			},
+			{
+				Config: testAccSonarqubeTelemetryConfig(rnd, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "enabled", "true"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -33,14 +33,34 @@ type CreateUserResponse struct {
 	User User `json:"user"`
 }
 
+// UserV2 used in GetUsersV2
+type UserV2 struct {
+	ID     string `json:"id"`
+	Login  string `json:"login"`
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+	Local  bool   `json:"local"`
+}
+
+// GetUsersV2 for unmarshalling response body of api/v2/users-management/users
+type GetUsersV2 struct {
+	Users []UserV2 `json:"users"`
+}
+
+// usersV2MinimumVersion is the first version of SonarQube with the api/v2/users-management endpoints. Only the
+// deactivation uses them, the users are still created, read and updated with api/users.
+const usersV2MinimumVersion = "10.4"
+
 // Returns the resource represented by this file.
 func resourceSonarqubeUser() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a Sonarqube User resource. This can be used to manage Sonarqube Users.",
-		Create:      resourceSonarqubeUserCreate,
-		Read:        resourceSonarqubeUserRead,
-		Update:      resourceSonarqubeUserUpdate,
-		Delete:      resourceSonarqubeUserDelete,
+		Description: `Provides a Sonarqube User resource. This can be used to manage Sonarqube Users. From SonarQube 10.4, users are
+deactivated with the ` + "`api/v2/users-management/users`" + ` endpoint. They are still created, read and updated with the ` + "`api/users`" + `
+endpoints.`,
+		Create: resourceSonarqubeUserCreate,
+		Read:   resourceSonarqubeUserRead,
+		Update: resourceSonarqubeUserUpdate,
+		Delete: resourceSonarqubeUserDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeUserImport,
 		},
@@ -77,6 +97,12 @@ func resourceSonarqubeUser() *schema.Resource {
 				ForceNew:    true,
 				Description: "`True` if the User should be of type `local`. Defaults to `true`.",
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When `true`, the User cannot be deactivated by Terraform, for example to protect the service accounts used by scanners. Use the `sonarqube_user_tokens` data source to list their tokens. Defaults to `false`.",
+			},
 		},
 	}
 }
@@ -226,6 +252,38 @@ func resourceSonarqubeUserUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeUserDelete(d *schema.ResourceData, m interface{}) error {
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("resourceSonarqubeUserDelete: cannot deactivate user '%s' because deletion_protection is enabled", d.Id())
+	}
+
+	if supportsV2API(m.(*ProviderConfiguration), usersV2MinimumVersion) {
+		user, err := readUserV2FromApi(d.Id(), m)
+		if err != nil {
+			return err
+		}
+
+		sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+		sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/users-management/users/" + url.PathEscape(user.ID)
+		sonarQubeURL.RawQuery = url.Values{
+			"anonymize": []string{strconv.FormatBool(m.(*ProviderConfiguration).sonarQubeAnonymizeUsers)},
+		}.Encode()
+
+		resp, err := httpRequestHelperV2(
+			m.(*ProviderConfiguration).httpClient,
+			"DELETE",
+			sonarQubeURL.String(),
+			nil,
+			http.StatusNoContent,
+			"resourceSonarqubeUserDelete",
+		)
+		if err != nil {
+			return fmt.Errorf("error deleting (deactivating) Sonarqube user: %+v", err)
+		}
+		defer resp.Body.Close()
+
+		return nil
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/deactivate"
 	sonarQubeURL.RawQuery = url.Values{
@@ -249,8 +307,48 @@ func resourceSonarqubeUserDelete(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceSonarqubeUserImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := d.Set("deletion_protection", false); err != nil {
+		return nil, err
+	}
 	if err := resourceSonarqubeUserRead(d, m); err != nil {
 		return nil, err
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// readUserV2FromApi returns the user with the given login from the v2 api
+func readUserV2FromApi(login string, m interface{}) (*UserV2, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/v2/users-management/users"
+	sonarQubeURL.RawQuery = url.Values{
+		"q":        []string{login},
+		"pageSize": []string{"500"},
+	}.Encode()
+
+	resp, err := httpRequestHelperV2(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		nil,
+		http.StatusOK,
+		"readUserV2FromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Sonarqube user: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	users := GetUsersV2{}
+	err = json.NewDecoder(resp.Body).Decode(&users)
+	if err != nil {
+		return nil, fmt.Errorf("readUserV2FromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	for _, user := range users.Users {
+		if user.Login == login {
+			return &user, nil
+		}
+	}
+	return nil, fmt.Errorf("readUserV2FromApi: user '%s' not found", login)
+}
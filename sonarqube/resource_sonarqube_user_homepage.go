@@ -0,0 +1,167 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// UserHomepage is the homepage of a user in api/users/current
+type UserHomepage struct {
+	Type      string `json:"type"`
+	Component string `json:"component"`
+	Branch    string `json:"branch"`
+}
+
+// GetCurrentUser for unmarshalling response body of api/users/current
+type GetCurrentUser struct {
+	Login    string       `json:"login"`
+	Name     string       `json:"name"`
+	Homepage UserHomepage `json:"homepage"`
+}
+
+// defaultUserHomepageType is the homepage of the users that never changed it
+const defaultUserHomepageType = "PROJECTS"
+
+// Returns the resource represented by this file.
+func resourceSonarqubeUserHomepage() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube User Homepage resource. This sets the homepage of the user authenticated by the provider with api/users/set_homepage,
+for example the dashboard of a project for a service account displayed on a screen. To manage the homepage of another account, configure a provider alias with its credentials.
+Destroying this resource resets the homepage to the list of projects.`,
+		Create: resourceSonarqubeUserHomepageCreate,
+		Read:   resourceSonarqubeUserHomepageRead,
+		Update: resourceSonarqubeUserHomepageCreate,
+		Delete: resourceSonarqubeUserHomepageDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{"PROJECT", "PROJECTS", "ISSUES", "PORTFOLIO", "PORTFOLIOS", "APPLICATION"}, false),
+				),
+				Description: "The type of homepage. Possible values are `PROJECT`, `PROJECTS`, `ISSUES`, `PORTFOLIO`, `PORTFOLIOS` and `APPLICATION`.",
+			},
+			"component": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The key of the project, portfolio or application. Required when `type` is `PROJECT`, `PORTFOLIO` or `APPLICATION`.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The branch of the project. Only used when `type` is `PROJECT`, the main branch is used when not set.",
+			},
+			"login": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The login of the user whose homepage is set.",
+			},
+		},
+	}
+}
+
+func resourceSonarqubeUserHomepageCreate(d *schema.ResourceData, m interface{}) error {
+	homepage := UserHomepage{
+		Type:      d.Get("type").(string),
+		Component: d.Get("component").(string),
+		Branch:    d.Get("branch").(string),
+	}
+	if err := setUserHomepage(homepage, m); err != nil {
+		return err
+	}
+
+	user, err := readCurrentUserFromApi(m)
+	if err != nil {
+		return err
+	}
+	d.SetId(user.Login)
+
+	return resourceSonarqubeUserHomepageRead(d, m)
+}
+
+func resourceSonarqubeUserHomepageRead(d *schema.ResourceData, m interface{}) error {
+	user, err := readCurrentUserFromApi(m)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	errs = append(errs, d.Set("type", user.Homepage.Type))
+	errs = append(errs, d.Set("component", user.Homepage.Component))
+	errs = append(errs, d.Set("branch", user.Homepage.Branch))
+	errs = append(errs, d.Set("login", user.Login))
+	return errors.Join(errs...)
+}
+
+func resourceSonarqubeUserHomepageDelete(d *schema.ResourceData, m interface{}) error {
+	return setUserHomepage(UserHomepage{Type: defaultUserHomepageType}, m)
+}
+
+// setUserHomepage sets the homepage of the user authenticated by the provider
+func setUserHomepage(homepage UserHomepage, m interface{}) error {
+	rawQuery := url.Values{
+		"type": []string{homepage.Type},
+	}
+	if homepage.Component != "" {
+		rawQuery.Add("component", homepage.Component)
+	}
+	if homepage.Branch != "" {
+		rawQuery.Add("branch", homepage.Branch)
+	}
+
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/set_homepage"
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"setUserHomepage",
+	)
+	if err != nil {
+		return fmt.Errorf("setUserHomepage: Failed to set the homepage to '%s': %+v", homepage.Type, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func readCurrentUserFromApi(m interface{}) (*GetCurrentUser, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/users/current"
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readCurrentUserFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readCurrentUserFromApi: Failed to read the current user: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	// Decode response into struct
+	user := GetCurrentUser{}
+	err = json.NewDecoder(resp.Body).Decode(&user)
+	if err != nil {
+		return nil, fmt.Errorf("readCurrentUserFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,60 @@
+package sonarqube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeUserHomepageProjectConfig(rnd string, project string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_project" "%[1]s" {
+			name    = "%[2]s"
+			project = "%[2]s"
+		}
+
+		resource "sonarqube_user_homepage" "%[1]s" {
+			type      = "PROJECT"
+			component = sonarqube_project.%[1]s.project
+		}`, rnd, project)
+}
+
+func testAccSonarqubeUserHomepageConfig(rnd string, homepageType string) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user_homepage" "%[1]s" {
+			type = "%[2]s"
+		}`, rnd, homepageType)
+}
+
+func TestAccSonarqubeUserHomepageBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user_homepage." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserHomepageProjectConfig(rnd, "testAccSonarqubeUserHomepage"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "type", "PROJECT"),
+					resource.TestCheckResourceAttr(name, "component", "testAccSonarqubeUserHomepage"),
+					resource.TestCheckResourceAttrSet(name, "login"),
+				),
+			},
+			{
+				Config: testAccSonarqubeUserHomepageConfig(rnd, "ISSUES"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "type", "ISSUES"),
+					resource.TestCheckResourceAttr(name, "component", ""),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -2,6 +2,7 @@ package sonarqube
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -101,3 +102,42 @@ func TestAccSonarqubeUserNotLocal(t *testing.T) {
 		},
 	})
 }
+
+func testAccSonarqubeUserDeletionProtectionConfig(rnd string, name string, deletionProtection bool) string {
+	return fmt.Sprintf(`
+		resource "sonarqube_user" "%[1]s" {
+			login_name          = "%[2]s"
+			name                = "%[2]s"
+			is_local            = false
+			deletion_protection = %[3]t
+		}`, rnd, name, deletionProtection)
+}
+
+func TestAccSonarqubeUserDeletionProtection(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_user." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeUserDeletionProtectionConfig(rnd, "testAccSonarqubeUserDeletionProtection", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccSonarqubeUserDeletionProtectionConfig(rnd, "testAccSonarqubeUserDeletionProtection", true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deletion_protection is enabled"),
+			},
+			{
+				Config: testAccSonarqubeUserDeletionProtectionConfig(rnd, "testAccSonarqubeUserDeletionProtection", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
@@ -28,6 +28,7 @@ type Token struct {
 	Type           string       `json:"type,omitempty"`
 	CreatedAt      string       `json:"createdAt,omitempty"`
 	IsExpired      bool         `json:"isExpired,omitempty"`
+	LastConnection string       `json:"lastConnectionDate,omitempty"`
 	Project        TokenProject `json:"project,omitempty"`
 }
 
@@ -0,0 +1,251 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	viewsReferencePortfolio   = "portfolio"
+	viewsReferenceApplication = "application"
+)
+
+// Returns the resource represented by this file.
+func resourceSonarqubeViewsLocalDefinition() *schema.Resource {
+	return &schema.Resource{
+		Description: `Provides a Sonarqube Views Local Definition resource. This can be used to build portfolio hierarchies by referencing
+other portfolios and applications from a portfolio. The references are kept in the configured order in the state.
+Destroying this resource removes the references from the portfolio. It supports importing using the key of the portfolio.`,
+		Create: resourceSonarqubeViewsLocalDefinitionCreate,
+		Read:   resourceSonarqubeViewsLocalDefinitionRead,
+		Update: resourceSonarqubeViewsLocalDefinitionUpdate,
+		Delete: resourceSonarqubeViewsLocalDefinitionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSonarqubeViewsLocalDefinitionImport,
+		},
+
+		// Define the fields of this schema.
+		Schema: map[string]*schema.Schema{
+			"portfolio": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The key of the portfolio the references are added to. Changing this forces a new resource to be created.",
+			},
+			"reference": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The ordered list of portfolios and applications referenced by the portfolio.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{viewsReferencePortfolio, viewsReferenceApplication}, false)),
+							Description:      "The type of the referenced component. Possible values are `portfolio` and `application`.",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The key of the referenced portfolio or application.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSonarqubeViewsLocalDefinitionCreate(d *schema.ResourceData, m interface{}) error {
+	if err := checkPortfolioSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	portfolio := d.Get("portfolio").(string)
+	for _, reference := range expandViewsReferences(d.Get("reference").([]interface{})) {
+		if err := updateViewsReference("add", portfolio, reference, m); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(portfolio)
+
+	return resourceSonarqubeViewsLocalDefinitionRead(d, m)
+}
+
+func resourceSonarqubeViewsLocalDefinitionRead(d *schema.ResourceData, m interface{}) error {
+	if err := checkPortfolioSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	portfolio, err := readPortfolioFromApi(d, m)
+	if err != nil {
+		return err
+	}
+
+	current := []viewsReference{}
+	for _, subView := range portfolio.SubViews {
+		key := subView.OriginalKey
+		if key == "" {
+			key = subView.Key
+		}
+		switch subView.Qualifier {
+		case "VW":
+			current = append(current, viewsReference{referenceType: viewsReferencePortfolio, key: key})
+		case "APP":
+			current = append(current, viewsReference{referenceType: viewsReferenceApplication, key: key})
+		}
+	}
+
+	// SonarQube does not keep the order of the references, so the configured order is preserved
+	ordered := orderViewsReferences(current, expandViewsReferences(d.Get("reference").([]interface{})))
+
+	if err := d.Set("portfolio", d.Id()); err != nil {
+		return err
+	}
+	return d.Set("reference", flattenViewsReferences(ordered))
+}
+
+func resourceSonarqubeViewsLocalDefinitionUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := checkPortfolioSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	oldReferences, newReferences := d.GetChange("reference")
+	toAdd, toRemove := diffViewsReferences(expandViewsReferences(oldReferences.([]interface{})), expandViewsReferences(newReferences.([]interface{})))
+	for _, reference := range toRemove {
+		if err := updateViewsReference("remove", d.Id(), reference, m); err != nil {
+			return err
+		}
+	}
+	for _, reference := range toAdd {
+		if err := updateViewsReference("add", d.Id(), reference, m); err != nil {
+			return err
+		}
+	}
+
+	return resourceSonarqubeViewsLocalDefinitionRead(d, m)
+}
+
+func resourceSonarqubeViewsLocalDefinitionDelete(d *schema.ResourceData, m interface{}) error {
+	if err := checkPortfolioSupport(m.(*ProviderConfiguration)); err != nil {
+		return err
+	}
+
+	for _, reference := range expandViewsReferences(d.Get("reference").([]interface{})) {
+		if err := updateViewsReference("remove", d.Id(), reference, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceSonarqubeViewsLocalDefinitionImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := resourceSonarqubeViewsLocalDefinitionRead(d, m); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+type viewsReference struct {
+	referenceType string
+	key           string
+}
+
+// updateViewsReference adds or removes a reference to a portfolio or application, using api/views/add_portfolio,
+// api/views/add_application and their remove counterparts
+func updateViewsReference(action string, portfolio string, reference viewsReference, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/views/" + action + "_" + reference.referenceType
+
+	RawQuery := url.Values{
+		"portfolio": []string{portfolio},
+	}
+	if reference.referenceType == viewsReferencePortfolio {
+		RawQuery.Add("reference", reference.key)
+	} else {
+		RawQuery.Add("application", reference.key)
+	}
+	sonarQubeURL.RawQuery = RawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"updateViewsReference",
+	)
+	if err != nil {
+		return fmt.Errorf("error calling %s on %s '%s' of portfolio '%s': %+v", action, reference.referenceType, reference.key, portfolio, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// diffViewsReferences returns the references to add and to remove to go from the old to the new references
+func diffViewsReferences(oldReferences []viewsReference, newReferences []viewsReference) ([]viewsReference, []viewsReference) {
+	toAdd := []viewsReference{}
+	toRemove := []viewsReference{}
+	for _, reference := range newReferences {
+		if !containsViewsReference(oldReferences, reference) {
+			toAdd = append(toAdd, reference)
+		}
+	}
+	for _, reference := range oldReferences {
+		if !containsViewsReference(newReferences, reference) {
+			toRemove = append(toRemove, reference)
+		}
+	}
+	return toAdd, toRemove
+}
+
+// orderViewsReferences sorts the current references like the configured ones, followed by the unknown ones
+func orderViewsReferences(current []viewsReference, configured []viewsReference) []viewsReference {
+	ordered := []viewsReference{}
+	for _, reference := range configured {
+		if containsViewsReference(current, reference) {
+			ordered = append(ordered, reference)
+		}
+	}
+	for _, reference := range current {
+		if !containsViewsReference(configured, reference) {
+			ordered = append(ordered, reference)
+		}
+	}
+	return ordered
+}
+
+func containsViewsReference(references []viewsReference, reference viewsReference) bool {
+	for _, r := range references {
+		if r == reference {
+			return true
+		}
+	}
+	return false
+}
+
+func expandViewsReferences(input []interface{}) []viewsReference {
+	references := make([]viewsReference, 0, len(input))
+	for _, r := range input {
+		r := r.(map[string]interface{})
+		references = append(references, viewsReference{referenceType: r["type"].(string), key: r["key"].(string)})
+	}
+	return references
+}
+
+func flattenViewsReferences(references []viewsReference) []interface{} {
+	result := make([]interface{}, 0, len(references))
+	for _, reference := range references {
+		result = append(result, map[string]interface{}{
+			"type": reference.referenceType,
+			"key":  reference.key,
+		})
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package sonarqube
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccSonarqubeViewsLocalDefinitionConfig(rnd string, references []string) string {
+	formattedReferences := ""
+	for _, reference := range references {
+		formattedReferences += fmt.Sprintf(`
+			reference {
+				type = "portfolio"
+				key  = sonarqube_portfolio.%s.key
+			}`, reference)
+	}
+
+	return fmt.Sprintf(`
+		resource "sonarqube_portfolio" "parent" {
+			key         = "%[1]s-parent"
+			name        = "%[1]s-parent"
+			description = "Parent portfolio"
+		}
+
+		resource "sonarqube_portfolio" "child_one" {
+			key         = "%[1]s-child-one"
+			name        = "%[1]s-child-one"
+			description = "First child portfolio"
+		}
+
+		resource "sonarqube_portfolio" "child_two" {
+			key         = "%[1]s-child-two"
+			name        = "%[1]s-child-two"
+			description = "Second child portfolio"
+		}
+
+		resource "sonarqube_views_local_definition" "%[1]s" {
+			portfolio = sonarqube_portfolio.parent.key
+			%[2]s
+		}`, rnd, formattedReferences)
+}
+
+func TestAccSonarqubeViewsLocalDefinitionBasic(t *testing.T) {
+	rnd := generateRandomResourceName()
+	name := "sonarqube_views_local_definition." + rnd
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t); testAccPreCheckPortfolioSupport(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSonarqubeViewsLocalDefinitionConfig(rnd, []string{"child_two", "child_one"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "reference.#", "2"),
+					resource.TestCheckResourceAttr(name, "reference.0.key", rnd+"-child-two"),
+					resource.TestCheckResourceAttr(name, "reference.1.key", rnd+"-child-one"),
+				),
+			},
+			{
+				Config: testAccSonarqubeViewsLocalDefinitionConfig(rnd, []string{"child_one"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(name, "reference.#", "1"),
+					resource.TestCheckResourceAttr(name, "reference.0.key", rnd+"-child-one"),
+				),
+			},
+			{
+				ResourceName:      name,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestOrderViewsReferences(t *testing.T) {
+	one := viewsReference{referenceType: viewsReferencePortfolio, key: "one"}
+	two := viewsReference{referenceType: viewsReferenceApplication, key: "two"}
+	three := viewsReference{referenceType: viewsReferencePortfolio, key: "three"}
+
+	tests := []struct {
+		name       string
+		current    []viewsReference
+		configured []viewsReference
+		want       []viewsReference
+	}{
+		{
+			name:       "Configured order is kept",
+			current:    []viewsReference{one, two},
+			configured: []viewsReference{two, one},
+			want:       []viewsReference{two, one},
+		},
+		{
+			name:       "Unknown references are appended",
+			current:    []viewsReference{three, one},
+			configured: []viewsReference{one},
+			want:       []viewsReference{one, three},
+		},
+		{
+			name:       "Missing references are dropped",
+			current:    []viewsReference{one},
+			configured: []viewsReference{two, one},
+			want:       []viewsReference{one},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := orderViewsReferences(tt.current, tt.configured); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("orderViewsReferences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
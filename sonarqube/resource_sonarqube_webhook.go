@@ -1,6 +1,7 @@
 package sonarqube
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,7 +10,9 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type Webhook struct {
@@ -17,8 +20,20 @@ type Webhook struct {
 	Name   string `json:"name"`
 	Url    string `json:"url"`
 	Secret string `json:"secret"`
+	// HasSecret replaces Secret from SonarQube 10.1
+	HasSecret bool `json:"hasSecret"`
 }
 
+// SonarQube signs the payload of webhooks that have a secret and keeps their deliveries for a limited time
+const (
+	webhookSignatureHeader         = "X-Sonar-Webhook-HMAC-SHA256"
+	webhookSignatureAlgorithm      = "HMAC-SHA256"
+	webhookDeliveriesRetentionDays = 30
+)
+
+// webhookLimit is the maximum number of webhooks of a project, and of global webhooks
+const webhookLimit = 10
+
 type CreateWebhookResponse struct {
 	Webhook *Webhook `json:"webhook"`
 }
@@ -30,14 +45,22 @@ type ListWebhooksResponse struct {
 // Returns the resource represented by this file.
 func resourceSonarqubeWebhook() *schema.Resource {
 	return &schema.Resource{
-		Description: "Provides a Sonarqube Webhook resource. This can be used to manage Sonarqube webhooks.",
-		Create:      resourceSonarqubeWebhookCreate,
-		Read:        resourceSonarqubeWebhookRead,
-		Update:      resourceSonarqubeWebhookUpdate,
-		Delete:      resourceSonarqubeWebhookDelete,
+		Description: `Provides a Sonarqube Webhook resource. This can be used to manage Sonarqube webhooks. A webhook is either global, and called
+for every project, or owned by a single project. SonarQube allows at most 10 webhooks per project and 10 global webhooks.
+It supports importing using the format '{key}/[{project}]', where the project is only set for project webhooks.`,
+		Create: resourceSonarqubeWebhookCreate,
+		Read:   resourceSonarqubeWebhookRead,
+		Update: resourceSonarqubeWebhookUpdate,
+		Delete: resourceSonarqubeWebhookDelete,
 		Importer: &schema.ResourceImporter{
 			State: resourceSonarqubeWebhookImport,
 		},
+		// Check that the scope and the project agree when both are set
+		CustomizeDiff: customdiff.All(
+			func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+				return validateWebhookScope(d)
+			},
+		),
 
 		// Define the fields of this schema.
 		Schema: map[string]*schema.Schema{
@@ -51,12 +74,17 @@ func resourceSonarqubeWebhook() *schema.Resource {
 				Required:    true,
 				Description: "The URL to send event payloads to. This must begin with either `https://` or `http://`.",
 			},
+			"web_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the page listing the webhook in the SonarQube UI: the webhooks of the project, or the global webhooks. Named `web_url` because `url` is the target of the webhook.",
+			},
 			"secret": {
 				Type:        schema.TypeString,
 				Sensitive:   true,
 				Optional:    true,
 				Computed:    true,
-				Description: "The secret to send with the event payload.",
+				Description: "The secret to send with the event payload. SonarQube has no global webhook secret, so every webhook is signed with its own secret.",
 			},
 			"project": {
 				Type:        schema.TypeString,
@@ -64,11 +92,43 @@ func resourceSonarqubeWebhook() *schema.Resource {
 				Optional:    true,
 				ForceNew:    true,
 			},
+			"scope": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{"global", "project"}, false)),
+				Description:      "Whether the webhook is `global` or owned by a `project`. Read from whether `project` is set when not configured. Setting it makes the intent explicit: `project` requires `project` to be set and `global` forbids it. It never replaces the webhook on its own, changing `project` does.",
+			},
+			"signature_header": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The HTTP header that carries the payload signature, empty when no `secret` is set.",
+			},
+			"signature_algorithm": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The algorithm used to sign the payload with the `secret`, empty when no `secret` is set. The signature is the hex encoded HMAC of the raw request body.",
+			},
+			"deliveries_retention": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of days SonarQube keeps the deliveries of the webhook before purging them.",
+			},
 		},
 	}
 }
 
 func resourceSonarqubeWebhookCreate(d *schema.ResourceData, m interface{}) error {
+	// SonarQube only answers with a generic error once the limit is reached, so report the webhooks that occupy the slots
+	project := d.Get("project").(string)
+	existing, err := readWebhooksFromApi(project, m)
+	if err != nil {
+		return err
+	}
+	if len(existing) >= webhookLimit {
+		return webhookLimitError(project, existing)
+	}
+
 	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
 	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/webhooks/create"
 
@@ -79,8 +139,8 @@ func resourceSonarqubeWebhookCreate(d *schema.ResourceData, m interface{}) error
 	if secret, ok := d.GetOk("secret"); ok {
 		params.Set("secret", secret.(string))
 	}
-	if project, ok := d.GetOk("project"); ok {
-		params.Set("project", project.(string))
+	if project != "" {
+		params.Set("project", project)
 	}
 
 	sonarQubeURL.RawQuery = params.Encode()
@@ -111,49 +171,38 @@ func resourceSonarqubeWebhookCreate(d *schema.ResourceData, m interface{}) error
 // unfortunately, there doesn't seem to be a way to get a webhook by its ID. the best we can do is list all webhooks and
 // loop through the result until we find the one we're looking for.
 func resourceSonarqubeWebhookRead(d *schema.ResourceData, m interface{}) error {
-	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
-	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/webhooks/list"
-
-	if project, ok := d.GetOk("project"); ok {
-		rawQuery := url.Values{
-			"project": []string{string(project.(string))},
-		}
-		sonarQubeURL.RawQuery = rawQuery.Encode()
-	}
-
-	resp, err := httpRequestHelper(
-		m.(*ProviderConfiguration).httpClient,
-		"GET",
-		sonarQubeURL.String(),
-		http.StatusOK,
-		"resourceWebhookRead",
-	)
-	if err != nil {
-		return fmt.Errorf("resourceWebhookRead: Failed to call %s: %+v", sonarQubeURL.Path, err)
-	}
-	defer resp.Body.Close()
-
-	webhookResponse := ListWebhooksResponse{}
-	err = json.NewDecoder(resp.Body).Decode(&webhookResponse)
+	project := d.Get("project").(string)
+	webhooks, err := readWebhooksFromApi(project, m)
 	if err != nil {
-		return fmt.Errorf("resourceWebhookRead: Failed to decode json into struct: %+v", err)
+		return err
 	}
 
-	for _, webhook := range webhookResponse.Webhooks {
+	for _, webhook := range webhooks {
 		log.Printf("[DEBUG][resourceSonarqubeWebhookRead] webhook.Key: '%s' vs %s ", webhook.Key, d.Id())
 		if webhook.Key == d.Id() {
 			errs := []error{}
 			errs = append(errs, d.Set("name", webhook.Name))
 			errs = append(errs, d.Set("url", webhook.Url))
 			// Field 'project' is not included in the webhook response object, so it is imported from the parameter.
-			if project, ok := d.GetOk("project"); ok {
-				errs = append(errs, d.Set("project", project.(string)))
+			if project != "" {
+				errs = append(errs, d.Set("project", project))
+				errs = append(errs, d.Set("scope", "project"))
+				errs = append(errs, d.Set("web_url", sonarQubeWebURL(m.(*ProviderConfiguration), "/project/webhooks", url.Values{"id": []string{project}})))
+			} else {
+				errs = append(errs, d.Set("scope", "global"))
+				errs = append(errs, d.Set("web_url", sonarQubeWebURL(m.(*ProviderConfiguration), "/admin/webhooks", nil)))
 			}
 			// Version 10.1 of sonarqube does not return the secret in the api response anymore. Field 'secret' replaced by flag 'hasSecret' in response
 			// Instead we just set the secret in state to the value being passed in to avoid constant drifts
 			if secret, ok := d.GetOk("secret"); ok {
 				errs = append(errs, d.Set("secret", secret.(string)))
+				errs = append(errs, d.Set("signature_header", webhookSignatureHeader))
+				errs = append(errs, d.Set("signature_algorithm", webhookSignatureAlgorithm))
+			} else {
+				errs = append(errs, d.Set("signature_header", ""))
+				errs = append(errs, d.Set("signature_algorithm", ""))
 			}
+			errs = append(errs, d.Set("deliveries_retention", webhookDeliveriesRetentionDays))
 			return errors.Join(errs...)
 		}
 	}
@@ -218,18 +267,15 @@ func resourceSonarqubeWebhookDelete(d *schema.ResourceData, m interface{}) error
 }
 
 func resourceSonarqubeWebhookImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	// import id in format {key}/{project}
-	importIdComponents := strings.SplitN(d.Id(), "/", 2)
-
-	if len(importIdComponents) == 2 {
-		log.Printf("[DEBUG][resourceSonarqubeWebhookImport] Import id: '%+v' is in format {key/project:%s/%s}", d.Id(), importIdComponents[0], importIdComponents[1])
+	importIdComponents, err := parseImportID(d.Id(), "{key}/[{project}]")
+	if err != nil {
+		return nil, fmt.Errorf("resourceSonarqubeWebhookImport: %+v", err)
+	}
+	if importIdComponents[1] != "" {
+		log.Printf("[DEBUG][resourceSonarqubeWebhookImport] Import id: '%+v' is in format '{key}/[{project}]': %s/%s", d.Id(), importIdComponents[0], importIdComponents[1])
 		if err := d.Set("project", importIdComponents[1]); err != nil {
 			return nil, err
 		}
-	} else if len(importIdComponents) == 1 {
-		log.Printf("[DEBUG][resourceSonarqubeWebhookImport] Import id: '%+v' is in format {key:%s}", d.Id(), importIdComponents[0])
-	} else {
-		return nil, fmt.Errorf("resourceSonarqubeWebhookImport: Import id: '%+v' is not in format {key}/{project} or {key}", d.Id())
 	}
 
 	// set Id to key for Read
@@ -239,3 +285,79 @@ func resourceSonarqubeWebhookImport(d *schema.ResourceData, m interface{}) ([]*s
 	}
 	return []*schema.ResourceData{d}, nil
 }
+
+// readWebhooksFromApi returns the webhooks of a project, or the global webhooks when the project is empty
+func readWebhooksFromApi(project string, m interface{}) ([]*Webhook, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/webhooks/list"
+
+	if project != "" {
+		sonarQubeURL.RawQuery = url.Values{
+			"project": []string{project},
+		}.Encode()
+	}
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"readWebhooksFromApi",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("readWebhooksFromApi: Failed to call %s: %+v", sonarQubeURL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	webhookResponse := ListWebhooksResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&webhookResponse)
+	if err != nil {
+		return nil, fmt.Errorf("readWebhooksFromApi: Failed to decode json into struct: %+v", err)
+	}
+
+	return webhookResponse.Webhooks, nil
+}
+
+// webhookLimitError describes the webhooks that occupy the slots of a project, or the global slots when the project is empty
+func webhookLimitError(project string, webhooks []*Webhook) error {
+	occupied := []string{}
+	for _, webhook := range webhooks {
+		occupied = append(occupied, fmt.Sprintf("'%s' (key: %s, url: %s)", webhook.Name, webhook.Key, webhook.Url))
+	}
+	owner := fmt.Sprintf("there are already %d global webhooks", len(webhooks))
+	if project != "" {
+		owner = fmt.Sprintf("project '%s' already has %d webhooks", project, len(webhooks))
+	}
+	return fmt.Errorf("resourceWebhookCreate: %s, which is the maximum allowed by SonarQube. Remove one of them first: %s",
+		owner, strings.Join(occupied, ", "))
+}
+
+// validateWebhookScope checks that the configured scope agrees with the project. The scope is checked against the
+// configuration, as the project may not be known yet. When it is not configured, Read derives it from the project.
+func validateWebhookScope(d *schema.ResourceDiff) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() {
+		return nil
+	}
+	projectConfigured := !rawConfig.GetAttr("project").IsNull()
+
+	scope := rawConfig.GetAttr("scope")
+	if scope.IsNull() {
+		return nil
+	}
+	if !scope.IsKnown() {
+		return nil
+	}
+
+	switch scope.AsString() {
+	case "project":
+		if !projectConfigured {
+			return fmt.Errorf("'project' must be set when 'scope' is 'project'")
+		}
+	case "global":
+		if projectConfigured {
+			return fmt.Errorf("'project' cannot be set when 'scope' is 'global'")
+		}
+	}
+	return nil
+}
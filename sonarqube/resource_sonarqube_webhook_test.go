@@ -1,9 +1,14 @@
 package sonarqube
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
@@ -26,6 +31,7 @@ func TestAccSonarqubeWebhookBasic(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(resourceName, "name", name),
 					resource.TestCheckResourceAttr(resourceName, "url", url),
+					resource.TestCheckResourceAttr(resourceName, "scope", "global"),
 				),
 			},
 			{
@@ -65,6 +71,9 @@ func TestAccSonarqubeWebhookUpdate(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", firstName),
 					resource.TestCheckResourceAttr(resourceName, "url", firstUrl),
 					resource.TestCheckResourceAttr(resourceName, "secret", firstSecret),
+					resource.TestCheckResourceAttr(resourceName, "signature_header", "X-Sonar-Webhook-HMAC-SHA256"),
+					resource.TestCheckResourceAttr(resourceName, "signature_algorithm", "HMAC-SHA256"),
+					resource.TestCheckResourceAttr(resourceName, "deliveries_retention", "30"),
 				),
 			},
 			{
@@ -107,6 +116,7 @@ func TestAccSonarqubeWebhookProjectBasic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", name),
 					resource.TestCheckResourceAttr(resourceName, "url", url),
 					resource.TestCheckResourceAttr(resourceName, "project", project),
+					resource.TestCheckResourceAttr(resourceName, "scope", "project"),
 				),
 			},
 			{
@@ -144,3 +154,113 @@ func testAccSonarqubeWebhookProjectBasicConfig(rnd string, name string, url stri
 			project = sonarqube_project.%[1]s.project
 		}`, rnd, name, url, project)
 }
+
+func TestAccSonarqubeWebhookScopeMismatch(t *testing.T) {
+	rnd := generateRandomResourceName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "sonarqube_webhook" "%[1]s" {
+						name  = "%[1]s"
+						url   = "https://%[1]s.example.com"
+						scope = "project"
+					}`, rnd),
+				ExpectError: regexp.MustCompile("'project' must be set when 'scope' is 'project'"),
+			},
+		},
+	})
+}
+
+func TestWebhookLimitError(t *testing.T) {
+	webhooks := []*Webhook{
+		{Key: "AU-1", Name: "ci", Url: "https://ci.example.com"},
+		{Key: "AU-2", Name: "chat", Url: "https://chat.example.com"},
+	}
+
+	tests := []struct {
+		name     string
+		project  string
+		expected string
+	}{
+		{
+			name:     "project",
+			project:  "my-project",
+			expected: "resourceWebhookCreate: project 'my-project' already has 2 webhooks, which is the maximum allowed by SonarQube. Remove one of them first: 'ci' (key: AU-1, url: https://ci.example.com), 'chat' (key: AU-2, url: https://chat.example.com)",
+		},
+		{
+			name:     "global",
+			project:  "",
+			expected: "resourceWebhookCreate: there are already 2 global webhooks, which is the maximum allowed by SonarQube. Remove one of them first: 'ci' (key: AU-1, url: https://ci.example.com), 'chat' (key: AU-2, url: https://chat.example.com)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := webhookLimitError(tt.project, webhooks).Error(); result != tt.expected {
+				t.Errorf("webhookLimitError() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResourceSonarqubeWebhookWebURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		project string
+		want    string
+	}{
+		{"global", "", "/admin/webhooks"},
+		{"project", "my_project", "/project/webhooks?id=my_project"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockSonarQube(t)
+			mock.respond("GET", "/api/webhooks/list", http.StatusOK, `{"webhooks": [{"key": "AX1", "name": "ci", "url": "https://ci.example.com/hook"}]}`)
+			r := resourceSonarqubeWebhook()
+
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project": tt.project})
+			d.SetId("AX1")
+			if err := r.Read(d, mock.conf("Community", "10.7")); err != nil {
+				t.Fatalf("Read() unexpected error = %v", err)
+			}
+			// url remains the target of the webhook
+			if d.Get("url").(string) != "https://ci.example.com/hook" || d.Get("web_url").(string) != mock.server.URL+tt.want {
+				t.Errorf("Read() url = %s, web_url = %s, want the target and %s", d.Get("url"), d.Get("web_url"), mock.server.URL+tt.want)
+			}
+			if d.Get("scope").(string) != tt.name {
+				t.Errorf("Read() scope = %s, want %s", d.Get("scope"), tt.name)
+			}
+		})
+	}
+}
+
+func TestResourceSonarqubeWebhookScopeDoesNotReplace(t *testing.T) {
+	r := resourceSonarqubeWebhook()
+	// A webhook read before the scope attribute existed
+	state := &sdkterraform.InstanceState{
+		ID: "AX1",
+		Attributes: map[string]string{
+			"id":   "AX1",
+			"name": "ci",
+			"url":  "https://ci.example.com/hook",
+		},
+	}
+	config := sdkterraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":  "ci",
+		"url":   "https://ci.example.com/hook",
+		"scope": "global",
+	})
+
+	diff, err := r.Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("Diff() unexpected error = %v", err)
+	}
+	if diff.RequiresNew() {
+		t.Errorf("Diff() should update the scope in place instead of replacing the webhook")
+	}
+}
@@ -0,0 +1,272 @@
+package sonarqube
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// settingAttribute maps an attribute of a typed settings resource to a SonarQube setting key
+type settingAttribute struct {
+	attribute string
+	key       string
+	// secured settings are never returned by api/settings/values, so their attribute keeps the configured value
+	secured bool
+}
+
+// setSettingAttributes sends the value of every attribute to api/settings/set, or to api/settings/reset when the
+// attribute is empty. When onlyChanged is true, attributes without a pending change are skipped. The attributes are sent
+// in order, which is why the authentication resources list their enabled attribute last: users can only log in once
+// the rest of the settings is applied.
+func setSettingAttributes(component string, attributes []settingAttribute, d *schema.ResourceData, m interface{}, onlyChanged bool) error {
+	for _, a := range attributes {
+		if onlyChanged && !d.HasChange(a.attribute) {
+			continue
+		}
+
+		values, multiValue := settingValuesFromAttribute(d.Get(a.attribute))
+		if len(values) == 0 {
+			if err := resetSettings(component, []string{a.key}, m); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := setSetting(component, a.key, values, multiValue, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authSettingsDescription returns the description of an authentication settings resource, from the sentences saying
+// what it configures and the names of its write-only attributes
+func authSettingsDescription(summary string, writeOnly string) string {
+	return summary + `
+The ` + writeOnly + ` attributes are write-only: SonarQube never returns them, so changes made to them outside of Terraform are not detected.
+Destroying this resource resets all the settings, which disables the authentication.`
+}
+
+// readSettingAttributes reads the current values of all setting keys and stores them in the matching attributes.
+// Settings that are not returned by SonarQube are stored as the zero value of the attribute, secured settings are skipped.
+// The settings of a component that are inherited from the global settings are not set on it, so they are also stored
+// as the zero value.
+func readSettingAttributes(component string, attributes []settingAttribute, d *schema.ResourceData, m interface{}) error {
+	keys := make([]string, 0, len(attributes))
+	for _, a := range attributes {
+		if !a.secured {
+			keys = append(keys, a.key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	settings, err := getSettingsByKeys(component, keys, m)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	for _, a := range attributes {
+		if a.secured {
+			continue
+		}
+		setting := settings[a.key]
+		if component != "" && setting.Inherited {
+			setting = Setting{Key: a.key}
+		}
+		value, err := attributeFromSetting(d.Get(a.attribute), setting)
+		if err != nil {
+			return fmt.Errorf("readSettingAttributes: Failed to convert setting '%s': %+v", a.key, err)
+		}
+		errs = append(errs, d.Set(a.attribute, value))
+	}
+	return errors.Join(errs...)
+}
+
+// resetSettingAttributes resets all setting keys to their default value
+func resetSettingAttributes(component string, attributes []settingAttribute, m interface{}) error {
+	keys := make([]string, 0, len(attributes))
+	for _, a := range attributes {
+		keys = append(keys, a.key)
+	}
+	return resetSettings(component, keys, m)
+}
+
+// getSettingsByKeys returns the settings of the given keys, indexed by key. An empty component reads global settings.
+func getSettingsByKeys(component string, keys []string, m interface{}) (map[string]Setting, error) {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/values"
+	rawQuery := url.Values{
+		"keys": []string{strings.Join(keys, ",")},
+	}
+	if component != "" {
+		rawQuery.Add("component", component)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"GET",
+		sonarQubeURL.String(),
+		http.StatusOK,
+		"getSettingsByKeys",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	settingReadResponse := GetSettings{}
+	err = json.NewDecoder(resp.Body).Decode(&settingReadResponse)
+	if err != nil {
+		return nil, fmt.Errorf("getSettingsByKeys: Failed to decode json into struct: %+v", err)
+	}
+
+	settings := make(map[string]Setting)
+	for _, setting := range settingReadResponse.Setting {
+		settings[setting.Key] = setting
+	}
+	return settings, nil
+}
+
+func setSetting(component string, key string, values []string, multiValue bool, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/set"
+	rawQuery := url.Values{
+		"key": []string{key},
+	}
+	if multiValue {
+		rawQuery["values"] = values
+	} else {
+		rawQuery.Add("value", values[0])
+	}
+	if component != "" {
+		rawQuery.Add("component", component)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"setSetting",
+	)
+	if err != nil {
+		return fmt.Errorf("setSetting: Failed to set setting '%s': %+v", key, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// setSettingFieldValues sets a property set setting, where each entry is a map of field names to values
+func setSettingFieldValues(component string, key string, fieldValues []map[string]string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/set"
+	rawQuery := url.Values{
+		"key": []string{key},
+	}
+	for _, fieldValue := range fieldValues {
+		b, err := json.Marshal(fieldValue)
+		if err != nil {
+			return fmt.Errorf("setSettingFieldValues: Failed to encode field values of setting '%s': %+v", key, err)
+		}
+		rawQuery.Add("fieldValues", string(b))
+	}
+	if component != "" {
+		rawQuery.Add("component", component)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"setSettingFieldValues",
+	)
+	if err != nil {
+		return fmt.Errorf("setSettingFieldValues: Failed to set setting '%s': %+v", key, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func resetSettings(component string, keys []string, m interface{}) error {
+	sonarQubeURL := m.(*ProviderConfiguration).sonarQubeURL
+	sonarQubeURL.Path = strings.TrimSuffix(sonarQubeURL.Path, "/") + "/api/settings/reset"
+	rawQuery := url.Values{
+		"keys": []string{strings.Join(keys, ",")},
+	}
+	if component != "" {
+		rawQuery.Add("component", component)
+	}
+	sonarQubeURL.RawQuery = rawQuery.Encode()
+
+	resp, err := httpRequestHelper(
+		m.(*ProviderConfiguration).httpClient,
+		"POST",
+		sonarQubeURL.String(),
+		http.StatusNoContent,
+		"resetSettings",
+	)
+	if err != nil {
+		return fmt.Errorf("resetSettings: Failed to reset settings '%s': %+v", strings.Join(keys, ","), err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// settingValuesFromAttribute converts an attribute value into the values sent to api/settings/set.
+// The second return value is true for multi-value settings.
+func settingValuesFromAttribute(value interface{}) ([]string, bool) {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return nil, false
+		}
+		return []string{v}, false
+	case bool:
+		return []string{strconv.FormatBool(v)}, false
+	case int:
+		return []string{strconv.Itoa(v)}, false
+	case []interface{}, *schema.Set:
+		return expandStringList(v), true
+	default:
+		return nil, false
+	}
+}
+
+// attributeFromSetting converts a setting returned by api/settings/values into the type of the current attribute value
+func attributeFromSetting(current interface{}, setting Setting) (interface{}, error) {
+	switch current.(type) {
+	case bool:
+		if setting.Value == "" {
+			return false, nil
+		}
+		return strconv.ParseBool(setting.Value)
+	case int:
+		if setting.Value == "" {
+			return 0, nil
+		}
+		return strconv.Atoi(setting.Value)
+	case []interface{}, *schema.Set:
+		values := setting.Values
+		if values == nil {
+			values = []string{}
+		}
+		return values, nil
+	default:
+		return setting.Value, nil
+	}
+}
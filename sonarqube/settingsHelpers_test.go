@@ -0,0 +1,143 @@
+package sonarqube
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSettingValuesFromAttribute(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      interface{}
+		expected   []string
+		multiValue bool
+	}{
+		{
+			name:     "empty string",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "string",
+			input:    "value",
+			expected: []string{"value"},
+		},
+		{
+			name:     "bool",
+			input:    false,
+			expected: []string{"false"},
+		},
+		{
+			name:     "int",
+			input:    42,
+			expected: []string{"42"},
+		},
+		{
+			name:       "list",
+			input:      []interface{}{"a", "b"},
+			expected:   []string{"a", "b"},
+			multiValue: true,
+		},
+		{
+			name:       "set",
+			input:      schema.NewSet(schema.HashString, []interface{}{"a"}),
+			expected:   []string{"a"},
+			multiValue: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, multiValue := settingValuesFromAttribute(tt.input)
+			if !reflect.DeepEqual(values, tt.expected) || multiValue != tt.multiValue {
+				t.Errorf("settingValuesFromAttribute(%v) = %v, %v; want %v, %v", tt.input, values, multiValue, tt.expected, tt.multiValue)
+			}
+		})
+	}
+}
+
+func TestAttributeFromSetting(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  interface{}
+		setting  Setting
+		expected interface{}
+	}{
+		{
+			name:     "string",
+			current:  "",
+			setting:  Setting{Value: "value"},
+			expected: "value",
+		},
+		{
+			name:     "bool",
+			current:  false,
+			setting:  Setting{Value: "true"},
+			expected: true,
+		},
+		{
+			name:     "unset bool",
+			current:  true,
+			setting:  Setting{},
+			expected: false,
+		},
+		{
+			name:     "int",
+			current:  0,
+			setting:  Setting{Value: "7"},
+			expected: 7,
+		},
+		{
+			name:     "unset list",
+			current:  []interface{}{"a"},
+			setting:  Setting{},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := attributeFromSetting(tt.current, tt.setting)
+			if err != nil {
+				t.Fatalf("attributeFromSetting returned an error: %v", err)
+			}
+			if !reflect.DeepEqual(value, tt.expected) {
+				t.Errorf("attributeFromSetting(%v, %+v) = %v; want %v", tt.current, tt.setting, value, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReadSettingAttributesInherited(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/settings/values", http.StatusOK, `{"settings": [
+		{"key": "sonar.exclusions", "values": ["**/vendor/**"], "inherited": true},
+		{"key": "sonar.test.exclusions", "values": ["**/fixtures/**"], "inherited": false}
+	]}`)
+	conf := mock.conf("Community", "10.7")
+	r := resourceSonarqubeAnalysisExclusions()
+
+	// The global exclusions are not read into the state of a project
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project": "my_project"})
+	if err := readSettingAttributes("my_project", analysisExclusionsSettings, d, conf); err != nil {
+		t.Fatalf("readSettingAttributes() unexpected error = %v", err)
+	}
+	if got := expandStringList(d.Get("exclusions")); len(got) != 0 {
+		t.Errorf("readSettingAttributes() exclusions = %v, want none", got)
+	}
+	if got := expandStringList(d.Get("test_exclusions")); !reflect.DeepEqual(got, []string{"**/fixtures/**"}) {
+		t.Errorf("readSettingAttributes() test_exclusions = %v, want the value of the project", got)
+	}
+
+	// Without component, the values are read as they are
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	if err := readSettingAttributes("", analysisExclusionsSettings, d, conf); err != nil {
+		t.Fatalf("readSettingAttributes() unexpected error = %v", err)
+	}
+	if got := expandStringList(d.Get("exclusions")); !reflect.DeepEqual(got, []string{"**/vendor/**"}) {
+		t.Errorf("readSettingAttributes() exclusions = %v, want the global value", got)
+	}
+}
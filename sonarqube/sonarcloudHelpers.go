@@ -0,0 +1,123 @@
+package sonarqube
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// sonarCloudVersion is the version of SonarQube whose web API the provider uses with SonarCloud, which has no version
+const sonarCloudVersion = "9.9"
+
+// sonarCloudEdition is the edition of the provider configuration with SonarCloud. The features of an edition of
+// SonarQube, such as portfolios, are not available.
+const sonarCloudEdition = "SonarCloud"
+
+// sonarCloudOrganizationPaths are the web services where SonarCloud requires the key of the organization
+var sonarCloudOrganizationPaths = []string{
+	"/api/components/search",
+	"/api/permissions/",
+	"/api/projects/",
+	"/api/qualitygates/",
+	"/api/qualityprofiles/",
+	"/api/rules/",
+	"/api/user_groups/",
+	"/api/webhooks/",
+}
+
+// organizationTransport adds the organization parameter to the requests sent to SonarCloud, so the resources that
+// SonarQube and SonarCloud have in common can be used without an organization attribute
+type organizationTransport struct {
+	base         http.RoundTripper
+	organization string
+}
+
+func (t *organizationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !requiresOrganization(req.URL.Path) || req.URL.Query().Has("organization") {
+		return t.base.RoundTrip(req)
+	}
+
+	// A RoundTripper must not modify the request
+	clone := req.Clone(req.Context())
+	query := clone.URL.Query()
+	query.Set("organization", t.organization)
+	clone.URL.RawQuery = query.Encode()
+	return t.base.RoundTrip(clone)
+}
+
+// requiresOrganization returns true when SonarCloud requires the organization for the web service
+func requiresOrganization(path string) bool {
+	for _, prefix := range sonarCloudOrganizationPaths {
+		if strings.Contains(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// organizationSchema is the organization attribute of the resources that belong to a SonarCloud organization
+func organizationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "The key of the SonarCloud organization. Defaults to the `organization` of the provider. Only supported when the provider has `sonarcloud` set. Changing this forces a new resource to be created.",
+	}
+}
+
+// validateResourceOrganization checks at plan time that the organization of a resource is only set with SonarCloud
+func validateResourceOrganization(d *schema.ResourceDiff, meta interface{}) error {
+	conf, ok := meta.(*ProviderConfiguration)
+	if !ok || d.Get("organization").(string) == "" {
+		return nil
+	}
+	if conf.sonarCloudOrganization == "" {
+		return fmt.Errorf("organization is only supported when the provider has sonarcloud set to true")
+	}
+	return nil
+}
+
+// withResourceOrganization returns the provider configuration to use for a resource, whose requests get the
+// organization of the resource instead of the one of the provider. The resources and data sources that share the
+// functions of these resources, like sonarqube_ephemeral_project, have no organization attribute.
+func withResourceOrganization(d *schema.ResourceData, m interface{}) interface{} {
+	conf := m.(*ProviderConfiguration)
+	organization, _ := d.Get("organization").(string)
+	if organization == "" || conf.sonarCloudOrganization == "" || organization == conf.sonarCloudOrganization {
+		return m
+	}
+
+	httpClient := *conf.httpClient.HTTPClient
+	httpClient.Transport = &organizationTransport{base: httpClient.Transport, organization: organization}
+
+	resourceConf := *conf
+	resourceConf.httpClient = &retryablehttp.Client{
+		HTTPClient:      &httpClient,
+		Logger:          conf.httpClient.Logger,
+		RetryWaitMin:    conf.httpClient.RetryWaitMin,
+		RetryWaitMax:    conf.httpClient.RetryWaitMax,
+		RetryMax:        conf.httpClient.RetryMax,
+		RequestLogHook:  conf.httpClient.RequestLogHook,
+		ResponseLogHook: conf.httpClient.ResponseLogHook,
+		CheckRetry:      conf.httpClient.CheckRetry,
+		Backoff:         conf.httpClient.Backoff,
+		ErrorHandler:    conf.httpClient.ErrorHandler,
+		PrepareRetry:    conf.httpClient.PrepareRetry,
+	}
+	return &resourceConf
+}
+
+// resourceOrganization returns the key of the SonarCloud organization of a resource, or an empty string with SonarQube
+func resourceOrganization(d *schema.ResourceData, m interface{}) string {
+	conf := m.(*ProviderConfiguration)
+	if conf.sonarCloudOrganization == "" {
+		return ""
+	}
+	if organization, _ := d.Get("organization").(string); organization != "" {
+		return organization
+	}
+	return conf.sonarCloudOrganization
+}
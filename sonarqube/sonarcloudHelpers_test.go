@@ -0,0 +1,88 @@
+package sonarqube
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestProviderConfigureSonarCloud(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/user_groups/search", http.StatusOK, `{"groups":[]}`)
+	mock.respond("GET", "/api/settings/values", http.StatusOK, `{"settings":[]}`)
+
+	provider := Provider()
+	raw := map[string]interface{}{"host": mock.server.URL, "token": "my-token", "sonarcloud": true, "organization": "my-org"}
+	if diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		t.Fatalf("Configure() error = %+v", diags)
+	}
+	conf := provider.Meta().(*ProviderConfiguration)
+	if conf.sonarQubeEdition != sonarCloudEdition || conf.sonarQubeVersion.String() != "9.9.0" || conf.sonarCloudOrganization != "my-org" {
+		t.Errorf("Configure() = %s %s %s", conf.sonarQubeEdition, conf.sonarQubeVersion, conf.sonarCloudOrganization)
+	}
+
+	if _, err := findGroupFromApi("developers", conf); err != nil {
+		t.Fatalf("findGroupFromApi() error = %+v", err)
+	}
+	if _, err := getSettingsByKeys("", []string{"sonar.core.serverBaseURL"}, conf); err != nil {
+		t.Fatalf("getSettingsByKeys() error = %+v", err)
+	}
+	// The version is not detected, and only the groups need the organization
+	expected := []string{
+		"GET /api/user_groups/search?organization=my-org&ps=500&q=developers",
+		"GET /api/settings/values?keys=sonar.core.serverBaseURL",
+	}
+	if got := mock.requests(); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("requests = %v, want %v", got, expected)
+	}
+}
+
+func TestProviderConfigureSonarCloudWithoutOrganization(t *testing.T) {
+	provider := Provider()
+	raw := map[string]interface{}{"host": "https://sonarcloud.io", "token": "my-token", "sonarcloud": true}
+	if diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw)); !diags.HasError() {
+		t.Errorf("Configure() should require the organization")
+	}
+}
+
+func TestResourceOrganization(t *testing.T) {
+	mock := newMockSonarQube(t)
+	mock.respond("GET", "/api/user_groups/search", http.StatusOK, `{"groups":[]}`)
+
+	provider := Provider()
+	raw := map[string]interface{}{"host": mock.server.URL, "token": "my-token", "sonarcloud": true, "organization": "my-org"}
+	if diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw)); diags.HasError() {
+		t.Fatalf("Configure() error = %+v", diags)
+	}
+	conf := provider.Meta().(*ProviderConfiguration)
+
+	// The organization of the resource replaces the one of the provider
+	r := resourceSonarqubeGroup()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"name": "developers", "organization": "other-org"})
+	if _, err := findGroupFromApi("developers", withResourceOrganization(d, conf)); err != nil {
+		t.Fatalf("findGroupFromApi() error = %+v", err)
+	}
+	expected := []string{"GET /api/user_groups/search?organization=other-org&ps=500&q=developers"}
+	if got := mock.requests(); fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("requests = %v, want %v", got, expected)
+	}
+
+	// The organization cannot be set with SonarQube
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"name": "developers", "organization": "other-org"})
+	if _, err := r.Diff(context.Background(), nil, config, mock.conf("Community", "10.7")); err == nil {
+		t.Errorf("Diff() should reject the organization without sonarcloud")
+	}
+	if _, err := r.Diff(context.Background(), nil, config, conf); err != nil {
+		t.Errorf("Diff() error = %v", err)
+	}
+
+	// The resources without an organization attribute use the organization of the provider
+	d = schema.TestResourceDataRaw(t, resourceSonarqubeEphemeralProject().Schema, map[string]interface{}{"project": "my_project"})
+	if got := withResourceOrganization(d, conf); got != conf {
+		t.Errorf("withResourceOrganization() = %v, want the provider configuration", got)
+	}
+}
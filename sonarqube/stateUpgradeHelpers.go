@@ -0,0 +1,35 @@
+package sonarqube
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// State upgrades
+//
+// When the type or the name of an attribute changes, the resource gets a new SchemaVersion and a StateUpgrader for
+// the previous version. The upgrader is declared with the implied type of the previous schema and rewrites the raw
+// state, so existing resources are migrated on the next refresh instead of being tainted or replaced. The previous
+// schema is built from the current one, overriding only the attributes that changed.
+
+// upgradeStringBoolAttributes converts attributes that were stored as "true" or "false" strings to booleans.
+// Missing or empty values get the default of the attribute.
+func upgradeStringBoolAttributes(rawState map[string]interface{}, defaults map[string]bool) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	for attribute, defaultValue := range defaults {
+		value, ok := rawState[attribute].(string)
+		if !ok || value == "" {
+			rawState[attribute] = defaultValue
+			continue
+		}
+		converted, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("upgradeStringBoolAttributes: attribute '%s' has the value '%s', which is not a boolean", attribute, value)
+		}
+		rawState[attribute] = converted
+	}
+	return rawState, nil
+}
@@ -0,0 +1,69 @@
+package sonarqube
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestUpgradeStringBoolAttributes(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawState map[string]interface{}
+		expected map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "strings",
+			rawState: map[string]interface{}{"id": "project/repository", "monorepo": "true", "summary_comment_enabled": "false"},
+			expected: map[string]interface{}{"id": "project/repository", "monorepo": true, "summary_comment_enabled": false},
+		},
+		{
+			name:     "missing and empty values get the default",
+			rawState: map[string]interface{}{"id": "project/repository", "monorepo": ""},
+			expected: map[string]interface{}{"id": "project/repository", "monorepo": false, "summary_comment_enabled": true},
+		},
+		{
+			name:     "invalid value",
+			rawState: map[string]interface{}{"id": "project/repository", "monorepo": "yes"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := upgradeStringBoolAttributes(tt.rawState, map[string]bool{"monorepo": false, "summary_comment_enabled": true})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("upgradeStringBoolAttributes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("upgradeStringBoolAttributes() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResourceSonarqubeGitlabBindingStateUpgradeV0(t *testing.T) {
+	result, err := resourceSonarqubeGitlabBindingStateUpgradeV0(context.Background(), map[string]interface{}{"monorepo": "true"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result["monorepo"] != true {
+		t.Errorf("resourceSonarqubeGitlabBindingStateUpgradeV0() monorepo = %v, want true", result["monorepo"])
+	}
+}
+
+// Every resource with a schema version must be able to upgrade the state of each previous version
+func TestResourceStateUpgraders(t *testing.T) {
+	for name, resource := range Provider().ResourcesMap {
+		if len(resource.StateUpgraders) != resource.SchemaVersion {
+			t.Errorf("%s: schema version %d has %d state upgraders, want one per previous version", name, resource.SchemaVersion, len(resource.StateUpgraders))
+			continue
+		}
+		for i, upgrader := range resource.StateUpgraders {
+			if upgrader.Version != i || upgrader.Upgrade == nil {
+				t.Errorf("%s: state upgrader %d upgrades version %d, want version %d with an upgrade function", name, i, upgrader.Version, i)
+			}
+		}
+	}
+}
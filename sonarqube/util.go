@@ -1,8 +1,12 @@
 package sonarqube
 
 import (
+	"net/url"
 	"reflect"
 	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // Checks if two string slices are equal, optionally ignoring ordering
@@ -18,3 +22,35 @@ func stringSlicesEqual(a, b []string, ignoreOrder bool) bool {
 
 	return reflect.DeepEqual(a, b)
 }
+
+// Converts a list or set attribute of strings into a string slice
+func expandStringList(v interface{}) []string {
+	if set, ok := v.(*schema.Set); ok {
+		v = set.List()
+	}
+	list, _ := v.([]interface{})
+	values := make([]string, 0, len(list))
+	for _, value := range list {
+		values = append(values, value.(string))
+	}
+	return values
+}
+
+// suppressCaseDiff suppresses the diff of the values that only differ in case, such as the logins and group names that
+// SonarQube matches case-insensitively and whose casing the Read functions write back, or hex encoded checksums.
+// Project keys are case-sensitive and must not use it.
+func suppressCaseDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// sonarQubeWebURL returns the public link to a page or a web service of SonarQube, without the credentials of the
+// provider nor the "?" it adds to the requests without query. An empty path returns the URL of the instance, as
+// configured on the DevOps platform side.
+func sonarQubeWebURL(conf *ProviderConfiguration, path string, query url.Values) string {
+	webURL := conf.sonarQubeURL
+	webURL.User = nil
+	webURL.Path = strings.TrimSuffix(webURL.Path, "/") + path
+	webURL.RawQuery = query.Encode()
+	webURL.ForceQuery = false
+	return webURL.String()
+}
@@ -0,0 +1,36 @@
+package sonarqube
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestSonarQubeWebURL(t *testing.T) {
+	host, err := url.Parse("https://sonarqube.example.com/sonar/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Built like configureProvider builds it
+	sonarQubeURL := sonarQubeBaseURL(host)
+	sonarQubeURL.User = url.UserPassword("admin", "secret")
+	conf := &ProviderConfiguration{sonarQubeURL: sonarQubeURL}
+
+	tests := []struct {
+		name  string
+		path  string
+		query url.Values
+		want  string
+	}{
+		{"instance", "", nil, "https://sonarqube.example.com/sonar"},
+		{"page without query", "/admin/webhooks", nil, "https://sonarqube.example.com/sonar/admin/webhooks"},
+		{"page with query", "/dashboard", url.Values{"id": []string{"my_project"}}, "https://sonarqube.example.com/sonar/dashboard?id=my_project"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sonarQubeWebURL(conf, tt.path, tt.query); got != tt.want {
+				t.Errorf("sonarQubeWebURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}